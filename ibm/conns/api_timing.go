@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The headers that carry the correlation ID of an API call, by order of preference. The services echo the ID of the
+// request or generate one, so the response headers are checked before the request headers.
+var apiTimingCorrelationHeaders = []string{"X-Correlation-Id", "Correlation-Id", "X-Request-Id"}
+
+// APITimingTransport logs the method, endpoint host, duration, status and correlation ID of each API call that it
+// sends, as a single INFO line of key=value fields named after the OpenTelemetry HTTP conventions. The duration
+// includes the retries of the transport that it wraps.
+type APITimingTransport struct {
+	// The name of the service, logged to tell the clients apart
+	Service string
+	// The transport that sends the calls, http.DefaultTransport when nil
+	Next http.RoundTripper
+
+	// Prints the log line, log.Printf when nil
+	logf func(format string, v ...interface{})
+}
+
+// NewAPITimingTransport returns a transport that logs the timing of the calls that next sends for service
+func NewAPITimingTransport(service string, next http.RoundTripper) *APITimingTransport {
+	return &APITimingTransport{Service: service, Next: next}
+}
+
+// WithAPITimingLogs returns a copy of client whose calls are logged by an APITimingTransport. The client itself is
+// not modified, so the clients that are shared by the session can be wrapped for a single service.
+func WithAPITimingLogs(client *http.Client, service string) *http.Client {
+	timed := &http.Client{}
+	if client != nil {
+		*timed = *client
+	}
+	timed.Transport = NewAPITimingTransport(service, timed.Transport)
+	return timed
+}
+
+func (t *APITimingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	duration := time.Since(start)
+
+	fields := []string{
+		apiTimingField("service", t.Service),
+		apiTimingField("http.request.method", req.Method),
+		apiTimingField("server.address", req.URL.Hostname()),
+		apiTimingField("duration_ms", strconv.FormatFloat(float64(duration.Microseconds())/1000, 'f', 3, 64)),
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	fields = append(fields,
+		apiTimingField("http.response.status_code", strconv.Itoa(status)),
+		apiTimingField("correlation_id", apiTimingCorrelationID(req, resp)),
+	)
+	if err != nil {
+		fields = append(fields, apiTimingField("error.message", err.Error()))
+	}
+
+	logf := t.logf
+	if logf == nil {
+		logf = log.Printf
+	}
+	logf("[INFO] api_call %s", strings.Join(fields, " "))
+	return resp, err
+}
+
+// apiTimingCorrelationID returns the correlation ID of the call, empty when neither the response nor the request has
+// one
+func apiTimingCorrelationID(req *http.Request, resp *http.Response) string {
+	for _, header := range apiTimingCorrelationHeaders {
+		if resp != nil {
+			if id := resp.Header.Get(header); id != "" {
+				return id
+			}
+		}
+		if id := req.Header.Get(header); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// apiTimingField formats a key=value field, quoting the values that are empty or contain spaces, quotes or equal
+// signs so that the line can be split on spaces
+func apiTimingField(key string, value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = strconv.Quote(value)
+	}
+	return fmt.Sprintf("%s=%s", key, value)
+}
@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// captureAPITimingLogs returns the transport for service with the lines that it logs
+func captureAPITimingLogs(service string, next http.RoundTripper) (*APITimingTransport, *[]string) {
+	lines := []string{}
+	transport := NewAPITimingTransport(service, next)
+	transport.logf = func(format string, v ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, v...))
+	}
+	return transport, &lines
+}
+
+var apiTimingDurationRegexp = regexp.MustCompile(` duration_ms=[0-9]+\.[0-9]{3} `)
+
+func TestAPITimingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Correlation-Id", "a1b2c3")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	transport, lines := captureAPITimingLogs("kms", nil)
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/v2/keys", nil)
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("bad status: %d", resp.StatusCode)
+	}
+
+	if len(*lines) != 1 {
+		t.Fatalf("expected a single line, got %#v", *lines)
+	}
+	line := (*lines)[0]
+	if !apiTimingDurationRegexp.MatchString(line) {
+		t.Fatalf("missing duration: %s", line)
+	}
+	expected := "[INFO] api_call service=kms http.request.method=GET server.address=127.0.0.1 duration_ms= http.response.status_code=404 correlation_id=a1b2c3"
+	if actual := apiTimingDurationRegexp.ReplaceAllString(line, " duration_ms= "); actual != expected {
+		t.Fatalf("bad line:\n\t%s\nexpected:\n\t%s", actual, expected)
+	}
+}
+
+func TestAPITimingTransportError(t *testing.T) {
+	transport, lines := captureAPITimingLogs("resource_controller", roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("dial tcp: i/o timeout")
+	}))
+	req, _ := http.NewRequest(http.MethodPost, "https://resource-controller.cloud.ibm.com/v2/resource_instances", nil)
+	req.Header.Set("X-Correlation-Id", "d4e5f6")
+
+	if _, err := transport.RoundTrip(req); err == nil {
+		t.Fatal("expected the error of the wrapped transport")
+	}
+	line := apiTimingDurationRegexp.ReplaceAllString((*lines)[0], " duration_ms= ")
+	expected := `[INFO] api_call service=resource_controller http.request.method=POST server.address=resource-controller.cloud.ibm.com duration_ms= http.response.status_code=0 correlation_id=d4e5f6 error.message="dial tcp: i/o timeout"`
+	if line != expected {
+		t.Fatalf("bad line:\n\t%s\nexpected:\n\t%s", line, expected)
+	}
+}
+
+func TestAPITimingCorrelationID(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://us-south.kms.cloud.ibm.com/api/v2/keys", nil)
+	if id := apiTimingCorrelationID(req, nil); id != "" {
+		t.Fatalf("expected no correlation ID, got %s", id)
+	}
+
+	req.Header.Set("Correlation-Id", "request")
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("X-Request-Id", "response")
+	if id := apiTimingCorrelationID(req, resp); id != "request" {
+		t.Fatalf("expected the correlation ID of the request, got %s", id)
+	}
+	resp.Header.Set("Correlation-Id", "response")
+	if id := apiTimingCorrelationID(req, resp); id != "response" {
+		t.Fatalf("expected the correlation ID of the response, got %s", id)
+	}
+}
+
+func TestAPITimingField(t *testing.T) {
+	for value, expected := range map[string]string{
+		"abc":       "key=abc",
+		"":          `key=""`,
+		"a b":       `key="a b"`,
+		`say "hi"`:  `key="say \"hi\""`,
+		"a=b":       `key="a=b"`,
+		"line\nend": `key="line\nend"`,
+	} {
+		if actual := apiTimingField("key", value); actual != expected {
+			t.Fatalf("bad field for %q: %s", value, actual)
+		}
+	}
+}
+
+func TestWithAPITimingLogs(t *testing.T) {
+	client := &http.Client{}
+	timed := WithAPITimingLogs(client, "kms")
+	if client.Transport != nil {
+		t.Fatal("the client was modified")
+	}
+	transport, ok := timed.Transport.(*APITimingTransport)
+	if !ok || transport.Service != "kms" || transport.Next != nil {
+		t.Fatalf("bad transport: %#v", timed.Transport)
+	}
+	if !strings.Contains(fmt.Sprintf("%T", WithAPITimingLogs(nil, "project").Transport), "APITimingTransport") {
+		t.Fatal("a nil client is not wrapped")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"sync"
+	"time"
+)
+
+type cachedCall struct {
+	done      chan struct{}
+	value     interface{}
+	err       error
+	expiresAt time.Time
+}
+
+// CallCache shares the results of the API calls made with a client session. Identical calls, identified by a key
+// that the caller prefixes with the name of the call, that run concurrently wait for a single call, and the result
+// of a successful call is kept until it expires or the provider process exits at the end of the Terraform operation.
+// Each session has its own cache, so that the provider configurations of a process never share results.
+type CallCache struct {
+	mu    sync.Mutex
+	calls map[string]*cachedCall
+	now   func() time.Time
+}
+
+// NewCallCache returns an empty CallCache
+func NewCallCache() *CallCache {
+	return &CallCache{calls: make(map[string]*cachedCall), now: time.Now}
+}
+
+// Do returns the result of the call identified by key, calling call only when no identical call is in flight or
+// succeeded and has not expired. The value of a successful call is kept until the expiration that call returns, or
+// until the process exits when the expiration is zero. Failed calls are not kept, so the next identical call calls
+// the API again. call runs without holding the lock of the cache, so that it does not block the other keys.
+func (c *CallCache) Do(key string, call func() (interface{}, time.Time, error)) (interface{}, error) {
+	c.mu.Lock()
+	if cached, ok := c.calls[key]; ok && !c.expired(cached) {
+		c.mu.Unlock()
+		<-cached.done
+		return cached.value, cached.err
+	}
+	cached := &cachedCall{done: make(chan struct{})}
+	c.calls[key] = cached
+	c.mu.Unlock()
+
+	defer func() {
+		if cached.err != nil {
+			c.mu.Lock()
+			if c.calls[key] == cached {
+				delete(c.calls, key)
+			}
+			c.mu.Unlock()
+		}
+		close(cached.done)
+	}()
+	cached.value, cached.expiresAt, cached.err = call()
+	return cached.value, cached.err
+}
+
+// Whether a call that completed failed or expired, the calls in flight are not expired
+func (c *CallCache) expired(cached *cachedCall) bool {
+	select {
+	case <-cached.done:
+		return cached.err != nil || (!cached.expiresAt.IsZero() && !c.now().Before(cached.expiresAt))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package conns
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallCacheConcurrentIdenticalCalls(t *testing.T) {
+	cache := NewCallCache()
+	var calls int32
+	call := func() (interface{}, time.Time, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		return &struct{ ID string }{ID: "key-id"}, time.Time{}, nil
+	}
+
+	const readers = 25
+	results := make([]interface{}, readers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			result, err := cache.Do("kms_key_lookup/instance/public/key_name/\"key\"", call)
+			assert.NoError(t, err)
+			results[i] = result
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, result := range results {
+		assert.Same(t, results[0], result)
+	}
+}
+
+func TestCallCacheFailedCallNotKept(t *testing.T) {
+	cache := NewCallCache()
+	calls := 0
+	failing := func() (interface{}, time.Time, error) {
+		calls++
+		return nil, time.Time{}, errors.New("Get Keys failed")
+	}
+
+	_, err := cache.Do("key", failing)
+	assert.Error(t, err)
+	_, err = cache.Do("key", failing)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+
+	result, err := cache.Do("key", func() (interface{}, time.Time, error) {
+		calls++
+		return "key-id", time.Time{}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "key-id", result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCallCacheDistinctKeys(t *testing.T) {
+	cache := NewCallCache()
+	calls := 0
+	call := func() (interface{}, time.Time, error) {
+		calls++
+		return calls, time.Time{}, nil
+	}
+	for _, key := range []string{"a", "b", "a", "b"} {
+		_, err := cache.Do(key, call)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, calls)
+
+	// The caches of two sessions do not share their results
+	_, err := NewCallCache().Do("a", call)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestCallCacheExpiration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewCallCache()
+	cache.now = func() time.Time { return now }
+	calls := 0
+	call := func() (interface{}, time.Time, error) {
+		calls++
+		return "token", now.Add(5 * time.Minute), nil
+	}
+
+	_, err := cache.Do("key", call)
+	assert.NoError(t, err)
+	_, err = cache.Do("key", call)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// The result is called again once it expires
+	now = now.Add(5 * time.Minute)
+	_, err = cache.Do("key", call)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCallCacheDoesNotBlockOtherKeys(t *testing.T) {
+	cache := NewCallCache()
+	calling := make(chan struct{})
+	release := make(chan struct{})
+	calls := make(chan int, 3)
+	go func() {
+		_, _ = cache.Do("slow", func() (interface{}, time.Time, error) {
+			close(calling)
+			<-release
+			calls <- 1
+			return "slow", time.Time{}, nil
+		})
+	}()
+	<-calling
+
+	// Another key is not blocked by the call in flight
+	value, err := cache.Do("other", func() (interface{}, time.Time, error) {
+		calls <- 2
+		return "other", time.Time{}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "other", value)
+	assert.Equal(t, 2, <-calls)
+
+	// The same key waits for the call in flight
+	done := make(chan interface{})
+	go func() {
+		value, _ := cache.Do("slow", func() (interface{}, time.Time, error) {
+			calls <- 3
+			return nil, time.Time{}, errors.New("called twice")
+		})
+		done <- value
+	}()
+	close(release)
+	assert.Equal(t, "slow", <-done)
+	assert.Equal(t, 1, <-calls)
+	assert.Empty(t, calls)
+}
@@ -194,6 +194,18 @@ type Config struct {
 	Zone          string
 	Visibility    string
 	EndpointsFile string
+
+	// Whether identical ibm_kms_key lookups share their result
+	KMSKeyLookupCache bool
+
+	// The maximum number of requests per second of the project listings, 0 for no limit
+	ProjectRequestsPerSecond float64
+
+	// The base interval of the polls of the project waiters, 0 for the default interval
+	ProjectPollInterval time.Duration
+
+	// Whether the clients that support it log the timing of their API calls
+	APITimingLogs bool
 }
 
 // Session stores the information required for communication with the SoftLayer and Bluemix API
@@ -301,11 +313,22 @@ type ClientSession interface {
 	UsageReportsV4() (*usagereportsv4.UsageReportsV4, error)
 	MqcloudV1() (*mqcloudv1.MqcloudV1, error)
 	VmwareV1() (*vmwarev1.VmwareV1, error)
+	KMSKeyLookupCacheEnabled() bool
+	CallCache() *CallCache
+	ProjectRequestsPerSecond() float64
+	ProjectPollInterval() time.Duration
+	APITimingLogsEnabled() bool
 }
 
 type clientSession struct {
 	session *Session
 
+	kmsKeyLookupCache        bool
+	callCache                *CallCache
+	projectRequestsPerSecond float64
+	projectPollInterval      time.Duration
+	apiTimingLogs            bool
+
 	appidErr error
 	appidAPI *appid.AppIDManagementV4
 
@@ -663,6 +686,31 @@ func (sess clientSession) BluemixSession() (*bxsession.Session, error) {
 	return sess.session.BluemixSession, sess.bluemixSessionErr
 }
 
+// KMSKeyLookupCacheEnabled reports whether identical ibm_kms_key lookups share their result
+func (sess clientSession) KMSKeyLookupCacheEnabled() bool {
+	return sess.kmsKeyLookupCache
+}
+
+// CallCache returns the cache of the API call results of the session, see CallCache
+func (sess clientSession) CallCache() *CallCache {
+	return sess.callCache
+}
+
+// ProjectRequestsPerSecond returns the maximum number of requests per second of the project listings, 0 for no limit
+func (sess clientSession) ProjectRequestsPerSecond() float64 {
+	return sess.projectRequestsPerSecond
+}
+
+// ProjectPollInterval returns the base interval of the polls of the project waiters, 0 for the default interval
+func (sess clientSession) ProjectPollInterval() time.Duration {
+	return sess.projectPollInterval
+}
+
+// APITimingLogsEnabled reports whether the clients that support it log the timing of their API calls
+func (sess clientSession) APITimingLogsEnabled() bool {
+	return sess.apiTimingLogs
+}
+
 // BluemixUserDetails ...
 func (sess clientSession) BluemixUserDetails() (*UserConfig, error) {
 	return sess.bmxUserDetails, sess.bmxUserFetchErr
@@ -1229,7 +1277,12 @@ func (c *Config) ClientSession() (interface{}, error) {
 	}
 	log.Printf("[INFO] Configured Region: %s\n", c.Region)
 	session := clientSession{
-		session: sess,
+		session:                  sess,
+		kmsKeyLookupCache:        c.KMSKeyLookupCache,
+		callCache:                NewCallCache(),
+		projectRequestsPerSecond: c.ProjectRequestsPerSecond,
+		projectPollInterval:      c.ProjectPollInterval,
+		apiTimingLogs:            c.APITimingLogs,
 	}
 
 	if sess.BluemixSession == nil {
@@ -3073,21 +3073,10 @@ func FlattenKeyPolicies(policies []kp.Policy) []map[string]interface{} {
 	dualAuthMap := make([]map[string]interface{}, 0, 1)
 	for _, policy := range policies {
 		log.Println("Policy CRN Data =============>", policy.CRN)
-		policyCRNData := strings.Split(policy.CRN, ":")
-		policyInstance := map[string]interface{}{
-			"id":               policyCRNData[9],
-			"crn":              policy.CRN,
-			"created_by":       policy.CreatedBy,
-			"creation_date":    (*(policy.CreatedAt)).String(),
-			"updated_by":       policy.UpdatedBy,
-			"last_update_date": (*(policy.UpdatedAt)).String(),
-		}
-		if policy.Rotation != nil {
-			policyInstance["interval_month"] = policy.Rotation.Interval
-			policyInstance["enabled"] = *policy.Rotation.Enabled
+		switch policyType, policyInstance := flattenKeyPolicy(policy); policyType {
+		case "rotation":
 			rotationMap = append(rotationMap, policyInstance)
-		} else if policy.DualAuth != nil {
-			policyInstance["enabled"] = *(policy.DualAuth.Enabled)
+		case "dual_auth_delete":
 			dualAuthMap = append(dualAuthMap, policyInstance)
 		}
 	}
@@ -3099,25 +3088,87 @@ func FlattenKeyPolicies(policies []kp.Policy) []map[string]interface{} {
 	return policyMap
 }
 
+// FlattenKeyPoliciesPayload flattens the raw payload of the policies of a key, as the policies API returns it, like
+// FlattenKeyPolicies. The payload is decoded with the policy models of the keyprotect client.
+func FlattenKeyPoliciesPayload(payload []byte) ([]map[string]interface{}, error) {
+	policies := kp.Policies{}
+	if err := json.Unmarshal(payload, &policies); err != nil {
+		return nil, fmt.Errorf("[ERROR] Error decoding the policies of the key: %s", err)
+	}
+	return FlattenKeyPolicies(policies.Policies), nil
+}
+
+// The versions of the payloads of the policies API, in the policy_api_version attribute of the policies. The v1
+// payloads only have the interval_month of the rotation policies, the v2 payloads have an enabled field.
+const (
+	KeyPolicyAPIVersionV1 = "v1"
+	KeyPolicyAPIVersionV2 = "v2"
+)
+
+// flattenKeyPolicy flattens a policy of a key with its metadata, and returns the attribute that it belongs to,
+// rotation or dual_auth_delete, or an empty string for the policies of other types.
+func flattenKeyPolicy(policy kp.Policy) (string, map[string]interface{}) {
+	policyInstance := flattenKeyPolicyMetadata(policy)
+	if policy.Rotation != nil {
+		flattenKeyRotationPolicy(policy.Rotation, policyInstance)
+		policyInstance["policy_api_version"] = keyPolicyAPIVersion(policy.Rotation.Enabled)
+		return "rotation", policyInstance
+	}
+	if policy.DualAuth != nil {
+		policyInstance["enabled"] = policy.DualAuth.Enabled != nil && *policy.DualAuth.Enabled
+		policyInstance["policy_api_version"] = keyPolicyAPIVersion(policy.DualAuth.Enabled)
+		return "dual_auth_delete", policyInstance
+	}
+	return "", policyInstance
+}
+
+// keyPolicyAPIVersion returns the version of the payload of a policy, v2 when it has the enabled field.
+func keyPolicyAPIVersion(enabled *bool) string {
+	if enabled != nil {
+		return KeyPolicyAPIVersionV2
+	}
+	return KeyPolicyAPIVersionV1
+}
+
+// flattenKeyPolicyMetadata flattens the attributes that are common to the policies of a key.
+func flattenKeyPolicyMetadata(policy kp.Policy) map[string]interface{} {
+	policyInstance := map[string]interface{}{
+		"crn":        policy.CRN,
+		"created_by": policy.CreatedBy,
+		"updated_by": policy.UpdatedBy,
+	}
+	if policyCRNData := strings.Split(policy.CRN, ":"); len(policyCRNData) > 9 {
+		policyInstance["id"] = policyCRNData[9]
+	}
+	if policy.CreatedAt != nil {
+		policyInstance["creation_date"] = (*(policy.CreatedAt)).String()
+	}
+	if policy.UpdatedAt != nil {
+		policyInstance["last_update_date"] = (*(policy.UpdatedAt)).String()
+	}
+	return policyInstance
+}
+
+// flattenKeyRotationPolicy normalizes the rotation policy payloads of both versions of the policies API.
+// The original payload only has interval_month and the policy is enabled when it is set, the newer payload
+// has an enabled field and omits interval_month when the policy is disabled.
+func flattenKeyRotationPolicy(rotation *kp.Rotation, policyInstance map[string]interface{}) {
+	enabled := rotation.Interval > 0
+	if rotation.Enabled != nil {
+		enabled = *rotation.Enabled
+	}
+	policyInstance["enabled"] = enabled
+	policyInstance["interval_month"] = rotation.Interval
+}
+
 func FlattenKeyIndividualPolicy(policy string, policies []kp.Policy) []map[string]interface{} {
 	rotationMap := make([]map[string]interface{}, 0, 1)
 	dualAuthMap := make([]map[string]interface{}, 0, 1)
 	for _, policy := range policies {
-		policyCRNData := strings.Split(policy.CRN, ":")
-		policyInstance := map[string]interface{}{
-			"id":               policyCRNData[9],
-			"crn":              policy.CRN,
-			"created_by":       policy.CreatedBy,
-			"creation_date":    (*(policy.CreatedAt)).String(),
-			"updated_by":       policy.UpdatedBy,
-			"last_update_date": (*(policy.UpdatedAt)).String(),
-		}
-		if policy.Rotation != nil {
-			policyInstance["interval_month"] = policy.Rotation.Interval
-			policyInstance["enabled"] = *policy.Rotation.Enabled
+		switch policyType, policyInstance := flattenKeyPolicy(policy); policyType {
+		case "rotation":
 			rotationMap = append(rotationMap, policyInstance)
-		} else if policy.DualAuth != nil {
-			policyInstance["enabled"] = *(policy.DualAuth.Enabled)
+		case "dual_auth_delete":
 			dualAuthMap = append(dualAuthMap, policyInstance)
 		}
 	}
@@ -3184,11 +3235,10 @@ func FlattenKeyPoliciesKey(policies []kp.Policy) []map[string]interface{} {
 	for _, policy := range policies {
 		policyInstance := map[string]interface{}{}
 		if policy.Rotation != nil {
-			policyInstance["interval_month"] = policy.Rotation.Interval
-			policyInstance["enabled"] = *(policy.Rotation.Enabled)
+			flattenKeyRotationPolicy(policy.Rotation, policyInstance)
 			rotationMap = append(rotationMap, policyInstance)
 		} else if policy.DualAuth != nil {
-			policyInstance["enabled"] = *(policy.DualAuth.Enabled)
+			policyInstance["enabled"] = policy.DualAuth.Enabled != nil && *policy.DualAuth.Enabled
 			dualAuthMap = append(dualAuthMap, policyInstance)
 		}
 	}
@@ -3200,6 +3250,46 @@ func FlattenKeyPoliciesKey(policies []kp.Policy) []map[string]interface{} {
 	return policyMap
 }
 
+// FlattenKMSKey flattens the metadata of a key protect or hpcs key into an element of the keys attribute
+// of the kms data sources. Keys created before the API reported their algorithm leave those attributes unset.
+func FlattenKMSKey(key kp.Key) map[string]interface{} {
+	keyInstance := map[string]interface{}{
+		"id":           key.ID,
+		"name":         key.Name,
+		"crn":          key.CRN,
+		"standard_key": key.Extractable,
+		"extractable":  key.Extractable,
+		"key_type":     KMSKeyType(key),
+		"description":  key.Description,
+		"aliases":      key.Aliases,
+		"key_ring_id":  key.KeyRingID,
+		"imported":     key.Imported,
+		"state":        key.State,
+	}
+	if key.AlgorithmType != "" {
+		keyInstance["algorithm_type"] = key.AlgorithmType
+	}
+	// The deletion dates are only reported for deleted keys
+	if key.DeletionDate != nil {
+		keyInstance["deletion_date"] = key.DeletionDate.UTC().Format(time.RFC3339)
+	}
+	if key.PurgeAllowedFrom != nil {
+		keyInstance["purge_allowed_from"] = key.PurgeAllowedFrom.UTC().Format(time.RFC3339)
+	}
+	if key.PurgeScheduledOn != nil {
+		keyInstance["purge_scheduled_on"] = key.PurgeScheduledOn.UTC().Format(time.RFC3339)
+	}
+	return keyInstance
+}
+
+// KMSKeyType returns standard for the keys whose material can be extracted and root for the others
+func KMSKeyType(key kp.Key) string {
+	if key.Extractable {
+		return "standard"
+	}
+	return "root"
+}
+
 // IgnoreSystemLabels returns non-IBM tag keys.
 func IgnoreSystemLabels(labels map[string]string) map[string]string {
 	result := make(map[string]string)
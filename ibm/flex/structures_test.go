@@ -1,9 +1,14 @@
 package flex
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/IBM/go-sdk-core/v5/core"
+	kp "github.com/IBM/keyprotect-go-client"
 	"github.com/go-openapi/strfmt"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +89,194 @@ func TestStringifyMap(t *testing.T) {
 	var foo interface{} = map[string]interface{}{"foo": "bar"}
 	assert.Equal(t, `{"foo":"bar"}`, Stringify(foo))
 }
+
+func TestFlattenKMSKey(t *testing.T) {
+	generated := kp.Key{
+		ID:            "12e8c9c2-a162-472d-b7d6-8b9a86b815a6",
+		Name:          "generated-root-key",
+		CRN:           "crn:v1:bluemix:public:kms:us-south:a/1234:5678:key:12e8c9c2-a162-472d-b7d6-8b9a86b815a6",
+		KeyRingID:     "default",
+		Aliases:       []string{"alias"},
+		AlgorithmType: "AES",
+		State:         1,
+	}
+	keyInstance := FlattenKMSKey(generated)
+	assert.Equal(t, generated.ID, keyInstance["id"])
+	assert.Equal(t, false, keyInstance["standard_key"])
+	assert.Equal(t, false, keyInstance["extractable"])
+	assert.Equal(t, "root", keyInstance["key_type"])
+	assert.Equal(t, false, keyInstance["imported"])
+	assert.Equal(t, 1, keyInstance["state"])
+	assert.Equal(t, "AES", keyInstance["algorithm_type"])
+
+	imported := generated
+	imported.Imported = true
+	keyInstance = FlattenKMSKey(imported)
+	assert.Equal(t, true, keyInstance["imported"])
+	assert.Equal(t, "AES", keyInstance["algorithm_type"])
+
+	legacy := kp.Key{
+		ID:          "4a3d3e8c-7f7f-4b0e-9a3c-6a1b2c3d4e5f",
+		Name:        "legacy-standard-key",
+		Extractable: true,
+	}
+	keyInstance = FlattenKMSKey(legacy)
+	assert.Equal(t, legacy.Name, keyInstance["name"])
+	assert.Equal(t, true, keyInstance["standard_key"])
+	assert.Equal(t, true, keyInstance["extractable"])
+	assert.Equal(t, "standard", keyInstance["key_type"])
+	assert.Equal(t, false, keyInstance["imported"])
+	assert.NotContains(t, keyInstance, "algorithm_type")
+}
+
+func TestFlattenKMSKeyTypeConsistent(t *testing.T) {
+	for _, extractable := range []bool{false, true} {
+		keyInstance := FlattenKMSKey(kp.Key{ID: "key", Extractable: extractable})
+		assert.Equal(t, keyInstance["extractable"], keyInstance["standard_key"])
+		assert.Equal(t, extractable, keyInstance["key_type"] == "standard")
+		assert.Equal(t, !extractable, keyInstance["key_type"] == "root")
+	}
+}
+
+func TestFlattenKeyPoliciesRotationPayloads(t *testing.T) {
+	testcases := []struct {
+		name            string
+		payload         string
+		expectedEnabled bool
+		expectedMonths  int
+	}{
+		{
+			name:            "original payload",
+			payload:         `{"type":"application/vnd.ibm.kms.policy+json","crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:0c6e3b3c-8ab4-4a8c-a7e5-3e1ad4f9a0c1","createdBy":"IBMid-1","creationDate":"2023-05-02T10:00:00Z","updatedBy":"IBMid-1","lastUpdateDate":"2023-05-02T10:00:00Z","rotation":{"interval_month":3}}`,
+			expectedEnabled: true,
+			expectedMonths:  3,
+		},
+		{
+			name:            "newer payload enabled",
+			payload:         `{"type":"application/vnd.ibm.kms.policy+json","crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:4f0a2e8d-3c1b-4b5d-8e6f-7a9b0c1d2e3f","createdBy":"IBMid-1","creationDate":"2024-02-12T08:30:00Z","updatedBy":"IBMid-2","lastUpdateDate":"2024-03-01T09:00:00Z","rotation":{"enabled":true,"interval_month":6}}`,
+			expectedEnabled: true,
+			expectedMonths:  6,
+		},
+		{
+			name:            "newer payload disabled",
+			payload:         `{"type":"application/vnd.ibm.kms.policy+json","crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a","createdBy":"IBMid-1","creationDate":"2024-02-12T08:30:00Z","updatedBy":"IBMid-2","lastUpdateDate":"2024-03-01T09:00:00Z","rotation":{"enabled":false}}`,
+			expectedEnabled: false,
+			expectedMonths:  0,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			var policy kp.Policy
+			assert.Nil(t, json.Unmarshal([]byte(tc.payload), &policy))
+
+			rotation := FlattenKeyPolicies([]kp.Policy{policy})[0]["rotation"].([]map[string]interface{})
+			assert.Len(t, rotation, 1)
+			assert.Equal(t, tc.expectedEnabled, rotation[0]["enabled"])
+			assert.Equal(t, tc.expectedMonths, rotation[0]["interval_month"])
+			assert.Equal(t, policy.CRN, rotation[0]["crn"])
+			assert.Equal(t, policy.CRN[len(policy.CRN)-36:], rotation[0]["id"])
+
+			assert.Equal(t, rotation, FlattenKeyIndividualPolicy("rotation", []kp.Policy{policy}))
+
+			overrides := FlattenKeyPoliciesKey([]kp.Policy{policy})[0]["rotation"].([]map[string]interface{})
+			assert.Equal(t, []map[string]interface{}{{"enabled": tc.expectedEnabled, "interval_month": tc.expectedMonths}}, overrides)
+		})
+	}
+}
+
+// Synthetic payloads of an instance with a dual auth delete policy, for a key that is set for deletion and for a key
+// that was deleted. They are written after the payloads documented for the Key Protect API, not captured from an
+// instance: the IDs, CRNs and dates are illustrative.
+const (
+	testDualAuthDeletePolicyPayload = `{"type":"application/vnd.ibm.kms.policy+json","crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:7f9c2d1e-3b4a-4c5d-9e8f-0a1b2c3d4e5f","createdBy":"IBMid-1","creationDate":"2024-04-08T14:21:09Z","updatedBy":"IBMid-2","lastUpdateDate":"2024-04-09T08:02:47Z","dualAuthDelete":{"enabled":true}}`
+	testDualAuthPendingKeyPayload   = `{"type":"application/vnd.ibm.kms.key+json","id":"0e7a3b2c-1d4f-4a5b-8c6d-9e0f1a2b3c4d","name":"dual-auth-root-key","state":1,"extractable":false,"crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:key:0e7a3b2c-1d4f-4a5b-8c6d-9e0f1a2b3c4d","dualAuthDelete":{"enabled":true,"keySetForDeletion":true,"authExpiration":"2024-04-16T09:15:00Z"},"deleted":false}`
+	testDualAuthDeletedKeyPayload   = `{"type":"application/vnd.ibm.kms.key+json","id":"0e7a3b2c-1d4f-4a5b-8c6d-9e0f1a2b3c4d","name":"dual-auth-root-key","state":5,"extractable":false,"crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:key:0e7a3b2c-1d4f-4a5b-8c6d-9e0f1a2b3c4d","dualAuthDelete":{"enabled":true},"deleted":true,"deletedBy":"IBMid-2","deletionDate":"2024-04-10T11:30:00+02:00","purgeAllowed":false,"purgeAllowedFrom":"2024-04-14T09:30:00Z","purgeScheduledOn":"2024-07-09T09:30:00Z"}`
+)
+
+func TestFlattenKeyPoliciesDualAuthDelete(t *testing.T) {
+	var policy kp.Policy
+	assert.Nil(t, json.Unmarshal([]byte(testDualAuthDeletePolicyPayload), &policy))
+
+	dualAuth := FlattenKeyPolicies([]kp.Policy{policy})[0]["dual_auth_delete"].([]map[string]interface{})
+	assert.Len(t, dualAuth, 1)
+	assert.Equal(t, true, dualAuth[0]["enabled"])
+	assert.Equal(t, policy.CRN, dualAuth[0]["crn"])
+	assert.Equal(t, "7f9c2d1e-3b4a-4c5d-9e8f-0a1b2c3d4e5f", dualAuth[0]["id"])
+	assert.Equal(t, "IBMid-1", dualAuth[0]["created_by"])
+	assert.Equal(t, "IBMid-2", dualAuth[0]["updated_by"])
+	assert.Equal(t, policy.CreatedAt.String(), dualAuth[0]["creation_date"])
+	assert.Equal(t, policy.UpdatedAt.String(), dualAuth[0]["last_update_date"])
+}
+
+func TestFlattenKMSKeyDeletionDates(t *testing.T) {
+	// The key set for deletion is not deleted yet, it has no deletion dates
+	var pending kp.Key
+	assert.Nil(t, json.Unmarshal([]byte(testDualAuthPendingKeyPayload), &pending))
+	keyInstance := FlattenKMSKey(pending)
+	assert.NotContains(t, keyInstance, "deletion_date")
+	assert.NotContains(t, keyInstance, "purge_allowed_from")
+	assert.NotContains(t, keyInstance, "purge_scheduled_on")
+
+	var deleted kp.Key
+	assert.Nil(t, json.Unmarshal([]byte(testDualAuthDeletedKeyPayload), &deleted))
+	keyInstance = FlattenKMSKey(deleted)
+	assert.Equal(t, "2024-04-10T09:30:00Z", keyInstance["deletion_date"])
+	assert.Equal(t, "2024-04-14T09:30:00Z", keyInstance["purge_allowed_from"])
+	assert.Equal(t, "2024-07-09T09:30:00Z", keyInstance["purge_scheduled_on"])
+}
+
+func TestFlattenKeyPoliciesDualAuthWithoutEnabled(t *testing.T) {
+	var policy kp.Policy
+	assert.Nil(t, json.Unmarshal([]byte(`{"crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:1a2b","dualAuthDelete":{}}`), &policy))
+
+	dualAuth := FlattenKeyPolicies([]kp.Policy{policy})[0]["dual_auth_delete"].([]map[string]interface{})
+	assert.Len(t, dualAuth, 1)
+	assert.Equal(t, false, dualAuth[0]["enabled"])
+	assert.NotContains(t, dualAuth[0], "creation_date")
+}
+
+// The policies payloads in testdata are synthetic payloads of both versions of the policies API, written after the
+// payloads documented for the Key Protect API rather than captured from an instance, each with the golden file of its
+// flattened policies. A change of the flattened policies, such as after an update of the keyprotect client,
+// fails here until the golden file is updated on purpose.
+func TestFlattenKeyPoliciesPayloadGolden(t *testing.T) {
+	payloads, err := filepath.Glob(filepath.Join("testdata", "key_policies_*.payload.json"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, payloads)
+	for _, payloadFile := range payloads {
+		goldenFile := strings.TrimSuffix(payloadFile, ".payload.json") + ".golden.json"
+		t.Run(filepath.Base(payloadFile), func(t *testing.T) {
+			payload, err := os.ReadFile(payloadFile)
+			if err != nil {
+				t.Fatalf("reading payload %s: %v", payloadFile, err)
+			}
+			golden, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("reading golden file %s: %v", goldenFile, err)
+			}
+
+			policies, err := FlattenKeyPoliciesPayload(payload)
+			assert.NoError(t, err)
+			flattened, err := json.Marshal(policies)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(golden), string(flattened), "the policies flattened from %s differ from %s", payloadFile, goldenFile)
+
+			// The resource flattens the same policies into its rotation and dual_auth_delete attributes
+			var keyPolicies kp.Policies
+			assert.NoError(t, json.Unmarshal(payload, &keyPolicies))
+			for _, policyType := range []string{"rotation", "dual_auth_delete"} {
+				assert.Equal(t, policies[0][policyType], FlattenKeyIndividualPolicy(policyType, keyPolicies.Policies), policyType)
+			}
+		})
+	}
+}
+
+func TestFlattenKeyPoliciesPayloadVersion(t *testing.T) {
+	policies, err := FlattenKeyPoliciesPayload([]byte(`{"resources":[{"crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:1a2b","rotation":{"interval_month":1}},{"crn":"crn:v1:bluemix:public:kms:us-south:a/1234:5678:policy:3c4d","dualAuthDelete":{"enabled":true}}]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, KeyPolicyAPIVersionV1, policies[0]["rotation"].([]map[string]interface{})[0]["policy_api_version"])
+	assert.Equal(t, KeyPolicyAPIVersionV2, policies[0]["dual_auth_delete"].([]map[string]interface{})[0]["policy_api_version"])
+
+	_, err = FlattenKeyPoliciesPayload([]byte(`{"resources":{}}`))
+	assert.Error(t, err)
+}
@@ -53,10 +53,11 @@ import (
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/secretsmanager"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/transitgateway"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/usagereports"
-	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/vpc"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/vmware"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/vpc"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a *schema.Provider.
@@ -217,6 +218,32 @@ func Provider() *schema.Provider {
 				Description: "Path of the file that contains private and public regional endpoints mapping",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"IC_ENDPOINTS_FILE_PATH", "IBMCLOUD_ENDPOINTS_FILE_PATH"}, nil),
 			},
+			"kms_key_lookup_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether identical ibm_kms_key lookups share their result for the duration of the Terraform operation. Set to false to debug key lookups.",
+			},
+			"project_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of requests per second that the project data sources send to list projects and configurations, shared by the data sources that are read in parallel. 0 does not limit the requests.",
+			},
+			"project_poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The interval in seconds of the first poll of the project resources that wait for a configuration or its Schematics workspace. The polls back off from it up to 60 seconds, and are jittered. 0 uses 5 seconds.",
+			},
+			"enable_api_timing_logs": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to log the method, endpoint host, duration, status and correlation ID of the API calls of the KMS resources and data sources, as a single INFO line per call.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -552,11 +579,19 @@ func Provider() *schema.Provider {
 			"ibm_org":                                cloudfoundry.DataSourceIBMOrg(),
 			"ibm_org_quota":                          cloudfoundry.DataSourceIBMOrgQuota(),
 			"ibm_kms_instance_policies":              kms.DataSourceIBMKmsInstancePolicies(),
+			"ibm_kms_instance_key_count":             kms.DataSourceIBMKMSInstanceKeyCount(),
+			"ibm_kms_import_token_status":            kms.DataSourceIBMKMSImportTokenStatus(),
+			"ibm_kms_alias_capacity":                 kms.DataSourceIBMKMSAliasCapacity(),
 			"ibm_kp_key":                             kms.DataSourceIBMkey(),
 			"ibm_kms_key_rings":                      kms.DataSourceIBMKMSkeyRings(),
 			"ibm_kms_key_policies":                   kms.DataSourceIBMKMSkeyPolicies(),
 			"ibm_kms_keys":                           kms.DataSourceIBMKMSkeys(),
+			"ibm_kms_keys_by_ids":                    kms.DataSourceIBMKMSKeysByIDs(),
 			"ibm_kms_key":                            kms.DataSourceIBMKMSkey(),
+			"ibm_kms_key_metadata":                   kms.DataSourceIBMKMSKeyMetadata(),
+			"ibm_kms_key_name_check":                 kms.DataSourceIBMKMSKeyNameCheck(),
+			"ibm_kms_keys_presence":                  kms.DataSourceIBMKMSKeysPresence(),
+			"ibm_kms_aliases":                        kms.DataSourceIBMKMSAliases(),
 			"ibm_pn_application_chrome":              pushnotification.DataSourceIBMPNApplicationChrome(),
 			"ibm_app_config_environment":             appconfiguration.DataSourceIBMAppConfigEnvironment(),
 			"ibm_app_config_environments":            appconfiguration.DataSourceIBMAppConfigEnvironments(),
@@ -895,10 +930,14 @@ func Provider() *schema.Provider {
 			"ibm_code_engine_secret":         codeengine.DataSourceIbmCodeEngineSecret(),
 
 			// Added for Project
-			"ibm_project":             project.DataSourceIbmProject(),
-			"ibm_project_config":      project.DataSourceIbmProjectConfig(),
-			"ibm_project_environment": project.DataSourceIbmProjectEnvironment(),
-		
+			"ibm_project":                  project.DataSourceIbmProject(),
+			"ibm_projects":                 project.DataSourceIbmProjects(),
+			"ibm_project_config":           project.DataSourceIbmProjectConfig(),
+			"ibm_project_config_reference": project.DataSourceIbmProjectConfigReference(),
+			"ibm_project_config_resources": project.DataSourceIbmProjectConfigResources(),
+			"ibm_project_configs":          project.DataSourceIbmProjectConfigs(),
+			"ibm_project_environment":      project.DataSourceIbmProjectEnvironment(),
+
 			// Added for VMware as a Service
 			"ibm_vmaas_vdc": vmware.DataSourceIbmVmaasVdc(),
 		},
@@ -1439,9 +1478,10 @@ func Provider() *schema.Provider {
 			"ibm_code_engine_secret":         codeengine.ResourceIbmCodeEngineSecret(),
 
 			// Added for Project
-			"ibm_project":             project.ResourceIbmProject(),
-			"ibm_project_config":      project.ResourceIbmProjectConfig(),
-			"ibm_project_environment": project.ResourceIbmProjectEnvironment(),
+			"ibm_project":                    project.ResourceIbmProject(),
+			"ibm_project_config":             project.ResourceIbmProjectConfig(),
+			"ibm_project_config_drift_check": project.ResourceIbmProjectConfigDriftCheck(),
+			"ibm_project_environment":        project.ResourceIbmProjectEnvironment(),
 
 			// Added for VMware as a Service
 			"ibm_vmaas_vdc": vmware.ResourceIbmVmaasVdc(),
@@ -1870,6 +1910,10 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	if f, ok := d.GetOk("endpoints_file_path"); ok {
 		file = f.(string)
 	}
+	kmsKeyLookupCache := d.Get("kms_key_lookup_cache").(bool)
+	projectRequestsPerSecond := d.Get("project_requests_per_second").(float64)
+	projectPollInterval := time.Duration(d.Get("project_poll_interval").(int)) * time.Second
+	apiTimingLogs := d.Get("enable_api_timing_logs").(bool)
 
 	resourceGrp := d.Get("resource_group").(string)
 	region := d.Get("region").(string)
@@ -1906,6 +1950,11 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		Visibility:           visibility,
 		EndpointsFile:        file,
 		IAMTrustedProfileID:  iamTrustedProfileId,
+		KMSKeyLookupCache:    kmsKeyLookupCache,
+
+		ProjectRequestsPerSecond: projectRequestsPerSecond,
+		ProjectPollInterval:      projectPollInterval,
+		APITimingLogs:            apiTimingLogs,
 	}
 
 	return config.ClientSession()
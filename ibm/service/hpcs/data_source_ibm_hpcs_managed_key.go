@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,9 +33,11 @@ func DataSourceIbmManagedKey() *schema.Resource {
 				Description: "The region of the UKO instance this resource exists in.",
 			},
 			"key_id": &schema.Schema{
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "UUID of the key.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key_id", "label"},
+				Description:  "UUID of the key.",
 			},
 			"uko_vault": &schema.Schema{
 				Type:        schema.TypeString,
@@ -95,9 +98,11 @@ func DataSourceIbmManagedKey() *schema.Resource {
 				Description: "Description of the managed key.",
 			},
 			"label": &schema.Schema{
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The label of the key.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"key_id", "label"},
+				Description:  "The label of the key. The key is looked up by its label in the vault when key_id is not set.",
 			},
 			"state": &schema.Schema{
 				Type:        schema.TypeString,
@@ -286,8 +291,6 @@ func DataSourceIbmManagedKeyRead(context context.Context, d *schema.ResourceData
 	instance_id := d.Get("instance_id").(string)
 	vault_id := d.Get("uko_vault").(string)
 	key_id := d.Get("key_id").(string)
-	getManagedKeyOptions.SetID(key_id)
-	getManagedKeyOptions.SetUKOVault(vault_id)
 
 	url, err := getUkoUrl(context, region, instance_id, ukoClient)
 	if err != nil {
@@ -295,6 +298,29 @@ func DataSourceIbmManagedKeyRead(context context.Context, d *schema.ResourceData
 	}
 	ukoClient.SetServiceURL(url)
 
+	if key_id == "" {
+		label := d.Get("label").(string)
+		key_id, err = hpcsManagedKeyIDByLabel(label, vault_id, func(offset int64) (*ukov4.ManagedKeyList, error) {
+			listManagedKeysOptions := &ukov4.ListManagedKeysOptions{}
+			listManagedKeysOptions.SetUKOVault(vault_id)
+			listManagedKeysOptions.SetLabel(label)
+			listManagedKeysOptions.SetLimit(hpcsManagedKeysPageSize)
+			listManagedKeysOptions.SetOffset(offset)
+
+			managedKeyList, response, err := ukoClient.ListManagedKeysWithContext(context, listManagedKeysOptions)
+			if err != nil {
+				log.Printf("[DEBUG] ListManagedKeysWithContext failed %s\n%s", err, response)
+				return nil, fmt.Errorf("ListManagedKeysWithContext failed %s\n%s", err, response)
+			}
+			return managedKeyList, nil
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	getManagedKeyOptions.SetID(key_id)
+	getManagedKeyOptions.SetUKOVault(vault_id)
+
 	managedKey, response, err := ukoClient.GetManagedKeyWithContext(context, getManagedKeyOptions)
 	if err != nil {
 		log.Printf("[DEBUG] GetManagedKeyWithContext failed %s\n%s", err, response)
@@ -303,6 +329,10 @@ func DataSourceIbmManagedKeyRead(context context.Context, d *schema.ResourceData
 
 	d.SetId(fmt.Sprintf("%s/%s/%s/%s", region, instance_id, vault_id, *getManagedKeyOptions.ID))
 
+	if err = d.Set("key_id", managedKey.ID); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting key_id: %s", err))
+	}
+
 	vault := []map[string]interface{}{}
 	if managedKey.Vault != nil {
 		modelMap, err := DataSourceIbmManagedKeyVaultReferenceToMap(managedKey.Vault)
@@ -435,6 +465,39 @@ func DataSourceIbmManagedKeyRead(context context.Context, d *schema.ResourceData
 	return nil
 }
 
+// hpcsManagedKeysPageSize is the number of keys that are listed per request when a key is looked up by its label.
+const hpcsManagedKeysPageSize int64 = 100
+
+// hpcsManagedKeyIDByLabel returns the ID of the key of the vault with the given label. The pages of the keys are
+// fetched from offset 0 until the total count is reached, and the labels are compared exactly because the label
+// filter of the service also matches partial labels. No match and several matches are errors.
+func hpcsManagedKeyIDByLabel(label string, vaultID string, fetch func(offset int64) (*ukov4.ManagedKeyList, error)) (string, error) {
+	matches := []string{}
+	var offset int64
+	for {
+		managedKeyList, err := fetch(offset)
+		if err != nil {
+			return "", err
+		}
+		for _, managedKey := range managedKeyList.ManagedKeys {
+			if managedKey.Label != nil && *managedKey.Label == label && managedKey.ID != nil {
+				matches = append(matches, *managedKey.ID)
+			}
+		}
+		offset += int64(len(managedKeyList.ManagedKeys))
+		if len(managedKeyList.ManagedKeys) == 0 || managedKeyList.TotalCount == nil || offset >= *managedKeyList.TotalCount {
+			break
+		}
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("No key with label %s in vault %s", label, vaultID)
+	}
+	if len(matches) > 1 {
+		return "", fmt.Errorf("%d keys with label %s in vault %s: %s. Set key_id to select one of them", len(matches), label, vaultID, strings.Join(matches, ", "))
+	}
+	return matches[0], nil
+}
+
 func DataSourceIbmManagedKeyVaultReferenceToMap(model *ukov4.VaultReference) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
 	if model.ID != nil {
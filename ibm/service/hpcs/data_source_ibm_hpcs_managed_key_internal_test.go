@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package hpcs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/ibm-hpcs-uko-sdk/ukov4"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHPCSManagedKeyPages serves the keys in pages of pageSize, recording the requested offsets
+func testHPCSManagedKeyPages(keys []ukov4.ManagedKey, pageSize int, offsets *[]int64) func(offset int64) (*ukov4.ManagedKeyList, error) {
+	return func(offset int64) (*ukov4.ManagedKeyList, error) {
+		*offsets = append(*offsets, offset)
+		page := []ukov4.ManagedKey{}
+		if int(offset) < len(keys) {
+			page = keys[offset:]
+		}
+		if len(page) > pageSize {
+			page = page[:pageSize]
+		}
+		return &ukov4.ManagedKeyList{ManagedKeys: page, TotalCount: core.Int64Ptr(int64(len(keys)))}, nil
+	}
+}
+
+func testHPCSManagedKeys(count int) []ukov4.ManagedKey {
+	keys := make([]ukov4.ManagedKey, 0, count)
+	for i := 0; i < count; i++ {
+		keys = append(keys, ukov4.ManagedKey{ID: core.StringPtr(fmt.Sprintf("key-%03d", i)), Label: core.StringPtr(fmt.Sprintf("label-%03d", i))})
+	}
+	return keys
+}
+
+func TestHPCSManagedKeyIDByLabel(t *testing.T) {
+	offsets := []int64{}
+	keyID, err := hpcsManagedKeyIDByLabel("label-004", "vault", testHPCSManagedKeyPages(testHPCSManagedKeys(5), 2, &offsets))
+	assert.NoError(t, err)
+	assert.Equal(t, "key-004", keyID)
+	assert.Equal(t, []int64{0, 2, 4}, offsets)
+
+	// Partial matches of the label filter are ignored.
+	keys := append(testHPCSManagedKeys(2), ukov4.ManagedKey{ID: core.StringPtr("key-prefix"), Label: core.StringPtr("label-001-old")})
+	offsets = []int64{}
+	keyID, err = hpcsManagedKeyIDByLabel("label-001", "vault", testHPCSManagedKeyPages(keys, 100, &offsets))
+	assert.NoError(t, err)
+	assert.Equal(t, "key-001", keyID)
+	assert.Equal(t, []int64{0}, offsets)
+
+	offsets = []int64{}
+	_, err = hpcsManagedKeyIDByLabel("label-999", "vault", testHPCSManagedKeyPages(testHPCSManagedKeys(3), 2, &offsets))
+	assert.EqualError(t, err, "No key with label label-999 in vault vault")
+
+	keys = append(testHPCSManagedKeys(1), ukov4.ManagedKey{ID: core.StringPtr("key-duplicate"), Label: core.StringPtr("label-000")})
+	offsets = []int64{}
+	_, err = hpcsManagedKeyIDByLabel("label-000", "vault", testHPCSManagedKeyPages(keys, 1, &offsets))
+	assert.EqualError(t, err, "2 keys with label label-000 in vault vault: key-000, key-duplicate. Set key_id to select one of them")
+	assert.Equal(t, []int64{0, 1}, offsets)
+
+	_, err = hpcsManagedKeyIDByLabel("label-000", "vault", func(offset int64) (*ukov4.ManagedKeyList, error) {
+		return nil, fmt.Errorf("ListManagedKeysWithContext failed")
+	})
+	assert.EqualError(t, err, "ListManagedKeysWithContext failed")
+}
@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The number of aliases that a key can have, as documented by Key Protect and Hyper Protect Crypto Services. The
+// service does not report it, so it must be updated here when the service changes it.
+const kmsMaxAliasesPerKey = 5
+
+func DataSourceIBMKMSAliasCapacity() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSAliasCapacityRead,
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID or an alias of the key",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"alias_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of aliases of the key",
+			},
+			"aliases_remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of aliases that can still be created for the key",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSAliasCapacityRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	if err := readKMSAliasCapacity(context, d, api, instanceID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Read the aliases of the key and set how many more aliases it can have
+func readKMSAliasCapacity(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, instanceID string) error {
+	keyID := d.Get("key_id").(string)
+	key, err := api.GetKey(ctx, keyID)
+	if err != nil {
+		if kmsKeyNotFound(err) {
+			return fmt.Errorf("[ERROR] No key %s in instance %s: %s", keyID, instanceID, err)
+		}
+		return fmt.Errorf("[ERROR] Get Key failed with error: %s", kmsAuthErrorHint(err, instanceID))
+	}
+	d.SetId(fmt.Sprintf("%s:%s", instanceID, key.ID))
+	for attribute, value := range flattenKMSKeyAliasCapacity(*key) {
+		d.Set(attribute, value)
+	}
+	return nil
+}
+
+// Flatten the number of aliases of the key and the number of aliases that can still be created under
+// kmsMaxAliasesPerKey, 0 when the key has as many aliases as the limit or more
+func flattenKMSKeyAliasCapacity(key kp.Key) map[string]interface{} {
+	aliasCount := len(key.Aliases)
+	aliasesRemaining := kmsMaxAliasesPerKey - aliasCount
+	if aliasesRemaining < 0 {
+		aliasesRemaining = 0
+	}
+	return map[string]interface{}{
+		"alias_count":       aliasCount,
+		"aliases_remaining": aliasesRemaining,
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"testing"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenKMSKeyAliasCapacity(t *testing.T) {
+	testCases := []struct {
+		aliases   []string
+		count     int
+		remaining int
+	}{
+		{nil, 0, kmsMaxAliasesPerKey},
+		{[]string{"a"}, 1, kmsMaxAliasesPerKey - 1},
+		{[]string{"a", "b", "c", "d", "e"}, 5, 0},
+		// A key over the limit, if the service lowers it, has no alias remaining
+		{[]string{"a", "b", "c", "d", "e", "f"}, 6, 0},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, map[string]interface{}{"alias_count": tc.count, "aliases_remaining": tc.remaining}, flattenKMSKeyAliasCapacity(kp.Key{Aliases: tc.aliases}))
+	}
+}
+
+func TestReadKMSAliasCapacity(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSKeys(1)
+	keys[0].Aliases = []string{"alias-1", "alias-2", "alias-3", "alias-4"}
+	api := &testKMSKeysAPI{keys: keys}
+
+	// The key is found by its ID or by one of its aliases
+	for _, keyID := range []string{"key-00", "alias-1"} {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSAliasCapacity().Schema, map[string]interface{}{"instance_id": instanceID, "key_id": keyID})
+		assert.NoError(t, readKMSAliasCapacity(context.Background(), d, api, instanceID))
+		assert.Equal(t, instanceID+":key-00", d.Id())
+		assert.Equal(t, 4, d.Get("alias_count"))
+		assert.Equal(t, 1, d.Get("aliases_remaining"))
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSAliasCapacity().Schema, map[string]interface{}{"instance_id": instanceID, "key_id": "missing"})
+	err := readKMSAliasCapacity(context.Background(), d, api, instanceID)
+	assert.ErrorContains(t, err, "No key missing in instance "+instanceID)
+
+	api.getKeyErr = &kp.Error{StatusCode: 403, Message: "Forbidden"}
+	d = schema.TestResourceDataRaw(t, DataSourceIBMKMSAliasCapacity().Schema, map[string]interface{}{"instance_id": instanceID, "key_id": "key-00"})
+	err = readKMSAliasCapacity(context.Background(), d, api, instanceID)
+	assert.ErrorContains(t, err, "Forbidden")
+}
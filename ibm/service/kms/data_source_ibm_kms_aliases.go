@@ -0,0 +1,147 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIBMKMSAliases() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMKMSAliasesRead,
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id of the key whose aliases are listed, all the keys of the instance when not set",
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only the aliases that start with the prefix",
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of aliases listed",
+			},
+			"aliases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The alias name",
+						},
+						"key_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the key the alias is associated with",
+						},
+						"key_crn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The CRN of the key the alias is associated with",
+						},
+						"key_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the key the alias is associated with",
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSAliasesRead(d *schema.ResourceData, meta interface{}) error {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, _, err := populateKPDataSourceClient(context.Background(), d, meta, instanceID)
+	if err != nil {
+		return err
+	}
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+
+	keyID := d.Get("key_id").(string)
+	prefix := d.Get("prefix").(string)
+
+	var keys []kp.Key
+	if keyID != "" {
+		key, err := api.GetKey(context.Background(), keyID)
+		if err != nil {
+			return fmt.Errorf("[ERROR] Get Key failed with error: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		keys = append(keys, *key)
+	} else {
+		//default page size of API is 200 as stated
+		pageSize := 200
+		offset := 0
+		for {
+			page, err := getKMSKeysInStates(context.Background(), api, pageSize, offset, kmsKeyLookupStates)
+			if err != nil {
+				return fmt.Errorf("[ERROR] Get Keys failed with error: %s", kmsAuthErrorHint(err, instanceID))
+			}
+			keys = append(keys, page.Keys...)
+			if len(page.Keys) < pageSize {
+				break
+			}
+			offset = offset + pageSize
+		}
+	}
+
+	aliases := flattenKMSKeyAliases(keys, prefix)
+
+	d.SetId(strings.Join([]string{instanceID, keyID, prefix}, "/"))
+	d.Set("aliases", aliases)
+	d.Set("total_count", len(aliases))
+	return nil
+}
+
+// Flatten the aliases of the keys, keeping only the aliases that start with the prefix
+func flattenKMSKeyAliases(keys []kp.Key, prefix string) []map[string]interface{} {
+	aliases := make([]map[string]interface{}, 0)
+	for _, key := range keys {
+		for _, alias := range key.Aliases {
+			if !strings.HasPrefix(alias, prefix) {
+				continue
+			}
+			aliases = append(aliases, map[string]interface{}{
+				"alias":    alias,
+				"key_id":   key.ID,
+				"key_crn":  key.CRN,
+				"key_name": key.Name,
+			})
+		}
+	}
+	return aliases
+}
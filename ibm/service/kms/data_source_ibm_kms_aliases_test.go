@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms_test
+
+import (
+	"fmt"
+	"testing"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMKMSAliasesDataSource_basic(t *testing.T) {
+	instanceName := fmt.Sprintf("tf_kms_%d", acctest.RandIntRange(10, 100))
+	keyName := fmt.Sprintf("key_%d", acctest.RandIntRange(10, 100))
+	aliasName := fmt.Sprintf("cleanup-alias-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMKmsAliasesDataSourceConfig(instanceName, keyName, aliasName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_kms_aliases.all", "total_count", "2"),
+					resource.TestCheckResourceAttr("data.ibm_kms_aliases.prefixed", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.ibm_kms_aliases.prefixed", "aliases.0.alias", aliasName),
+					resource.TestCheckResourceAttrPair("data.ibm_kms_aliases.prefixed", "aliases.0.key_id", "ibm_kms_key.test", "key_id"),
+					resource.TestCheckResourceAttr("data.ibm_kms_aliases.by_key", "total_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMKmsAliasesDataSourceConfig(instanceName, keyName, aliasName string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "kms_instance" {
+		name              = "%s"
+		service           = "kms"
+		plan              = "tiered-pricing"
+		location          = "us-south"
+	}
+	resource "ibm_kms_key" "test" {
+		instance_id = "${ibm_resource_instance.kms_instance.guid}"
+		key_name = "%s"
+		standard_key =  true
+		force_delete = true
+	}
+	resource "ibm_kms_key_alias" "cleanup" {
+		instance_id = "${ibm_kms_key.test.instance_id}"
+		alias = "%s"
+		key_id = "${ibm_kms_key.test.key_id}"
+	}
+	resource "ibm_kms_key_alias" "keep" {
+		instance_id = "${ibm_kms_key_alias.cleanup.instance_id}"
+		alias = "keep-alias"
+		key_id = "${ibm_kms_key.test.key_id}"
+	}
+	data "ibm_kms_aliases" "all" {
+		instance_id = "${ibm_kms_key_alias.keep.instance_id}"
+	}
+	data "ibm_kms_aliases" "prefixed" {
+		instance_id = "${ibm_kms_key_alias.keep.instance_id}"
+		prefix = "cleanup-"
+	}
+	data "ibm_kms_aliases" "by_key" {
+		instance_id = "${ibm_kms_key_alias.keep.instance_id}"
+		key_id = "${ibm_kms_key.test.key_id}"
+	}
+`, instanceName, keyName, aliasName)
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DataSourceIBMKMSImportTokenStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSImportTokenStatusRead,
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"allow_retrieval": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether to accept that each read of the data source retrieves the import token, which uses one of its remaining_retrievals. The service has no request that returns the metadata of the import token without retrieving it, so the read fails unless it is true",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the import token was created. The date format follows RFC 3339",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the import token expires. The date format follows RFC 3339",
+			},
+			"max_allowed_retrievals": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of times the import token can be retrieved before it expires",
+			},
+			"remaining_retrievals": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of retrievals of the import token that remain, after the retrieval of this read",
+			},
+			"expired": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the import token expired at the time of the read",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSImportTokenStatusRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	if !d.Get("allow_retrieval").(bool) {
+		return diag.Errorf("[ERROR] Reading the import token of instance %s retrieves it, which uses one of its remaining retrievals. Set allow_retrieval to true to accept it", instanceID)
+	}
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+
+	metadata, err := getKMSImportTokenMetadata(context, api)
+	if err != nil {
+		if kmsKeyNotFound(err) {
+			return diag.Errorf("[ERROR] Instance %s has no import token, create one before importing key material: %s", instanceID, err)
+		}
+		return diag.Errorf("[ERROR] Error retrieving the import token of instance %s: %s", instanceID, kmsAuthErrorHint(err, instanceID))
+	}
+	d.SetId(instanceID)
+	for key, value := range flattenKMSImportTokenMetadata(metadata, time.Now()) {
+		d.Set(key, value)
+	}
+	return nil
+}
+
+// Retrieve the metadata of the import token of the instance, which uses one of its retrievals. The client only
+// returns the import token with its public key and nonce, without the retrievals, so the token is requested directly
+// and its metadata is decoded from the response. The public key and the nonce are not decoded.
+func getKMSImportTokenMetadata(ctx context.Context, api *kp.Client) (*kp.ImportTokenMetadata, error) {
+	metadata := &kp.ImportTokenMetadata{}
+	if err := kmsGetJSON(ctx, api, "import_token", nil, metadata); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// Flatten the metadata of the import token into the attributes of the data source. The token expired when its
+// expiration date is not after now.
+func flattenKMSImportTokenMetadata(metadata *kp.ImportTokenMetadata, now time.Time) map[string]interface{} {
+	flattened := map[string]interface{}{
+		"max_allowed_retrievals": metadata.MaxAllowedRetrievals,
+		"remaining_retrievals":   metadata.RemainingRetrievals,
+		"expired":                metadata.ExpirationDate != nil && !now.Before(*metadata.ExpirationDate),
+	}
+	if metadata.CreationDate != nil {
+		flattened["creation_date"] = metadata.CreationDate.Format(time.RFC3339)
+	}
+	if metadata.ExpirationDate != nil {
+		flattened["expiration_date"] = metadata.ExpirationDate.Format(time.RFC3339)
+	}
+	return flattened
+}
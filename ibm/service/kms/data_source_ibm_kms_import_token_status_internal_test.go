@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetKMSImportTokenMetadata(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		metadata *kp.ImportTokenMetadata
+		notFound bool
+	}{
+		{
+			name:   "import token",
+			status: http.StatusOK,
+			body:   `{"id": "4b5c6d7e", "creationDate": "2024-01-02T03:04:05Z", "expirationDate": "2024-01-02T04:04:05Z", "maxAllowedRetrievals": 3, "remainingRetrievals": 2, "payload": "cHVibGljIGtleQ==", "nonce": "bm9uY2U="}`,
+			metadata: &kp.ImportTokenMetadata{
+				ID:                   "4b5c6d7e",
+				CreationDate:         testKMSTime("2024-01-02T03:04:05Z"),
+				ExpirationDate:       testKMSTime("2024-01-02T04:04:05Z"),
+				MaxAllowedRetrievals: 3,
+				RemainingRetrievals:  2,
+			},
+		},
+		{
+			name:     "no import token",
+			status:   http.StatusNotFound,
+			body:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1}, "resources": [{"errorMsg": "Not Found: Import token does not exist"}]}`,
+			notFound: true,
+		},
+		{
+			name:   "forbidden",
+			status: http.StatusForbidden,
+			body:   `{"metadata": {"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1}, "resources": [{"errorMsg": "Forbidden"}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v2/import_token", r.URL.Path)
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+				assert.Equal(t, "instance", r.Header.Get("Bluemix-Instance"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+			api, err := kp.New(kp.ClientConfig{BaseURL: server.URL, Authorization: "Bearer token", InstanceID: "instance"}, nil)
+			assert.NoError(t, err)
+
+			metadata, err := getKMSImportTokenMetadata(context.Background(), api)
+			assert.Equal(t, tc.metadata, metadata)
+			if tc.metadata != nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, tc.notFound, kmsKeyNotFound(err))
+		})
+	}
+}
+
+func TestDataSourceIBMKMSImportTokenStatusRequiresAllowRetrieval(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSImportTokenStatus().Schema, map[string]interface{}{
+		"instance_id":     "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		"allow_retrieval": false,
+	})
+	diags := dataSourceIBMKMSImportTokenStatusRead(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "Set allow_retrieval to true")
+	assert.Empty(t, d.Id())
+}
+
+func TestFlattenKMSImportTokenMetadata(t *testing.T) {
+	metadata := &kp.ImportTokenMetadata{
+		ID:                   "4b5c6d7e",
+		CreationDate:         testKMSTime("2024-01-02T03:04:05Z"),
+		ExpirationDate:       testKMSTime("2024-01-02T04:04:05Z"),
+		MaxAllowedRetrievals: 3,
+		RemainingRetrievals:  2,
+	}
+	assert.Equal(t, map[string]interface{}{
+		"creation_date":          "2024-01-02T03:04:05Z",
+		"expiration_date":        "2024-01-02T04:04:05Z",
+		"max_allowed_retrievals": 3,
+		"remaining_retrievals":   2,
+		"expired":                false,
+	}, flattenKMSImportTokenMetadata(metadata, time.Date(2024, 1, 2, 4, 4, 4, 0, time.UTC)))
+	assert.Equal(t, true, flattenKMSImportTokenMetadata(metadata, time.Date(2024, 1, 2, 4, 4, 5, 0, time.UTC))["expired"])
+
+	flattened := flattenKMSImportTokenMetadata(&kp.ImportTokenMetadata{}, time.Now())
+	assert.Equal(t, false, flattened["expired"])
+	assert.NotContains(t, flattened, "expiration_date")
+}
+
+func testKMSTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
@@ -0,0 +1,194 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The largest page of keys that the list keys API returns. The API only reports the number of keys of each page, so
+// the keys of a state are counted by pages of this size.
+const kmsKeyCountPageSize = 5000
+
+// The key states that are counted, with the name of their entry in keys_by_state
+var kmsKeyCountStates = []struct {
+	Name  string
+	State kp.KeyState
+}{
+	{"active", kp.Active},
+	{"suspended", kp.Suspended},
+	{"deactivated", kp.Deactivated},
+	{"destroyed", kp.Destroyed},
+}
+
+// The key limits of the plans, by resource plan ID. The resource controller does not report the limits of the plans
+// and the current Key Protect and Hyper Protect Crypto Services plans do not cap the number of keys, so no plan has a
+// limit until a plan with a limit is added here.
+var kmsPlanKeyLimits = map[string]int{}
+
+func DataSourceIBMKMSInstanceKeyCount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSInstanceKeyCountRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"total_keys": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of keys of the instance in any state",
+			},
+			"keys_by_state": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The number of keys of the instance by state: active, suspended, deactivated and destroyed",
+			},
+			"key_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of keys of the plan of the instance. 0 means that the plan has no key limit",
+			},
+			"keys_remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of keys that can still be created before total_keys reaches key_limit. Not set when key_limit is 0, as the plan has no key limit",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSInstanceKeyCountRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, instanceData, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	if err := readKMSInstanceKeyCount(ctx, d, api, meta.(conns.ClientSession).CallCache(), api.URL.String(), instanceID, kmsPlanKeyLimit(instanceData)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Read the key counts of the instance with the given client, and the headroom of the key limit of its plan, 0 when the
+// plan has no key limit. The counts of an instance and endpoint are kept in the call cache of the session until the
+// provider process exits, so that every read of the Terraform operation reports the same counts.
+func readKMSInstanceKeyCount(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, cache *conns.CallCache, endpoint string, instanceID string, keyLimit int) error {
+	cached, err := cache.Do(fmt.Sprintf("kms_key_count/%s/%s", instanceID, endpoint), func() (interface{}, time.Time, error) {
+		counts, err := countKMSKeysByState(ctx, api, instanceID)
+		return counts, time.Time{}, err
+	})
+	if err != nil {
+		return err
+	}
+	counts := cached.(map[string]int)
+
+	total := 0
+	keysByState := make(map[string]interface{}, len(counts))
+	for state, count := range counts {
+		total += count
+		keysByState[state] = count
+	}
+	d.SetId(instanceID)
+	d.Set("total_keys", total)
+	d.Set("keys_by_state", keysByState)
+	d.Set("key_limit", keyLimit)
+	if keysRemaining, ok := kmsKeysRemaining(keyLimit, total); ok {
+		d.Set("keys_remaining", keysRemaining)
+	}
+	return nil
+}
+
+// Get the key limit of the plan of the instance, 0 when the plan has no key limit or the instance was not read from
+// the resource controller
+func kmsPlanKeyLimit(instanceData *rc.ResourceInstance) int {
+	if instanceData == nil || instanceData.ResourcePlanID == nil {
+		return 0
+	}
+	return kmsPlanKeyLimits[*instanceData.ResourcePlanID]
+}
+
+// Get the number of keys that can still be created under the key limit. Every key counts, whatever its state, and
+// the remaining keys are 0 when the total is over the limit. The second return value is false when there is no limit.
+func kmsKeysRemaining(keyLimit int, totalKeys int) (int, bool) {
+	if keyLimit <= 0 {
+		return 0, false
+	}
+	if totalKeys >= keyLimit {
+		return 0, true
+	}
+	return keyLimit - totalKeys, true
+}
+
+// Count the keys of the instance in each state of kmsKeyCountStates. The states are counted concurrently, and the
+// error of the first state in kmsKeyCountStates that failed is returned.
+func countKMSKeysByState(ctx context.Context, api kmsKeysAPI, instanceID string) (map[string]int, error) {
+	counts := make([]int, len(kmsKeyCountStates))
+	errs := make([]error, len(kmsKeyCountStates))
+	var wg sync.WaitGroup
+	for i, state := range kmsKeyCountStates {
+		wg.Add(1)
+		go func(i int, state kp.KeyState) {
+			defer wg.Done()
+			counts[i], errs[i] = countKMSKeysInState(ctx, api, state)
+		}(i, state.State)
+	}
+	wg.Wait()
+
+	keysByState := make(map[string]int, len(kmsKeyCountStates))
+	for i, state := range kmsKeyCountStates {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("[ERROR] Counting the %s keys of instance %s failed: %s", state.Name, instanceID, kmsAuthErrorHint(errs[i], instanceID))
+		}
+		keysByState[state.Name] = counts[i]
+	}
+	return keysByState, nil
+}
+
+// Count the keys in a state by listing them in pages of kmsKeyCountPageSize until a page is not full
+func countKMSKeysInState(ctx context.Context, api kmsKeysAPI, state kp.KeyState) (int, error) {
+	count := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		keys, err := getKMSKeysInStates(ctx, api, kmsKeyCountPageSize, count, []kp.KeyState{state})
+		if err != nil {
+			return count, err
+		}
+		count += keys.Metadata.NumberOfKeys
+		if keys.Metadata.NumberOfKeys < kmsKeyCountPageSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/go-sdk-core/v5/core"
+	kp "github.com/IBM/keyprotect-go-client"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKMSKeyCountAPI fakes the key protect client with a number of keys by state. It records the options of each
+// list call and how many calls were in flight at once.
+type testKMSKeyCountAPI struct {
+	testKMSKeysAPI
+	keysByState map[kp.KeyState]int
+	stateErrs   map[kp.KeyState]error
+
+	mu         sync.Mutex
+	options    []kp.ListKeysOptions
+	firstPages int
+	allStarted chan struct{}
+	inFlight   int
+	maxCalls   int
+}
+
+func (api *testKMSKeyCountAPI) ListKeys(ctx context.Context, listKeysOptions *kp.ListKeysOptions) (*kp.Keys, error) {
+	first := listKeysOptions.Offset != nil && *listKeysOptions.Offset == 0
+	api.mu.Lock()
+	api.options = append(api.options, *listKeysOptions)
+	api.inFlight++
+	if api.inFlight > api.maxCalls {
+		api.maxCalls = api.inFlight
+	}
+	if first {
+		api.firstPages++
+		if api.firstPages == len(kmsKeyCountStates) {
+			close(api.allStarted)
+		}
+	}
+	api.mu.Unlock()
+	defer func() {
+		api.mu.Lock()
+		api.inFlight--
+		api.mu.Unlock()
+	}()
+
+	// Hold the first page of each state until the first pages of every state were requested
+	if first {
+		select {
+		case <-api.allStarted:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("the states are not counted concurrently")
+		}
+	}
+
+	state := listKeysOptions.State[0]
+	if err := api.stateErrs[state]; err != nil {
+		return nil, err
+	}
+	remaining := api.keysByState[state] - int(*listKeysOptions.Offset)
+	if remaining < 0 {
+		remaining = 0
+	}
+	if remaining > int(*listKeysOptions.Limit) {
+		remaining = int(*listKeysOptions.Limit)
+	}
+	return &kp.Keys{Metadata: kp.KeysMetadata{NumberOfKeys: remaining}, Keys: make([]kp.Key, remaining)}, nil
+}
+
+func newTestKMSKeyCountAPI(keysByState map[kp.KeyState]int) *testKMSKeyCountAPI {
+	return &testKMSKeyCountAPI{keysByState: keysByState, allStarted: make(chan struct{})}
+}
+
+func TestCountKMSKeysByState(t *testing.T) {
+	api := newTestKMSKeyCountAPI(map[kp.KeyState]int{kp.Active: 12001, kp.Suspended: 3, kp.Destroyed: 5000})
+
+	counts, err := countKMSKeysByState(context.Background(), api, "instance")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"active": 12001, "suspended": 3, "deactivated": 0, "destroyed": 5000}, counts)
+
+	// The states are counted concurrently
+	assert.Equal(t, len(kmsKeyCountStates), api.maxCalls)
+
+	// Every request lists a single state with the largest page size, active keys take 3 pages and the 5000
+	// destroyed keys take a full page and an empty one
+	requests := map[kp.KeyState][]uint32{}
+	for _, options := range api.options {
+		assert.Len(t, options.State, 1)
+		assert.Equal(t, uint32(kmsKeyCountPageSize), *options.Limit)
+		requests[options.State[0]] = append(requests[options.State[0]], *options.Offset)
+	}
+	assert.Equal(t, map[kp.KeyState][]uint32{
+		kp.Active:      {0, 5000, 10000},
+		kp.Suspended:   {0},
+		kp.Deactivated: {0},
+		kp.Destroyed:   {0, 5000},
+	}, requests)
+}
+
+func TestCountKMSKeysByStateError(t *testing.T) {
+	api := newTestKMSKeyCountAPI(map[kp.KeyState]int{kp.Active: 1})
+	api.stateErrs = map[kp.KeyState]error{
+		kp.Deactivated: errors.New("deactivated failed"),
+		kp.Destroyed:   errors.New("destroyed failed"),
+	}
+
+	_, err := countKMSKeysByState(context.Background(), api, "instance")
+	assert.EqualError(t, err, "[ERROR] Counting the deactivated keys of instance instance failed: deactivated failed")
+}
+
+func TestReadKMSInstanceKeyCount(t *testing.T) {
+	cache := conns.NewCallCache()
+	api := newTestKMSKeyCountAPI(map[kp.KeyState]int{kp.Active: 7, kp.Deactivated: 2, kp.Destroyed: 1})
+	keyLimit := 0
+	read := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSInstanceKeyCount().Schema, map[string]interface{}{
+			"instance_id": "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		})
+		err := readKMSInstanceKeyCount(context.Background(), d, api, cache, "https://us-south.kms.cloud.ibm.com/api/v2/keys", "30372f20-d9f1-40b3-b486-a709e1932c9c", keyLimit)
+		assert.NoError(t, err)
+		return d
+	}
+
+	d := read()
+	assert.Equal(t, "30372f20-d9f1-40b3-b486-a709e1932c9c", d.Id())
+	assert.Equal(t, 10, d.Get("total_keys"))
+	assert.Equal(t, map[string]interface{}{"active": 7, "suspended": 0, "deactivated": 2, "destroyed": 1}, d.Get("keys_by_state"))
+	assert.Equal(t, 0, d.Get("key_limit"))
+	_, ok := d.GetOk("keys_remaining")
+	assert.False(t, ok)
+
+	// The counts stay the same for the rest of the operation, without listing the keys again
+	calls := len(api.options)
+	api.keysByState[kp.Active] = 8
+	d = read()
+	assert.Equal(t, 10, d.Get("total_keys"))
+	assert.Len(t, api.options, calls)
+
+	keyLimit = 25
+	d = read()
+	assert.Equal(t, 25, d.Get("key_limit"))
+	assert.Equal(t, 15, d.Get("keys_remaining"))
+}
+
+func TestKMSKeysRemaining(t *testing.T) {
+	testCases := []struct {
+		keyLimit      int
+		totalKeys     int
+		keysRemaining int
+		ok            bool
+	}{
+		{keyLimit: 0, totalKeys: 10},
+		{keyLimit: -1, totalKeys: 10},
+		{keyLimit: 20, totalKeys: 0, keysRemaining: 20, ok: true},
+		{keyLimit: 20, totalKeys: 12, keysRemaining: 8, ok: true},
+		{keyLimit: 20, totalKeys: 20, keysRemaining: 0, ok: true},
+		{keyLimit: 20, totalKeys: 25, keysRemaining: 0, ok: true},
+	}
+	for _, tc := range testCases {
+		keysRemaining, ok := kmsKeysRemaining(tc.keyLimit, tc.totalKeys)
+		assert.Equal(t, tc.ok, ok, "limit %d, total %d", tc.keyLimit, tc.totalKeys)
+		assert.Equal(t, tc.keysRemaining, keysRemaining, "limit %d, total %d", tc.keyLimit, tc.totalKeys)
+	}
+}
+
+func TestKMSPlanKeyLimit(t *testing.T) {
+	kmsPlanKeyLimits = map[string]int{"plan-with-limit": 20}
+	defer func() { kmsPlanKeyLimits = map[string]int{} }()
+
+	assert.Equal(t, 20, kmsPlanKeyLimit(&rc.ResourceInstance{ResourcePlanID: core.StringPtr("plan-with-limit")}))
+	assert.Equal(t, 0, kmsPlanKeyLimit(&rc.ResourceInstance{ResourcePlanID: core.StringPtr("plan-without-limit")}))
+	assert.Equal(t, 0, kmsPlanKeyLimit(&rc.ResourceInstance{}))
+	assert.Equal(t, 0, kmsPlanKeyLimit(nil))
+}
@@ -5,6 +5,8 @@ package kms
 
 import (
 	"context"
+	"fmt"
+	"log"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
@@ -15,16 +17,22 @@ import (
 
 func DataSourceIBMKmsInstancePolicies() *schema.Resource {
 	return &schema.Resource{
-		ReadContext: resourceIBMKmsInstancePolicyRead,
+		ReadContext: dataSourceIBMKmsInstancePoliciesRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
 			"instance_id": {
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
 				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
+			},
 			"policy_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -163,6 +171,61 @@ func DataSourceIBMKmsInstancePolicies() *schema.Resource {
 					},
 				},
 			},
+			"metrics_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the metrics policy of the instance is enabled, false when the policy is not set or the instance does not support it. Not set when policy_type is another policy",
+			},
+			"can_create_root_keys": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key create import access policy of the instance allows creating root keys, true when the policy is not set or disabled. Not set when policy_type is another policy",
+			},
+			"can_create_standard_keys": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key create import access policy of the instance allows creating standard keys, true when the policy is not set or disabled. Not set when policy_type is another policy",
+			},
+			"allowed_ip": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Data associated with the allowed IP policy for the instance, only read when policy_type is not set",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Data associated with enable/disable allowed IP policy on the instance.",
+						},
+						"ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The IPv4 or IPv6 CIDR blocks the instance accepts requests from.",
+						},
+						"created_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique identifier for the resource that created the policy.",
+						},
+						"creation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the policy was created. The date format follows RFC 3339.",
+						},
+						"updated_by": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique identifier for the resource that updated the policy.",
+						},
+						"last_updated": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Updates when the policy is replaced or modified. The date format follows RFC 3339.",
+						},
+					},
+				},
+			},
 			"metrics": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -197,13 +260,155 @@ func DataSourceIBMKmsInstancePolicies() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
+	}
+}
+
+func dataSourceIBMKmsInstancePoliciesRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourceIBMKmsInstancePolicyRead(context, d, meta); diags.HasError() {
+		return diags
+	}
+	policyType := d.Get("policy_type").(string)
+	if policyType == "" || policyType == kp.Metrics {
+		d.Set("metrics_enabled", kmsMetricsEnabled(d.Get("metrics").([]interface{})))
+	}
+	if policyType != "" && policyType != kp.KeyCreateImportAccess {
+		return nil
+	}
+
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	kpAPI, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return readKMSExtraInstancePolicies(context, d, kpAPI, instanceID, policyType)
+}
+
+// The methods of the Key Protect client that the data source reads the policies with, besides the instance policies
+// that the resource reads
+type kmsExtraInstancePoliciesAPI interface {
+	GetKeyCreateImportAccessInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error)
+	GetAllowedIPInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error)
+}
+
+// Read the policies that only the data source reads, for can_create_root_keys, can_create_standard_keys and allowed_ip.
+// A policy that the credentials are forbidden to read leaves its attributes unset with a warning, instead of failing
+// the policies that were read.
+func readKMSExtraInstancePolicies(ctx context.Context, d *schema.ResourceData, api kmsExtraInstancePoliciesAPI, instanceID string, policyType string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	keyCreateImportAccessPolicy, err := api.GetKeyCreateImportAccessInstancePolicy(ctx)
+	if err != nil {
+		if !kmsForbidden(err) {
+			return diag.Errorf("[ERROR] Error retrieving key create import access instance policy: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		diags = append(diags, kmsInstancePolicyForbiddenWarning(err, instanceID, "key create import access", "can_create_root_keys and can_create_standard_keys are"))
+	} else {
+		canCreateRootKeys, canCreateStandardKeys := kmsKeyCreationAllowed(keyCreateImportAccessPolicy)
+		d.Set("can_create_root_keys", canCreateRootKeys)
+		d.Set("can_create_standard_keys", canCreateStandardKeys)
+	}
+	if policyType != "" {
+		return diags
+	}
+
+	allowedIPPolicy, err := api.GetAllowedIPInstancePolicy(ctx)
+	if err != nil {
+		if !kmsForbidden(err) {
+			return diag.Errorf("[ERROR] Error retrieving allowed IP instance policy: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		return append(diags, kmsInstancePolicyForbiddenWarning(err, instanceID, "allowed IP", "allowed_ip is"))
+	}
+	d.Set("allowed_ip", flattenKMSAllowedIPInstancePolicy(allowedIPPolicy))
+	return diags
+}
+
+// The warning of an instance policy that the credentials are forbidden to read
+func kmsInstancePolicyForbiddenWarning(err error, instanceID string, policy string, attributes string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The %s policy of instance %s could not be read, %s not set", policy, instanceID, attributes),
+		Detail:   kmsAuthErrorHint(err, instanceID).Error(),
+	}
+}
+
+// Whether the key create import access policy allows creating root keys and standard keys. Keys of both types can be
+// created when the policy is not set or disabled. An enabled policy allows the key types whose attribute is true or
+// not reported, as the service defaults the attributes to true.
+func kmsKeyCreationAllowed(policy *kp.InstancePolicy) (rootKeys bool, standardKeys bool) {
+	if policy == nil || policy.PolicyData.Enabled == nil || !*policy.PolicyData.Enabled || policy.PolicyData.Attributes == nil {
+		return true, true
+	}
+	attributes := policy.PolicyData.Attributes
+	rootKeys = attributes.CreateRootKey == nil || *attributes.CreateRootKey
+	standardKeys = attributes.CreateStandardKey == nil || *attributes.CreateStandardKey
+	return rootKeys, standardKeys
+}
+
+// Flatten the allowed IP instance policy, which is nil when the policy was never set on the instance
+func flattenKMSAllowedIPInstancePolicy(policy *kp.InstancePolicy) []map[string]interface{} {
+	if policy == nil {
+		return []map[string]interface{}{}
+	}
+	policyInstance := map[string]interface{}{
+		"enabled":      policy.PolicyData.Enabled != nil && *policy.PolicyData.Enabled,
+		"ip_addresses": []string{},
+		"created_by":   policy.CreatedBy,
+		"updated_by":   policy.UpdatedBy,
+	}
+	if policy.CreatedAt != nil {
+		policyInstance["creation_date"] = policy.CreatedAt.String()
+	}
+	if policy.UpdatedAt != nil {
+		policyInstance["last_updated"] = policy.UpdatedAt.String()
+	}
+	if policy.PolicyData.Attributes != nil && policy.PolicyData.Attributes.AllowedIP != nil {
+		policyInstance["ip_addresses"] = []string(policy.PolicyData.Attributes.AllowedIP)
+	}
+	return []map[string]interface{}{policyInstance}
+}
+
+// Flatten the metrics instance policy, which is nil when the policy was never set on the instance or when the instance
+// does not support it
+func flattenKMSMetricsInstancePolicy(policy *kp.InstancePolicy) []map[string]interface{} {
+	if policy == nil {
+		return []map[string]interface{}{}
+	}
+	policyInstance := map[string]interface{}{
+		"enabled":    policy.PolicyData.Enabled != nil && *policy.PolicyData.Enabled,
+		"created_by": policy.CreatedBy,
+		"updated_by": policy.UpdatedBy,
+	}
+	if policy.CreatedAt != nil {
+		policyInstance["creation_date"] = policy.CreatedAt.String()
+	}
+	if policy.UpdatedAt != nil {
+		policyInstance["last_updated"] = policy.UpdatedAt.String()
+	}
+	return []map[string]interface{}{policyInstance}
+}
+
+// The instance policy of the given type in the policies of an instance, nil when it is not in them
+func kmsInstancePolicyOfType(policies []kp.InstancePolicy, policyType string) *kp.InstancePolicy {
+	for i := range policies {
+		if policies[i].PolicyType == policyType {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+// Whether the flattened metrics policy is enabled
+func kmsMetricsEnabled(metrics []interface{}) bool {
+	if len(metrics) == 0 || metrics[0] == nil {
+		return false
 	}
+	enabled, _ := metrics[0].(map[string]interface{})["enabled"].(bool)
+	return enabled
 }
 
 func resourceIBMKmsInstancePolicyRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
-	kpAPI, _, err := populateKPClient(d, meta, instanceID)
+	kpAPI, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -215,7 +420,7 @@ func resourceIBMKmsInstancePolicyRead(context context.Context, d *schema.Resourc
 			var dualAuthInstancePolicy []kp.InstancePolicy
 			instancePolicies, err := kpAPI.GetDualAuthInstancePolicy(context)
 			if err != nil {
-				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", err)
+				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", kmsAuthErrorHint(err, instanceID))
 			}
 			dualAuthInstancePolicy = append(dualAuthInstancePolicy, *instancePolicies)
 			d.Set("dual_auth_delete", flex.FlattenInstancePolicy("dual_auth_delete", dualAuthInstancePolicy))
@@ -224,25 +429,26 @@ func resourceIBMKmsInstancePolicyRead(context context.Context, d *schema.Resourc
 			var createImportAccessPolicy []kp.InstancePolicy
 			instancePolicies, err := kpAPI.GetKeyCreateImportAccessInstancePolicy(context)
 			if err != nil {
-				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", err)
+				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", kmsAuthErrorHint(err, instanceID))
 			}
 			createImportAccessPolicy = append(createImportAccessPolicy, *instancePolicies)
 			d.Set("key_create_import_access", flex.FlattenInstancePolicy("key_create_import_access", createImportAccessPolicy))
 
 		case "metrics":
-			var metricsPolicy []kp.InstancePolicy
-			instancePolicies, err := kpAPI.GetMetricsInstancePolicy(context)
+			instancePolicy, err := kpAPI.GetMetricsInstancePolicy(context)
 			if err != nil {
-				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", err)
+				if !kmsInstancePolicyTypeUnsupported(err) {
+					return diag.Errorf("[ERROR] Error retrieving instance policies: %s", kmsAuthErrorHint(err, instanceID))
+				}
+				log.Printf("[DEBUG] Instance %s does not support the metrics policy: %s", instanceID, err)
 			}
-			metricsPolicy = append(metricsPolicy, *instancePolicies)
-			d.Set("metrics", flex.FlattenInstancePolicy("metrics", metricsPolicy))
+			d.Set("metrics", flattenKMSMetricsInstancePolicy(instancePolicy))
 
 		case "rotation":
 			var rotationPolicy []kp.InstancePolicy
 			instancePolicies, err := kpAPI.GetRotationInstancePolicy(context)
 			if err != nil {
-				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", err)
+				return diag.Errorf("[ERROR] Error retrieving instance policies: %s", kmsAuthErrorHint(err, instanceID))
 			}
 			rotationPolicy = append(rotationPolicy, *instancePolicies)
 			d.Set("rotation", flex.FlattenInstancePolicy("rotation", rotationPolicy))
@@ -253,10 +459,10 @@ func resourceIBMKmsInstancePolicyRead(context context.Context, d *schema.Resourc
 	} else {
 		instancePolicies, err := kpAPI.GetInstancePolicies(context)
 		if err != nil {
-			return diag.Errorf("[ERROR] Error retrieving instance policies: %s", err)
+			return diag.Errorf("[ERROR] Error retrieving instance policies: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		d.Set("key_create_import_access", flex.FlattenInstancePolicy("key_create_import_access", instancePolicies))
-		d.Set("metrics", flex.FlattenInstancePolicy("metrics", instancePolicies))
+		d.Set("metrics", flattenKMSMetricsInstancePolicy(kmsInstancePolicyOfType(instancePolicies, kp.Metrics)))
 		d.Set("rotation", flex.FlattenInstancePolicy("rotation", instancePolicies))
 		d.Set("dual_auth_delete", flex.FlattenInstancePolicy("dual_auth_delete", instancePolicies))
 	}
@@ -0,0 +1,278 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenKMSAllowedIPInstancePolicy(t *testing.T) {
+	assert.Empty(t, flattenKMSAllowedIPInstancePolicy(nil))
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	enabled := true
+	policy := &kp.InstancePolicy{
+		CreatedBy:  "IBMid-creator",
+		CreatedAt:  &createdAt,
+		UpdatedBy:  "IBMid-updater",
+		UpdatedAt:  &createdAt,
+		PolicyType: kp.AllowedIP,
+		PolicyData: kp.PolicyData{
+			Enabled:    &enabled,
+			Attributes: &kp.Attributes{AllowedIP: kp.IPAddresses{"192.0.2.0/24", "2001:db8::/32"}},
+		},
+	}
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"enabled":       true,
+			"ip_addresses":  []string{"192.0.2.0/24", "2001:db8::/32"},
+			"created_by":    "IBMid-creator",
+			"creation_date": createdAt.String(),
+			"updated_by":    "IBMid-updater",
+			"last_updated":  createdAt.String(),
+		},
+	}, flattenKMSAllowedIPInstancePolicy(policy))
+
+	policy.PolicyData = kp.PolicyData{}
+	flattened := flattenKMSAllowedIPInstancePolicy(policy)
+	assert.Equal(t, false, flattened[0]["enabled"])
+	assert.Equal(t, []string{}, flattened[0]["ip_addresses"])
+}
+
+func TestKMSKeyCreationAllowed(t *testing.T) {
+	enabled, disabled := true, false
+	policy := func(policyEnabled *bool, createRootKey *bool, createStandardKey *bool) *kp.InstancePolicy {
+		return &kp.InstancePolicy{
+			PolicyType: kp.KeyCreateImportAccess,
+			PolicyData: kp.PolicyData{
+				Enabled:    policyEnabled,
+				Attributes: &kp.Attributes{CreateRootKey: createRootKey, CreateStandardKey: createStandardKey},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		policy       *kp.InstancePolicy
+		rootKeys     bool
+		standardKeys bool
+	}{
+		{"policy not set", nil, true, true},
+		{"policy without enabled", policy(nil, &disabled, &disabled), true, true},
+		{"disabled policy", policy(&disabled, &disabled, &disabled), true, true},
+		{"enabled policy without attributes", &kp.InstancePolicy{PolicyData: kp.PolicyData{Enabled: &enabled}}, true, true},
+		{"enabled policy with unreported attributes", policy(&enabled, nil, nil), true, true},
+		{"enabled policy allowing both", policy(&enabled, &enabled, &enabled), true, true},
+		{"enabled policy allowing root keys", policy(&enabled, &enabled, &disabled), true, false},
+		{"enabled policy allowing standard keys", policy(&enabled, &disabled, &enabled), false, true},
+		{"enabled policy allowing neither", policy(&enabled, &disabled, &disabled), false, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rootKeys, standardKeys := kmsKeyCreationAllowed(tc.policy)
+			assert.Equal(t, tc.rootKeys, rootKeys)
+			assert.Equal(t, tc.standardKeys, standardKeys)
+		})
+	}
+}
+
+func TestKMSMetricsInstancePolicyPayloads(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      int
+		metrics     string
+		all         string
+		flattened   []map[string]interface{}
+		enabled     bool
+		unsupported bool
+	}{
+		{
+			name:    "key protect",
+			status:  http.StatusOK,
+			metrics: `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 1}, "resources": [{"policy_type": "metrics", "policy_data": {"enabled": true}, "creationDate": "2024-01-02T03:04:05Z", "createdBy": "IBMid-creator", "lastUpdated": "2024-01-02T03:04:05Z", "updatedBy": "IBMid-updater"}]}`,
+			all:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 2}, "resources": [{"policy_type": "dualAuthDelete", "policy_data": {"enabled": false}, "creationDate": "2024-01-02T03:04:05Z", "lastUpdated": "2024-01-02T03:04:05Z"}, {"policy_type": "metrics", "policy_data": {"enabled": true}, "creationDate": "2024-01-02T03:04:05Z", "createdBy": "IBMid-creator", "lastUpdated": "2024-01-02T03:04:05Z", "updatedBy": "IBMid-updater"}]}`,
+			flattened: []map[string]interface{}{
+				{
+					"enabled":       true,
+					"created_by":    "IBMid-creator",
+					"creation_date": "2024-01-02 03:04:05 +0000 UTC",
+					"updated_by":    "IBMid-updater",
+					"last_updated":  "2024-01-02 03:04:05 +0000 UTC",
+				},
+			},
+			enabled: true,
+		},
+		{
+			name:    "key protect without metrics policy",
+			status:  http.StatusOK,
+			metrics: `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 0}}`,
+			all:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 0}}`,
+		},
+		{
+			name:    "hyper protect crypto services",
+			status:  http.StatusOK,
+			metrics: `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 1}, "resources": [{"policy_type": "metrics", "policy_data": {"enabled": false}}]}`,
+			all:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 1}, "resources": [{"policy_type": "metrics", "policy_data": {"enabled": false}}]}`,
+			flattened: []map[string]interface{}{
+				{"enabled": false, "created_by": "", "updated_by": ""},
+			},
+		},
+		{
+			name:        "hyper protect crypto services without metrics support",
+			status:      http.StatusBadRequest,
+			metrics:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1}, "resources": [{"errorMsg": "Bad Request: The query parameter 'policy' has an unsupported value", "reasons": [{"code": "INVALID_QUERY_PARAM_ERR", "status": 400}]}]}`,
+			all:         `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 1}, "resources": [{"policy_type": "dualAuthDelete", "policy_data": {"enabled": true}}]}`,
+			unsupported: true,
+		},
+		{
+			name:    "bad request",
+			status:  http.StatusBadRequest,
+			metrics: `{"metadata": {"collectionType": "application/vnd.ibm.kms.error+json", "collectionTotal": 1}, "resources": [{"errorMsg": "Bad Request: The request is not valid", "reasons": [{"code": "BAD_BODY_ERR", "status": 400}]}]}`,
+			all:     `{"metadata": {"collectionType": "application/vnd.ibm.kms.policy+json", "collectionTotal": 1}, "resources": [{"policy_type": "dualAuthDelete", "policy_data": {"enabled": true}}]}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Query().Get("policy") == kp.Metrics {
+					w.WriteHeader(tc.status)
+					_, _ = w.Write([]byte(tc.metrics))
+					return
+				}
+				_, _ = w.Write([]byte(tc.all))
+			}))
+			defer server.Close()
+			api, err := kp.New(kp.ClientConfig{BaseURL: server.URL, Authorization: "Bearer token", InstanceID: "instance"}, nil)
+			assert.NoError(t, err)
+
+			flattened := tc.flattened
+			if flattened == nil {
+				flattened = []map[string]interface{}{}
+			}
+			policy, err := api.GetMetricsInstancePolicy(context.Background())
+			assert.Equal(t, tc.unsupported, kmsInstancePolicyTypeUnsupported(err))
+			assert.Equal(t, flattened, flattenKMSMetricsInstancePolicy(policy))
+
+			policies, err := api.GetInstancePolicies(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, flattened, flattenKMSMetricsInstancePolicy(kmsInstancePolicyOfType(policies, kp.Metrics)))
+
+			metrics := []interface{}{}
+			for _, m := range flattened {
+				metrics = append(metrics, m)
+			}
+			assert.Equal(t, tc.enabled, kmsMetricsEnabled(metrics))
+		})
+	}
+}
+
+type testKMSExtraInstancePoliciesAPI struct {
+	keyCreateImportAccessErr error
+	allowedIPErr             error
+}
+
+func (api *testKMSExtraInstancePoliciesAPI) GetKeyCreateImportAccessInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error) {
+	if api.keyCreateImportAccessErr != nil {
+		return nil, api.keyCreateImportAccessErr
+	}
+	enabled, createStandardKey := true, false
+	return &kp.InstancePolicy{PolicyData: kp.PolicyData{Enabled: &enabled, Attributes: &kp.Attributes{CreateStandardKey: &createStandardKey}}}, nil
+}
+
+func (api *testKMSExtraInstancePoliciesAPI) GetAllowedIPInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error) {
+	if api.allowedIPErr != nil {
+		return nil, api.allowedIPErr
+	}
+	enabled := true
+	return &kp.InstancePolicy{PolicyData: kp.PolicyData{Enabled: &enabled, Attributes: &kp.Attributes{AllowedIP: []string{"10.0.0.0/8"}}}}, nil
+}
+
+func TestReadKMSExtraInstancePolicies(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c04"
+	forbidden := &kp.Error{StatusCode: http.StatusForbidden, Message: "Forbidden"}
+	testCases := []struct {
+		name       string
+		api        *testKMSExtraInstancePoliciesAPI
+		policyType string
+		warnings   []string
+		err        string
+		canCreate  bool
+		allowedIPs int
+	}{
+		{
+			name:       "all policies",
+			api:        &testKMSExtraInstancePoliciesAPI{},
+			canCreate:  true,
+			allowedIPs: 1,
+		},
+		{
+			name:       "key create import access forbidden",
+			api:        &testKMSExtraInstancePoliciesAPI{keyCreateImportAccessErr: forbidden},
+			warnings:   []string{"The key create import access policy of instance " + instanceID + " could not be read, can_create_root_keys and can_create_standard_keys are not set"},
+			allowedIPs: 1,
+		},
+		{
+			name:      "allowed IP forbidden",
+			api:       &testKMSExtraInstancePoliciesAPI{allowedIPErr: forbidden},
+			warnings:  []string{"The allowed IP policy of instance " + instanceID + " could not be read, allowed_ip is not set"},
+			canCreate: true,
+		},
+		{
+			name: "both forbidden",
+			api:  &testKMSExtraInstancePoliciesAPI{keyCreateImportAccessErr: forbidden, allowedIPErr: forbidden},
+			warnings: []string{
+				"The key create import access policy of instance " + instanceID + " could not be read, can_create_root_keys and can_create_standard_keys are not set",
+				"The allowed IP policy of instance " + instanceID + " could not be read, allowed_ip is not set",
+			},
+		},
+		{
+			name:       "allowed IP not read with a policy type",
+			api:        &testKMSExtraInstancePoliciesAPI{allowedIPErr: forbidden},
+			policyType: kp.KeyCreateImportAccess,
+			canCreate:  true,
+		},
+		{
+			name: "other errors fail the read",
+			api:  &testKMSExtraInstancePoliciesAPI{allowedIPErr: &kp.Error{StatusCode: http.StatusInternalServerError, Message: "Internal Server Error"}},
+			err:  "Error retrieving allowed IP instance policy",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, DataSourceIBMKmsInstancePolicies().Schema, map[string]interface{}{"instance_id": instanceID, "policy_type": tc.policyType})
+			diags := readKMSExtraInstancePolicies(context.Background(), d, tc.api, instanceID, tc.policyType)
+			if tc.err != "" {
+				if assert.True(t, diags.HasError()) {
+					assert.Contains(t, diags[0].Summary, tc.err)
+				}
+				return
+			}
+			assert.False(t, diags.HasError())
+			summaries := []string{}
+			for _, warning := range diags {
+				assert.Equal(t, diag.Warning, warning.Severity)
+				assert.Contains(t, warning.Detail, "allowed_network and allowed_ip policies of instance "+instanceID)
+				summaries = append(summaries, warning.Summary)
+			}
+			assert.ElementsMatch(t, tc.warnings, summaries)
+
+			_, set := d.GetOk("can_create_root_keys")
+			assert.Equal(t, tc.canCreate, set)
+			if tc.canCreate {
+				assert.False(t, d.Get("can_create_standard_keys").(bool))
+			}
+			assert.Len(t, d.Get("allowed_ip").([]interface{}), tc.allowedIPs)
+		})
+	}
+}
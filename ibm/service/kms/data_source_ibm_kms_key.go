@@ -5,53 +5,192 @@ package kms
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func DataSourceIBMKMSkey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceIBMKMSKeyRead,
+		ReadContext: dataSourceIBMKMSKeyRead,
 
-		Schema: map[string]*schema.Schema{
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
 			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"service": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Key protect or hpcs instance GUID",
+				Computed:    true,
+				Description: "The service of the instance, kms for key protect and hs-crypto for hpcs",
+			},
+			"allowed_network": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The networks the instance accepts requests from, public-and-private or private-only, read from policy_endpoint_url or the resource controller when they are known. Not set when the allowed network policy of the instance cannot be read",
+			},
+			"instance_guid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Key protect or hpcs instance GUID normalized from instance_id",
 			},
 			"limit": {
-				Type:        schema.TypeInt,
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"scan_limit"},
+				Deprecated:    "Use scan_limit instead, limit caps the number of keys that are scanned and not the number of keys that are returned",
+				Description:   "The maximum number of keys to scan when looking up key_name or listing the keys to find alias. Deprecated, use scan_limit, which has the same behavior",
+			},
+			"scan_limit": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"limit"},
+				Description:   "The maximum number of keys to scan when looking up key_name or listing the keys to find alias, every key by default. A key beyond the scanned keys is not found, even when its name matches. Replaces limit",
+			},
+			"result_limit": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"max_results"},
+				Description:   "The maximum number of matched keys to return, every match by default. The keys are truncated after they are scanned, filtered and sorted, so it does not change which keys are scanned. Replaces max_results",
+			},
+			"first_page_only": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Limit till the keys to be fetched",
+				Default:     false,
+				Description: "Whether to look up key_name in the first 2000 keys of the instance only, with a single request, when scan_limit and limit are not set. The keys beyond them are not found",
+			},
+			"created_after": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"alias", "key_id"},
+				Description:   "Only look up key_name in the keys created at or after this RFC3339 timestamp, inclusive. The keys without a creation date are excluded",
+			},
+			"created_before": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"alias", "key_id"},
+				Description:   "Only look up key_name in the keys created before this RFC3339 timestamp, exclusive. The keys without a creation date are excluded",
+			},
+			"max_pages": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      kmsKeyDefaultMaxPages,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of pages of keys to list when looking up a key by key_name. The lookup fails when it is reached",
 			},
 			"key_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
+				Description:  "The id of the key to be fetched. When the key is looked up by key_name or alias and exactly one key matches, the id of that key",
 				ExactlyOneOf: []string{"alias", "key_name", "key_id"},
 			},
+			"key_crn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CRN of the key when exactly one key matches",
+			},
 			"key_name": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "The name of the key to be fetched",
 				ExactlyOneOf: []string{"alias", "key_name", "key_id"},
 			},
+			"key_ring_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return keys of this key ring. The lookup fails when the key ring does not exist in the instance",
+			},
+			"sort": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues(kmsKeySortValues),
+				Description:  "Sort the matched keys by name, creation_date or last_rotate_date, descending when prefixed with -",
+			},
+			"max_results": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ValidateFunc:  validation.IntAtLeast(1),
+				ConflictsWith: []string{"result_limit"},
+				Deprecated:    "Use result_limit instead, which has the same behavior",
+				Description:   "The maximum number of matched keys to return. The keys are truncated after they are filtered and sorted. Deprecated, use result_limit, which has the same behavior",
+			},
+			"check_registrations": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to count the registrations of each key in registration_count, with one request per key",
+			},
+			"allow_missing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to succeed with an empty keys list and found set to false when no key matches the lookup, instead of failing",
+			},
+			"found": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a key matches the lookup. It is only false when allow_missing is set",
+			},
+			"fail_if_multiple": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail when more than one key matches key_name instead of returning all of them",
+			},
 			"alias": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Description:  "The alias associated with the key",
 				ExactlyOneOf: []string{"alias", "key_name", "key_id"},
 			},
+			"alias_list_fallback": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to look up the alias in the listed keys when the service rejects the request for the key by alias as forbidden or not found. Set it to false to fail fast",
+			},
 			"endpoint_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
-				Description:  "public or private",
-				Default:      "public",
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
+			},
+			"policy_endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL to read the policies of the keys from, such as the endpoint of the primary region of a hs-crypto instance with failover. The other requests use the endpoint of the instance",
 			},
 			"keys": {
 				Type:     schema.TypeList,
@@ -63,6 +202,16 @@ func DataSourceIBMKMSkey() *schema.Resource {
 							Computed: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+						"alias_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of aliases of the key",
+						},
+						"aliases_remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of aliases that can still be created for the key, under the limit of aliases per key of the service",
+						},
 						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
@@ -70,7 +219,12 @@ func DataSourceIBMKMSkey() *schema.Resource {
 						"key_ring_id": {
 							Type:        schema.TypeString,
 							Computed:    true,
-							Description: "The key ring id of the key to be fetched",
+							Description: "The key ring id of the key to be fetched, default for the keys of the default key ring",
+						},
+						"registration_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of registrations of the key, the resources that the key protects. Only set when check_registrations is set",
 						},
 						"crn": {
 							Type:     schema.TypeString,
@@ -85,8 +239,111 @@ func DataSourceIBMKMSkey() *schema.Resource {
 							Computed: true,
 						},
 						"standard_key": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key is a standard key, false for root keys",
+							Deprecated:  "Use key_type or extractable instead",
+						},
+						"key_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the key, root or standard",
+						},
+						"extractable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key material can leave the service, true for standard keys and false for root keys",
+						},
+						"state": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The key state, 0 for pre-activation, 1 for active, 2 for suspended (disabled), 3 for deactivated and 5 for destroyed",
+						},
+						"imported": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key material was imported or generated by the service",
+						},
+						"algorithm_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The algorithm type of the key",
+						},
+						"deletion_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the key was deleted, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"purge_allowed_from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time from which the deleted key can be purged, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"purge_scheduled_on": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the deleted key is scheduled to be purged, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"restorable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the destroyed key can still be restored, which is until restore_expires_at. Not set for keys that are not destroyed",
+						},
+						"restore_expires_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time until which the destroyed key can be restored, 30 days after its deletion_date, in RFC 3339 format. Not set for keys that are not destroyed",
+						},
+						"dual_auth_delete_enabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether deleting the key requires an authorization from two users",
+						},
+						"rotation_overdue": {
 							Type:     schema.TypeBool,
 							Computed: true,
+							Description: "Whether the rotation of the key is overdue: true when a rotation policy is enabled and more than interval_month " +
+								"months passed since the last rotation of the key, or since its creation when it was never rotated. False without an enabled rotation policy",
+						},
+						"last_update_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+							Description: "The time of the last update of the key metadata, in RFC 3339 format, empty when the service does not report it. " +
+								"Updates of the key policies do not change it",
+						},
+						"crn_components": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The components of the key CRN, empty when the CRN cannot be parsed",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"account_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the account of the key",
+									},
+									"region": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The region of the instance",
+									},
+									"service_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The service of the instance, kms for key protect and hs-crypto for hpcs",
+									},
+									"instance_guid": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The GUID of the instance",
+									},
+									"key_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the key",
+									},
+								},
+							},
 						},
 						"policies": {
 							Type:     schema.TypeList,
@@ -131,6 +388,7 @@ func DataSourceIBMKMSkey() *schema.Resource {
 													Type:     schema.TypeBool,
 													Computed: true,
 												},
+												"policy_api_version": kmsPolicyAPIVersionSchema(),
 											},
 										},
 									},
@@ -168,6 +426,7 @@ func DataSourceIBMKMSkey() *schema.Resource {
 													Type:     schema.TypeBool,
 													Computed: true,
 												},
+												"policy_api_version": kmsPolicyAPIVersionSchema(),
 											},
 										},
 									},
@@ -177,66 +436,130 @@ func DataSourceIBMKMSkey() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
-func dataSourceIBMKMSKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceIBMKMSKeyRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
-	api, _, err := populateKPClient(d, meta, instanceID)
+	api, instanceData, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("service", kmsInstanceService(kmsInstanceCRN(instanceData), d.Get("instance_id").(string), api.URL.String()))
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	lookupClient := kmsKeyLookupClient{Client: api, instanceAllowedNetwork: kmsInstanceAllowedNetwork(instanceData)}
+	if policyEndpointURL := strings.TrimSpace(d.Get("policy_endpoint_url").(string)); policyEndpointURL != "" {
+		policyURL, err := kmsOverrideEndpointURL(policyEndpointURL)
+		if err != nil {
+			return diag.Errorf("[ERROR] Error Parsing KMS policy_endpoint_url %q, it must be an absolute URL such as https://us-south.kms.cloud.ibm.com", policyEndpointURL)
+		}
+		lookupClient.policyClient = kmsClientWithEndpointURL(api, policyURL)
+	}
+	diags, err := readKMSKey(ctx, d, meta, lookupClient, api.URL.String(), instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+// Bound the read of the data source with its read timeout, which also stops the pagination of name lookups
+func contextWithKMSReadTimeout(ctx context.Context, d *schema.ResourceData) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, d.Timeout(schema.TimeoutRead))
+}
+
+// Read the keys of the data source with the given client. The endpoint of the client is part of the lookup cache key.
+// A warning is returned for each key of a name lookup that was deleted before its policies were read.
+func readKMSKey(ctx context.Context, d *schema.ResourceData, meta interface{}, api kmsKeyLookupAPI, endpoint string, instanceID string) (diag.Diagnostics, error) {
+	endpointType := kmsEndpointType(d, meta)
+	d.Set("endpoint_type", endpointType)
+	allowedNetwork, err := getKMSAllowedNetwork(ctx, meta, api, instanceID)
+	if err != nil {
+		log.Printf("[WARN] Failed to read the allowed network policy of instance %s: %s", instanceID, err)
+	}
+	if err := validateKMSEndpointURL(endpoint, allowedNetwork); err != nil {
+		return nil, err
+	}
+	d.Set("allowed_network", allowedNetwork)
+
+	cacheKey := kmsKeyLookupCacheKey(instanceID, endpoint, d)
+	result, err := kmsKeyLookupCacheDo(meta, cacheKey, func() (*kmsKeyLookupResult, error) {
+		return lookupKMSKeys(ctx, d, api, instanceID)
+	})
+	// a lookup without match is read as an empty result with allow_missing, the key_id of a lookup by key_id is kept
+	if kmsKeyIsMissing(err) && d.Get("allow_missing").(bool) {
+		log.Printf("[DEBUG] No key matches the lookup in instance %s, reading it as not found as allow_missing is set: %s", instanceID, err)
+		result, err = &kmsKeyLookupResult{Keys: []map[string]interface{}{}, KeyID: d.Get("key_id").(string)}, nil
+	}
 	if err != nil {
-		return err
+		return nil, err
 	}
-	var totalKeys []kp.Key
 
+	d.SetId(instanceID)
+	d.Set("keys", result.Keys)
+	d.Set("instance_guid", instanceID)
+	d.Set("key_id", result.KeyID)
+	d.Set("key_crn", result.KeyCRN)
+	d.Set("found", len(result.Keys) > 0)
+	return append(kmsDeletedKeysWarnings(result.DeletedKeyIDs, instanceID), result.Warnings...), nil
+}
+
+// Look up the keys of the instance by key_name, key_id or alias, and flatten them with their policies
+func lookupKMSKeys(ctx context.Context, d *schema.ResourceData, api kmsKeyLookupAPI, instanceID string) (*kmsKeyLookupResult, error) {
+	keyRingID := d.Get("key_ring_id").(string)
+	if keyRingID != "" {
+		if err := validateKMSKeyRingExists(ctx, api, keyRingID, instanceID); err != nil {
+			return nil, err
+		}
+	}
 	if v, ok := d.GetOk("key_name"); ok {
-		limit := d.Get("limit")
-		limitVal := limit.(int)
+		createdAfter, createdBefore, err := kmsKeyCreationDateRange(d)
+		if err != nil {
+			return nil, err
+		}
+		var totalKeys []kp.Key
+		limitVal := kmsKeyScanLimit(d)
 		offset := 0
-		//default page size of API is 200 as stated
-		pageSize := 200
 
-		// when the limit is not passed, the api works in default way to avoid backward compatibility issues
-
-		if limitVal == 0 {
-			keys, err := api.GetKeys(context.Background(), 0, offset)
+		// when no scan limit is passed, all the keys are listed by pages, unless first_page_only restores the single
+		// request of the first 2000 keys, which misses the keys beyond them
+		if limitVal == 0 && d.Get("first_page_only").(bool) {
+			keys, err := getKMSKeysInStates(ctx, api, 0, offset, kmsKeyLookupStates)
 			if err != nil {
-				return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
+				return nil, kmsKeysListError(ctx, err, instanceID, 0)
 			}
 			retreivedKeys := keys.Keys
 			totalKeys = append(totalKeys, retreivedKeys...)
 		} else {
-			// when the limit is passed by the user
-			for {
-				if offset < limitVal {
-					if (limitVal - offset) < pageSize {
-						keys, err := api.GetKeys(context.Background(), (limitVal - offset), offset)
-						if err != nil {
-							return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-						}
-						retreivedKeys := keys.Keys
-						totalKeys = append(totalKeys, retreivedKeys...)
-						break
-					} else {
-						keys, err := api.GetKeys(context.Background(), pageSize, offset)
-						if err != nil {
-							return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-						}
-						numOfKeysFetched := keys.Metadata.NumberOfKeys
-						retreivedKeys := keys.Keys
-						totalKeys = append(totalKeys, retreivedKeys...)
-						if numOfKeysFetched < pageSize || offset+pageSize == limitVal {
-							break
-						}
-
-						offset = offset + pageSize
-					}
-				}
+			// the keys are listed by pages until the limit when it is passed by the user, the last page, the read
+			// timeout or max_pages is reached
+			var err error
+			totalKeys, err = listKMSKeys(ctx, kmsKeysInLookupStates(api), limitVal, d.Get("max_pages").(int), instanceID)
+			if err != nil {
+				return nil, err
 			}
 		}
 
 		if len(totalKeys) == 0 {
-			return fmt.Errorf("[ERROR] No keys in instance %s", instanceID)
+			return nil, kmsKeyMissing(fmt.Errorf("[ERROR] No keys in instance %s", instanceID))
+		}
+		scannedKeys := len(totalKeys)
+		// a key that is beyond the scanned keys may still match, so only a lookup that scanned every key of the
+		// instance can report that no key matches
+		scanLimit := limitVal
+		if limitVal == 0 && d.Get("first_page_only").(bool) {
+			scanLimit = 2000
+		}
+		scanComplete := scanLimit == 0 || scannedKeys < scanLimit
+		// the keys outside of the creation date range are discarded before their policies are read
+		totalKeys = filterKMSKeysByCreationDate(totalKeys, createdAfter, createdBefore)
+		if len(totalKeys) == 0 {
+			return nil, kmsKeyMissingIf(scanComplete, fmt.Errorf("[ERROR] No keys created in the range of created_after and created_before in instance %s, %d keys scanned", instanceID, scannedKeys))
+		}
+		totalKeys = filterKMSKeysByKeyRing(totalKeys, keyRingID)
+		if len(totalKeys) == 0 {
+			return nil, kmsKeyMissingIf(scanComplete, fmt.Errorf("[ERROR] No keys in key ring %s of instance %s", keyRingID, instanceID))
 		}
 		var keyName string
 		var matchKeys []kp.Key
@@ -250,94 +573,684 @@ func dataSourceIBMKMSKeyRead(d *schema.ResourceData, meta interface{}) error {
 		} else {
 			matchKeys = totalKeys
 		}
+		if len(matchKeys) == 0 && keyRingID != "" {
+			return nil, kmsKeyMissingIf(scanComplete, fmt.Errorf("[ERROR] No keys with name %s in key ring %s of instance %s, %d keys scanned", keyName, keyRingID, instanceID, scannedKeys))
+		}
 		if len(matchKeys) == 0 {
-			return fmt.Errorf("[ERROR] No keys with name %s in instance  %s", keyName, instanceID)
+			return nil, kmsKeyNameNotFoundError(ctx, api, keyName, instanceID, scannedKeys, createdAfter, createdBefore, scanComplete)
 		}
+		if len(matchKeys) > 1 && d.Get("fail_if_multiple").(bool) {
+			return nil, kmsKeyMultipleMatchesError(keyName, instanceID, matchKeys)
+		}
+		matchKeys = truncateKMSKeys(sortKMSKeys(matchKeys, d.Get("sort").(string)), kmsKeyResultLimit(d))
 
-		keyMap := make([]map[string]interface{}, 0, len(matchKeys))
-
-		for _, key := range matchKeys {
-			keyInstance := make(map[string]interface{})
-			keyInstance["id"] = key.ID
-			keyInstance["name"] = key.Name
-			keyInstance["crn"] = key.CRN
-			keyInstance["standard_key"] = key.Extractable
-			keyInstance["aliases"] = key.Aliases
-			keyInstance["key_ring_id"] = key.KeyRingID
-			keyInstance["description"] = key.Description
-			policies, err := api.GetPolicies(context.Background(), key.ID)
-			if err != nil {
-				return fmt.Errorf("[ERROR] Failed to read policies: %s", err)
-			}
-			if len(policies) == 0 {
-				log.Printf("No Policy Configurations read\n")
-			} else {
-				keyInstance["policies"] = flex.FlattenKeyPolicies(policies)
-			}
-			keyMap = append(keyMap, keyInstance)
+		// a key that is deleted after the listing is skipped, the other keys are still returned
+		matchKeys, keyPolicies, deletedKeyIDs, err := getKMSKeysPoliciesSkipDeleted(matchKeys, func(keyID string) ([]kp.Policy, error) {
+			return api.GetPolicies(ctx, keyID)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[ERROR] Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		if len(matchKeys) == 0 {
+			return nil, kmsKeyMissing(fmt.Errorf("[ERROR] No keys with name %s in instance %s, the matching keys %s were deleted while they were read", keyName, instanceID, strings.Join(deletedKeyIDs, ", ")))
+		}
 
+		result := &kmsKeyLookupResult{DeletedKeyIDs: deletedKeyIDs}
+		if err := setKMSKeyDataSourceKeys(ctx, d, api, result, matchKeys, keyPolicies, instanceID); err != nil {
+			return nil, err
 		}
-		d.SetId(instanceID)
-		d.Set("keys", keyMap)
-		d.Set("instance_id", instanceID)
+		if len(matchKeys) == 1 {
+			result.KeyID = matchKeys[0].ID
+			result.KeyCRN = matchKeys[0].CRN
+		}
+		return result, nil
 	} else if v, ok := d.GetOk("key_id"); ok {
-		key, err := api.GetKey(context.Background(), v.(string))
+		key, err := api.GetKey(ctx, v.(string))
+		if err != nil && kmsKeyNotFound(err) {
+			return nil, kmsKeyMissing(fmt.Errorf("[ERROR] Get Keys failed with error: %s", err))
+		}
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-		}
-		keyMap := make([]map[string]interface{}, 0, 1)
-		keyInstance := make(map[string]interface{})
-		keyInstance["id"] = key.ID
-		keyInstance["name"] = key.Name
-		keyInstance["crn"] = key.CRN
-		keyInstance["standard_key"] = key.Extractable
-		keyInstance["description"] = key.Description
-		keyInstance["aliases"] = key.Aliases
-		keyInstance["key_ring_id"] = key.KeyRingID
-		policies, err := api.GetPolicies(context.Background(), key.ID)
+			return nil, fmt.Errorf("[ERROR] Get Keys failed with error: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		if err := validateKMSKeyInKeyRing(*key, keyRingID, instanceID); err != nil {
+			return nil, kmsKeyMissing(err)
+		}
+		policies, err := api.GetPolicies(ctx, key.ID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Failed to read policies: %s", err)
+			return nil, fmt.Errorf("[ERROR] Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
 		}
-		if len(policies) == 0 {
-			log.Printf("No Policy Configurations read\n")
-		} else {
-			keyInstance["policies"] = flex.FlattenKeyPolicies(policies)
+		result := &kmsKeyLookupResult{KeyID: v.(string), KeyCRN: key.CRN}
+		if err := setKMSKeyDataSourceKeys(ctx, d, api, result, []kp.Key{*key}, [][]kp.Policy{policies}, instanceID); err != nil {
+			return nil, err
 		}
-		keyMap = append(keyMap, keyInstance)
-
-		d.SetId(instanceID)
-		d.Set("keys", keyMap)
-		d.Set("instance_id", instanceID)
+		return result, nil
 	} else {
 		aliasName := d.Get("alias").(string)
-		key, err := api.GetKey(context.Background(), aliasName)
+		key, err := getKMSKeyByAlias(ctx, d, api, aliasName, instanceID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-		}
-		keyMap := make([]map[string]interface{}, 0, 1)
-		keyInstance := make(map[string]interface{})
-		keyInstance["id"] = key.ID
-		keyInstance["name"] = key.Name
-		keyInstance["crn"] = key.CRN
-		keyInstance["standard_key"] = key.Extractable
-		keyInstance["description"] = key.Description
-		keyInstance["aliases"] = key.Aliases
-		keyInstance["key_ring_id"] = key.KeyRingID
-		policies, err := api.GetPolicies(context.Background(), key.ID)
+			return nil, err
+		}
+		if err := validateKMSKeyInKeyRing(*key, keyRingID, instanceID); err != nil {
+			return nil, kmsKeyMissing(err)
+		}
+		policies, err := api.GetPolicies(ctx, key.ID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Failed to read policies: %s", err)
+			return nil, fmt.Errorf("[ERROR] Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
+		}
+		result := &kmsKeyLookupResult{KeyID: key.ID, KeyCRN: key.CRN}
+		if err := setKMSKeyDataSourceKeys(ctx, d, api, result, []kp.Key{*key}, [][]kp.Policy{policies}, instanceID); err != nil {
+			return nil, err
 		}
+		return result, nil
+	}
+}
+
+// Get the key with the alias. Instances with some network policies reject the requests for a key by alias while they
+// allow listing the keys, so when the service rejects the request as forbidden or not found, the key is looked up in
+// the aliases of the listed keys, unless alias_list_fallback is false. The error of the request is returned, with a
+// note that the listing was tried, when no listed key has the alias.
+func getKMSKeyByAlias(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, aliasName string, instanceID string) (*kp.Key, error) {
+	key, err := api.GetKey(ctx, aliasName)
+	if err == nil {
+		return key, nil
+	}
+	getErr := kmsAuthErrorHint(err, instanceID)
+	var kpError *kp.Error
+	if !d.Get("alias_list_fallback").(bool) || !errors.As(err, &kpError) || (kpError.StatusCode != http.StatusForbidden && kpError.StatusCode != http.StatusNotFound) {
+		if kmsKeyNotFound(err) {
+			return nil, kmsKeyMissing(fmt.Errorf("[ERROR] Get Keys failed with error: %s", getErr))
+		}
+		return nil, fmt.Errorf("[ERROR] Get Keys failed with error: %s", getErr)
+	}
+
+	log.Printf("[DEBUG] Get key by alias %s failed with status %d, looking up the alias in the keys of instance %s", aliasName, kpError.StatusCode, instanceID)
+	keys, listErr := listKMSKeys(ctx, kmsKeysInLookupStates(api), kmsKeyScanLimit(d), d.Get("max_pages").(int), instanceID)
+	if listErr != nil {
+		return nil, fmt.Errorf("[ERROR] Get Keys failed with error: %s. Listing the keys to find alias %s also failed: %s", getErr, aliasName, strings.TrimPrefix(listErr.Error(), "[ERROR] "))
+	}
+	for _, listedKey := range keys {
+		for _, alias := range listedKey.Aliases {
+			if alias == aliasName {
+				return &listedKey, nil
+			}
+		}
+	}
+	// the alias may be on a key beyond the scan limit, which is not reported as missing
+	scanLimit := kmsKeyScanLimit(d)
+	return nil, kmsKeyMissingIf(scanLimit == 0 || len(keys) < scanLimit, fmt.Errorf("[ERROR] Get Keys failed with error: %s. No key with alias %s was found by listing the %d keys of instance %s either", getErr, aliasName, len(keys), instanceID))
+}
+
+// The number of keys to scan when looking up a key, from scan_limit or from the deprecated limit, which has the same
+// behavior. 0 scans every key.
+func kmsKeyScanLimit(d *schema.ResourceData) int {
+	if scanLimit := d.Get("scan_limit").(int); scanLimit > 0 {
+		return scanLimit
+	}
+	return d.Get("limit").(int)
+}
+
+// The maximum number of matched keys to return, from result_limit or from the deprecated max_results, which has the
+// same behavior.
+// 0 returns every match.
+func kmsKeyResultLimit(d *schema.ResourceData) int {
+	if resultLimit := d.Get("result_limit").(int); resultLimit > 0 {
+		return resultLimit
+	}
+	return d.Get("max_results").(int)
+}
+
+// The pages of the keys of the instance that are in the lookup states
+func kmsKeysInLookupStates(api kmsKeysAPI) kmsKeysPageFunc {
+	return func(ctx context.Context, size int, offset int) (*kp.Keys, error) {
+		return getKMSKeysInStates(ctx, api, size, offset, kmsKeyLookupStates)
+	}
+}
+
+// Flatten the keys of a lookup into the keys of the result, keyPolicies[i] being the policies of keys[i], with the
+// warnings of the registrations that could not be counted.
+func setKMSKeyDataSourceKeys(ctx context.Context, d *schema.ResourceData, api kmsRegistrationsAPI, result *kmsKeyLookupResult, keys []kp.Key, keyPolicies [][]kp.Policy, instanceID string) error {
+	now := time.Now()
+	for i, key := range keys {
+		keyInstance := flex.FlattenKMSKey(key)
+		policies := keyPolicies[i]
 		if len(policies) == 0 {
 			log.Printf("No Policy Configurations read\n")
 		} else {
 			keyInstance["policies"] = flex.FlattenKeyPolicies(policies)
 		}
-		keyMap = append(keyMap, keyInstance)
+		keyInstance["dual_auth_delete_enabled"] = kmsKeyDualAuthDeleteEnabled(key, policies)
+		keyInstance["rotation_overdue"] = kmsKeyRotationOverdue(key, policies, time.Now)
+		keyInstance["last_update_date"] = kmsKeyLastUpdateDate(key)
+		keyInstance["key_ring_id"] = kmsKeyRingID(key)
+		keyInstance["crn_components"] = flattenKMSKeyCRNComponents(key.CRN)
+		for attribute, value := range flattenKMSKeyAliasCapacity(key) {
+			keyInstance[attribute] = value
+		}
+		for attribute, value := range flattenKMSKeyRestore(key, now) {
+			keyInstance[attribute] = value
+		}
+		warnings, err := setKMSKeyRegistrationCount(ctx, d, api, keyInstance, key.ID, instanceID)
+		if err != nil {
+			return err
+		}
+		result.Warnings = append(result.Warnings, warnings...)
+		result.Keys = append(result.Keys, keyInstance)
+	}
+	return nil
+}
 
-		d.SetId(instanceID)
-		d.Set("keys", keyMap)
-		d.Set("instance_id", instanceID)
+// Set the registration_count of a key when check_registrations is set. When the registrations cannot be read for lack
+// of permissions, the count is left unset and a warning is returned, so that it is not read as a key without
+// registrations.
+func setKMSKeyRegistrationCount(ctx context.Context, d *schema.ResourceData, api kmsRegistrationsAPI, keyInstance map[string]interface{}, keyID string, instanceID string) (diag.Diagnostics, error) {
+	if !d.Get("check_registrations").(bool) {
+		return nil, nil
 	}
+	count, err := api.CountRegistrations(ctx, keyID)
+	if err != nil {
+		var kpError *kp.Error
+		if errors.As(err, &kpError) && (kpError.StatusCode == http.StatusUnauthorized || kpError.StatusCode == http.StatusForbidden) {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The registrations of key %s of instance %s could not be read, registration_count is not set", keyID, instanceID),
+				Detail:   kmsAuthErrorHint(err, instanceID).Error(),
+			}}, nil
+		}
+		return nil, fmt.Errorf("[ERROR] Failed to read the registrations of key %s: %s", keyID, kmsAuthErrorHint(err, instanceID))
+	}
+	keyInstance["registration_count"] = count
+	return nil, nil
+}
+
+// kmsDefaultKeyRingID is the key ring of the keys that are created without one
+const kmsDefaultKeyRingID = "default"
+
+// The key ring of the key. Key Protect reports the default key ring as default in some responses and leaves it empty
+// in others, so an empty key ring is normalized to default to keep the value stable across reads.
+func kmsKeyRingID(key kp.Key) string {
+	if key.KeyRingID == "" {
+		return kmsDefaultKeyRingID
+	}
+	return key.KeyRingID
+}
 
+// Keep the keys of the key ring, all the keys when keyRingID is empty
+func filterKMSKeysByKeyRing(keys []kp.Key, keyRingID string) []kp.Key {
+	if keyRingID == "" {
+		return keys
+	}
+	filtered := make([]kp.Key, 0, len(keys))
+	for _, key := range keys {
+		if kmsKeyRingID(key) == keyRingID {
+			filtered = append(filtered, key)
+		}
+	}
+	return filtered
+}
+
+// Keep the keys created in the range from createdAfter, inclusive, to createdBefore, exclusive, so that consecutive
+// ranges do not overlap. A nil bound is not checked, and all the keys are kept when both are nil. The keys without a
+// creation date are discarded when a bound is set, as they cannot be placed in the range.
+func filterKMSKeysByCreationDate(keys []kp.Key, createdAfter *time.Time, createdBefore *time.Time) []kp.Key {
+	if createdAfter == nil && createdBefore == nil {
+		return keys
+	}
+	filtered := make([]kp.Key, 0, len(keys))
+	for _, key := range keys {
+		if key.CreationDate == nil {
+			continue
+		}
+		if createdAfter != nil && key.CreationDate.Before(*createdAfter) {
+			continue
+		}
+		if createdBefore != nil && !key.CreationDate.Before(*createdBefore) {
+			continue
+		}
+		filtered = append(filtered, key)
+	}
+	return filtered
+}
+
+// Parse created_after and created_before, nil when they are not set. Their format is validated at plan time, and the
+// range fails when it is empty.
+func kmsKeyCreationDateRange(d *schema.ResourceData) (*time.Time, *time.Time, error) {
+	var createdAfter, createdBefore *time.Time
+	if v, ok := d.GetOk("created_after"); ok {
+		t, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("[ERROR] Invalid created_after %s: %s", v.(string), err)
+		}
+		createdAfter = &t
+	}
+	if v, ok := d.GetOk("created_before"); ok {
+		t, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return nil, nil, fmt.Errorf("[ERROR] Invalid created_before %s: %s", v.(string), err)
+		}
+		createdBefore = &t
+	}
+	if createdAfter != nil && createdBefore != nil && !createdAfter.Before(*createdBefore) {
+		return nil, nil, fmt.Errorf("[ERROR] created_after %s must be before created_before %s", d.Get("created_after").(string), d.Get("created_before").(string))
+	}
+	return createdAfter, createdBefore, nil
+}
+
+// Fail when the key ring does not exist in the instance, listing the key rings that do, so that a misspelled key
+// ring is not reported as a key ring without keys
+func validateKMSKeyRingExists(ctx context.Context, api kmsKeysAPI, keyRingID string, instanceID string) error {
+	keyRings, err := api.GetKeyRings(ctx)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Failed to list the key rings: %s", kmsAuthErrorHint(err, instanceID))
+	}
+	available := make([]string, 0, len(keyRings.KeyRings))
+	for _, keyRing := range keyRings.KeyRings {
+		if keyRing.ID == keyRingID {
+			return nil
+		}
+		available = append(available, keyRing.ID)
+	}
+	sort.Strings(available)
+	return fmt.Errorf("[ERROR] key ring %s not found in instance %s (available: %s)", keyRingID, instanceID, strings.Join(available, ", "))
+}
+
+// Fail when a key that is looked up by key_id or alias is not in the key ring, when a key ring is given
+func validateKMSKeyInKeyRing(key kp.Key, keyRingID string, instanceID string) error {
+	if keyRingID == "" || kmsKeyRingID(key) == keyRingID {
+		return nil
+	}
+	return fmt.Errorf("[ERROR] Key %s of instance %s is in key ring %s, not in key ring %s", key.ID, instanceID, kmsKeyRingID(key), keyRingID)
+}
+
+// Name lookups that match more keys than kmsKeyPoliciesConcurrencyThreshold read the policies of the keys
+// concurrently, with at most kmsKeyPoliciesWorkers requests in flight. Key Protect has no endpoint that returns
+// the policies of all the keys of an instance, so one request per key is still needed.
+const (
+	kmsKeyPoliciesConcurrencyThreshold = 10
+	kmsKeyPoliciesWorkers              = 5
+)
+
+// Get the policies of each key, in the order of the keys
+func getKMSKeysPolicies(keys []kp.Key, getPolicies func(keyID string) ([]kp.Policy, error)) ([][]kp.Policy, error) {
+	keyPolicies := make([][]kp.Policy, len(keys))
+	if len(keys) <= kmsKeyPoliciesConcurrencyThreshold {
+		for i, key := range keys {
+			policies, err := getPolicies(key.ID)
+			if err != nil {
+				return nil, err
+			}
+			keyPolicies[i] = policies
+		}
+		return keyPolicies, nil
+	}
+
+	errs := make([]error, len(keys))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < kmsKeyPoliciesWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				keyPolicies[i], errs[i] = getPolicies(keys[i].ID)
+			}
+		}()
+	}
+	for i := range keys {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keyPolicies, nil
+}
+
+// Get the policies of each key like getKMSKeysPolicies, skipping the keys that the service no longer finds, such as
+// the keys that were deleted after they were listed. The keys that are kept, their policies and the IDs of the
+// skipped keys are returned in the order of the keys. Other errors fail the read.
+func getKMSKeysPoliciesSkipDeleted(keys []kp.Key, getPolicies func(keyID string) ([]kp.Policy, error)) ([]kp.Key, [][]kp.Policy, []string, error) {
+	var mu sync.Mutex
+	deleted := map[string]bool{}
+	keyPolicies, err := getKMSKeysPolicies(keys, func(keyID string) ([]kp.Policy, error) {
+		policies, err := getPolicies(keyID)
+		if kmsKeyNotFound(err) {
+			mu.Lock()
+			deleted[keyID] = true
+			mu.Unlock()
+			return nil, nil
+		}
+		return policies, err
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(deleted) == 0 {
+		return keys, keyPolicies, nil, nil
+	}
+
+	keptKeys := make([]kp.Key, 0, len(keys)-len(deleted))
+	keptPolicies := make([][]kp.Policy, 0, len(keys)-len(deleted))
+	deletedKeyIDs := make([]string, 0, len(deleted))
+	for i, key := range keys {
+		if deleted[key.ID] {
+			deletedKeyIDs = append(deletedKeyIDs, key.ID)
+			continue
+		}
+		keptKeys = append(keptKeys, key)
+		keptPolicies = append(keptPolicies, keyPolicies[i])
+	}
+	return keptKeys, keptPolicies, deletedKeyIDs, nil
+}
+
+// The warnings of the keys that were deleted while a name lookup read them
+func kmsDeletedKeysWarnings(deletedKeyIDs []string, instanceID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, keyID := range deletedKeyIDs {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Key %s of instance %s was deleted while it was read, it is not in keys", keyID, instanceID),
+			Detail:   "The key was listed, but the service no longer found it when its policies were read.",
+		})
+	}
+	return diags
+}
+
+// The time of the last update of the key, empty when the service does not report it. Key Protect does not return
+// entity tags and the client cannot send conditional requests, so the keys and their policies are read in full on
+// every refresh; the time only makes updates of the key visible in the state.
+func kmsKeyLastUpdateDate(key kp.Key) string {
+	if key.LastUpdateDate == nil {
+		return ""
+	}
+	return key.LastUpdateDate.UTC().Format(time.RFC3339)
+}
+
+// Whether the dual authorization delete policy is enabled for the key, read from the key metadata and from
+// the dual_auth_delete policy when the metadata does not report it
+func kmsKeyDualAuthDeleteEnabled(key kp.Key, policies []kp.Policy) bool {
+	if key.DualAuthDelete != nil && key.DualAuthDelete.Enabled != nil {
+		return *key.DualAuthDelete.Enabled
+	}
+	for _, policy := range policies {
+		if policy.DualAuth != nil && policy.DualAuth.Enabled != nil {
+			return *policy.DualAuth.Enabled
+		}
+	}
+	return false
+}
+
+// Whether the rotation of the key is overdue at the time returned by clock: a rotation policy is enabled and more
+// than its interval of months passed since the last rotation of the key, or since its creation when it was never
+// rotated. A missing or disabled policy, or a key without dates, is never overdue.
+func kmsKeyRotationOverdue(key kp.Key, policies []kp.Policy, clock func() time.Time) bool {
+	for _, policy := range policies {
+		if policy.Rotation == nil {
+			continue
+		}
+		enabled := policy.Rotation.Interval > 0
+		if policy.Rotation.Enabled != nil {
+			enabled = *policy.Rotation.Enabled
+		}
+		if !enabled || policy.Rotation.Interval <= 0 {
+			return false
+		}
+		rotatedAt := key.LastRotateDate
+		if rotatedAt == nil {
+			rotatedAt = key.CreationDate
+		}
+		if rotatedAt == nil {
+			return false
+		}
+		return clock().After(rotatedAt.AddDate(0, policy.Rotation.Interval, 0))
+	}
+	return false
+}
+
+// The time after its deletion during which a destroyed key can be restored, as documented by Key Protect and Hyper
+// Protect Crypto Services. The service does not report it, so it must be updated here when the service changes it.
+const kmsKeyRestoreWindow = 30 * 24 * time.Hour
+
+// Get the time until which a key deleted at deletionDate can be restored, and whether it can still be restored at
+// now. A key without a deletion date cannot be restored.
+func kmsKeyRestoreExpiry(deletionDate *time.Time, now time.Time) (time.Time, bool) {
+	if deletionDate == nil {
+		return time.Time{}, false
+	}
+	expiresAt := deletionDate.Add(kmsKeyRestoreWindow)
+	return expiresAt, now.Before(expiresAt)
+}
+
+// Flatten restorable and restore_expires_at of a destroyed key at now. The attributes of the keys in the other states
+// are not set.
+func flattenKMSKeyRestore(key kp.Key, now time.Time) map[string]interface{} {
+	if key.State != int(kp.Destroyed) {
+		return map[string]interface{}{}
+	}
+	expiresAt, restorable := kmsKeyRestoreExpiry(key.DeletionDate, now)
+	restore := map[string]interface{}{"restorable": restorable}
+	if !expiresAt.IsZero() {
+		restore["restore_expires_at"] = expiresAt.UTC().Format(time.RFC3339)
+	}
+	return restore
+}
+
+// Get the networks the instance accepts requests from, public-and-private when the allowed network policy
+// is not enabled. The policy of an instance is read once per provider configuration, through the call cache of the
+// session.
+func getKMSAllowedNetwork(ctx context.Context, meta interface{}, api kmsKeysAPI, instanceID string) (string, error) {
+	allowedNetwork, err := meta.(conns.ClientSession).CallCache().Do("kms_allowed_network/"+instanceID, func() (interface{}, time.Time, error) {
+		policy, err := api.GetAllowedNetworkInstancePolicy(ctx)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return kmsAllowedNetworkFromPolicy(policy), time.Time{}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return allowedNetwork.(string), nil
+}
+
+func kmsAllowedNetworkFromPolicy(policy *kp.InstancePolicy) string {
+	if policy == nil || policy.PolicyData.Enabled == nil || !*policy.PolicyData.Enabled ||
+		policy.PolicyData.Attributes == nil || policy.PolicyData.Attributes.AllowedNetwork == nil {
+		return "public-and-private"
+	}
+	return *policy.PolicyData.Attributes.AllowedNetwork
+}
+
+// Whether the host of the endpoint is on the private network, private.<region>.kms.cloud.ibm.com for key protect
+// and api.private.<region>.hs-crypto.cloud.ibm.com for hpcs
+func kmsEndpointIsPrivate(endpointURL *url.URL) bool {
+	host := endpointURL.Hostname()
+	return strings.HasPrefix(host, "private.") || strings.Contains(host, ".private.")
+}
+
+// Fail fast when the endpoint that the keys are read from, resolved from endpoint_type or set with endpoint_url, is
+// not accepted by the allowed network policy of the instance, instead of failing later with a connection error. An
+// unknown policy or endpoint is not validated.
+func validateKMSEndpointURL(endpoint string, allowedNetwork string) error {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil
+	}
+	if allowedNetwork == "private-only" && !kmsEndpointIsPrivate(endpointURL) {
+		return fmt.Errorf("[ERROR] instance allows only private network access but the endpoint %s is public; set endpoint_type = \"private\"", endpointURL.Host)
+	}
 	return nil
 }
+
+// kmsKeySortValues are the allowed values of the sort argument of the key listings
+var kmsKeySortValues = []string{"name", "-name", "creation_date", "-creation_date", "last_rotate_date", "-last_rotate_date"}
+
+// Sort a copy of the keys by name, creation_date or last_rotate_date, descending when sortBy is prefixed
+// with -. Keys without the date sort as the oldest and ties are broken by key id, so the order is stable
+// across reads. The keys are returned as is when sortBy is empty.
+func sortKMSKeys(keys []kp.Key, sortBy string) []kp.Key {
+	if sortBy == "" {
+		return keys
+	}
+	descending := strings.HasPrefix(sortBy, "-")
+	field := strings.TrimPrefix(sortBy, "-")
+
+	sorted := make([]kp.Key, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		cmp := compareKMSKeys(sorted[i], sorted[j], field)
+		if cmp == 0 {
+			return sorted[i].ID < sorted[j].ID
+		}
+		if descending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+	return sorted
+}
+
+func compareKMSKeys(a, b kp.Key, field string) int {
+	switch field {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "creation_date":
+		return compareKMSKeyDates(a.CreationDate, b.CreationDate)
+	case "last_rotate_date":
+		return compareKMSKeyDates(a.LastRotateDate, b.LastRotateDate)
+	}
+	return 0
+}
+
+func compareKMSKeyDates(a, b *time.Time) int {
+	var at, bt time.Time
+	if a != nil {
+		at = *a
+	}
+	if b != nil {
+		bt = *b
+	}
+	switch {
+	case at.Before(bt):
+		return -1
+	case at.After(bt):
+		return 1
+	}
+	return 0
+}
+
+// Keep the first maxResults keys, all the keys when maxResults is 0
+func truncateKMSKeys(keys []kp.Key, maxResults int) []kp.Key {
+	if maxResults <= 0 || len(keys) <= maxResults {
+		return keys
+	}
+	return keys[:maxResults]
+}
+
+// kmsKeyLookupStates are the key states requested by name lookups, so that disabled keys are reported
+// with their state instead of being hidden. The client has no constant for the pre-activation state.
+var kmsKeyLookupStates = []kp.KeyState{kp.KeyState(0), kp.Active, kp.Suspended, kp.Deactivated}
+
+// kmsKeyNameProbeLimit is the number of keys with the name of a lookup without matches that are listed to tell which
+// filter excluded them
+const kmsKeyNameProbeLimit = 10
+
+// With the default page size of 200 keys, name lookups list at most 100000 keys by default
+const kmsKeyDefaultMaxPages = 500
+
+// The error of a failed listing of keys. When the read timeout expired, it reports how many keys were listed
+// before the deadline.
+func kmsKeysListError(ctx context.Context, err error, instanceID string, fetched int) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("[ERROR] Listing the keys of instance %s stopped at the read timeout after %d keys. Increase the read timeout or lower scan_limit", instanceID, fetched)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("[ERROR] Listing the keys of instance %s stopped after %d keys: %s", instanceID, fetched, ctx.Err())
+	}
+	return fmt.Errorf("[ERROR] Get Keys failed with error: %s", kmsAuthErrorHint(err, instanceID))
+}
+
+// Get a page of the keys that are in one of the given states, a limit of 0 fetches the default of 2000 keys
+func getKMSKeysInStates(ctx context.Context, api kmsKeysAPI, limit int, offset int, states []kp.KeyState) (*kp.Keys, error) {
+	if limit == 0 {
+		limit = 2000
+	}
+	pageLimit := uint32(limit)
+	pageOffset := uint32(offset)
+	listKeysOptions := &kp.ListKeysOptions{
+		Limit:  &pageLimit,
+		Offset: &pageOffset,
+		State:  states,
+	}
+	return api.ListKeys(ctx, listKeysOptions)
+}
+
+// Build the error of a name lookup without matches, telling apart a name that does not exist in the
+// instance from a matching key that was excluded by the state filter, created_after or created_before, or by the
+// limit or first_page_only. The error states the number of keys that were scanned. Only a name that does not exist in
+// the instance is reported as missing.
+func kmsKeyNameNotFoundError(ctx context.Context, api kmsKeysAPI, keyName string, instanceID string, scannedKeys int, createdAfter *time.Time, createdBefore *time.Time, scanComplete bool) error {
+	search, _ := kp.GetKeySearchQuery(&keyName, kp.WithExactMatch(), kp.AddKeyNameScope())
+	pageLimit := uint32(kmsKeyNameProbeLimit)
+	listKeysOptions := &kp.ListKeysOptions{
+		Limit:  &pageLimit,
+		Search: search,
+		State:  []kp.KeyState{kp.KeyState(0), kp.Active, kp.Suspended, kp.Deactivated, kp.Destroyed},
+	}
+	keys, err := api.ListKeys(ctx, listKeysOptions)
+	if err != nil {
+		return fmt.Errorf("[ERROR] No keys with name %s in instance %s, %d keys scanned", keyName, instanceID, scannedKeys)
+	}
+	if len(keys.Keys) == 0 {
+		return kmsKeyMissing(fmt.Errorf("[ERROR] No keys with name %s in instance %s, %d keys scanned", keyName, instanceID, scannedKeys))
+	}
+	// the filters are checked in the order of the lookup, the key that passed the most of them is reported
+	var dateExcluded *kp.Key
+	for i, key := range keys.Keys {
+		if key.State == int(kp.Destroyed) {
+			continue
+		}
+		if len(filterKMSKeysByCreationDate([]kp.Key{key}, createdAfter, createdBefore)) == 0 {
+			if dateExcluded == nil {
+				dateExcluded = &keys.Keys[i]
+			}
+			continue
+		}
+		if scanComplete {
+			return fmt.Errorf("[ERROR] A key with name %s exists in instance %s but was not among the %d keys scanned, it may have been created after they were listed", keyName, instanceID, scannedKeys)
+		}
+		return fmt.Errorf("[ERROR] A key with name %s exists in instance %s but was excluded by scan_limit or first_page_only after %d keys scanned, increase scan_limit or unset first_page_only to retrieve it", keyName, instanceID, scannedKeys)
+	}
+	if dateExcluded != nil {
+		return fmt.Errorf("[ERROR] A key with name %s exists in instance %s but was excluded because %s, %d keys scanned", keyName, instanceID, kmsKeyCreationDateExclusion(*dateExcluded, createdAfter), scannedKeys)
+	}
+	return fmt.Errorf("[ERROR] A key with name %s exists in instance %s but was excluded because it is in the destroyed state, %d keys scanned", keyName, instanceID, scannedKeys)
+}
+
+// Tell which of created_after and created_before excluded a key
+func kmsKeyCreationDateExclusion(key kp.Key, createdAfter *time.Time) string {
+	if key.CreationDate == nil {
+		return "it has no creation date to compare with created_after and created_before"
+	}
+	creationDate := key.CreationDate.UTC().Format(time.RFC3339)
+	if createdAfter != nil && key.CreationDate.Before(*createdAfter) {
+		return fmt.Sprintf("it was created at %s, before created_after", creationDate)
+	}
+	return fmt.Sprintf("it was created at %s, not before created_before", creationDate)
+}
+
+// Build the error of a name lookup that matches several keys when fail_if_multiple is set, listing the
+// id and creation date of each match so that the key can be selected by key_id instead
+func kmsKeyMultipleMatchesError(keyName string, instanceID string, keys []kp.Key) error {
+	matches := make([]string, 0, len(keys))
+	for _, key := range keys {
+		creationDate := "unknown"
+		if key.CreationDate != nil {
+			creationDate = key.CreationDate.UTC().Format(time.RFC3339)
+		}
+		matches = append(matches, fmt.Sprintf("%s (created %s)", key.ID, creationDate))
+	}
+	return fmt.Errorf("[ERROR] %d keys with name %s in instance %s, use key_id to select one of: %s", len(keys), keyName, instanceID, strings.Join(matches, ", "))
+}
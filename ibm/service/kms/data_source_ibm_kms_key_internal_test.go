@@ -0,0 +1,1478 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	kp "github.com/IBM/keyprotect-go-client"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func testKMSKeys(count int) []kp.Key {
+	keys := make([]kp.Key, 0, count)
+	for i := 0; i < count; i++ {
+		keys = append(keys, kp.Key{ID: fmt.Sprintf("key-%02d", i)})
+	}
+	return keys
+}
+
+func testKMSKeyPolicies(keyID string) ([]kp.Policy, error) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	enabled := true
+	interval := len(keyID)
+	return []kp.Policy{
+		{
+			Type:      "application/vnd.ibm.kms.policy+json",
+			CRN:       "crn:v1:bluemix:public:kms:us-south:a/account:instance:key:" + keyID,
+			CreatedBy: "IBMid-" + keyID,
+			CreatedAt: &createdAt,
+			UpdatedAt: &createdAt,
+			Rotation:  &kp.Rotation{Enabled: &enabled, Interval: interval},
+		},
+		{
+			Type:      "application/vnd.ibm.kms.policy+json",
+			CRN:       "crn:v1:bluemix:public:kms:us-south:a/account:instance:key:" + keyID,
+			CreatedBy: "IBMid-" + keyID,
+			CreatedAt: &createdAt,
+			UpdatedAt: &createdAt,
+			DualAuth:  &kp.DualAuth{Enabled: &enabled},
+		},
+	}, nil
+}
+
+func TestGetKMSKeysPoliciesMatchesPerKeyCalls(t *testing.T) {
+	for _, count := range []int{1, kmsKeyPoliciesConcurrencyThreshold, kmsKeyPoliciesConcurrencyThreshold + 1, 42} {
+		t.Run(fmt.Sprintf("%d keys", count), func(t *testing.T) {
+			keys := testKMSKeys(count)
+
+			keyPolicies, err := getKMSKeysPolicies(keys, testKMSKeyPolicies)
+			assert.Nil(t, err)
+			assert.Len(t, keyPolicies, count)
+
+			for i, key := range keys {
+				expected, _ := testKMSKeyPolicies(key.ID)
+				assert.Equal(t, flex.FlattenKeyPolicies(expected), flex.FlattenKeyPolicies(keyPolicies[i]))
+			}
+		})
+	}
+}
+
+func TestGetKMSKeysPoliciesError(t *testing.T) {
+	for _, count := range []int{3, 42} {
+		t.Run(fmt.Sprintf("%d keys", count), func(t *testing.T) {
+			keyPolicies, err := getKMSKeysPolicies(testKMSKeys(count), func(keyID string) ([]kp.Policy, error) {
+				if keyID == "key-02" {
+					return nil, fmt.Errorf("policies of %s unavailable", keyID)
+				}
+				return testKMSKeyPolicies(keyID)
+			})
+			assert.Nil(t, keyPolicies)
+			assert.EqualError(t, err, "policies of key-02 unavailable")
+		})
+	}
+}
+
+func TestGetKMSKeysPoliciesSkipDeleted(t *testing.T) {
+	for _, count := range []int{3, 42} {
+		t.Run(fmt.Sprintf("%d keys", count), func(t *testing.T) {
+			keys := testKMSKeys(count)
+			keptKeys, keyPolicies, deletedKeyIDs, err := getKMSKeysPoliciesSkipDeleted(keys, func(keyID string) ([]kp.Policy, error) {
+				if keyID == "key-01" {
+					return nil, &kp.Error{StatusCode: 404, Message: "Not Found"}
+				}
+				return testKMSKeyPolicies(keyID)
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"key-01"}, deletedKeyIDs)
+			assert.Len(t, keptKeys, count-1)
+			assert.Len(t, keyPolicies, count-1)
+			for i, key := range keptKeys {
+				assert.NotEqual(t, "key-01", key.ID)
+				expected, _ := testKMSKeyPolicies(key.ID)
+				assert.Equal(t, expected, keyPolicies[i])
+			}
+
+			// The other errors still fail the read
+			_, _, _, err = getKMSKeysPoliciesSkipDeleted(keys, func(keyID string) ([]kp.Policy, error) {
+				if keyID == "key-01" {
+					return nil, &kp.Error{StatusCode: 500, Message: "Internal Server Error"}
+				}
+				return testKMSKeyPolicies(keyID)
+			})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestReadKMSKeyDeletedDuringRead(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(3, kp.Active)
+	for i := range keys {
+		keys[i].Name = "shared"
+	}
+	read := func(api *testKMSKeysAPI) (*schema.ResourceData, diag.Diagnostics, error) {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "key_name": "shared"})
+		diags, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		return d, diags, err
+	}
+	notFound := &kp.Error{StatusCode: 404, Message: "Not Found"}
+
+	d, diags, err := read(&testKMSKeysAPI{keys: keys, policiesErrs: map[string]error{"key-01": notFound}})
+	assert.NoError(t, err)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Key key-01 of instance 30372f20-d9f1-40b3-b486-a709e1932c9c was deleted while it was read, it is not in keys", diags[0].Summary)
+	assert.Equal(t, 2, d.Get("keys.#"))
+	assert.Equal(t, "key-00", d.Get("keys.0.id"))
+	assert.Equal(t, "key-02", d.Get("keys.1.id"))
+	assert.Equal(t, "", d.Get("key_id"))
+
+	// The remaining key is the result of the lookup
+	d, diags, err = read(&testKMSKeysAPI{keys: keys, policiesErrs: map[string]error{"key-00": notFound, "key-02": notFound}})
+	assert.NoError(t, err)
+	assert.Len(t, diags, 2)
+	assert.Equal(t, 1, d.Get("keys.#"))
+	assert.Equal(t, "key-01", d.Get("key_id"))
+
+	_, _, err = read(&testKMSKeysAPI{keys: keys, policiesErrs: map[string]error{"key-00": notFound, "key-01": notFound, "key-02": notFound}})
+	assert.EqualError(t, err, "[ERROR] No keys with name shared in instance 30372f20-d9f1-40b3-b486-a709e1932c9c, the matching keys key-00, key-01, key-02 were deleted while they were read")
+
+	_, _, err = read(&testKMSKeysAPI{keys: keys, policiesErrs: map[string]error{"key-01": &kp.Error{StatusCode: 500, Message: "Internal Server Error"}}})
+	assert.ErrorContains(t, err, "Failed to read policies")
+}
+
+func TestKMSKeyDualAuthDeleteEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	// A key that received the first of the two deletion authorizations is still active and reports the
+	// policy in its metadata.
+	pending := kp.Key{ID: "key-00", State: 1, DualAuthDelete: &kp.DualAuth{Enabled: &enabled}}
+	assert.True(t, kmsKeyDualAuthDeleteEnabled(pending, nil))
+
+	policies, _ := testKMSKeyPolicies("key-01")
+	assert.True(t, kmsKeyDualAuthDeleteEnabled(kp.Key{ID: "key-01"}, policies))
+	assert.False(t, kmsKeyDualAuthDeleteEnabled(kp.Key{ID: "key-02", DualAuthDelete: &kp.DualAuth{Enabled: &disabled}}, policies))
+	assert.False(t, kmsKeyDualAuthDeleteEnabled(kp.Key{ID: "key-03", DualAuthDelete: &kp.DualAuth{}}, nil))
+	assert.False(t, kmsKeyDualAuthDeleteEnabled(kp.Key{ID: "key-04"}, nil))
+}
+
+func TestKMSEndpointTypeExplicitValueWins(t *testing.T) {
+	for _, endpointType := range []string{"public", "private"} {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+			"instance_id":   "30372f20-d9f1-40b3-b486-a709e1932c9c",
+			"key_name":      "key",
+			"endpoint_type": endpointType,
+		})
+		// The provider visibility is not consulted when endpoint_type is set.
+		assert.Equal(t, endpointType, kmsEndpointType(d, nil))
+	}
+}
+
+func TestKMSDefaultEndpointType(t *testing.T) {
+	assert.Equal(t, "private", kmsDefaultEndpointType("private"))
+	assert.Equal(t, "public", kmsDefaultEndpointType("public"))
+	assert.Equal(t, "public", kmsDefaultEndpointType("public-and-private"))
+	assert.Equal(t, "public", kmsDefaultEndpointType(""))
+}
+
+func TestSortKMSKeys(t *testing.T) {
+	day := func(d int) *time.Time {
+		date := time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	keys := []kp.Key{
+		{ID: "key-00", Name: "beta", CreationDate: day(3), LastRotateDate: day(5)},
+		{ID: "key-01", Name: "alpha", CreationDate: day(1)},
+		{ID: "key-02", Name: "gamma", CreationDate: day(2), LastRotateDate: day(4)},
+		{ID: "key-03", Name: "alpha", CreationDate: day(2), LastRotateDate: day(6)},
+	}
+	ids := func(keys []kp.Key) []string {
+		result := make([]string, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, key.ID)
+		}
+		return result
+	}
+
+	testcases := map[string][]string{
+		"":                  {"key-00", "key-01", "key-02", "key-03"},
+		"name":              {"key-01", "key-03", "key-00", "key-02"},
+		"-name":             {"key-02", "key-00", "key-01", "key-03"},
+		"creation_date":     {"key-01", "key-02", "key-03", "key-00"},
+		"-creation_date":    {"key-00", "key-02", "key-03", "key-01"},
+		"last_rotate_date":  {"key-01", "key-02", "key-00", "key-03"},
+		"-last_rotate_date": {"key-03", "key-00", "key-02", "key-01"},
+	}
+	for sortBy, expected := range testcases {
+		t.Run(sortBy, func(t *testing.T) {
+			assert.Equal(t, expected, ids(sortKMSKeys(keys, sortBy)))
+		})
+	}
+	// The keys of the caller are not reordered.
+	assert.Equal(t, []string{"key-00", "key-01", "key-02", "key-03"}, ids(keys))
+}
+
+func TestTruncateKMSKeys(t *testing.T) {
+	keys := testKMSKeys(5)
+	assert.Equal(t, keys, truncateKMSKeys(keys, 0))
+	assert.Equal(t, keys, truncateKMSKeys(keys, 5))
+	assert.Equal(t, keys, truncateKMSKeys(keys, 10))
+	assert.Equal(t, keys[:2], truncateKMSKeys(keys, 2))
+}
+
+func TestKMSAllowedNetworkFromPolicy(t *testing.T) {
+	enabled := true
+	disabled := false
+	privateOnly := "private-only"
+	publicAndPrivate := "public-and-private"
+
+	assert.Equal(t, "public-and-private", kmsAllowedNetworkFromPolicy(nil))
+	assert.Equal(t, "public-and-private", kmsAllowedNetworkFromPolicy(&kp.InstancePolicy{
+		PolicyData: kp.PolicyData{Enabled: &disabled, Attributes: &kp.Attributes{AllowedNetwork: &privateOnly}},
+	}))
+	assert.Equal(t, "private-only", kmsAllowedNetworkFromPolicy(&kp.InstancePolicy{
+		PolicyData: kp.PolicyData{Enabled: &enabled, Attributes: &kp.Attributes{AllowedNetwork: &privateOnly}},
+	}))
+	assert.Equal(t, "public-and-private", kmsAllowedNetworkFromPolicy(&kp.InstancePolicy{
+		PolicyData: kp.PolicyData{Enabled: &enabled, Attributes: &kp.Attributes{AllowedNetwork: &publicAndPrivate}},
+	}))
+}
+
+func TestValidateKMSEndpointURL(t *testing.T) {
+	assert.EqualError(t, validateKMSEndpointURL("https://us-south.kms.cloud.ibm.com", "private-only"),
+		`[ERROR] instance allows only private network access but the endpoint us-south.kms.cloud.ibm.com is public; set endpoint_type = "private"`)
+	// the resolved endpoint is validated, including an endpoint_url that does not match endpoint_type
+	assert.Error(t, validateKMSEndpointURL("https://kms.example.com:8443/proxy", "private-only"))
+	assert.Nil(t, validateKMSEndpointURL("https://private.us-south.kms.cloud.ibm.com", "private-only"))
+	assert.Nil(t, validateKMSEndpointURL("https://api.private.us-south.hs-crypto.cloud.ibm.com:8443", "private-only"))
+	assert.Nil(t, validateKMSEndpointURL("https://us-south.kms.cloud.ibm.com", "public-and-private"))
+	assert.Nil(t, validateKMSEndpointURL("https://private.us-south.kms.cloud.ibm.com", "public-and-private"))
+	assert.Nil(t, validateKMSEndpointURL("https://us-south.kms.cloud.ibm.com", ""))
+	assert.Nil(t, validateKMSEndpointURL("", "private-only"))
+}
+
+func TestKMSKeyLookupClientAllowedNetwork(t *testing.T) {
+	assert.Equal(t, "", kmsInstanceAllowedNetwork(nil))
+	assert.Equal(t, "", kmsInstanceAllowedNetwork(&rc.ResourceInstance{}))
+	instanceData := &rc.ResourceInstance{Parameters: map[string]interface{}{"allowed_network": "private-only"}}
+	assert.Equal(t, "private-only", kmsInstanceAllowedNetwork(instanceData))
+
+	// The policy is not read through the endpoint of the client, which is the endpoint that is checked
+	api := kmsKeyLookupClient{instanceAllowedNetwork: kmsInstanceAllowedNetwork(instanceData)}
+	policy, err := api.GetAllowedNetworkInstancePolicy(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "private-only", kmsAllowedNetworkFromPolicy(policy))
+
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "30372f20-d9f1-40b3-b486-a709e1932c04", "key_id": "key-1"})
+	_, err = readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", "30372f20-d9f1-40b3-b486-a709e1932c04")
+	assert.ErrorContains(t, err, "instance allows only private network access")
+}
+
+func TestGetKMSAllowedNetworkCache(t *testing.T) {
+	sess := &testKMSClientSession{}
+	api := &testKMSKeysAPI{}
+	for i := 0; i < 2; i++ {
+		allowedNetwork, err := getKMSAllowedNetwork(context.Background(), sess, api, "30372f20-d9f1-40b3-b486-a709e1932c04")
+		assert.NoError(t, err)
+		assert.Equal(t, "public-and-private", allowedNetwork)
+	}
+	assert.Equal(t, 1, api.allowedNetworkReads)
+
+	// The sessions of two provider configurations do not share the policy
+	_, err := getKMSAllowedNetwork(context.Background(), &testKMSClientSession{}, api, "30372f20-d9f1-40b3-b486-a709e1932c04")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, api.allowedNetworkReads)
+}
+
+// testKMSKeysAPI fakes the Key Protect client with the keys of an instance, recording the pages that are listed
+type testKMSKeysAPI struct {
+	keys        []kp.Key
+	listKeysErr error
+	getKeyErr   error
+	pages       [][2]int
+	onListKeys  func(ctx context.Context) error
+	keyRings    []string
+	keyRingsErr error
+
+	registrations     map[string]int
+	registrationsErr  error
+	registrationCalls int
+
+	policiesErrs map[string]error
+
+	allowedNetworkReads int
+}
+
+func (api *testKMSKeysAPI) ListKeys(ctx context.Context, listKeysOptions *kp.ListKeysOptions) (*kp.Keys, error) {
+	if api.listKeysErr != nil {
+		return nil, api.listKeysErr
+	}
+	if api.onListKeys != nil {
+		if err := api.onListKeys(ctx); err != nil {
+			return nil, err
+		}
+	}
+	matches := []kp.Key{}
+	for _, key := range api.keys {
+		if listKeysOptions.Search != nil {
+			search := *listKeysOptions.Search
+			if key.Name != search[strings.LastIndex(search, ":")+1:] {
+				continue
+			}
+		}
+		for _, state := range listKeysOptions.State {
+			if key.State == int(state) {
+				matches = append(matches, key)
+				break
+			}
+		}
+	}
+	limit, offset := int(*listKeysOptions.Limit), 0
+	if listKeysOptions.Offset != nil {
+		offset = int(*listKeysOptions.Offset)
+	}
+	if listKeysOptions.Search == nil {
+		api.pages = append(api.pages, [2]int{limit, offset})
+	}
+	page := []kp.Key{}
+	if offset < len(matches) {
+		page = matches[offset:]
+	}
+	if len(page) > limit {
+		page = page[:limit]
+	}
+	return &kp.Keys{Metadata: kp.KeysMetadata{NumberOfKeys: len(page)}, Keys: page}, nil
+}
+
+func (api *testKMSKeysAPI) GetKey(ctx context.Context, idOrAlias string) (*kp.Key, error) {
+	if api.getKeyErr != nil {
+		return nil, api.getKeyErr
+	}
+	for _, key := range api.keys {
+		if key.ID == idOrAlias {
+			return &key, nil
+		}
+		for _, alias := range key.Aliases {
+			if alias == idOrAlias {
+				return &key, nil
+			}
+		}
+	}
+	return nil, &kp.Error{StatusCode: 404, Message: "Not Found"}
+}
+
+func (api *testKMSKeysAPI) GetPolicies(ctx context.Context, idOrAlias string) ([]kp.Policy, error) {
+	if err := api.policiesErrs[idOrAlias]; err != nil {
+		return nil, err
+	}
+	return testKMSKeyPolicies(idOrAlias)
+}
+
+func (api *testKMSKeysAPI) GetAllowedNetworkInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error) {
+	api.allowedNetworkReads++
+	return nil, nil
+}
+
+func (api *testKMSKeysAPI) GetKeyRings(ctx context.Context) (*kp.KeyRings, error) {
+	if api.keyRingsErr != nil {
+		return nil, api.keyRingsErr
+	}
+	keyRings := &kp.KeyRings{Metadata: kp.KeysMetadata{NumberOfKeys: len(api.keyRings)}}
+	for _, id := range api.keyRings {
+		keyRings.KeyRings = append(keyRings.KeyRings, kp.KeyRing{ID: id})
+	}
+	return keyRings, nil
+}
+
+func (api *testKMSKeysAPI) CountRegistrations(ctx context.Context, keyID string) (int, error) {
+	api.registrationCalls++
+	if api.registrationsErr != nil {
+		return 0, api.registrationsErr
+	}
+	return api.registrations[keyID], nil
+}
+
+func testKMSNamedKeys(count int, state kp.KeyState) []kp.Key {
+	keys := testKMSKeys(count)
+	for i := range keys {
+		keys[i].Name = fmt.Sprintf("name-%03d", i)
+		keys[i].CRN = "crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c:key:" + keys[i].ID
+		keys[i].State = int(state)
+	}
+	return keys
+}
+
+func TestReadKMSKey(t *testing.T) {
+	testCases := []struct {
+		name      string
+		raw       map[string]interface{}
+		api       *testKMSKeysAPI
+		pages     [][2]int
+		keyID     string
+		keysCount int
+		err       string
+	}{
+		{
+			name:      "name without limit",
+			raw:       map[string]interface{}{"key_name": "name-003"},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(5, kp.Active)},
+			pages:     [][2]int{{200, 0}},
+			keyID:     "key-03",
+			keysCount: 1,
+		},
+		{
+			name:      "name without limit beyond the first page",
+			raw:       map[string]interface{}{"key_name": "name-240"},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(250, kp.Active)},
+			pages:     [][2]int{{200, 0}, {200, 200}},
+			keyID:     "key-240",
+			keysCount: 1,
+		},
+		{
+			name:      "name with first_page_only",
+			raw:       map[string]interface{}{"key_name": "name-003", "first_page_only": true},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(5, kp.Active)},
+			pages:     [][2]int{{2000, 0}},
+			keyID:     "key-03",
+			keysCount: 1,
+		},
+		{
+			name:  "name excluded by first_page_only",
+			raw:   map[string]interface{}{"key_name": "name-2100", "first_page_only": true},
+			api:   &testKMSKeysAPI{keys: testKMSNamedKeys(2200, kp.Active)},
+			pages: [][2]int{{2000, 0}},
+			err:   "excluded by scan_limit or first_page_only after 2000 keys scanned",
+		},
+		{
+			name:  "name not found",
+			raw:   map[string]interface{}{"key_name": "missing"},
+			api:   &testKMSKeysAPI{keys: testKMSNamedKeys(5, kp.Active)},
+			pages: [][2]int{{200, 0}},
+			err:   ", 5 keys scanned",
+		},
+		{
+			name:      "name with a limit of several pages",
+			raw:       map[string]interface{}{"key_name": "name-420", "limit": 450},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(500, kp.Active)},
+			pages:     [][2]int{{200, 0}, {200, 200}, {50, 400}},
+			keyID:     "key-420",
+			keysCount: 1,
+		},
+		{
+			name:      "name with a limit of one page",
+			raw:       map[string]interface{}{"key_name": "name-150", "limit": 200},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(500, kp.Active)},
+			pages:     [][2]int{{200, 0}},
+			keyID:     "key-150",
+			keysCount: 1,
+		},
+		{
+			name:      "name with a limit above the keys",
+			raw:       map[string]interface{}{"key_name": "name-250", "limit": 1000},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active)},
+			pages:     [][2]int{{200, 0}, {200, 200}},
+			keyID:     "key-250",
+			keysCount: 1,
+		},
+		{
+			name:  "name excluded by the limit",
+			raw:   map[string]interface{}{"key_name": "name-250", "limit": 100},
+			api:   &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active)},
+			pages: [][2]int{{100, 0}},
+			err:   "excluded by scan_limit or first_page_only after 100 keys scanned",
+		},
+		{
+			name:      "name with a scan_limit of several pages",
+			raw:       map[string]interface{}{"key_name": "name-420", "scan_limit": 450},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(500, kp.Active)},
+			pages:     [][2]int{{200, 0}, {200, 200}, {50, 400}},
+			keyID:     "key-420",
+			keysCount: 1,
+		},
+		{
+			name:  "name excluded by the scan_limit",
+			raw:   map[string]interface{}{"key_name": "name-250", "scan_limit": 100},
+			api:   &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active)},
+			pages: [][2]int{{100, 0}},
+			err:   "excluded by scan_limit or first_page_only after 100 keys scanned",
+		},
+		{
+			name:      "name with a scan_limit and first_page_only",
+			raw:       map[string]interface{}{"key_name": "name-150", "scan_limit": 200, "first_page_only": true},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(500, kp.Active)},
+			pages:     [][2]int{{200, 0}},
+			keyID:     "key-150",
+			keysCount: 1,
+		},
+		{
+			name:  "name of a destroyed key",
+			raw:   map[string]interface{}{"key_name": "name-001"},
+			api:   &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active), kp.Key{ID: "destroyed", Name: "name-001", State: int(kp.Destroyed)})},
+			pages: [][2]int{{200, 0}},
+			err:   "destroyed state",
+		},
+		{
+			name:  "no keys",
+			raw:   map[string]interface{}{"key_name": "name-001"},
+			api:   &testKMSKeysAPI{},
+			pages: [][2]int{{200, 0}},
+			err:   "No keys in instance",
+		},
+		{
+			name: "name matching several keys",
+			raw:  map[string]interface{}{"key_name": "name-000", "fail_if_multiple": true},
+			api: &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active),
+				kp.Key{ID: "duplicate", Name: "name-000", State: int(kp.Active)})},
+			pages: [][2]int{{200, 0}},
+			err:   "duplicate",
+		},
+		{
+			name:      "name matching several keys without fail_if_multiple",
+			raw:       map[string]interface{}{"key_name": "name-000"},
+			api:       &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active), kp.Key{ID: "duplicate", Name: "name-000", State: int(kp.Active)})},
+			pages:     [][2]int{{200, 0}},
+			keysCount: 2,
+		},
+		{
+			name: "name matching several keys with a result_limit",
+			raw:  map[string]interface{}{"key_name": "name-000", "result_limit": 1, "scan_limit": 250},
+			api: &testKMSKeysAPI{keys: append(testKMSNamedKeys(240, kp.Active),
+				kp.Key{ID: "duplicate", Name: "name-000", State: int(kp.Active)})},
+			pages:     [][2]int{{200, 0}, {50, 200}},
+			keysCount: 1,
+		},
+		{
+			name: "name matching several keys with a result_limit above the matches",
+			raw:  map[string]interface{}{"key_name": "name-000", "result_limit": 5},
+			api: &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active),
+				kp.Key{ID: "duplicate", Name: "name-000", State: int(kp.Active)})},
+			pages:     [][2]int{{200, 0}},
+			keysCount: 2,
+		},
+		{
+			name:  "list failure",
+			raw:   map[string]interface{}{"key_name": "name-000"},
+			api:   &testKMSKeysAPI{listKeysErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}},
+			pages: nil,
+			err:   "allowed_network and allowed_ip policies",
+		},
+		{
+			name:      "key id",
+			raw:       map[string]interface{}{"key_id": "key-01"},
+			api:       &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active)},
+			keyID:     "key-01",
+			keysCount: 1,
+		},
+		{
+			name: "key id failure",
+			raw:  map[string]interface{}{"key_id": "key-01"},
+			api:  &testKMSKeysAPI{getKeyErr: &kp.Error{StatusCode: 500, Message: "Internal Server Error"}},
+			err:  "Get Keys failed",
+		},
+		{
+			name: "alias",
+			raw:  map[string]interface{}{"alias": "my-alias"},
+			api: &testKMSKeysAPI{keys: []kp.Key{
+				{ID: "key-00", Name: "name-000", Aliases: []string{"my-alias"}, CRN: "crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c:key:key-00"},
+			}},
+			keyID:     "key-00",
+			keysCount: 1,
+		},
+		{
+			name: "alias forbidden found by listing",
+			raw:  map[string]interface{}{"alias": "my-alias"},
+			api: func() *testKMSKeysAPI {
+				keys := testKMSKeys(250)
+				keys[240].Aliases = []string{"other-alias", "my-alias"}
+				return &testKMSKeysAPI{keys: keys, getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+			}(),
+			pages:     [][2]int{{200, 0}, {200, 200}},
+			keyID:     "key-240",
+			keysCount: 1,
+		},
+		{
+			name: "alias beyond the scan_limit",
+			raw:  map[string]interface{}{"alias": "my-alias", "scan_limit": 200},
+			api: func() *testKMSKeysAPI {
+				keys := testKMSKeys(250)
+				keys[240].Aliases = []string{"my-alias"}
+				return &testKMSKeysAPI{keys: keys, getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+			}(),
+			pages: [][2]int{{200, 0}},
+			err:   "No key with alias my-alias was found by listing the 200 keys of instance",
+		},
+		{
+			name:  "alias not found by listing",
+			raw:   map[string]interface{}{"alias": "my-alias"},
+			api:   &testKMSKeysAPI{keys: testKMSKeys(3), getKeyErr: &kp.Error{StatusCode: 404, Message: "Not Found"}},
+			pages: [][2]int{{200, 0}},
+			err:   "No key with alias my-alias was found by listing the 3 keys of instance",
+		},
+		{
+			name: "alias listing fails",
+			raw:  map[string]interface{}{"alias": "my-alias"},
+			api:  &testKMSKeysAPI{getKeyErr: &kp.Error{StatusCode: 404, Message: "Not Found"}, listKeysErr: errors.New("Get Keys failed")},
+			err:  "Listing the keys to find alias my-alias also failed",
+		},
+		{
+			name: "alias fallback disabled",
+			raw:  map[string]interface{}{"alias": "my-alias", "alias_list_fallback": false},
+			api:  &testKMSKeysAPI{keys: testKMSKeys(3), getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}},
+			err:  "Forbidden",
+		},
+		{
+			name: "alias server error",
+			raw:  map[string]interface{}{"alias": "my-alias"},
+			api:  &testKMSKeysAPI{keys: testKMSKeys(3), getKeyErr: &kp.Error{StatusCode: 500, Message: "Internal Server Error"}},
+			err:  "Internal Server Error",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instanceID := fmt.Sprintf("30372f20-d9f1-40b3-b486-a709e1932%03d", i)
+			raw := map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public"}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+
+			_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, tc.api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+			assert.Equal(t, tc.pages, tc.api.pages)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, instanceID, d.Id())
+			assert.Equal(t, tc.keyID, d.Get("key_id"))
+			assert.Len(t, d.Get("keys").([]interface{}), tc.keysCount)
+			assert.Equal(t, "public-and-private", d.Get("allowed_network"))
+		})
+	}
+}
+
+func TestReadKMSKeyAllowMissing(t *testing.T) {
+	testCases := []struct {
+		name  string
+		raw   map[string]interface{}
+		api   *testKMSKeysAPI
+		keyID string
+		found bool
+		err   string
+	}{
+		{name: "name found", raw: map[string]interface{}{"key_name": "name-001"}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active)}, keyID: "key-01", found: true},
+		{name: "name missing", raw: map[string]interface{}{"key_name": "missing"}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active)}},
+		{name: "name in an empty instance", raw: map[string]interface{}{"key_name": "name-001"}, api: &testKMSKeysAPI{}},
+		{name: "name beyond the scan_limit", raw: map[string]interface{}{"key_name": "name-250", "scan_limit": 100}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active)}, err: "excluded by scan_limit"},
+		{name: "name missing with a scan_limit", raw: map[string]interface{}{"key_name": "missing", "scan_limit": 100}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active)}},
+		{
+			name: "name of another key ring beyond the scan_limit",
+			raw:  map[string]interface{}{"key_name": "name-000", "key_ring_id": "ring", "scan_limit": 100},
+			api:  &testKMSKeysAPI{keys: testKMSNamedKeys(300, kp.Active), keyRings: []string{"default", "ring"}},
+			err:  "No keys in key ring ring",
+		},
+		{name: "name of a destroyed key", raw: map[string]interface{}{"key_name": "name-001"}, api: &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active), kp.Key{ID: "destroyed", Name: "name-001", State: int(kp.Destroyed)})}, err: "destroyed state"},
+		{name: "name listing fails", raw: map[string]interface{}{"key_name": "name-001"}, api: &testKMSKeysAPI{listKeysErr: &kp.Error{StatusCode: 500, Message: "Internal Server Error"}}, err: "Internal Server Error"},
+		{
+			name: "name matching several keys with fail_if_multiple",
+			raw:  map[string]interface{}{"key_name": "name-000", "fail_if_multiple": true},
+			api:  &testKMSKeysAPI{keys: append(testKMSNamedKeys(1, kp.Active), kp.Key{ID: "duplicate", Name: "name-000", State: int(kp.Active)})},
+			err:  "use key_id to select one of",
+		},
+		{name: "key id found", raw: map[string]interface{}{"key_id": "key-01"}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active)}, keyID: "key-01", found: true},
+		{name: "key id missing", raw: map[string]interface{}{"key_id": "key-09"}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active)}, keyID: "key-09"},
+		{name: "key id in another key ring", raw: map[string]interface{}{"key_id": "key-01", "key_ring_id": "ring"}, api: &testKMSKeysAPI{keys: testKMSNamedKeys(3, kp.Active), keyRings: []string{"default", "ring"}}, keyID: "key-01"},
+		{name: "key id forbidden", raw: map[string]interface{}{"key_id": "key-01"}, api: &testKMSKeysAPI{getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}, err: "Forbidden"},
+		{
+			name:  "alias found",
+			raw:   map[string]interface{}{"alias": "my-alias"},
+			api:   &testKMSKeysAPI{keys: []kp.Key{{ID: "key-00", Name: "name-000", Aliases: []string{"my-alias"}}}},
+			keyID: "key-00",
+			found: true,
+		},
+		{name: "alias missing", raw: map[string]interface{}{"alias": "my-alias"}, api: &testKMSKeysAPI{keys: testKMSKeys(3)}},
+		{
+			name: "alias beyond the scan_limit",
+			raw:  map[string]interface{}{"alias": "my-alias", "scan_limit": 200},
+			api: func() *testKMSKeysAPI {
+				keys := testKMSKeys(250)
+				keys[240].Aliases = []string{"my-alias"}
+				return &testKMSKeysAPI{keys: keys, getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+			}(),
+			err: "No key with alias my-alias was found by listing the 200 keys",
+		},
+		{name: "alias missing without fallback", raw: map[string]interface{}{"alias": "my-alias", "alias_list_fallback": false}, api: &testKMSKeysAPI{keys: testKMSKeys(3)}},
+		{
+			name: "alias forbidden without fallback",
+			raw:  map[string]interface{}{"alias": "my-alias", "alias_list_fallback": false},
+			api:  &testKMSKeysAPI{keys: testKMSKeys(3), getKeyErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}},
+			err:  "Forbidden",
+		},
+	}
+
+	for i, tc := range testCases {
+		for _, allowMissing := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s allow_missing=%t", tc.name, allowMissing), func(t *testing.T) {
+				instanceID := fmt.Sprintf("30372f20-d9f1-40b3-b486-a709e1931%03d", i)
+				raw := map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "allow_missing": allowMissing}
+				for k, v := range tc.raw {
+					raw[k] = v
+				}
+				d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+				api := *tc.api
+				api.pages = nil
+
+				_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, &api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+				if tc.err != "" {
+					assert.ErrorContains(t, err, tc.err)
+					assert.False(t, kmsKeyIsMissing(err))
+					return
+				}
+				if !tc.found && !allowMissing {
+					assert.Error(t, err)
+					assert.True(t, kmsKeyIsMissing(err))
+					assert.Empty(t, d.Id())
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, instanceID, d.Id())
+				assert.Equal(t, tc.found, d.Get("found"))
+				assert.Equal(t, tc.keyID, d.Get("key_id"))
+				if tc.found {
+					assert.Len(t, d.Get("keys").([]interface{}), 1)
+				} else {
+					assert.Empty(t, d.Get("keys"))
+					assert.Empty(t, d.Get("key_crn"))
+				}
+			})
+		}
+	}
+}
+
+func TestDataSourceIBMKMSKeyLimitArguments(t *testing.T) {
+	testCases := []struct {
+		name       string
+		raw        map[string]interface{}
+		err        string
+		deprecated bool
+	}{
+		{name: "scan_limit", raw: map[string]interface{}{"scan_limit": 100}},
+		{name: "result_limit", raw: map[string]interface{}{"result_limit": 1}},
+		{name: "limit is deprecated", raw: map[string]interface{}{"limit": 100}, deprecated: true},
+		{name: "limit and scan_limit", raw: map[string]interface{}{"limit": 100, "scan_limit": 100}, err: "conflicts with"},
+		{name: "result_limit and max_results", raw: map[string]interface{}{"result_limit": 1, "max_results": 1}, err: "conflicts with"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{"instance_id": "30372f20-d9f1-40b3-b486-a709e1932c9c", "key_name": "name-000"}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+			diags := DataSourceIBMKMSkey().Validate(terraform.NewResourceConfigRaw(raw))
+			if tc.err != "" {
+				assert.True(t, diags.HasError())
+				assert.Contains(t, fmt.Sprint(diags), tc.err)
+				return
+			}
+			assert.False(t, diags.HasError())
+			if tc.deprecated {
+				assert.Len(t, diags, 1)
+				assert.Equal(t, diag.Warning, diags[0].Severity)
+				assert.Contains(t, diags[0].Detail, "Use scan_limit instead")
+			} else {
+				assert.Empty(t, diags)
+			}
+		})
+	}
+}
+
+func TestKMSKeyRotationOverdue(t *testing.T) {
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rotatedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	enabled, disabled := true, false
+	rotation := func(enabled *bool, interval int) []kp.Policy {
+		return []kp.Policy{{Rotation: &kp.Rotation{Enabled: enabled, Interval: interval}}}
+	}
+
+	testCases := []struct {
+		name     string
+		key      kp.Key
+		policies []kp.Policy
+		overdue  bool
+	}{
+		{"no policy", kp.Key{CreationDate: &createdAt}, nil, false},
+		{"only a dual auth delete policy", kp.Key{CreationDate: &createdAt}, []kp.Policy{{DualAuth: &kp.DualAuth{Enabled: &enabled}}}, false},
+		{"disabled policy", kp.Key{CreationDate: &createdAt}, rotation(&disabled, 1), false},
+		{"never rotated and overdue", kp.Key{CreationDate: &createdAt}, rotation(&enabled, 3), true},
+		{"never rotated within the interval", kp.Key{CreationDate: &createdAt}, rotation(&enabled, 6), false},
+		{"rotated within the interval", kp.Key{CreationDate: &createdAt, LastRotateDate: &rotatedAt}, rotation(&enabled, 3), false},
+		{"rotated and overdue", kp.Key{CreationDate: &createdAt, LastRotateDate: &rotatedAt}, rotation(&enabled, 1), true},
+		{"policy without enabled field", kp.Key{CreationDate: &createdAt}, rotation(nil, 2), true},
+		{"policy without interval", kp.Key{CreationDate: &createdAt}, rotation(nil, 0), false},
+		{"key without dates", kp.Key{}, rotation(&enabled, 1), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.overdue, kmsKeyRotationOverdue(tc.key, tc.policies, clock))
+		})
+	}
+}
+
+func TestReadKMSKeyLastUpdateDate(t *testing.T) {
+	firstUpdate := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	secondUpdate := time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC)
+	api := &testKMSKeysAPI{keys: testKMSNamedKeys(1, kp.Active)}
+	api.keys[0].Description = "before"
+	api.keys[0].LastUpdateDate = &firstUpdate
+
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+		"instance_id":   instanceID,
+		"endpoint_type": "public",
+		"key_id":        "key-00",
+	})
+	read := func() map[string]interface{} {
+		// Every refresh uses its own session, as the lookup cache only lives for one provider run
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		return d.Get("keys").([]interface{})[0].(map[string]interface{})
+	}
+
+	key := read()
+	assert.Equal(t, "2024-03-01T10:00:00Z", key["last_update_date"])
+	assert.Equal(t, "before", key["description"])
+
+	// The key is refreshed in full when its update time changes
+	api.keys[0].Description = "after"
+	api.keys[0].LastUpdateDate = &secondUpdate
+	key = read()
+	assert.Equal(t, "2024-03-02T10:00:00Z", key["last_update_date"])
+	assert.Equal(t, "after", key["description"])
+
+	// and when it does not, as the update time does not cover the policies
+	api.keys[0].Description = "unchanged time"
+	key = read()
+	assert.Equal(t, "2024-03-02T10:00:00Z", key["last_update_date"])
+	assert.Equal(t, "unchanged time", key["description"])
+
+	api.keys[0].LastUpdateDate = nil
+	assert.Equal(t, "", read()["last_update_date"])
+}
+
+func TestReadKMSKeyPaginationCutoffs(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	read := func(ctx context.Context, api *testKMSKeysAPI, raw map[string]interface{}) error {
+		raw["instance_id"] = instanceID
+		raw["endpoint_type"] = "public"
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		_, err := readKMSKey(ctx, d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		return err
+	}
+
+	t.Run("max_pages", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: testKMSNamedKeys(1000, kp.Active)}
+		err := read(context.Background(), api, map[string]interface{}{"key_name": "name-900", "limit": 1000, "max_pages": 2})
+		assert.ErrorContains(t, err, "stopped after 2 pages and 400 keys, the max_pages limit")
+		assert.Equal(t, [][2]int{{200, 0}, {200, 200}}, api.pages)
+	})
+
+	t.Run("default max_pages", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: testKMSNamedKeys(1000, kp.Active)}
+		err := read(context.Background(), api, map[string]interface{}{"key_name": "name-900", "limit": 1000})
+		assert.NoError(t, err)
+		assert.Len(t, api.pages, 5)
+	})
+
+	t.Run("cancellation between pages", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		calls := 0
+		api := &testKMSKeysAPI{keys: testKMSNamedKeys(1000, kp.Active), onListKeys: func(context.Context) error {
+			calls++
+			if calls == 2 {
+				cancel()
+			}
+			return nil
+		}}
+		err := read(ctx, api, map[string]interface{}{"key_name": "name-900", "limit": 1000})
+		assert.ErrorContains(t, err, "stopped after 400 keys: context canceled")
+		assert.Equal(t, [][2]int{{200, 0}, {200, 200}}, api.pages)
+	})
+
+	t.Run("read timeout between pages", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		calls := 0
+		api := &testKMSKeysAPI{keys: testKMSNamedKeys(1000, kp.Active), onListKeys: func(ctx context.Context) error {
+			calls++
+			if calls == 2 {
+				// The second page is returned at the deadline
+				<-ctx.Done()
+			}
+			return nil
+		}}
+		err := read(ctx, api, map[string]interface{}{"key_name": "name-900", "limit": 1000})
+		assert.ErrorContains(t, err, "stopped at the read timeout after 400 keys")
+		assert.Equal(t, [][2]int{{200, 0}, {200, 200}}, api.pages)
+	})
+
+	t.Run("read timeout during a page", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		api := &testKMSKeysAPI{keys: testKMSNamedKeys(1000, kp.Active), onListKeys: func(ctx context.Context) error {
+			// The client fails the request that is in flight at the deadline
+			<-ctx.Done()
+			return ctx.Err()
+		}}
+		err := read(ctx, api, map[string]interface{}{"key_name": "name-900", "limit": 1000})
+		assert.ErrorContains(t, err, "stopped at the read timeout after 0 keys")
+		assert.Empty(t, api.pages)
+	})
+}
+
+func TestKMSKeyRingID(t *testing.T) {
+	assert.Equal(t, "default", kmsKeyRingID(kp.Key{ID: "key-00"}))
+	assert.Equal(t, "default", kmsKeyRingID(kp.Key{ID: "key-01", KeyRingID: "default"}))
+	assert.Equal(t, "ring-a", kmsKeyRingID(kp.Key{ID: "key-02", KeyRingID: "ring-a"}))
+}
+
+func TestReadKMSKeyRingIDNormalized(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(3, kp.Active)
+	keys[0].Aliases = []string{"alias-00"}
+	keys[1].KeyRingID = "default"
+	keys[2].KeyRingID = "ring-a"
+	api := &testKMSKeysAPI{keys: keys, keyRings: []string{"default", "ring-a"}}
+
+	read := func(raw map[string]interface{}) []interface{} {
+		raw["instance_id"] = instanceID
+		raw["endpoint_type"] = "public"
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		return d.Get("keys").([]interface{})
+	}
+	keyRingID := func(key interface{}) interface{} {
+		return key.(map[string]interface{})["key_ring_id"]
+	}
+
+	// The key without a key ring and the key of the default key ring report the same key ring in every lookup
+	assert.Equal(t, "default", keyRingID(read(map[string]interface{}{"key_name": "name-000"})[0]))
+	assert.Equal(t, "default", keyRingID(read(map[string]interface{}{"key_name": "name-001"})[0]))
+	assert.Equal(t, "default", keyRingID(read(map[string]interface{}{"key_id": "key-00"})[0]))
+	assert.Equal(t, "default", keyRingID(read(map[string]interface{}{"alias": "alias-00"})[0]))
+	assert.Equal(t, "ring-a", keyRingID(read(map[string]interface{}{"key_id": "key-02"})[0]))
+
+	// Filtering on the default key ring matches the keys without a key ring
+	for i := range api.keys {
+		api.keys[i].Name = "shared"
+	}
+	keysOfDefaultRing := read(map[string]interface{}{"key_name": "shared", "key_ring_id": "default"})
+	assert.Len(t, keysOfDefaultRing, 2)
+	keysOfRingA := read(map[string]interface{}{"key_name": "shared", "key_ring_id": "ring-a"})
+	assert.Len(t, keysOfRingA, 1)
+	assert.Equal(t, "key-02", keysOfRingA[0].(map[string]interface{})["id"])
+}
+
+func TestFilterKMSKeysByCreationDate(t *testing.T) {
+	date := func(day int) *time.Time {
+		t := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	keys := []kp.Key{
+		{ID: "key-00", CreationDate: date(1)},
+		{ID: "key-01", CreationDate: date(10)},
+		{ID: "key-02", CreationDate: date(20)},
+		{ID: "key-03"},
+	}
+	ids := func(keys []kp.Key) []string {
+		ids := []string{}
+		for _, key := range keys {
+			ids = append(ids, key.ID)
+		}
+		return ids
+	}
+
+	// All the keys are kept without a bound, including the key without a creation date
+	assert.Equal(t, []string{"key-00", "key-01", "key-02", "key-03"}, ids(filterKMSKeysByCreationDate(keys, nil, nil)))
+	// created_after is inclusive and created_before exclusive
+	assert.Equal(t, []string{"key-01", "key-02"}, ids(filterKMSKeysByCreationDate(keys, date(10), nil)))
+	assert.Equal(t, []string{"key-00"}, ids(filterKMSKeysByCreationDate(keys, nil, date(10))))
+	assert.Equal(t, []string{"key-01"}, ids(filterKMSKeysByCreationDate(keys, date(10), date(20))))
+	// Consecutive ranges do not overlap
+	assert.Equal(t, []string{"key-02"}, ids(filterKMSKeysByCreationDate(keys, date(20), date(21))))
+	assert.Empty(t, filterKMSKeysByCreationDate(keys, date(2), date(10)))
+	// The key without a creation date is discarded by either bound
+	assert.NotContains(t, ids(filterKMSKeysByCreationDate(keys, date(1), nil)), "key-03")
+	assert.NotContains(t, ids(filterKMSKeysByCreationDate(keys, nil, date(21))), "key-03")
+}
+
+func TestListKMSKeysInCreationDateRange(t *testing.T) {
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Without a range or a limit, the default page of the API is read with a single request
+	api := &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	_, err := listKMSKeysInCreationDateRange(context.Background(), api, 0, nil, nil, "instance")
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 0}}, api.pages)
+
+	// A range scans every key of the instance
+	api = &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	keys, err := listKMSKeysInCreationDateRange(context.Background(), api, 0, &createdAfter, nil, "instance")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 450)
+	assert.Equal(t, [][2]int{{200, 0}, {200, 200}, {200, 400}}, api.pages)
+
+	// limit bounds the keys that are scanned, with or without a range
+	api = &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	keys, err = listKMSKeysInCreationDateRange(context.Background(), api, 250, nil, &createdAfter, "instance")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 250)
+	assert.Equal(t, [][2]int{{200, 0}, {50, 200}}, api.pages)
+}
+
+func TestKMSKeyCreationDateRange(t *testing.T) {
+	rangeOf := func(raw map[string]interface{}) (*time.Time, *time.Time, error) {
+		raw["instance_id"] = "30372f20-d9f1-40b3-b486-a709e1932c9c"
+		raw["key_name"] = "name-000"
+		return kmsKeyCreationDateRange(schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw))
+	}
+
+	createdAfter, createdBefore, err := rangeOf(map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Nil(t, createdAfter)
+	assert.Nil(t, createdBefore)
+
+	createdAfter, createdBefore, err = rangeOf(map[string]interface{}{"created_after": "2024-01-10T00:00:00Z", "created_before": "2024-01-20T02:00:00+02:00"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), createdAfter.UTC())
+	assert.Equal(t, time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), createdBefore.UTC())
+
+	_, _, err = rangeOf(map[string]interface{}{"created_after": "2024-01-20T00:00:00Z", "created_before": "2024-01-20T00:00:00Z"})
+	assert.EqualError(t, err, "[ERROR] created_after 2024-01-20T00:00:00Z must be before created_before 2024-01-20T00:00:00Z")
+
+	// Invalid timestamps fail at plan time
+	for _, name := range []string{"created_after", "created_before"} {
+		for _, value := range []string{"2024-01-20", "20 Jan 2024", "2024-01-20T00:00:00"} {
+			_, errs := DataSourceIBMKMSkey().Schema[name].ValidateFunc(value, name)
+			assert.NotEmpty(t, errs, value)
+			_, errs = DataSourceIBMKMSkeys().Schema[name].ValidateFunc(value, name)
+			assert.NotEmpty(t, errs, value)
+		}
+	}
+}
+
+func TestReadKMSKeyCreationDateRange(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(4, kp.Active)
+	for i := range keys[:3] {
+		creationDate := time.Date(2024, 1, 1+i*10, 0, 0, 0, 0, time.UTC)
+		keys[i].CreationDate = &creationDate
+		keys[i].Name = "shared"
+	}
+	keys[3].Name = "shared"
+	api := &testKMSKeysAPI{keys: keys}
+
+	read := func(raw map[string]interface{}) (*schema.ResourceData, error) {
+		raw["instance_id"] = instanceID
+		raw["endpoint_type"] = "public"
+		raw["key_name"] = "shared"
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		return d, err
+	}
+
+	d, err := read(map[string]interface{}{"created_before": "2024-01-11T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Len(t, d.Get("keys").([]interface{}), 2)
+
+	d, err = read(map[string]interface{}{"created_after": "2024-01-21T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, "key-02", d.Get("key_id"))
+
+	_, err = read(map[string]interface{}{"created_after": "2024-02-01T00:00:00Z"})
+	assert.EqualError(t, err, "[ERROR] No keys created in the range of created_after and created_before in instance 30372f20-d9f1-40b3-b486-a709e1932c9c, 4 keys scanned")
+}
+
+func TestKMSKeyNameNotFoundError(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	createdAfter := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	early := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC)
+	inRange := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		keys         []kp.Key
+		scanComplete bool
+		err          string
+		missing      bool
+	}{
+		{name: "name missing", scanComplete: true, err: "[ERROR] No keys with name shared in instance " + instanceID + ", 100 keys scanned", missing: true},
+		{
+			name: "destroyed key",
+			keys: []kp.Key{{ID: "destroyed", Name: "shared", State: int(kp.Destroyed), CreationDate: &inRange}},
+			err:  "excluded because it is in the destroyed state, 100 keys scanned",
+		},
+		{
+			name: "key created before created_after",
+			keys: []kp.Key{{ID: "early", Name: "shared", State: int(kp.Active), CreationDate: &early}},
+			err:  "excluded because it was created at 2024-01-01T00:00:00Z, before created_after, 100 keys scanned",
+		},
+		{
+			name: "key created after created_before",
+			keys: []kp.Key{{ID: "late", Name: "shared", State: int(kp.Active), CreationDate: &late}},
+			err:  "excluded because it was created at 2024-01-25T00:00:00Z, not before created_before, 100 keys scanned",
+		},
+		{
+			name: "key without creation date",
+			keys: []kp.Key{{ID: "undated", Name: "shared", State: int(kp.Active)}},
+			err:  "excluded because it has no creation date to compare with created_after and created_before",
+		},
+		{
+			name: "key beyond the scanned keys",
+			keys: []kp.Key{
+				{ID: "destroyed", Name: "shared", State: int(kp.Destroyed), CreationDate: &inRange},
+				{ID: "early", Name: "shared", State: int(kp.Active), CreationDate: &early},
+				{ID: "scanned", Name: "shared", State: int(kp.Active), CreationDate: &inRange},
+			},
+			err: "excluded by scan_limit or first_page_only after 100 keys scanned",
+		},
+		{
+			name:         "key created after the scan",
+			keys:         []kp.Key{{ID: "new", Name: "shared", State: int(kp.Active), CreationDate: &inRange}},
+			scanComplete: true,
+			err:          "was not among the 100 keys scanned",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := kmsKeyNameNotFoundError(context.Background(), &testKMSKeysAPI{keys: tc.keys}, "shared", instanceID, 100, &createdAfter, &createdBefore, tc.scanComplete)
+			assert.ErrorContains(t, err, tc.err)
+			assert.Equal(t, tc.missing, kmsKeyIsMissing(err))
+		})
+	}
+}
+
+func TestReadKMSKeyRingExists(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(2, kp.Active)
+	keys[1].KeyRingID = "ring-a"
+
+	testCases := []struct {
+		name     string
+		raw      map[string]interface{}
+		keyRings []string
+		err      string
+	}{
+		{
+			name:     "missing key ring",
+			raw:      map[string]interface{}{"key_name": "name-000", "key_ring_id": "ring-b"},
+			keyRings: []string{"ring-a", "default"},
+			err:      "[ERROR] key ring ring-b not found in instance 30372f20-d9f1-40b3-b486-a709e1932c9c (available: default, ring-a)",
+		},
+		{
+			name:     "missing key ring of a key id lookup",
+			raw:      map[string]interface{}{"key_id": "key-00", "key_ring_id": "ring-b"},
+			keyRings: []string{"default"},
+			err:      "[ERROR] key ring ring-b not found in instance 30372f20-d9f1-40b3-b486-a709e1932c9c (available: default)",
+		},
+		{
+			name:     "existing key ring without the key",
+			raw:      map[string]interface{}{"key_name": "name-000", "key_ring_id": "ring-a"},
+			keyRings: []string{"default", "ring-a"},
+			err:      "[ERROR] No keys with name name-000 in key ring ring-a of instance 30372f20-d9f1-40b3-b486-a709e1932c9c, 2 keys scanned",
+		},
+		{
+			name:     "key id in another key ring",
+			raw:      map[string]interface{}{"key_id": "key-01", "key_ring_id": "default"},
+			keyRings: []string{"default", "ring-a"},
+			err:      "[ERROR] Key key-01 of instance 30372f20-d9f1-40b3-b486-a709e1932c9c is in key ring ring-a, not in key ring default",
+		},
+		{
+			name:     "existing key ring",
+			raw:      map[string]interface{}{"key_name": "name-001", "key_ring_id": "ring-a"},
+			keyRings: []string{"default", "ring-a"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public"}
+			for k, v := range tc.raw {
+				raw[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+			api := &testKMSKeysAPI{keys: keys, keyRings: tc.keyRings}
+			_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "key-01", d.Get("key_id"))
+		})
+	}
+
+	// The key rings are not listed without key_ring_id
+	api := &testKMSKeysAPI{keys: keys, keyRingsErr: fmt.Errorf("key rings unavailable")}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "key_id": "key-00"})
+	diags, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+	assert.NoError(t, err)
+	assert.Empty(t, diags)
+}
+
+func TestLookupKMSKeysRegistrationCount(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := append(testKMSNamedKeys(2, kp.Active), kp.Key{ID: "key-02", Name: "name-000", State: int(kp.Active)})
+	lookup := func(api *testKMSKeysAPI, raw map[string]interface{}) (*kmsKeyLookupResult, error) {
+		raw["instance_id"] = instanceID
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		return lookupKMSKeys(context.Background(), d, api, instanceID)
+	}
+
+	t.Run("not checked", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: keys, registrations: map[string]int{"key-00": 3}}
+		result, err := lookup(api, map[string]interface{}{"key_name": "name-000"})
+		assert.NoError(t, err)
+		assert.Len(t, result.Keys, 2)
+		for _, key := range result.Keys {
+			assert.NotContains(t, key, "registration_count")
+		}
+		assert.Equal(t, 0, api.registrationCalls)
+	})
+
+	t.Run("one request per key", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: keys, registrations: map[string]int{"key-00": 3}}
+		result, err := lookup(api, map[string]interface{}{"key_name": "name-000", "check_registrations": true})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Keys[0]["registration_count"])
+		assert.Equal(t, 0, result.Keys[1]["registration_count"])
+		assert.Equal(t, 2, api.registrationCalls)
+
+		result, err = lookup(api, map[string]interface{}{"key_id": "key-00", "check_registrations": true})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.Keys[0]["registration_count"])
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: keys, registrationsErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+		result, err := lookup(api, map[string]interface{}{"alias": "key-01", "check_registrations": true})
+		assert.NoError(t, err)
+		assert.NotContains(t, result.Keys[0], "registration_count")
+		if assert.Len(t, result.Warnings, 1) {
+			assert.Equal(t, diag.Warning, result.Warnings[0].Severity)
+			assert.Contains(t, result.Warnings[0].Summary, "registrations of key key-01")
+		}
+
+		// The warning is returned by the read, which does not fail
+		api = &testKMSKeysAPI{keys: keys, registrationsErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "key_id": "key-01", "check_registrations": true})
+		diags, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, api, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		assert.Len(t, diags, 1)
+		assert.Equal(t, true, d.Get("found"))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &testKMSKeysAPI{keys: keys, registrationsErr: &kp.Error{StatusCode: 500, Message: "Internal Server Error"}}
+		_, err := lookup(api, map[string]interface{}{"key_id": "key-01", "check_registrations": true})
+		assert.ErrorContains(t, err, "Failed to read the registrations of key key-01")
+	})
+}
+
+func TestKMSKeyLookupClientCountRegistrations(t *testing.T) {
+	var offsets []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/keys/key-00/registrations", r.URL.Path)
+		assert.Equal(t, "5000", r.URL.Query().Get("limit"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "10000" {
+			w.Write([]byte(`{"metadata": {"collectionType": "application/vnd.ibm.kms.registration+json", "collectionTotal": 12}, "resources": []}`))
+			return
+		}
+		w.Write([]byte(`{"metadata": {"collectionType": "application/vnd.ibm.kms.registration+json", "collectionTotal": 5000}, "resources": []}`))
+	}))
+	defer server.Close()
+	api, err := kp.New(kp.ClientConfig{BaseURL: server.URL, Authorization: "Bearer token", InstanceID: "instance"}, nil)
+	assert.NoError(t, err)
+
+	// The registrations beyond the first page are counted
+	count, err := kmsKeyLookupClient{Client: api}.CountRegistrations(context.Background(), "key-00")
+	assert.NoError(t, err)
+	assert.Equal(t, 10012, count)
+	assert.Equal(t, []string{"0", "5000", "10000"}, offsets)
+}
+
+func TestReadKMSKeyType(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(2, kp.Active)
+	keys[1].Extractable = true
+	keys[1].Aliases = []string{"standard-alias"}
+
+	for _, raw := range []map[string]interface{}{
+		{"key_name": "name-001"},
+		{"key_id": "key-01"},
+		{"alias": "standard-alias"},
+	} {
+		raw["instance_id"] = instanceID
+		raw["endpoint_type"] = "public"
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, &testKMSKeysAPI{keys: keys}, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		assert.Equal(t, "standard", d.Get("keys.0.key_type"))
+		assert.Equal(t, true, d.Get("keys.0.extractable"))
+		assert.Equal(t, true, d.Get("keys.0.standard_key"))
+	}
+
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "key_id": "key-00"})
+	diags, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, &testKMSKeysAPI{keys: keys}, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+	assert.NoError(t, err)
+	assert.Empty(t, diags)
+	assert.Equal(t, "root", d.Get("keys.0.key_type"))
+	assert.Equal(t, false, d.Get("keys.0.extractable"))
+	assert.Equal(t, false, d.Get("keys.0.standard_key"))
+}
+
+func TestReadKMSKeyAliasCapacity(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	keys := testKMSNamedKeys(1, kp.Active)
+	keys[0].Aliases = []string{"alias-1", "alias-2"}
+
+	for _, raw := range []map[string]interface{}{
+		{"key_name": "name-000"},
+		{"key_id": "key-00"},
+		{"alias": "alias-1"},
+	} {
+		raw["instance_id"] = instanceID
+		raw["endpoint_type"] = "public"
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, &testKMSKeysAPI{keys: keys}, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, d.Get("keys.0.alias_count"))
+		assert.Equal(t, 3, d.Get("keys.0.aliases_remaining"))
+	}
+}
+
+func TestKMSKeyRestoreExpiry(t *testing.T) {
+	deletionDate := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	expiresAt := time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		now        time.Time
+		restorable bool
+	}{
+		{deletionDate, true},
+		{expiresAt.Add(-time.Second), true},
+		// The window ends at the expiry
+		{expiresAt, false},
+		{expiresAt.AddDate(0, 1, 0), false},
+	}
+	for _, tc := range testCases {
+		expiry, restorable := kmsKeyRestoreExpiry(&deletionDate, tc.now)
+		assert.Equal(t, expiresAt, expiry)
+		assert.Equal(t, tc.restorable, restorable, tc.now.String())
+	}
+
+	expiry, restorable := kmsKeyRestoreExpiry(nil, deletionDate)
+	assert.True(t, expiry.IsZero())
+	assert.False(t, restorable)
+}
+
+func TestFlattenKMSKeyRestore(t *testing.T) {
+	deletionDate := time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	now := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	key := kp.Key{State: int(kp.Destroyed), DeletionDate: &deletionDate}
+	assert.Equal(t, map[string]interface{}{"restorable": true, "restore_expires_at": "2024-03-31T09:00:00Z"}, flattenKMSKeyRestore(key, now))
+	assert.Equal(t, map[string]interface{}{"restorable": false, "restore_expires_at": "2024-03-31T09:00:00Z"}, flattenKMSKeyRestore(key, now.AddDate(0, 1, 0)))
+
+	// A destroyed key without a deletion date cannot be restored
+	assert.Equal(t, map[string]interface{}{"restorable": false}, flattenKMSKeyRestore(kp.Key{State: int(kp.Destroyed)}, now))
+
+	for _, state := range []kp.KeyState{kp.KeyState(0), kp.Active, kp.Suspended, kp.Deactivated} {
+		assert.Empty(t, flattenKMSKeyRestore(kp.Key{State: int(state), DeletionDate: &deletionDate}, now))
+	}
+}
+
+func TestReadKMSKeyRestore(t *testing.T) {
+	instanceID := "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	deletionDate := time.Now().Add(-24 * time.Hour).UTC().Truncate(time.Second)
+	keys := testKMSNamedKeys(2, kp.Active)
+	keys[1].State = int(kp.Destroyed)
+	keys[1].DeletionDate = &deletionDate
+
+	read := func(keyID string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": instanceID, "endpoint_type": "public", "key_id": keyID})
+		_, err := readKMSKey(context.Background(), d, &testKMSClientSession{}, &testKMSKeysAPI{keys: keys}, "https://us-south.kms.cloud.ibm.com/api/v2/keys", instanceID)
+		assert.NoError(t, err)
+		return d
+	}
+
+	d := read("key-01")
+	assert.Equal(t, true, d.Get("keys.0.restorable"))
+	assert.Equal(t, deletionDate.Add(kmsKeyRestoreWindow).Format(time.RFC3339), d.Get("keys.0.restore_expires_at"))
+
+	d = read("key-00")
+	assert.Equal(t, false, d.Get("keys.0.restorable"))
+	assert.Equal(t, "", d.Get("keys.0.restore_expires_at"))
+}
+
+func TestKMSKeyLookupClientPolicyEndpoint(t *testing.T) {
+	newServer := func(paths *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*paths = append(*paths, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			if strings.HasSuffix(r.URL.Path, "/policies") {
+				_, _ = w.Write([]byte(`{"metadata": {"collectionTotal": 1}, "resources": [{"rotation": {"interval_month": 3}}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"metadata": {"collectionTotal": 1}, "resources": [{"id": "key-1", "name": "key"}]}`))
+		}))
+	}
+	var mainPaths, policyPaths []string
+	mainServer, policyServer := newServer(&mainPaths), newServer(&policyPaths)
+	defer mainServer.Close()
+	defer policyServer.Close()
+
+	api, err := kp.New(kp.ClientConfig{BaseURL: mainServer.URL, Authorization: "Bearer token", InstanceID: "instance"}, nil)
+	assert.NoError(t, err)
+	policyURL, err := kmsOverrideEndpointURL(policyServer.URL)
+	assert.NoError(t, err)
+	client := kmsKeyLookupClient{Client: api, policyClient: kmsClientWithEndpointURL(api, policyURL)}
+
+	key, err := client.GetKey(context.Background(), "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", key.ID)
+	policies, err := client.GetPolicies(context.Background(), "key-1")
+	assert.NoError(t, err)
+	assert.Len(t, policies, 1)
+	assert.Equal(t, []string{"/api/v2/keys/key-1"}, mainPaths)
+	assert.Equal(t, []string{"/api/v2/keys/key-1/policies"}, policyPaths)
+	// The client of the instance keeps its endpoint
+	assert.Equal(t, mainServer.URL+"/api/v2/", api.URL.String())
+
+	// Without a policy endpoint, the policies are read from the endpoint of the instance
+	_, err = kmsKeyLookupClient{Client: api}.GetPolicies(context.Background(), "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/api/v2/keys/key-1", "/api/v2/keys/key-1/policies"}, mainPaths)
+}
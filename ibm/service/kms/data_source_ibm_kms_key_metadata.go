@@ -0,0 +1,244 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceIBMKMSKeyMetadata reads a single key from its CRN. Unlike the other kms data sources, it never looks up
+// the instance in the resource controller: the endpoint is derived from the region and the service of the CRN. It
+// only needs access to the key, such as for a consumer in another account that is granted the key by an
+// authorization policy.
+func DataSourceIBMKMSKeyMetadata() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSKeyMetadataRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"crn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateKMSKeyMetadataCRN,
+				Description:  "The CRN of the key, crn:v1:<cname>:<ctype>:<kms or hs-crypto>:<region>:a/<account>:<instance GUID>:key:<key ID>",
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The endpoint of the instance, derived from the region and the service of the CRN",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The GUID of the instance of the key",
+			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the account of the instance",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the instance",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The service of the instance, kms or hs-crypto",
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the key",
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"key_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the key, standard or root",
+			},
+			"standard_key": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"key_ring_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the key ring that the key belongs to",
+			},
+			"aliases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"imported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key material was imported",
+			},
+			"algorithm_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The algorithm of the key",
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key was created, in RFC 3339 format",
+			},
+			"last_update_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key was last updated, in RFC 3339 format",
+			},
+			"last_rotate_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key material was last rotated, in RFC 3339 format",
+			},
+			"expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key expires, in RFC 3339 format",
+			},
+		}),
+	}
+}
+
+// Validate the crn of ibm_kms_key_metadata, which must be the CRN of a key of a key protect or hpcs instance
+func validateKMSKeyMetadataCRN(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseKMSKeyMetadataCRN(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// Parse the CRN of a key of a key protect or hpcs instance
+func parseKMSKeyMetadataCRN(crn string) (kmsKeyCRNComponents, error) {
+	components, ok := parseKMSKeyCRN(crn)
+	if !ok || components.Region == "" {
+		return kmsKeyCRNComponents{}, fmt.Errorf("%q is not a valid key CRN, expected crn:v1:<cname>:<ctype>:<service>:<region>:a/<account>:<instance GUID>:key:<key ID>", crn)
+	}
+	if components.ServiceName != kmsServiceKeyProtect && components.ServiceName != kmsServiceHPCS {
+		return kmsKeyCRNComponents{}, fmt.Errorf("the key CRN %q is for service %s, expected %s or %s", crn, components.ServiceName, kmsServiceKeyProtect, kmsServiceHPCS)
+	}
+	return components, nil
+}
+
+// Derive the keys endpoint of an instance from the region and the service of a key CRN. Key protect has a regional
+// endpoint, and hpcs an endpoint per instance. IBMCLOUD_KP_API_ENDPOINT overrides the endpoint, as for the other kms
+// data sources.
+func kmsKeyCRNEndpointURL(components kmsKeyCRNComponents, endpointType string) (*url.URL, error) {
+	endpointURL := kmsServiceEndpoint(kmsInstanceCRNComponents{
+		ServiceName:  components.ServiceName,
+		Region:       components.Region,
+		InstanceGUID: components.InstanceGUID,
+	}, endpointType)
+	return kmsOverrideEndpointURL(conns.EnvFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, endpointURL))
+}
+
+// Configure the key protect client of the session for the instance of a key CRN, without the resource controller
+// lookup of populateKPClient
+func populateKPClientFromKeyCRN(ctx context.Context, d *schema.ResourceData, meta interface{}, components kmsKeyCRNComponents, endpointType string) (*kp.Client, error) {
+	kpAPI, err := meta.(conns.ClientSession).KeyManagementAPI()
+	if err != nil {
+		return nil, err
+	}
+	if meta.(conns.ClientSession).APITimingLogsEnabled() {
+		kpAPI.HttpClient = *conns.WithAPITimingLogs(&kpAPI.HttpClient, "kms")
+	}
+	if _, err := kmsApplyCredentialOverrides(ctx, d, meta, kpAPI); err != nil {
+		return nil, err
+	}
+	kpAPI.URL, err = kmsKeyCRNEndpointURL(components, endpointType)
+	if err != nil {
+		return nil, err
+	}
+	kpAPI.Config.InstanceID = components.InstanceGUID
+	return kpAPI, nil
+}
+
+func dataSourceIBMKMSKeyMetadataRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	components, err := parseKMSKeyMetadataCRN(d.Get("crn").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	endpointType := kmsEndpointType(d, meta)
+	api, err := populateKPClientFromKeyCRN(context, d, meta, components, endpointType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	d.Set("endpoint_type", endpointType)
+	d.Set("endpoint_url", api.URL.String())
+	if err := readKMSKeyMetadata(ctx, d, api, components); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Read the key of a key CRN with the given client
+func readKMSKeyMetadata(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, components kmsKeyCRNComponents) error {
+	key, err := api.GetKey(ctx, components.KeyID)
+	if err != nil {
+		return fmt.Errorf("[ERROR] Get Key failed for key %s of instance %s: %s", components.KeyID, components.InstanceGUID, kmsAuthErrorHint(err, components.InstanceGUID))
+	}
+
+	d.SetId(key.CRN)
+	d.Set("instance_id", components.InstanceGUID)
+	d.Set("account_id", components.AccountID)
+	d.Set("region", components.Region)
+	d.Set("service_name", components.ServiceName)
+	d.Set("key_id", key.ID)
+	keyInstance := flex.FlattenKMSKey(*key)
+	for _, attribute := range []string{"name", "description", "key_type", "standard_key", "state", "key_ring_id", "aliases", "imported", "algorithm_type"} {
+		d.Set(attribute, keyInstance[attribute])
+	}
+	d.Set("creation_date", kmsKeyMetadataDate(key.CreationDate))
+	d.Set("last_update_date", kmsKeyMetadataDate(key.LastUpdateDate))
+	d.Set("last_rotate_date", kmsKeyMetadataDate(key.LastRotateDate))
+	d.Set("expiration_date", kmsKeyMetadataDate(key.Expiration))
+	return nil
+}
+
+// Format a date of a key in RFC 3339 format, an empty string when the key does not have the date
+func kmsKeyMetadataDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
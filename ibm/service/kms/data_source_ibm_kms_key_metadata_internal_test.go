@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKMSKeyMetadataCRN  = "crn:v1:bluemix:public:kms:eu-de:a/6e1b5a8c2c53:30372f20-d9f1-40b3-b486-a709e1932c9c:key:5d5c2e5e-62d7-4d6b-9a3c-a1b2c3d4e5f6"
+	testHPCSKeyMetadataCRN = "crn:v1:bluemix:public:hs-crypto:us-south:a/6e1b5a8c2c53:8d3b2f4a-1c2d-4e5f-8a9b-0c1d2e3f4a5b:key:0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"
+)
+
+func TestKMSKeyCRNEndpointURL(t *testing.T) {
+	t.Setenv("IBMCLOUD_KP_API_ENDPOINT", "")
+	testCases := []struct {
+		crn          string
+		endpointType string
+		endpointURL  string
+	}{
+		{testKMSKeyMetadataCRN, "public", "https://eu-de.kms.cloud.ibm.com/api/v2/keys"},
+		{testKMSKeyMetadataCRN, "private", "https://private.eu-de.kms.cloud.ibm.com/api/v2/keys"},
+		{testHPCSKeyMetadataCRN, "public", "https://8d3b2f4a-1c2d-4e5f-8a9b-0c1d2e3f4a5b.api.us-south.hs-crypto.appdomain.cloud/api/v2/keys"},
+		{testHPCSKeyMetadataCRN, "private", "https://8d3b2f4a-1c2d-4e5f-8a9b-0c1d2e3f4a5b.api.private.us-south.hs-crypto.appdomain.cloud/api/v2/keys"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.endpointURL, func(t *testing.T) {
+			components, err := parseKMSKeyMetadataCRN(tc.crn)
+			assert.NoError(t, err)
+			endpointURL, err := kmsKeyCRNEndpointURL(components, tc.endpointType)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.endpointURL, endpointURL.String())
+		})
+	}
+
+	t.Setenv("IBMCLOUD_KP_API_ENDPOINT", "https://qa.us-south.kms.test.cloud.ibm.com")
+	components, _ := parseKMSKeyMetadataCRN(testKMSKeyMetadataCRN)
+	endpointURL, err := kmsKeyCRNEndpointURL(components, "public")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://qa.us-south.kms.test.cloud.ibm.com/api/v2/keys", endpointURL.String())
+}
+
+func TestParseKMSKeyMetadataCRN(t *testing.T) {
+	components, err := parseKMSKeyMetadataCRN(testHPCSKeyMetadataCRN)
+	assert.NoError(t, err)
+	assert.Equal(t, kmsKeyCRNComponents{
+		AccountID:    "6e1b5a8c2c53",
+		Region:       "us-south",
+		ServiceName:  "hs-crypto",
+		InstanceGUID: "8d3b2f4a-1c2d-4e5f-8a9b-0c1d2e3f4a5b",
+		KeyID:        "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+	}, components)
+
+	for crn, message := range map[string]string{
+		"30372f20-d9f1-40b3-b486-a709e1932c9c":                                                                   "is not a valid key CRN",
+		"crn:v1:bluemix:public:kms:eu-de:a/6e1b5a8c2c53:30372f20-d9f1-40b3-b486-a709e1932c9c::":                  "is not a valid key CRN",
+		"crn:v1:bluemix:public:kms::a/6e1b5a8c2c53:30372f20-d9f1-40b3-b486-a709e1932c9c:key:5d5c2e5e":            "is not a valid key CRN",
+		"crn:v1:bluemix:public:secrets-manager:eu-de:a/6e1b5a8c2c53:30372f20-d9f1-40b3-b486-a709e1932c9c:key:5d": "is for service secrets-manager, expected kms or hs-crypto",
+	} {
+		_, err := parseKMSKeyMetadataCRN(crn)
+		assert.ErrorContains(t, err, message, crn)
+	}
+}
+
+func TestReadKMSKeyMetadata(t *testing.T) {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	api := &testKMSGetKeyAPI{keysByID: map[string]kp.Key{
+		"0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0": {
+			ID:            "0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0",
+			CRN:           testHPCSKeyMetadataCRN,
+			Name:          "byok-root",
+			State:         1,
+			KeyRingID:     "default",
+			Aliases:       []string{"byok"},
+			AlgorithmType: "AES",
+			CreationDate:  &created,
+		},
+	}}
+	components, _ := parseKMSKeyMetadataCRN(testHPCSKeyMetadataCRN)
+
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSKeyMetadata().Schema, map[string]interface{}{"crn": testHPCSKeyMetadataCRN})
+	assert.NoError(t, readKMSKeyMetadata(context.Background(), d, api, components))
+	assert.Equal(t, []string{"0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"}, api.ids)
+	assert.Equal(t, testHPCSKeyMetadataCRN, d.Id())
+	assert.Equal(t, "8d3b2f4a-1c2d-4e5f-8a9b-0c1d2e3f4a5b", d.Get("instance_id"))
+	assert.Equal(t, "6e1b5a8c2c53", d.Get("account_id"))
+	assert.Equal(t, "hs-crypto", d.Get("service_name"))
+	assert.Equal(t, "byok-root", d.Get("name"))
+	assert.Equal(t, "root", d.Get("key_type"))
+	assert.Equal(t, []interface{}{"byok"}, d.Get("aliases"))
+	assert.Equal(t, "2024-03-01T09:00:00Z", d.Get("creation_date"))
+	assert.Equal(t, "", d.Get("expiration_date"))
+
+	// The key is read from its ID only, a missing key is an error
+	components, _ = parseKMSKeyMetadataCRN(testKMSKeyMetadataCRN)
+	d = schema.TestResourceDataRaw(t, DataSourceIBMKMSKeyMetadata().Schema, map[string]interface{}{"crn": testKMSKeyMetadataCRN})
+	err := readKMSKeyMetadata(context.Background(), d, api, components)
+	assert.ErrorContains(t, err, "Get Key failed for key 5d5c2e5e-62d7-4d6b-9a3c-a1b2c3d4e5f6 of instance 30372f20-d9f1-40b3-b486-a709e1932c9c")
+}
@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The methods of the Key Protect client that ibm_kms_key_name_check uses
+type kmsKeyNameCheckAPI interface {
+	kmsKeysAPI
+	kmsKeysPageAPI
+}
+
+var _ kmsKeyNameCheckAPI = (*kp.Client)(nil)
+
+func DataSourceIBMKMSKeyNameCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSKeyNameCheckRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"key_ring_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only count the keys of this key ring. The keys of all the key rings are counted when it is not set",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The candidate key names to check",
+			},
+			"name_counts": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The number of existing keys with each candidate name",
+			},
+			"all_unique": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether no existing key has one of the candidate names",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSKeyNameCheckRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	if err := readKMSKeyNameCheck(ctx, d, api, instanceID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Count the existing keys with the candidate names, from a single listing of the keys of the instance. The keys of
+// the other key rings are not counted when a key ring is given.
+func readKMSKeyNameCheck(ctx context.Context, d *schema.ResourceData, api kmsKeyNameCheckAPI, instanceID string) error {
+	keyRingID := d.Get("key_ring_id").(string)
+	if keyRingID != "" {
+		if err := validateKMSKeyRingExists(ctx, api, keyRingID, instanceID); err != nil {
+			return err
+		}
+	}
+	keys, err := listKMSKeys(ctx, api.GetKeys, 0, kmsKeyDefaultMaxPages, instanceID)
+	if err != nil {
+		return err
+	}
+
+	nameCounts := countKMSKeyNames(filterKMSKeysByKeyRing(keys, keyRingID), flex.ExpandStringList(d.Get("names").([]interface{})))
+	allUnique := true
+	for _, count := range nameCounts {
+		if count > 0 {
+			allUnique = false
+		}
+	}
+
+	if keyRingID != "" {
+		d.SetId(fmt.Sprintf("%s/%s", instanceID, keyRingID))
+	} else {
+		d.SetId(instanceID)
+	}
+	d.Set("name_counts", nameCounts)
+	d.Set("all_unique", allUnique)
+	return nil
+}
+
+// Count the keys with each of the names. The names match exactly and are case sensitive, as the key names of the
+// service. Every name has a count, 0 when no key has the name.
+func countKMSKeyNames(keys []kp.Key, names []string) map[string]int {
+	nameCounts := make(map[string]int, len(names))
+	for _, name := range names {
+		nameCounts[name] = 0
+	}
+	for _, key := range keys {
+		if _, ok := nameCounts[key.Name]; ok {
+			nameCounts[key.Name]++
+		}
+	}
+	return nameCounts
+}
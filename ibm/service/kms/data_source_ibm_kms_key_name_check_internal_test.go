@@ -0,0 +1,137 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"testing"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKMSKeyPagesAPI fakes the paged listing of the keys of an instance
+type testKMSKeyPagesAPI struct {
+	testKMSKeysAPI
+}
+
+func (api *testKMSKeyPagesAPI) GetKeys(ctx context.Context, limit int, offset int) (*kp.Keys, error) {
+	if api.listKeysErr != nil {
+		return nil, api.listKeysErr
+	}
+	api.pages = append(api.pages, [2]int{limit, offset})
+	page := []kp.Key{}
+	if offset < len(api.keys) {
+		page = api.keys[offset:]
+	}
+	if len(page) > limit {
+		page = page[:limit]
+	}
+	return &kp.Keys{Metadata: kp.KeysMetadata{NumberOfKeys: len(page)}, Keys: page}, nil
+}
+
+func TestListKMSKeys(t *testing.T) {
+	api := &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	keys, err := listKMSKeys(context.Background(), api.GetKeys, 0, kmsKeyDefaultMaxPages, "instance")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 450)
+	assert.Equal(t, [][2]int{{200, 0}, {200, 200}, {200, 400}}, api.pages)
+
+	api = &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	keys, err = listKMSKeys(context.Background(), api.GetKeys, 250, kmsKeyDefaultMaxPages, "instance")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 250)
+	assert.Equal(t, [][2]int{{200, 0}, {50, 200}}, api.pages)
+
+	// A full last page needs another request to know that there are no more keys
+	api = &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(200)}}
+	keys, err = listKMSKeys(context.Background(), api.GetKeys, 0, kmsKeyDefaultMaxPages, "instance")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 200)
+	assert.Equal(t, [][2]int{{200, 0}, {200, 200}}, api.pages)
+
+	// The listing of every key is bounded by maxPages
+	api = &testKMSKeyPagesAPI{testKMSKeysAPI{keys: testKMSKeys(450)}}
+	_, err = listKMSKeys(context.Background(), api.GetKeys, 0, 2, "instance")
+	assert.EqualError(t, err, "[ERROR] Listing the keys of instance instance stopped after 2 pages and 400 keys, the max_pages limit")
+	assert.Equal(t, [][2]int{{200, 0}, {200, 200}}, api.pages)
+}
+
+func TestReadKMSKeyNameCheck(t *testing.T) {
+	keys := testKMSKeys(450)
+	keys[10].Name = "app-key"
+	keys[10].KeyRingID = "ring-a"
+	keys[320].Name = "app-key"
+	keys[320].KeyRingID = "ring-b"
+	keys[440].Name = "App-Key"
+	keys[441].Name = "db-key"
+
+	testCases := []struct {
+		name       string
+		keyRingID  string
+		names      []interface{}
+		nameCounts map[string]interface{}
+		allUnique  bool
+		id         string
+		err        string
+	}{
+		{
+			name:       "duplicates across pages",
+			names:      []interface{}{"app-key", "db-key", "new-key"},
+			nameCounts: map[string]interface{}{"app-key": 2, "db-key": 1, "new-key": 0},
+			id:         "instance",
+		},
+		{
+			name:       "case sensitive",
+			names:      []interface{}{"APP-KEY", "app-key-2"},
+			nameCounts: map[string]interface{}{"APP-KEY": 0, "app-key-2": 0},
+			allUnique:  true,
+			id:         "instance",
+		},
+		{
+			name:       "key ring",
+			keyRingID:  "ring-b",
+			names:      []interface{}{"app-key", "db-key"},
+			nameCounts: map[string]interface{}{"app-key": 1, "db-key": 0},
+			id:         "instance/ring-b",
+		},
+		{
+			name:       "unique in key ring",
+			keyRingID:  "ring-c",
+			names:      []interface{}{"app-key"},
+			nameCounts: map[string]interface{}{"app-key": 0},
+			allUnique:  true,
+			id:         "instance/ring-c",
+		},
+		{
+			name:      "key ring not found",
+			keyRingID: "ring-x",
+			names:     []interface{}{"app-key"},
+			err:       "key ring ring-x not found in instance instance (available: default, ring-a, ring-b, ring-c)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &testKMSKeyPagesAPI{testKMSKeysAPI{keys: keys, keyRings: []string{"default", "ring-a", "ring-b", "ring-c"}}}
+			d := schema.TestResourceDataRaw(t, DataSourceIBMKMSKeyNameCheck().Schema, map[string]interface{}{
+				"instance_id": "instance",
+				"key_ring_id": tc.keyRingID,
+				"names":       tc.names,
+			})
+			err := readKMSKeyNameCheck(context.Background(), d, api, "instance")
+			if tc.err != "" {
+				assert.EqualError(t, err, "[ERROR] "+tc.err)
+				assert.Empty(t, api.pages)
+				return
+			}
+			assert.NoError(t, err)
+			// The keys are listed once, whatever the number of names
+			assert.Equal(t, [][2]int{{200, 0}, {200, 200}, {200, 400}}, api.pages)
+			assert.Equal(t, tc.nameCounts, d.Get("name_counts"))
+			assert.Equal(t, tc.allUnique, d.Get("all_unique"))
+			assert.Equal(t, tc.id, d.Id())
+		})
+	}
+}
@@ -19,7 +19,7 @@ func DataSourceIBMKMSkeyPolicies() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceIBMKMSKeyPoliciesRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
 			"instance_id": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -32,6 +32,11 @@ func DataSourceIBMKMSkeyPolicies() *schema.Resource {
 				Description:  "public or private",
 				Default:      "public",
 			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
+			},
 			"key_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -96,6 +101,7 @@ func DataSourceIBMKMSkeyPolicies() *schema.Resource {
 										Computed:    true,
 										Description: "Specifies whether the key rotation policy is enabled.",
 									},
+									"policy_api_version": kmsPolicyAPIVersionSchema(),
 								},
 							},
 						},
@@ -140,19 +146,20 @@ func DataSourceIBMKMSkeyPolicies() *schema.Resource {
 										Computed:    true,
 										Description: "Specifies the dual authorization policy on a single key.",
 									},
+									"policy_api_version": kmsPolicyAPIVersionSchema(),
 								},
 							},
 						},
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func dataSourceIBMKMSKeyPoliciesRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
-	api, _, err := populateKPClient(d, meta, instanceID)
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -166,14 +173,14 @@ func dataSourceIBMKMSKeyPoliciesRead(context context.Context, d *schema.Resource
 		id = v.(string)
 		key, err := api.GetKey(context, id)
 		if err != nil {
-			return diag.Errorf("Failed to get Key: %s", err)
+			return diag.Errorf("Failed to get Key: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		d.Set("alias", id)
 		d.Set("key_id", key.ID)
 	}
 	policies, err := api.GetPolicies(context, id)
 	if err != nil {
-		return diag.Errorf("Failed to read policies: %s", err)
+		return diag.Errorf("Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
 	}
 
 	if len(policies) == 0 {
@@ -17,11 +17,12 @@ func DataSourceIBMKMSkeyRings() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceIBMKMSKeyRingsRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
 			"instance_id": {
 				Type:             schema.TypeString,
 				Required:         true,
 				Description:      "Key protect or hpcs instance GUID",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"endpoint_type": {
@@ -31,6 +32,11 @@ func DataSourceIBMKMSkeyRings() *schema.Resource {
 				Description:  "public or private",
 				Default:      "public",
 			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
+			},
 			"key_rings": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -52,20 +58,20 @@ func DataSourceIBMKMSkeyRings() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 }
 
 func dataSourceIBMKMSKeyRingsRead(d *schema.ResourceData, meta interface{}) error {
 	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
-	api, _, err := populateKPClient(d, meta, instanceID)
+	api, _, err := populateKPDataSourceClient(context.Background(), d, meta, instanceID)
 	if err != nil {
 		return err
 	}
 	endpointType := d.Get("endpoint_type").(string)
 	keys, err := api.GetKeyRings(context.Background())
 	if err != nil || keys == nil {
-		return fmt.Errorf("[ERROR] Get Key Rings failed with error: %s", err)
+		return fmt.Errorf("[ERROR] Get Key Rings failed with error: %s", kmsAuthErrorHint(err, instanceID))
 	}
 	if keys == nil || keys.KeyRings == nil || len(keys.KeyRings) == 0 {
 		return fmt.Errorf("[ERROR] No key Rings in instance  %s", instanceID)
@@ -4,13 +4,19 @@
 package kms_test
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/service/kms"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccIBMKMSKeyDataSource_basic(t *testing.T) {
@@ -114,6 +120,106 @@ func TestAccIBMKmsDataSourceKeyPolicy_basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMKMSKeyDataSource_DisabledKey(t *testing.T) {
+	instanceName := fmt.Sprintf("kms_%d", acctest.RandIntRange(10, 100))
+	keyName := fmt.Sprintf("key_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMKmsKeyDataSourceRootKeyConfig(instanceName, keyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_kms_key.test", "keys.0.name", keyName),
+					resource.TestCheckResourceAttr("data.ibm_kms_key.test", "keys.0.state", "1"),
+					testAccCheckIBMKmsKeyDisable("ibm_kms_key.test"),
+				),
+			},
+			{
+				Config: testAccCheckIBMKmsKeyDataSourceRootKeyConfig(instanceName, keyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_kms_key.test", "keys.#", "1"),
+					resource.TestCheckResourceAttr("data.ibm_kms_key.test", "keys.0.name", keyName),
+					resource.TestCheckResourceAttr("data.ibm_kms_key.test", "keys.0.state", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMKMSKeyDataSource_FailIfMultiple(t *testing.T) {
+	instanceName := fmt.Sprintf("kms_%d", acctest.RandIntRange(10, 100))
+	keyName := fmt.Sprintf("key_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMKmsKeyDataSourceConfig(instanceName, keyName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ibm_kms_key.test", "key_id", "ibm_kms_key.test", "key_id"),
+					resource.TestCheckResourceAttrPair("data.ibm_kms_key.test", "key_crn", "ibm_kms_key.test", "crn"),
+				),
+			},
+			{
+				Config:      testAccCheckIBMKmsKeyDataSourceDuplicateNameConfig(instanceName, keyName),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("2 keys with name %s in instance", keyName)),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMKmsKeyDisable(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		instanceID := rs.Primary.Attributes["instance_id"]
+		kpAPI, err := acc.TestAccProvider.Meta().(conns.ClientSession).KeyManagementAPI()
+		if err != nil {
+			return err
+		}
+		rsConClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).ResourceControllerV2API()
+		if err != nil {
+			return err
+		}
+		instanceData, _, err := rsConClient.GetResourceInstance(&rc.GetResourceInstanceOptions{ID: &instanceID})
+		if err != nil {
+			return err
+		}
+		kpAPI.URL, err = kms.KmsEndpointURL(kpAPI, "public", instanceData.Extensions)
+		if err != nil {
+			return err
+		}
+		kpAPI.Config.InstanceID = instanceID
+		return kpAPI.DisableKey(context.Background(), rs.Primary.Attributes["key_id"])
+	}
+}
+
+func testAccCheckIBMKmsKeyDataSourceRootKeyConfig(instanceName, keyName string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "kms_instance" {
+		name              = "%s"
+		service           = "kms"
+		plan              = "tiered-pricing"
+		location          = "us-south"
+	  }
+	  resource "ibm_kms_key" "test" {
+		instance_id = "${ibm_resource_instance.kms_instance.guid}"
+		key_name = "%s"
+		standard_key =  false
+		force_delete = true
+	}
+	data "ibm_kms_key" "test" {
+		instance_id = "${ibm_kms_key.test.instance_id}"
+		key_name = "${ibm_kms_key.test.key_name}"
+	}
+`, instanceName, keyName)
+}
+
 func testAccCheckIBMKmsKeyDataSourceKeyConfig(instanceName, keyName string) string {
 	return fmt.Sprintf(`
 	resource "ibm_resource_instance" "kms_instance" {
@@ -173,6 +279,34 @@ func testAccCheckIBMKmsKeyDataSourceConfig(instanceName, keyName string) string
 `, instanceName, keyName)
 }
 
+func testAccCheckIBMKmsKeyDataSourceDuplicateNameConfig(instanceName, keyName string) string {
+	return fmt.Sprintf(`
+	resource "ibm_resource_instance" "kms_instance" {
+		name              = "%s"
+		service           = "kms"
+		plan              = "tiered-pricing"
+		location          = "us-south"
+	  }
+	  resource "ibm_kms_key" "test" {
+		instance_id = "${ibm_resource_instance.kms_instance.guid}"
+		key_name = "%s"
+		standard_key =  true
+		force_delete = true
+	}
+	resource "ibm_kms_key" "duplicate" {
+		instance_id = "${ibm_resource_instance.kms_instance.guid}"
+		key_name = "${ibm_kms_key.test.key_name}"
+		standard_key =  true
+		force_delete = true
+	}
+	data "ibm_kms_key" "test" {
+		instance_id = "${ibm_kms_key.duplicate.instance_id}"
+		key_name = "${ibm_kms_key.duplicate.key_name}"
+		fail_if_multiple = true
+	}
+`, instanceName, keyName)
+}
+
 func testAccCheckIBMKmsKeyDataSourceConfigAndDescription(instanceName, keyName string, description string) string {
 	return fmt.Sprintf(`
 	resource "ibm_resource_instance" "kms_instance" {
@@ -7,22 +7,25 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 	kp "github.com/IBM/keyprotect-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func DataSourceIBMKMSkeys() *schema.Resource {
 	return &schema.Resource{
 		Read: dataSourceIBMKMSKeysRead,
 
-		Schema: map[string]*schema.Schema{
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
 			"instance_id": {
 				Type:             schema.TypeString,
 				Required:         true,
 				Description:      "Key protect or hpcs instance GUID",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"key_name": {
@@ -36,6 +39,32 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 				Optional:    true,
 				Description: "Limit till the keys to be fetched",
 			},
+			"created_after": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"alias", "key_id"},
+				Description:   "Only return the keys created at or after this RFC3339 timestamp, inclusive. The keys without a creation date are excluded",
+			},
+			"created_before": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"alias", "key_id"},
+				Description:   "Only return the keys created before this RFC3339 timestamp, exclusive. The keys without a creation date are excluded",
+			},
+			"sort": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues(kmsKeySortValues),
+				Description:  "Sort the matched keys by name, creation_date or last_rotate_date, descending when prefixed with -",
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of matched keys to return. The keys are truncated after they are filtered and sorted",
+			},
 			"alias": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -47,13 +76,23 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 				Optional:      true,
 				ConflictsWith: []string{"alias", "key_name"},
 			},
+			"service": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The service of the instance, kms for key protect and hs-crypto for hpcs",
+			},
 			"endpoint_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
-				Description:  "public or private",
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
 				ForceNew:     true,
-				Default:      "public",
+			},
+			"endpoint_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The endpoint URL of the instance, such as https://us-south.kms.cloud.ibm.com. When it is set, the instance is not looked up in the resource controller",
 			},
 			"keys": {
 				Type:     schema.TypeList,
@@ -87,8 +126,60 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 							Description: "The key ring id of the key to be fetched",
 						},
 						"standard_key": {
-							Type:     schema.TypeBool,
-							Computed: true,
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key is a standard key, false for root keys",
+							Deprecated:  "Use key_type or extractable instead",
+						},
+						"key_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the key, root or standard",
+						},
+						"extractable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key material can leave the service, true for standard keys and false for root keys",
+						},
+						"state": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The key state, 0 for pre-activation, 1 for active, 2 for suspended (disabled), 3 for deactivated and 5 for destroyed",
+						},
+						"imported": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the key material was imported or generated by the service",
+						},
+						"algorithm_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The algorithm type of the key",
+						},
+						"deletion_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the key was deleted, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"purge_allowed_from": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time from which the deleted key can be purged, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"purge_scheduled_on": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the deleted key is scheduled to be purged, in RFC 3339 format. Empty for keys that are not deleted",
+						},
+						"restorable": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the destroyed key can still be restored, which is until restore_expires_at. Not set for keys that are not destroyed",
+						},
+						"restore_expires_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time until which the destroyed key can be restored, 30 days after its deletion_date, in RFC 3339 format. Not set for keys that are not destroyed",
 						},
 						"policies": {
 							Type:     schema.TypeList,
@@ -133,6 +224,7 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 													Type:     schema.TypeBool,
 													Computed: true,
 												},
+												"policy_api_version": kmsPolicyAPIVersionSchema(),
 											},
 										},
 									},
@@ -170,6 +262,7 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 													Type:     schema.TypeBool,
 													Computed: true,
 												},
+												"policy_api_version": kmsPolicyAPIVersionSchema(),
 											},
 										},
 									},
@@ -179,36 +272,34 @@ func DataSourceIBMKMSkeys() *schema.Resource {
 					},
 				},
 			},
-		},
+		}),
 	}
 
 }
 
 func dataSourceIBMKMSKeysRead(d *schema.ResourceData, meta interface{}) error {
 	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
-	api, _, err := populateKPClient(d, meta, instanceID)
+	api, instanceData, err := populateKPDataSourceClient(context.Background(), d, meta, instanceID)
 	if err != nil {
 		return err
 	}
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	d.Set("service", kmsInstanceService(kmsInstanceCRN(instanceData), d.Get("instance_id").(string), api.URL.String()))
 	var totalKeys []kp.Key
 	if v, ok := d.GetOk("alias"); ok {
 		aliasName := v.(string)
 		key, err := api.GetKey(context.Background(), aliasName)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
+			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		keyMap := make([]map[string]interface{}, 0, 1)
-		keyInstance := make(map[string]interface{})
-		keyInstance["id"] = key.ID
-		keyInstance["name"] = key.Name
-		keyInstance["crn"] = key.CRN
-		keyInstance["standard_key"] = key.Extractable
-		keyInstance["description"] = key.Description
-		keyInstance["aliases"] = key.Aliases
-		keyInstance["key_ring_id"] = key.KeyRingID
+		keyInstance := flex.FlattenKMSKey(*key)
+		for attribute, value := range flattenKMSKeyRestore(*key, time.Now()) {
+			keyInstance[attribute] = value
+		}
 		policies, err := api.GetPolicies(context.Background(), key.ID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Failed to read policies: %s", err)
+			return fmt.Errorf("[ERROR] Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		if len(policies) == 0 {
 			log.Printf("No Policy Configurations read\n")
@@ -221,20 +312,16 @@ func dataSourceIBMKMSKeysRead(d *schema.ResourceData, meta interface{}) error {
 	} else if v, ok := d.GetOk("key_id"); ok {
 		key, err := api.GetKey(context.Background(), v.(string))
 		if err != nil {
-			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
+			return fmt.Errorf("[ERROR] Get Keys failed with error: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		keyMap := make([]map[string]interface{}, 0, 1)
-		keyInstance := make(map[string]interface{})
-		keyInstance["id"] = key.ID
-		keyInstance["name"] = key.Name
-		keyInstance["crn"] = key.CRN
-		keyInstance["standard_key"] = key.Extractable
-		keyInstance["description"] = key.Description
-		keyInstance["aliases"] = key.Aliases
-		keyInstance["key_ring_id"] = key.KeyRingID
+		keyInstance := flex.FlattenKMSKey(*key)
+		for attribute, value := range flattenKMSKeyRestore(*key, time.Now()) {
+			keyInstance[attribute] = value
+		}
 		policies, err := api.GetPolicies(context.Background(), key.ID)
 		if err != nil {
-			return fmt.Errorf("[ERROR] Failed to read policies: %s", err)
+			return fmt.Errorf("[ERROR] Failed to read policies: %s", kmsAuthErrorHint(err, instanceID))
 		}
 		if len(policies) == 0 {
 			log.Printf("No Policy Configurations read\n")
@@ -247,53 +334,26 @@ func dataSourceIBMKMSKeysRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("keys", keyMap)
 		d.Set("instance_id", instanceID)
 	} else {
-		limit := d.Get("limit")
-		limitVal := limit.(int)
-		offset := 0
-		//default page size of API is 200 as stated
-		pageSize := 200
-
-		// when the limit is not passed, the api works in default way to avoid backward compatibility issues
-
-		if limitVal == 0 {
-			{
-				keys, err := api.GetKeys(context.Background(), 0, offset)
-				if err != nil {
-					return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-				}
-				retreivedKeys := keys.Keys
-				totalKeys = append(totalKeys, retreivedKeys...)
-			}
-		} else {
-			// when the limit is passed by the user
-			for {
-				if offset < limitVal {
-					if (limitVal - offset) < pageSize {
-						keys, err := api.GetKeys(context.Background(), (limitVal - offset), offset)
-						if err != nil {
-							return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-						}
-						retreivedKeys := keys.Keys
-						totalKeys = append(totalKeys, retreivedKeys...)
-						break
-					} else {
-						keys, err := api.GetKeys(context.Background(), pageSize, offset)
-						if err != nil {
-							return fmt.Errorf("[ERROR] Get Keys failed with error: %s", err)
-						}
-						numOfKeysFetched := keys.Metadata.NumberOfKeys
-						retreivedKeys := keys.Keys
-						totalKeys = append(totalKeys, retreivedKeys...)
-						if numOfKeysFetched < pageSize || offset+pageSize == limitVal {
-							break
-						}
-
-						offset = offset + pageSize
-					}
-				}
-			}
+		createdAfter, createdBefore, err := kmsKeyCreationDateRange(d)
+		if err != nil {
+			return err
+		}
+		totalKeys, err = listKMSKeysInCreationDateRange(context.Background(), api, d.Get("limit").(int), createdAfter, createdBefore, instanceID)
+		if err != nil {
+			return err
 		}
-		if len(totalKeys) == 0 {
+		if createdAfter != nil || createdBefore != nil {
+			// a range that matches no key is a valid result, as the keys of the range may not be created yet
+			scannedKeys := len(totalKeys)
+			totalKeys = filterKMSKeysByCreationDate(totalKeys, createdAfter, createdBefore)
+			if len(totalKeys) == 0 {
+				log.Printf("[DEBUG] No keys created in the range of created_after and created_before in instance %s, %d keys scanned", instanceID, scannedKeys)
+				d.SetId(instanceID)
+				d.Set("instance_id", instanceID)
+				d.Set("keys", []map[string]interface{}{})
+				return nil
+			}
+		} else if len(totalKeys) == 0 {
 			return fmt.Errorf("[ERROR] No keys in instance %s", instanceID)
 		}
 		var keyName string
@@ -312,18 +372,15 @@ func dataSourceIBMKMSKeysRead(d *schema.ResourceData, meta interface{}) error {
 		if len(matchKeys) == 0 {
 			return fmt.Errorf("[ERROR] No keys with name %s in instance  %s", keyName, instanceID)
 		}
+		matchKeys = truncateKMSKeys(sortKMSKeys(matchKeys, d.Get("sort").(string)), d.Get("max_results").(int))
 
 		keyMap := make([]map[string]interface{}, 0, len(matchKeys))
 
 		for _, key := range matchKeys {
-			keyInstance := make(map[string]interface{})
-			keyInstance["id"] = key.ID
-			keyInstance["name"] = key.Name
-			keyInstance["crn"] = key.CRN
-			keyInstance["standard_key"] = key.Extractable
-			keyInstance["description"] = key.Description
-			keyInstance["aliases"] = key.Aliases
-			keyInstance["key_ring_id"] = key.KeyRingID
+			keyInstance := flex.FlattenKMSKey(key)
+			for attribute, value := range flattenKMSKeyRestore(key, time.Now()) {
+				keyInstance[attribute] = value
+			}
 			keyMap = append(keyMap, keyInstance)
 
 		}
@@ -336,3 +393,51 @@ func dataSourceIBMKMSKeysRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 
 }
+
+// List the keys of the instance for the keys data source, up to limit keys. Without limit, a single request returns
+// the default page of the API, as before limit existed, unless a creation date range is set, which has to scan every
+// key of the instance to find the keys of the range.
+func listKMSKeysInCreationDateRange(ctx context.Context, api kmsKeysPageAPI, limit int, createdAfter *time.Time, createdBefore *time.Time, instanceID string) ([]kp.Key, error) {
+	if limit == 0 && createdAfter == nil && createdBefore == nil {
+		keys, err := api.GetKeys(ctx, 0, 0)
+		if err != nil {
+			return nil, kmsKeysListError(ctx, err, instanceID, 0)
+		}
+		return keys.Keys, nil
+	}
+	return listKMSKeys(ctx, api.GetKeys, limit, kmsKeyDefaultMaxPages, instanceID)
+}
+
+// The page size of the key listings, the default page size of the API
+const kmsKeysPageSize = 200
+
+// A page of the keys of the instance, of the given size at the given offset
+type kmsKeysPageFunc func(ctx context.Context, size int, offset int) (*kp.Keys, error)
+
+// List the keys of the instance page by page, up to maxKeys keys, or every key when maxKeys is 0. The listing stops
+// at the first page that is not full, and fails at the read timeout or when it reaches maxPages pages. Every listing
+// of the keys of an instance shares it.
+func listKMSKeys(ctx context.Context, getPage kmsKeysPageFunc, maxKeys int, maxPages int, instanceID string) ([]kp.Key, error) {
+	var keys []kp.Key
+	for pages, offset := 0, 0; maxKeys == 0 || offset < maxKeys; pages, offset = pages+1, offset+kmsKeysPageSize {
+		if ctx.Err() != nil {
+			return nil, kmsKeysListError(ctx, ctx.Err(), instanceID, len(keys))
+		}
+		if pages == maxPages {
+			return nil, fmt.Errorf("[ERROR] Listing the keys of instance %s stopped after %d pages and %d keys, the max_pages limit", instanceID, pages, len(keys))
+		}
+		pageSize := kmsKeysPageSize
+		if maxKeys > 0 && maxKeys-offset < pageSize {
+			pageSize = maxKeys - offset
+		}
+		page, err := getPage(ctx, pageSize, offset)
+		if err != nil {
+			return nil, kmsKeysListError(ctx, err, instanceID, len(keys))
+		}
+		keys = append(keys, page.Keys...)
+		if len(page.Keys) < pageSize {
+			break
+		}
+	}
+	return keys, nil
+}
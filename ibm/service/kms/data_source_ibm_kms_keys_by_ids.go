@@ -0,0 +1,191 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The keys of ibm_kms_keys_by_ids are read concurrently, with at most kmsKeysByIDsWorkers requests in flight
+const kmsKeysByIDsWorkers = 5
+
+func DataSourceIBMKMSKeysByIDs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSKeysByIDsRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"key_ids": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The IDs of the keys to read",
+			},
+			"skip_missing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the key IDs that do not exist in not_found instead of failing",
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The keys, in the order of key_ids",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"crn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"key_ring_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the key ring that the key belongs to",
+						},
+						"key_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the key, standard or root",
+						},
+					},
+				},
+			},
+			"not_found": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The key IDs that do not exist in the instance, when skip_missing is true",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSKeysByIDsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	if err := readKMSKeysByIDs(ctx, d, api, instanceID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// Read the keys of key_ids with the given client. Every key that cannot be read is reported in a single error, except
+// the keys that do not exist when skip_missing is true, which are listed in not_found.
+func readKMSKeysByIDs(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, instanceID string) error {
+	keyIDs := uniqueKMSKeyIDs(flex.ExpandStringList(d.Get("key_ids").([]interface{})))
+	skipMissing := d.Get("skip_missing").(bool)
+	keys, errs := getKMSKeysByIDs(ctx, api, keyIDs)
+
+	keyList := make([]map[string]interface{}, 0, len(keyIDs))
+	notFound := []string{}
+	failures := []string{}
+	for i, keyID := range keyIDs {
+		switch {
+		case errs[i] == nil:
+			keyList = append(keyList, map[string]interface{}{
+				"id":          keys[i].ID,
+				"crn":         keys[i].CRN,
+				"name":        keys[i].Name,
+				"state":       keys[i].State,
+				"key_ring_id": kmsKeyRingID(*keys[i]),
+				"key_type":    flex.KMSKeyType(*keys[i]),
+			})
+		case skipMissing && kmsKeyNotFound(errs[i]):
+			notFound = append(notFound, keyID)
+		default:
+			failures = append(failures, fmt.Sprintf("%s: %s", keyID, kmsAuthErrorHint(errs[i], instanceID)))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("[ERROR] Get Keys failed for %d of the %d keys of instance %s:\n%s", len(failures), len(keyIDs), instanceID, strings.Join(failures, "\n"))
+	}
+
+	d.SetId(instanceID)
+	d.Set("keys", keyList)
+	d.Set("not_found", notFound)
+	return nil
+}
+
+// Get the keys of keyIDs concurrently, with at most kmsKeysByIDsWorkers requests in flight. The keys and errors are
+// in the order of keyIDs.
+func getKMSKeysByIDs(ctx context.Context, api kmsKeysAPI, keyIDs []string) ([]*kp.Key, []error) {
+	keys := make([]*kp.Key, len(keyIDs))
+	errs := make([]error, len(keyIDs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < kmsKeysByIDsWorkers && w < len(keyIDs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				keys[i], errs[i] = api.GetKey(ctx, keyIDs[i])
+			}
+		}()
+	}
+	for i := range keyIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return keys, errs
+}
+
+// Remove the duplicates of the key IDs, keeping the first occurrence of each
+func uniqueKMSKeyIDs(keyIDs []string) []string {
+	seen := make(map[string]bool, len(keyIDs))
+	unique := make([]string, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		if !seen[keyID] {
+			seen[keyID] = true
+			unique = append(unique, keyID)
+		}
+	}
+	return unique
+}
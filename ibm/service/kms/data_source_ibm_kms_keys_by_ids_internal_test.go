@@ -0,0 +1,121 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKMSGetKeyAPI fakes the key protect client with keys by ID. The IDs without a key are not found, and the IDs
+// of getKeyErrs fail with their error. It records the IDs that were read and how many reads were in flight at once.
+type testKMSGetKeyAPI struct {
+	testKMSKeysAPI
+	keysByID   map[string]kp.Key
+	getKeyErrs map[string]error
+
+	mu       sync.Mutex
+	ids      []string
+	inFlight int
+	maxCalls int
+}
+
+func (api *testKMSGetKeyAPI) GetKey(ctx context.Context, idOrAlias string) (*kp.Key, error) {
+	api.mu.Lock()
+	api.ids = append(api.ids, idOrAlias)
+	api.inFlight++
+	if api.inFlight > api.maxCalls {
+		api.maxCalls = api.inFlight
+	}
+	api.mu.Unlock()
+	defer func() {
+		api.mu.Lock()
+		api.inFlight--
+		api.mu.Unlock()
+	}()
+
+	// Hold each read so that the reads of the workers overlap
+	time.Sleep(10 * time.Millisecond)
+	if err := api.getKeyErrs[idOrAlias]; err != nil {
+		return nil, err
+	}
+	key, ok := api.keysByID[idOrAlias]
+	if !ok {
+		return nil, &kp.Error{StatusCode: 404, Message: "Not Found: Key does not exist"}
+	}
+	return &key, nil
+}
+
+func readTestKMSKeysByIDs(t *testing.T, api kmsKeysAPI, raw map[string]interface{}) (*schema.ResourceData, error) {
+	raw["instance_id"] = "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSKeysByIDs().Schema, raw)
+	return d, readKMSKeysByIDs(context.Background(), d, api, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+}
+
+func TestReadKMSKeysByIDs(t *testing.T) {
+	api := &testKMSGetKeyAPI{keysByID: map[string]kp.Key{}}
+	keyIDs := []interface{}{}
+	for i := 0; i < 12; i++ {
+		id := fmt.Sprintf("key-%02d", i)
+		api.keysByID[id] = kp.Key{ID: id, Name: "name-" + id, CRN: "crn:" + id, State: 1, Extractable: i%2 == 0, KeyRingID: "ring"}
+		keyIDs = append(keyIDs, id)
+	}
+	// The duplicate is read once
+	keyIDs = append(keyIDs, "key-03")
+
+	d, err := readTestKMSKeysByIDs(t, api, map[string]interface{}{"key_ids": keyIDs})
+	assert.NoError(t, err)
+	assert.Equal(t, "30372f20-d9f1-40b3-b486-a709e1932c9c", d.Id())
+	assert.Len(t, api.ids, 12)
+	assert.Equal(t, kmsKeysByIDsWorkers, api.maxCalls)
+
+	keys := d.Get("keys").([]interface{})
+	assert.Len(t, keys, 12)
+	assert.Equal(t, map[string]interface{}{
+		"id":          "key-00",
+		"crn":         "crn:key-00",
+		"name":        "name-key-00",
+		"state":       1,
+		"key_ring_id": "ring",
+		"key_type":    "standard",
+	}, keys[0])
+	assert.Equal(t, "key-11", keys[11].(map[string]interface{})["id"])
+	assert.Equal(t, "root", keys[11].(map[string]interface{})["key_type"])
+	assert.Empty(t, d.Get("not_found"))
+}
+
+func TestReadKMSKeysByIDsMissing(t *testing.T) {
+	api := &testKMSGetKeyAPI{keysByID: map[string]kp.Key{"key-1": {ID: "key-1", State: 1}}}
+
+	_, err := readTestKMSKeysByIDs(t, api, map[string]interface{}{"key_ids": []interface{}{"key-1", "key-2", "key-3"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Get Keys failed for 2 of the 3 keys of instance 30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.Contains(t, err.Error(), "\nkey-2: ")
+	assert.Contains(t, err.Error(), "\nkey-3: ")
+
+	d, err := readTestKMSKeysByIDs(t, api, map[string]interface{}{"key_ids": []interface{}{"key-1", "key-2", "key-3"}, "skip_missing": true})
+	assert.NoError(t, err)
+	assert.Len(t, d.Get("keys"), 1)
+	assert.Equal(t, []interface{}{"key-2", "key-3"}, d.Get("not_found"))
+}
+
+func TestReadKMSKeysByIDsFailure(t *testing.T) {
+	api := &testKMSGetKeyAPI{
+		keysByID:   map[string]kp.Key{"key-1": {ID: "key-1", State: 1}},
+		getKeyErrs: map[string]error{"key-1": &kp.Error{StatusCode: 403, Message: "Unauthorized"}},
+	}
+
+	// Only the keys that do not exist are skipped
+	_, err := readTestKMSKeysByIDs(t, api, map[string]interface{}{"key_ids": []interface{}{"key-1", "key-2"}, "skip_missing": true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Get Keys failed for 1 of the 2 keys")
+	assert.Contains(t, err.Error(), "allowed_network")
+}
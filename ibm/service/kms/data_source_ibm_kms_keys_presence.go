@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The names of the key states in wrong_state
+var kmsKeyStateNames = map[int]string{
+	int(kp.KeyState(0)): "pre-activation",
+	int(kp.Active):      "active",
+	int(kp.Suspended):   "suspended",
+	int(kp.Deactivated): "deactivated",
+	int(kp.Destroyed):   "destroyed",
+}
+
+func DataSourceIBMKMSKeysPresence() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIBMKMSKeysPresenceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: kmsWithCredentialOverrideSchema(map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
+				DiffSuppressFunc: suppressKMSInstanceIDDiff,
+			},
+			"endpoint_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validate.ValidateAllowedStringValues([]string{"public", "private"}),
+				Description:  "public or private, defaults to private when the provider visibility is private and to public otherwise",
+			},
+			"expected_keys": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names or CRNs of the keys that are expected in the instance. A value that starts with crn: matches the CRN of a key, another value matches the name of a key exactly",
+			},
+			"present": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ID of the key that matches each expected key that was found, whatever its state. The first key in the listing matches when several keys have the same name",
+			},
+			"missing": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The expected keys that no key of the instance matches, in the order of expected_keys",
+			},
+			"wrong_state": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The state of each expected key that was found but is not active: pre-activation, suspended or deactivated",
+			},
+		}),
+	}
+}
+
+func dataSourceIBMKMSKeysPresenceRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceID := getInstanceIDFromCRN(d.Get("instance_id").(string))
+	api, _, err := populateKPDataSourceClient(context, d, meta, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := contextWithKMSReadTimeout(context, d)
+	defer cancel()
+	d.Set("endpoint_type", kmsEndpointType(d, meta))
+	return readKMSKeysPresence(ctx, d, api, instanceID)
+}
+
+// Match the expected keys with the keys of the instance, from a single paginated listing of the keys that are not
+// destroyed. A warning is returned for each expected key that several keys match.
+func readKMSKeysPresence(ctx context.Context, d *schema.ResourceData, api kmsKeysAPI, instanceID string) diag.Diagnostics {
+	keys, err := listKMSKeys(ctx, kmsKeysInLookupStates(api), 0, kmsKeyDefaultMaxPages, instanceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	presence := matchKMSKeysPresence(keys, flex.ExpandStringList(d.Get("expected_keys").([]interface{})))
+	d.SetId(instanceID)
+	d.Set("present", presence.Present)
+	d.Set("missing", presence.Missing)
+	d.Set("wrong_state", presence.WrongState)
+
+	var diags diag.Diagnostics
+	expectedKeys := make([]string, 0, len(presence.Duplicates))
+	for expected := range presence.Duplicates {
+		expectedKeys = append(expectedKeys, expected)
+	}
+	sort.Strings(expectedKeys)
+	for _, expected := range expectedKeys {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%d keys of instance %s match %s", len(presence.Duplicates[expected]), instanceID, expected),
+			Detail:   fmt.Sprintf("The first key in the listing, %s, is reported. The matching keys are %s", presence.Present[expected], strings.Join(presence.Duplicates[expected], ", ")),
+		})
+	}
+	return diags
+}
+
+// The expected keys of ibm_kms_keys_presence that were found, that are missing, that are not active, and the IDs of
+// the keys that match each expected key that several keys match
+type kmsKeysPresence struct {
+	Present    map[string]string
+	Missing    []string
+	WrongState map[string]string
+	Duplicates map[string][]string
+}
+
+// Match the expected keys, names or CRNs, with the keys. The names match exactly and are case sensitive, as the key
+// names of the service, and the first key in the order of keys matches when several keys have the same name.
+func matchKMSKeysPresence(keys []kp.Key, expectedKeys []string) kmsKeysPresence {
+	presence := kmsKeysPresence{
+		Present:    map[string]string{},
+		Missing:    []string{},
+		WrongState: map[string]string{},
+		Duplicates: map[string][]string{},
+	}
+	seen := make(map[string]bool, len(expectedKeys))
+	for _, expected := range expectedKeys {
+		if seen[expected] {
+			continue
+		}
+		seen[expected] = true
+		byCRN := strings.HasPrefix(expected, "crn:")
+		var matches []kp.Key
+		for _, key := range keys {
+			if (byCRN && key.CRN == expected) || (!byCRN && key.Name == expected) {
+				matches = append(matches, key)
+			}
+		}
+		if len(matches) == 0 {
+			presence.Missing = append(presence.Missing, expected)
+			continue
+		}
+		presence.Present[expected] = matches[0].ID
+		if matches[0].State != int(kp.Active) {
+			presence.WrongState[expected] = kmsKeyStateName(matches[0].State)
+		}
+		if len(matches) > 1 {
+			ids := make([]string, 0, len(matches))
+			for _, match := range matches {
+				ids = append(ids, match.ID)
+			}
+			presence.Duplicates[expected] = ids
+		}
+	}
+	return presence
+}
+
+func kmsKeyStateName(state int) string {
+	if name, ok := kmsKeyStateNames[state]; ok {
+		return name
+	}
+	return fmt.Sprintf("state %d", state)
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"testing"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchKMSKeysPresence(t *testing.T) {
+	keys := testKMSNamedKeys(5, kp.Active)
+	keys[1].State = int(kp.Suspended)
+	keys[2].State = int(kp.KeyState(0))
+	keys = append(keys, kp.Key{ID: "duplicate", Name: "name-003", State: int(kp.Deactivated)})
+
+	presence := matchKMSKeysPresence(keys, []string{"name-000", "name-001", "name-002", "name-003", "Name-004", "missing", keys[4].CRN, "name-000", "missing"})
+	assert.Equal(t, map[string]string{
+		"name-000":  "key-00",
+		"name-001":  "key-01",
+		"name-002":  "key-02",
+		"name-003":  "key-03",
+		keys[4].CRN: "key-04",
+	}, presence.Present)
+	// The names are case sensitive, and an expected key that is listed twice is reported once
+	assert.Equal(t, []string{"Name-004", "missing"}, presence.Missing)
+	assert.Equal(t, map[string]string{"name-001": "suspended", "name-002": "pre-activation"}, presence.WrongState)
+	// The first key in the listing matches a duplicate name, whatever the state of the others
+	assert.Equal(t, map[string][]string{"name-003": {"key-03", "duplicate"}}, presence.Duplicates)
+
+	// A CRN does not match the name of a key
+	presence = matchKMSKeysPresence([]kp.Key{{ID: "key-00", Name: "crn:v1:name", CRN: "crn:v1:other"}}, []string{"crn:v1:name"})
+	assert.Equal(t, []string{"crn:v1:name"}, presence.Missing)
+	assert.Empty(t, presence.Present)
+}
+
+func TestReadKMSKeysPresence(t *testing.T) {
+	keys := testKMSNamedKeys(450, kp.Active)
+	keys[300].State = int(kp.Deactivated)
+	keys[420].Name = "name-010"
+	api := &testKMSKeysAPI{keys: keys}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSKeysPresence().Schema, map[string]interface{}{
+		"instance_id":   "instance",
+		"expected_keys": []interface{}{"name-010", "name-300", "name-449", "name-999"},
+	})
+
+	diags := readKMSKeysPresence(context.Background(), d, api, "instance")
+	// The keys are listed once, by pages
+	assert.Equal(t, [][2]int{{200, 0}, {200, 200}, {200, 400}}, api.pages)
+	assert.Equal(t, "instance", d.Id())
+	assert.Equal(t, map[string]interface{}{"name-010": "key-10", "name-300": "key-300", "name-449": "key-449"}, d.Get("present"))
+	assert.Equal(t, []interface{}{"name-999"}, d.Get("missing"))
+	assert.Equal(t, map[string]interface{}{"name-300": "deactivated"}, d.Get("wrong_state"))
+	assert.Equal(t, diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "2 keys of instance instance match name-010",
+		Detail:   "The first key in the listing, key-10, is reported. The matching keys are key-10, key-420",
+	}}, diags)
+
+	api = &testKMSKeysAPI{listKeysErr: &kp.Error{StatusCode: 403, Message: "Forbidden"}}
+	diags = readKMSKeysPresence(context.Background(), d, api, "instance")
+	assert.True(t, diags.HasError())
+}
@@ -0,0 +1,203 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/go-sdk-core/v5/core"
+	kp "github.com/IBM/keyprotect-go-client"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+)
+
+// The methods of the Key Protect client that the key lookups use. The client returned by KeyManagementAPI
+// implements it, and the unit tests implement it with fakes so that the lookups run without credentials.
+type kmsKeysAPI interface {
+	ListKeys(ctx context.Context, listKeysOptions *kp.ListKeysOptions) (*kp.Keys, error)
+	GetKey(ctx context.Context, idOrAlias string) (*kp.Key, error)
+	GetPolicies(ctx context.Context, idOrAlias string) ([]kp.Policy, error)
+	GetAllowedNetworkInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error)
+	GetKeyRings(ctx context.Context) (*kp.KeyRings, error)
+}
+
+// The method of the Key Protect client that lists the keys page by page, without filters
+type kmsKeysPageAPI interface {
+	GetKeys(ctx context.Context, limit int, offset int) (*kp.Keys, error)
+}
+
+// The registrations of the keys, which the Key Protect client lists with an unexported collection type. The lookups
+// of ibm_kms_key count them through kmsKeyLookupClient.
+type kmsRegistrationsAPI interface {
+	CountRegistrations(ctx context.Context, keyID string) (int, error)
+}
+
+// The methods of the Key Protect client that the ibm_kms_key lookups use
+type kmsKeyLookupAPI interface {
+	kmsKeysAPI
+	kmsRegistrationsAPI
+}
+
+// kmsKeyLookupClient adds the counting of the registrations of a key to the Key Protect client. The policies of the
+// keys are read with policyClient when it is set, a copy of the client for another endpoint. instanceAllowedNetwork
+// is the allowed_network parameter of the instance in the resource controller, empty when it is not known.
+type kmsKeyLookupClient struct {
+	*kp.Client
+	policyClient           *kp.Client
+	instanceAllowedNetwork string
+}
+
+// Read the policies of the key from the policy endpoint, when there is one
+func (c kmsKeyLookupClient) GetPolicies(ctx context.Context, idOrAlias string) ([]kp.Policy, error) {
+	if c.policyClient != nil {
+		return c.policyClient.GetPolicies(ctx, idOrAlias)
+	}
+	return c.Client.GetPolicies(ctx, idOrAlias)
+}
+
+// Read the allowed network policy of the instance from the policy endpoint, when there is one, and otherwise from the
+// allowed_network parameter of the instance in the resource controller. The policy is read from the endpoint of the
+// client only when neither is known: the endpoint of the client is the one that the policy is checked against, and a
+// private-only instance rejects the read of its policy on a public endpoint.
+func (c kmsKeyLookupClient) GetAllowedNetworkInstancePolicy(ctx context.Context) (*kp.InstancePolicy, error) {
+	if c.policyClient != nil {
+		return c.policyClient.GetAllowedNetworkInstancePolicy(ctx)
+	}
+	if c.instanceAllowedNetwork != "" {
+		enabled := true
+		allowedNetwork := c.instanceAllowedNetwork
+		return &kp.InstancePolicy{PolicyData: kp.PolicyData{Enabled: &enabled, Attributes: &kp.Attributes{AllowedNetwork: &allowedNetwork}}}, nil
+	}
+	return c.Client.GetAllowedNetworkInstancePolicy(ctx)
+}
+
+// Return the allowed_network parameter that the instance was created with, empty when the instance was not looked up
+// in the resource controller, as with endpoint_url, or was created without it
+func kmsInstanceAllowedNetwork(instanceData *rc.ResourceInstance) string {
+	if instanceData == nil || instanceData.Parameters == nil {
+		return ""
+	}
+	allowedNetwork, _ := instanceData.Parameters["allowed_network"].(string)
+	return allowedNetwork
+}
+
+// Return a copy of the Key Protect client whose requests go to another endpoint of the instance, with the same
+// credentials, HTTP client and instance
+func kmsClientWithEndpointURL(api *kp.Client, endpointURL *url.URL) *kp.Client {
+	client := *api
+	client.URL = endpointURL
+	return &client
+}
+
+// The largest page of registrations that the list registrations API returns
+const kmsRegistrationsPageSize = 5000
+
+// Count the registrations of the key, page by page. The client lists the first page of registrations only, so the
+// pages are requested directly and only their number of registrations is decoded.
+func (c kmsKeyLookupClient) CountRegistrations(ctx context.Context, keyID string) (int, error) {
+	count := 0
+	for offset := 0; ; offset += kmsRegistrationsPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(kmsRegistrationsPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+		var page struct {
+			Metadata struct {
+				CollectionTotal int `json:"collectionTotal"`
+			} `json:"metadata"`
+		}
+		if err := kmsGetJSON(ctx, c.Client, "keys/"+url.PathEscape(keyID)+"/registrations", query, &page); err != nil {
+			return count, err
+		}
+		count += page.Metadata.CollectionTotal
+		if page.Metadata.CollectionTotal < kmsRegistrationsPageSize {
+			return count, nil
+		}
+	}
+}
+
+// Send a GET request for path, relative to the keys endpoint of the client, with the credentials and the instance of
+// the client, and decode its JSON response into v. It is used for the requests that the client does not implement.
+func kmsGetJSON(ctx context.Context, api *kp.Client, path string, query url.Values, v interface{}) error {
+	accessToken, err := kmsClientAccessToken(ctx, api)
+	if err != nil {
+		return err
+	}
+	requestURL := api.URL.ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()})
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+	request.Header.Set("Bluemix-Instance", api.Config.InstanceID)
+	request.Header.Set("Accept", "application/json")
+	response, err := api.HttpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		return kmsResponseError(requestURL.String(), response.StatusCode, body)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding the response of %s: %w", requestURL.String(), err)
+	}
+	return nil
+}
+
+// Build the error of a request that the service rejected from the error message of its body, as the client does
+func kmsResponseError(requestURL string, statusCode int, body []byte) error {
+	var errorBody struct {
+		Resources []struct {
+			ErrorMsg string `json:"errorMsg"`
+		} `json:"resources"`
+	}
+	message := http.StatusText(statusCode)
+	if json.Unmarshal(body, &errorBody) == nil && len(errorBody.Resources) > 0 && errorBody.Resources[0].ErrorMsg != "" {
+		message = errorBody.Resources[0].ErrorMsg
+	}
+	return &kp.Error{StatusCode: statusCode, Message: fmt.Sprintf("%s: %s", requestURL, message)}
+}
+
+// The method of the resource controller client that resolves the endpoints of an instance. The client returned by
+// ResourceControllerV2API implements it.
+type kmsResourceInstanceAPI interface {
+	GetResourceInstance(getResourceInstanceOptions *rc.GetResourceInstanceOptions) (*rc.ResourceInstance, *core.DetailedResponse, error)
+}
+
+// Return a copy of the resource controller client that logs the timing of its API calls. The client of the session
+// is shared by every service, so it is cloned rather than wrapped in place.
+func kmsResourceControllerWithAPITimingLogs(rsConClient *rc.ResourceControllerV2) *rc.ResourceControllerV2 {
+	timedClient := &rc.ResourceControllerV2{
+		Service: rsConClient.Service.Clone(),
+	}
+	timedClient.Service.SetHTTPClient(conns.WithAPITimingLogs(timedClient.Service.GetHTTPClient(), "resource_controller"))
+	return timedClient
+}
+
+// Return a copy of the resource controller client that authenticates with an access token instead of the
+// credentials of the session
+func kmsResourceControllerWithAccessToken(rsConClient *rc.ResourceControllerV2, accessToken string) *rc.ResourceControllerV2 {
+	client := &rc.ResourceControllerV2{
+		Service: rsConClient.Service.Clone(),
+	}
+	client.Service.Options.Authenticator = &core.BearerTokenAuthenticator{BearerToken: accessToken}
+	return client
+}
+
+var (
+	_ kmsKeysAPI             = (*kp.Client)(nil)
+	_ kmsKeysPageAPI         = (*kp.Client)(nil)
+	_ kmsKeyLookupAPI        = kmsKeyLookupClient{}
+	_ kmsResourceInstanceAPI = (*rc.ResourceControllerV2)(nil)
+)
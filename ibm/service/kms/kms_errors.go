@@ -0,0 +1,100 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	kp "github.com/IBM/keyprotect-go-client"
+)
+
+// Add a hint about the network policies of the instance to the errors of requests the service rejected as
+// unauthorized or forbidden. Requests from outside the allowed IP addresses or networks of an instance are
+// rejected with the same status codes as requests with invalid credentials. Other errors are returned unchanged.
+func kmsAuthErrorHint(err error, instanceID string) error {
+	var kpError *kp.Error
+	if !errors.As(err, &kpError) {
+		return err
+	}
+	if kpError.StatusCode != http.StatusUnauthorized && kpError.StatusCode != http.StatusForbidden {
+		return err
+	}
+	return fmt.Errorf("%w. If the credentials are valid, check that the allowed_network and allowed_ip policies of instance %s allow requests from this network, see the ibm_kms_instance_policies data source", err, instanceID)
+}
+
+// The error of an ibm_kms_key lookup that matches no key, as opposed to a lookup that fails. allow_missing reads it
+// as an empty result instead of a failure.
+type kmsKeyMissingError struct {
+	err error
+}
+
+func (e *kmsKeyMissingError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kmsKeyMissingError) Unwrap() error {
+	return e.err
+}
+
+// Mark the error of a lookup as matching no key
+func kmsKeyMissing(err error) error {
+	return &kmsKeyMissingError{err: err}
+}
+
+// Mark the error of a lookup as matching no key when complete is set, such as a lookup that scanned every key of the
+// instance. The error is returned unchanged otherwise.
+func kmsKeyMissingIf(complete bool, err error) error {
+	if !complete {
+		return err
+	}
+	return kmsKeyMissing(err)
+}
+
+// Whether a lookup failed because it matched no key
+func kmsKeyIsMissing(err error) bool {
+	var missingErr *kmsKeyMissingError
+	return errors.As(err, &missingErr)
+}
+
+// Whether the service rejected the request because the key does not exist, such as a key that was deleted after it
+// was listed. The reads that skip the missing keys and the reads that fail on them share this classification.
+func kmsKeyNotFound(err error) bool {
+	var kpError *kp.Error
+	return errors.As(err, &kpError) && kpError.StatusCode == http.StatusNotFound
+}
+
+// The reason code of the bad requests for a policy type that the instance does not support
+const kmsInvalidQueryParamReason = "INVALID_QUERY_PARAM_ERR"
+
+// Whether the service rejected an instance policy request because the instance does not support the policy type, such
+// as the metrics policy on older Hyper Protect Crypto Services instances. These instances reject the policy query
+// parameter as a bad request with the INVALID_QUERY_PARAM_ERR reason, or the request as not implemented. The other
+// bad requests are real failures.
+func kmsInstancePolicyTypeUnsupported(err error) bool {
+	var kpError *kp.Error
+	if !errors.As(err, &kpError) {
+		return false
+	}
+	if kpError.StatusCode == http.StatusNotImplemented {
+		return true
+	}
+	if kpError.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	for _, reason := range kpError.Reasons {
+		if reason.Code == kmsInvalidQueryParamReason {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether the service rejected the request as forbidden, such as the read of a policy that the credentials are not
+// allowed to read while they are allowed to read the other policies of the instance
+func kmsForbidden(err error) bool {
+	var kpError *kp.Error
+	return errors.As(err, &kpError) && kpError.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,57 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMSAuthErrorHint(t *testing.T) {
+	const instanceID = "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	testCases := []struct {
+		name string
+		err  error
+		hint bool
+	}{
+		{name: "unauthorized", err: &kp.Error{StatusCode: 401, Message: "Unauthorized"}, hint: true},
+		{name: "forbidden", err: &kp.Error{StatusCode: 403, Message: "Forbidden"}, hint: true},
+		{name: "wrapped forbidden", err: fmt.Errorf("list keys: %w", &kp.Error{StatusCode: 403}), hint: true},
+		{name: "not found", err: &kp.Error{StatusCode: 404, Message: "Not Found"}, hint: false},
+		{name: "server error", err: &kp.Error{StatusCode: 500, Message: "Internal Server Error"}, hint: false},
+		{name: "url error", err: &kp.URLError{Err: errors.New("connection refused")}, hint: false},
+		{name: "other error", err: errors.New("401 in the message is not a status code"), hint: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := kmsAuthErrorHint(tc.err, instanceID)
+			assert.True(t, errors.Is(err, tc.err))
+			if tc.hint {
+				assert.Contains(t, err.Error(), "allowed_network and allowed_ip policies of instance "+instanceID)
+			} else {
+				assert.Equal(t, tc.err, err)
+			}
+		})
+	}
+}
+
+func TestKMSKeyNotFound(t *testing.T) {
+	assert.True(t, kmsKeyNotFound(&kp.Error{StatusCode: 404}))
+	assert.True(t, kmsKeyNotFound(fmt.Errorf("wrapped: %w", &kp.Error{StatusCode: 404})))
+	assert.False(t, kmsKeyNotFound(&kp.Error{StatusCode: 500}))
+	assert.False(t, kmsKeyNotFound(errors.New("Not Found")))
+}
+
+func TestKMSInstancePolicyTypeUnsupported(t *testing.T) {
+	// A bad request is only unsupported with the INVALID_QUERY_PARAM_ERR reason, see the instance policies tests
+	assert.False(t, kmsInstancePolicyTypeUnsupported(&kp.Error{StatusCode: 400}))
+	assert.True(t, kmsInstancePolicyTypeUnsupported(fmt.Errorf("wrapped: %w", &kp.Error{StatusCode: 501})))
+	assert.False(t, kmsInstancePolicyTypeUnsupported(&kp.Error{StatusCode: 403}))
+	assert.False(t, kmsInstancePolicyTypeUnsupported(&kp.Error{StatusCode: 500}))
+	assert.False(t, kmsInstancePolicyTypeUnsupported(errors.New("Bad Request")))
+}
@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// kmsInstanceGUIDRegexp matches the GUID of a key protect or hpcs instance
+var kmsInstanceGUIDRegexp = regexp.MustCompile(`^[0-9a-zA-Z]+(-[0-9a-zA-Z]+)*$`)
+
+// The number of segments of a CRN, crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource
+const kmsCRNSegments = 10
+
+// Parse the instance_id of the kms resources and data sources, which is either an instance GUID, the CRN of
+// the instance or the CRN of a key of the instance. The key id is only returned for key CRNs.
+func parseKMSInstanceID(value string) (instanceID string, keyID string, err error) {
+	if kmsInstanceGUIDRegexp.MatchString(value) {
+		return value, "", nil
+	}
+	if !strings.HasPrefix(value, "crn:") {
+		return "", "", kmsInstanceIDFormatError(value)
+	}
+
+	segments := strings.Split(value, ":")
+	// Tolerate empty trailing segments after the resource of the CRN
+	for len(segments) > kmsCRNSegments && segments[len(segments)-1] == "" {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) != kmsCRNSegments || !kmsInstanceGUIDRegexp.MatchString(segments[7]) {
+		return "", "", kmsInstanceIDFormatError(value)
+	}
+
+	switch resourceType, resource := segments[8], segments[9]; {
+	case resourceType == "" && resource == "":
+		return segments[7], "", nil
+	case resourceType == "key" && resource != "":
+		return segments[7], resource, nil
+	}
+	return "", "", kmsInstanceIDFormatError(value)
+}
+
+func kmsInstanceIDFormatError(value string) error {
+	return fmt.Errorf("%q is not a valid instance ID, expected an instance GUID, an instance CRN (crn:v1:<cname>:<ctype>:<service>:<location>:a/<account>:<instance GUID>::) or a key CRN (crn:v1:<cname>:<ctype>:<service>:<location>:a/<account>:<instance GUID>:key:<key ID>)", value)
+}
+
+// Validate the instance_id of the kms resources and data sources
+func validateKMSInstanceID(v interface{}, k string) (ws []string, errors []error) {
+	if _, _, err := parseKMSInstanceID(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
+	return
+}
+
+// The components of a key CRN
+type kmsKeyCRNComponents struct {
+	AccountID    string
+	Region       string
+	ServiceName  string
+	InstanceGUID string
+	KeyID        string
+}
+
+// Parse a key CRN, crn:v1:<cname>:<ctype>:<service>:<region>:a/<account>:<instance GUID>:key:<key ID>. Segments that
+// may be added after the key ID are ignored. The second return value is false when the CRN is not a key CRN.
+func parseKMSKeyCRN(crn string) (kmsKeyCRNComponents, bool) {
+	segments := strings.Split(crn, ":")
+	if len(segments) < kmsCRNSegments || segments[0] != "crn" {
+		return kmsKeyCRNComponents{}, false
+	}
+	if !strings.HasPrefix(segments[6], "a/") || segments[8] != "key" {
+		return kmsKeyCRNComponents{}, false
+	}
+	components := kmsKeyCRNComponents{
+		AccountID:    strings.TrimPrefix(segments[6], "a/"),
+		Region:       segments[5],
+		ServiceName:  segments[4],
+		InstanceGUID: segments[7],
+		KeyID:        segments[9],
+	}
+	if components.AccountID == "" || components.ServiceName == "" || !kmsInstanceGUIDRegexp.MatchString(components.InstanceGUID) || components.KeyID == "" {
+		return kmsKeyCRNComponents{}, false
+	}
+	return components, true
+}
+
+// Flatten the components of a key CRN, an empty list when the CRN cannot be parsed
+func flattenKMSKeyCRNComponents(crn string) []map[string]interface{} {
+	components, ok := parseKMSKeyCRN(crn)
+	if !ok {
+		return []map[string]interface{}{}
+	}
+	return []map[string]interface{}{
+		{
+			"account_id":    components.AccountID,
+			"region":        components.Region,
+			"service_name":  components.ServiceName,
+			"instance_guid": components.InstanceGUID,
+			"key_id":        components.KeyID,
+		},
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKMSInstanceID(t *testing.T) {
+	const guid = "30372f20-d9f1-40b3-b486-a709e1932c9c"
+	const keyID = "c2c5e4bd-b1b9-4ab6-9a06-dc4ea6b1b6b1"
+	testCases := []struct {
+		name       string
+		value      string
+		instanceID string
+		keyID      string
+		valid      bool
+	}{
+		{name: "guid", value: guid, instanceID: guid, valid: true},
+		{name: "instance crn", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid + "::", instanceID: guid, valid: true},
+		{name: "instance crn with extra trailing colons", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid + ":::", instanceID: guid, valid: true},
+		{name: "hpcs instance crn", value: "crn:v1:bluemix:public:hs-crypto:us-south:a/account:" + guid + "::", instanceID: guid, valid: true},
+		{name: "key crn", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid + ":key:" + keyID, instanceID: guid, keyID: keyID, valid: true},
+		{name: "instance crn without trailing colons", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid, valid: false},
+		{name: "key crn without key id", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid + ":key:", valid: false},
+		{name: "crn of another resource type", value: "crn:v1:bluemix:public:kms:us-south:a/account:" + guid + ":keyRing:default", valid: false},
+		{name: "crn with an empty instance", value: "crn:v1:bluemix:public:kms:us-south:a/account:::", valid: false},
+		{name: "too few segments", value: "crn:v1:bluemix:public:kms:" + guid, valid: false},
+		{name: "url", value: "https://us-south.kms.cloud.ibm.com/api/v2/keys/" + keyID, valid: false},
+		{name: "empty", value: "", valid: false},
+		{name: "whitespace", value: " " + guid + " ", valid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instanceID, keyID, err := parseKMSInstanceID(tc.value)
+			if !tc.valid {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "expected an instance GUID")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.instanceID, instanceID)
+			assert.Equal(t, tc.keyID, keyID)
+		})
+	}
+}
+
+func TestValidateKMSInstanceID(t *testing.T) {
+	_, errs := validateKMSInstanceID("crn:v1:bluemix:public:kms:us-south:a/account:guid::", "instance_id")
+	assert.Empty(t, errs)
+
+	_, errs = validateKMSInstanceID("https://us-south.kms.cloud.ibm.com", "instance_id")
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "instance_id: ")
+}
+
+func TestGetInstanceAndKeyDataFromCRN(t *testing.T) {
+	instanceCRN, instanceID, keyID := getInstanceAndKeyDataFromCRN("crn:v1:bluemix:public:kms:us-south:a/account:guid:key:key-id")
+	assert.Equal(t, "crn:v1:bluemix:public:kms:us-south:a/account:guid::", instanceCRN)
+	assert.Equal(t, "guid", instanceID)
+	assert.Equal(t, "key-id", keyID)
+
+	assert.Equal(t, "guid", getInstanceIDFromCRN("crn:v1:bluemix:public:kms:us-south:a/account:guid::"))
+	assert.Equal(t, "not a crn", getInstanceIDFromCRN("not a crn"))
+}
+
+func TestParseKMSKeyCRN(t *testing.T) {
+	testCases := []struct {
+		name       string
+		crn        string
+		components kmsKeyCRNComponents
+		valid      bool
+	}{
+		{
+			name: "key protect",
+			crn:  "crn:v1:bluemix:public:kms:us-south:a/1f3e0e7a8d3b4b5c9a7e2d1c0b9a8f7e:30372f20-d9f1-40b3-b486-a709e1932c9c:key:c2c5e4bd-b1b9-4ab6-9a06-dc4ea6b1b6b1",
+			components: kmsKeyCRNComponents{
+				AccountID:    "1f3e0e7a8d3b4b5c9a7e2d1c0b9a8f7e",
+				Region:       "us-south",
+				ServiceName:  "kms",
+				InstanceGUID: "30372f20-d9f1-40b3-b486-a709e1932c9c",
+				KeyID:        "c2c5e4bd-b1b9-4ab6-9a06-dc4ea6b1b6b1",
+			},
+			valid: true,
+		},
+		{
+			name: "hpcs",
+			crn:  "crn:v1:bluemix:public:hs-crypto:eu-de:a/1f3e0e7a8d3b4b5c9a7e2d1c0b9a8f7e:8a7b6c5d-4e3f-2a1b-0c9d-8e7f6a5b4c3d:key:5f4e3d2c-1b0a-9f8e-7d6c-5b4a3f2e1d0c",
+			components: kmsKeyCRNComponents{
+				AccountID:    "1f3e0e7a8d3b4b5c9a7e2d1c0b9a8f7e",
+				Region:       "eu-de",
+				ServiceName:  "hs-crypto",
+				InstanceGUID: "8a7b6c5d-4e3f-2a1b-0c9d-8e7f6a5b4c3d",
+				KeyID:        "5f4e3d2c-1b0a-9f8e-7d6c-5b4a3f2e1d0c",
+			},
+			valid: true,
+		},
+		{
+			name: "additional segments",
+			crn:  "crn:v1:staging:public:kms:us-east:a/account:instance:key:key-id:version:2",
+			components: kmsKeyCRNComponents{
+				AccountID:    "account",
+				Region:       "us-east",
+				ServiceName:  "kms",
+				InstanceGUID: "instance",
+				KeyID:        "key-id",
+			},
+			valid: true,
+		},
+		{name: "instance crn", crn: "crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c::"},
+		{name: "key ring crn", crn: "crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c:keyRing:default"},
+		{name: "scope is not an account", crn: "crn:v1:bluemix:public:kms:us-south:o/org:30372f20-d9f1-40b3-b486-a709e1932c9c:key:key-id"},
+		{name: "empty key id", crn: "crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c:key:"},
+		{name: "too few segments", crn: "crn:v1:bluemix:public:kms:us-south:a/account:key:key-id"},
+		{name: "not a crn", crn: "https://us-south.kms.cloud.ibm.com/api/v2/keys/key-id"},
+		{name: "empty", crn: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			components, ok := parseKMSKeyCRN(tc.crn)
+			assert.Equal(t, tc.valid, ok)
+			assert.Equal(t, tc.components, components)
+		})
+	}
+}
+
+func TestFlattenKMSKeyCRNComponents(t *testing.T) {
+	assert.Empty(t, flattenKMSKeyCRNComponents("not-a-crn"))
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"account_id":    "account",
+			"region":        "us-south",
+			"service_name":  "kms",
+			"instance_guid": "instance",
+			"key_id":        "key-id",
+		},
+	}, flattenKMSKeyCRNComponents("crn:v1:bluemix:public:kms:us-south:a/account:instance:key:key-id"))
+}
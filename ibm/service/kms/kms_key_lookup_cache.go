@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The flattened keys of an ibm_kms_key lookup
+type kmsKeyLookupResult struct {
+	Keys   []map[string]interface{}
+	KeyID  string
+	KeyCRN string
+	// The keys of a name lookup that were deleted before their policies were read, they are not in Keys
+	DeletedKeyIDs []string
+	// The warnings of the lookup, such as the registrations that could not be read
+	Warnings diag.Diagnostics
+}
+
+// Run the lookup through the call cache of the session, unless the cache is disabled with the kms_key_lookup_cache
+// provider argument. Identical lookups that run concurrently share a single call, and the result of a successful
+// lookup is kept until the provider process exits at the end of the Terraform operation.
+func kmsKeyLookupCacheDo(meta interface{}, key string, lookup func() (*kmsKeyLookupResult, error)) (*kmsKeyLookupResult, error) {
+	sess := meta.(conns.ClientSession)
+	if !sess.KMSKeyLookupCacheEnabled() {
+		return lookup()
+	}
+	result, err := sess.CallCache().Do("kms_key_lookup/"+key, func() (interface{}, time.Time, error) {
+		result, err := lookup()
+		return result, time.Time{}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*kmsKeyLookupResult), nil
+}
+
+// Build the cache key of an ibm_kms_key lookup from the instance, the endpoint, the key ring, check_registrations,
+// the lookup type and value, and the arguments that filter the keys of name lookups
+func kmsKeyLookupCacheKey(instanceID string, endpoint string, d *schema.ResourceData) string {
+	prefix := fmt.Sprintf("%s/%s/policy_endpoint_url=%q/key_ring_id=%q/check_registrations=%t/iam_trusted_profile_id=%q/iam_token=%q", instanceID, endpoint,
+		d.Get("policy_endpoint_url").(string), d.Get("key_ring_id").(string), d.Get("check_registrations").(bool), d.Get("iam_trusted_profile_id").(string),
+		kmsCredentialFingerprint(kmsBareToken(d.Get("iam_token").(string))))
+	if v, ok := d.GetOk("key_name"); ok {
+		return fmt.Sprintf("%s/key_name/%q/scan_limit=%d/first_page_only=%t/max_pages=%d/created_after=%q/created_before=%q/sort=%q/result_limit=%d/fail_if_multiple=%t", prefix, v.(string),
+			kmsKeyScanLimit(d), d.Get("first_page_only").(bool), d.Get("max_pages").(int), d.Get("created_after").(string), d.Get("created_before").(string),
+			d.Get("sort").(string), kmsKeyResultLimit(d), d.Get("fail_if_multiple").(bool))
+	}
+	if v, ok := d.GetOk("key_id"); ok {
+		return fmt.Sprintf("%s/key_id/%q", prefix, v.(string))
+	}
+	return fmt.Sprintf("%s/alias/%q/scan_limit=%d/max_pages=%d/alias_list_fallback=%t", prefix, d.Get("alias").(string), kmsKeyScanLimit(d), d.Get("max_pages").(int), d.Get("alias_list_fallback").(bool))
+}
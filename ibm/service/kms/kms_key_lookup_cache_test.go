@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMSKeyLookupCacheDo(t *testing.T) {
+	calls := 0
+	lookup := func() (*kmsKeyLookupResult, error) {
+		calls++
+		return &kmsKeyLookupResult{KeyID: "key-id"}, nil
+	}
+
+	// The lookups of a session share their result
+	sess := &testKMSClientSession{keyLookupCache: true}
+	for i := 0; i < 2; i++ {
+		result, err := kmsKeyLookupCacheDo(sess, "instance/public/key_id/\"key-id\"", lookup)
+		assert.NoError(t, err)
+		assert.Equal(t, "key-id", result.KeyID)
+	}
+	assert.Equal(t, 1, calls)
+
+	// Another provider configuration has its own session and cache
+	_, err := kmsKeyLookupCacheDo(&testKMSClientSession{keyLookupCache: true}, "instance/public/key_id/\"key-id\"", lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// A failed lookup is not kept
+	_, err = kmsKeyLookupCacheDo(sess, "failing", func() (*kmsKeyLookupResult, error) { return nil, errors.New("Get Keys failed") })
+	assert.EqualError(t, err, "Get Keys failed")
+	_, err = kmsKeyLookupCacheDo(sess, "failing", lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	// Every lookup calls the API when the cache is disabled
+	sess.keyLookupCache = false
+	_, err = kmsKeyLookupCacheDo(sess, "instance/public/key_id/\"key-id\"", lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, calls)
+}
+
+func TestKMSKeyLookupCacheKey(t *testing.T) {
+	cacheKey := func(raw map[string]interface{}) string {
+		d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, raw)
+		return kmsKeyLookupCacheKey("instance", "public", d)
+	}
+
+	byName := cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key"})
+	assert.Equal(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key"}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "limit": 10}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "first_page_only": true}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "max_results": 1}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "max_pages": 1}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "created_after": "2024-01-01T00:00:00Z"}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "created_before": "2024-01-01T00:00:00Z"}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "other"}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "check_registrations": true}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "policy_endpoint_url": "https://eu-de.kms.cloud.ibm.com"}))
+	assert.NotEqual(t, byName, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "iam_trusted_profile_id": "Profile-1"}))
+	byToken := cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "iam_token": "token-1"})
+	assert.NotEqual(t, byName, byToken)
+	assert.NotEqual(t, byToken, cacheKey(map[string]interface{}{"instance_id": "instance", "key_name": "key", "iam_token": "token-2"}))
+	assert.NotContains(t, byToken, "token-1")
+	byAlias := cacheKey(map[string]interface{}{"instance_id": "instance", "alias": "key"})
+	assert.NotEqual(t, byAlias, cacheKey(map[string]interface{}{"instance_id": "instance", "alias": "key", "alias_list_fallback": false}))
+	assert.NotEqual(t, cacheKey(map[string]interface{}{"instance_id": "instance", "key_id": "key"}),
+		cacheKey(map[string]interface{}{"instance_id": "instance", "alias": "key"}))
+}
@@ -0,0 +1,156 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	kp "github.com/IBM/keyprotect-go-client"
+)
+
+// The services of the key protect and hpcs instances, the service segment of their CRNs
+const (
+	kmsServiceKeyProtect = "kms"
+	kmsServiceHPCS       = "hs-crypto"
+)
+
+// The service, region and GUID of an instance, from its CRN or from the CRN of one of its keys
+type kmsInstanceCRNComponents struct {
+	ServiceName  string
+	Region       string
+	InstanceGUID string
+}
+
+// Parse the CRN of a key protect or hpcs instance, or of one of their keys. The second return value is false when
+// the CRN is not a CRN of either service.
+func parseKMSInstanceCRN(crn string) (kmsInstanceCRNComponents, bool) {
+	segments := strings.Split(crn, ":")
+	if len(segments) < kmsCRNSegments || segments[0] != "crn" {
+		return kmsInstanceCRNComponents{}, false
+	}
+	components := kmsInstanceCRNComponents{
+		ServiceName:  segments[4],
+		Region:       segments[5],
+		InstanceGUID: segments[7],
+	}
+	if components.ServiceName != kmsServiceKeyProtect && components.ServiceName != kmsServiceHPCS {
+		return kmsInstanceCRNComponents{}, false
+	}
+	if !kmsInstanceGUIDRegexp.MatchString(components.InstanceGUID) {
+		return kmsInstanceCRNComponents{}, false
+	}
+	return components, true
+}
+
+// Get the service of an endpoint URL: hpcs for the hs-crypto domains, key protect otherwise
+func kmsServiceFromEndpointURL(endpointURL string) string {
+	u, err := url.Parse(endpointURL)
+	if err == nil && strings.Contains(u.Hostname(), ".hs-crypto.") {
+		return kmsServiceHPCS
+	}
+	return kmsServiceKeyProtect
+}
+
+// Get the service of the instance of a data source, from the CRN that the resource controller returned, else from
+// the CRN of instance_id, else from the endpoint of the client when the resource controller lookup was skipped
+func kmsInstanceService(instanceCRN *string, instanceID string, endpointURL string) string {
+	if instanceCRN != nil {
+		if components, ok := parseKMSInstanceCRN(*instanceCRN); ok {
+			return components.ServiceName
+		}
+	}
+	if components, ok := parseKMSInstanceCRN(instanceID); ok {
+		return components.ServiceName
+	}
+	return kmsServiceFromEndpointURL(endpointURL)
+}
+
+// Derive the endpoint of an instance from its service and region. Key protect has a regional endpoint, and hpcs an
+// endpoint per instance.
+func kmsServiceEndpoint(components kmsInstanceCRNComponents, visibility string) string {
+	switch {
+	case components.ServiceName == kmsServiceHPCS && visibility == "private":
+		return fmt.Sprintf("https://%s.api.private.%s.hs-crypto.appdomain.cloud", components.InstanceGUID, components.Region)
+	case components.ServiceName == kmsServiceHPCS:
+		return fmt.Sprintf("https://%s.api.%s.hs-crypto.appdomain.cloud", components.InstanceGUID, components.Region)
+	case visibility == "private":
+		return conns.ContructEndpoint(fmt.Sprintf("private.%s.kms", components.Region), "cloud.ibm.com")
+	}
+	return conns.ContructEndpoint(fmt.Sprintf("%s.kms", components.Region), "cloud.ibm.com")
+}
+
+// Get the endpoint of the visibility from the extensions of an instance. The resource controller returns the public
+// and private endpoints under endpoints, but the hpcs instances may also return endpoints of other services, or hold
+// them under other keys. For hpcs instances, the endpoints of the service are preferred: the key that is the
+// visibility first, then the other keys that contain it, in order. This endpoint resolution is shared by every kms
+// resource and data source, so the key protect instances, and the instances of unknown service, keep the endpoint
+// under the key that is the visibility as it is returned, and only fall back to the other keys when it is missing.
+func kmsExtensionsEndpoint(serviceName string, visibility string, extensions map[string]interface{}) string {
+	endpoints, _ := extensions["endpoints"].(map[string]interface{})
+	if serviceName != kmsServiceHPCS {
+		if endpoint, ok := endpoints[visibility].(string); ok && strings.TrimSpace(endpoint) != "" {
+			return strings.TrimSpace(endpoint)
+		}
+	}
+	keys := make([]string, 0, len(endpoints))
+	for key := range endpoints {
+		if key != visibility && strings.Contains(key, visibility) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	keys = append([]string{visibility}, keys...)
+	for _, key := range keys {
+		endpoint, ok := endpoints[key].(string)
+		if !ok || strings.TrimSpace(endpoint) == "" {
+			continue
+		}
+		if serviceName == "" || kmsServiceFromEndpointURL(endpoint) == serviceName {
+			return strings.TrimSpace(endpoint)
+		}
+	}
+	return ""
+}
+
+// Construct the keys URL of an instance from its CRN and the extensions that the resource controller returned. When
+// the extensions have no endpoint of the service of the instance, the endpoint is derived from the CRN.
+// IBMCLOUD_KP_API_ENDPOINT overrides the endpoint.
+func kmsInstanceEndpointURL(kpAPI *kp.Client, endpointType string, instanceCRN string, extensions map[string]interface{}) (*url.URL, error) {
+	visibility := "public"
+	if endpointType == "private" || strings.Contains(kpAPI.Config.BaseURL, "private") {
+		visibility = "private"
+	}
+	components, ok := parseKMSInstanceCRN(instanceCRN)
+	endpoint := kmsExtensionsEndpoint(components.ServiceName, visibility, extensions)
+	if endpoint == "" && ok && components.Region != "" {
+		endpoint = kmsServiceEndpoint(components, visibility)
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("[ERROR] Error Parsing KMS EndpointURL: the resource controller returned no %s endpoint for instance %s", visibility, instanceCRN)
+	}
+
+	url1 := conns.EnvFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, strings.TrimSuffix(endpoint, "/")+"/api/v2/keys")
+	if !strings.HasSuffix(url1, "/api/v2/keys") {
+		url1 = url1 + "/api/v2/keys"
+	}
+	u, err := url.Parse(url1)
+	if err != nil {
+		return nil, fmt.Errorf("[ERROR] Error Parsing KMS EndpointURL")
+	}
+	return u, nil
+}
+
+// Get the console URL of a key, under the service of its CRN
+func kmsResourceControllerURL(rcontroller string, keyCRN string, keyID string) string {
+	serviceName := kmsServiceKeyProtect
+	if components, ok := parseKMSInstanceCRN(keyCRN); ok {
+		serviceName = components.ServiceName
+	}
+	crn1 := strings.TrimSuffix(keyCRN, ":key:"+keyID)
+	return rcontroller + "/services/" + serviceName + "/" + url.QueryEscape(crn1) + "%3A%3A"
+}
@@ -0,0 +1,152 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+// Synthetic CRNs of a key protect instance, an hpcs instance and one of its keys
+const (
+	testKMSInstanceCRN  = "crn:v1:bluemix:public:kms:us-south:a/4448261269a14562b839e0a3019ed980:30372f20-d9f1-40b3-b486-a709e1932c9c::"
+	testHPCSInstanceCRN = "crn:v1:bluemix:public:hs-crypto:us-south:a/4448261269a14562b839e0a3019ed980:9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d::"
+	testHPCSKeyCRN      = "crn:v1:bluemix:public:hs-crypto:us-south:a/4448261269a14562b839e0a3019ed980:9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d:key:2a8e3f9c-7b1d-4e6a-9f2c-5d8b1a3e7c4f"
+)
+
+// Synthetic extensions of the instances, written after the shape of the extensions that the resource controller returns.
+// They are not captured from real instances: the endpoints, GUIDs and ports are illustrative.
+var (
+	testKMSExtensions = map[string]interface{}{
+		"endpoints": map[string]interface{}{
+			"private": "https://private.us-south.kms.cloud.ibm.com",
+			"public":  "https://us-south.kms.cloud.ibm.com",
+		},
+	}
+	testHPCSExtensions = map[string]interface{}{
+		"crypto_units": 2,
+		"endpoints": map[string]interface{}{
+			"ep11-private": "https://ep11.private.us-south.hs-crypto.cloud.ibm.com:13412",
+			"ep11-public":  "https://ep11.us-south.hs-crypto.cloud.ibm.com:13412",
+			"private":      "https://api.private.us-south.hs-crypto.cloud.ibm.com:13411",
+			"public":       "https://api.us-south.hs-crypto.cloud.ibm.com:13411",
+		},
+	}
+	// The extensions of an hpcs instance whose public and private keys hold the regional key protect endpoints
+	testHPCSMixedExtensions = map[string]interface{}{
+		"endpoints": map[string]interface{}{
+			"private":           "https://private.us-south.kms.cloud.ibm.com",
+			"public":            "https://us-south.kms.cloud.ibm.com",
+			"hs-crypto-private": "https://api.private.us-south.hs-crypto.cloud.ibm.com:13411",
+			"hs-crypto-public":  "https://api.us-south.hs-crypto.cloud.ibm.com:13411",
+		},
+	}
+)
+
+func TestParseKMSInstanceCRN(t *testing.T) {
+	testCases := []struct {
+		crn        string
+		components kmsInstanceCRNComponents
+		ok         bool
+	}{
+		{testKMSInstanceCRN, kmsInstanceCRNComponents{ServiceName: "kms", Region: "us-south", InstanceGUID: "30372f20-d9f1-40b3-b486-a709e1932c9c"}, true},
+		{testHPCSInstanceCRN, kmsInstanceCRNComponents{ServiceName: "hs-crypto", Region: "us-south", InstanceGUID: "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d"}, true},
+		{testHPCSKeyCRN, kmsInstanceCRNComponents{ServiceName: "hs-crypto", Region: "us-south", InstanceGUID: "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d"}, true},
+		{"crn:v1:bluemix:public:hs-crypto:eu-de:a/4448261269a14562b839e0a3019ed980:9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d:key:2a8e3f9c", kmsInstanceCRNComponents{ServiceName: "hs-crypto", Region: "eu-de", InstanceGUID: "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d"}, true},
+		{"crn:v1:bluemix:public:cloud-object-storage:global:a/4448261269a14562b839e0a3019ed980:9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d::", kmsInstanceCRNComponents{}, false},
+		{"crn:v1:bluemix:public:hs-crypto:us-south", kmsInstanceCRNComponents{}, false},
+		{"9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d", kmsInstanceCRNComponents{}, false},
+	}
+	for _, tc := range testCases {
+		components, ok := parseKMSInstanceCRN(tc.crn)
+		assert.Equal(t, tc.ok, ok, tc.crn)
+		assert.Equal(t, tc.components, components, tc.crn)
+	}
+}
+
+func TestKMSInstanceService(t *testing.T) {
+	testCases := []struct {
+		name        string
+		instanceCRN *string
+		instanceID  string
+		endpointURL string
+		service     string
+	}{
+		{"kms instance", core.StringPtr(testKMSInstanceCRN), "30372f20-d9f1-40b3-b486-a709e1932c9c", "https://us-south.kms.cloud.ibm.com/api/v2/keys", "kms"},
+		{"hpcs instance", core.StringPtr(testHPCSInstanceCRN), "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d", "https://api.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys", "hs-crypto"},
+		{"hpcs instance with a key protect endpoint", core.StringPtr(testHPCSInstanceCRN), "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d", "https://us-south.kms.cloud.ibm.com/api/v2/keys", "hs-crypto"},
+		{"hpcs instance_id with endpoint_url", nil, testHPCSInstanceCRN, "https://kms.internal.example.com/api/v2/keys", "hs-crypto"},
+		{"hpcs endpoint_url", nil, "9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d", "https://9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d.api.us-south.hs-crypto.appdomain.cloud/api/v2/keys", "hs-crypto"},
+		{"kms endpoint_url", nil, "30372f20-d9f1-40b3-b486-a709e1932c9c", "https://private.us-south.kms.cloud.ibm.com/api/v2/keys", "kms"},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.service, kmsInstanceService(tc.instanceCRN, tc.instanceID, tc.endpointURL), tc.name)
+	}
+}
+
+func TestKMSInstanceEndpointURL(t *testing.T) {
+	t.Setenv("IBMCLOUD_KP_API_ENDPOINT", "")
+	testCases := []struct {
+		name         string
+		instanceCRN  string
+		extensions   map[string]interface{}
+		endpointType string
+		baseURL      string
+		url          string
+	}{
+		{"kms public", testKMSInstanceCRN, testKMSExtensions, "public", "", "https://us-south.kms.cloud.ibm.com/api/v2/keys"},
+		{"kms private", testKMSInstanceCRN, testKMSExtensions, "private", "", "https://private.us-south.kms.cloud.ibm.com/api/v2/keys"},
+		{"kms private provider", testKMSInstanceCRN, testKMSExtensions, "public", "https://private.us-south.kms.cloud.ibm.com", "https://private.us-south.kms.cloud.ibm.com/api/v2/keys"},
+		{"kms without extensions", testKMSInstanceCRN, map[string]interface{}{}, "public", "", "https://us-south.kms.cloud.ibm.com/api/v2/keys"},
+		{"hpcs public", testHPCSInstanceCRN, testHPCSExtensions, "public", "", "https://api.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys"},
+		{"hpcs private", testHPCSInstanceCRN, testHPCSExtensions, "private", "", "https://api.private.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys"},
+		{"hpcs public with key protect endpoints", testHPCSInstanceCRN, testHPCSMixedExtensions, "public", "", "https://api.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys"},
+		{"hpcs private with key protect endpoints", testHPCSInstanceCRN, testHPCSMixedExtensions, "private", "", "https://api.private.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys"},
+		{"hpcs with key protect endpoints only", testHPCSInstanceCRN, testKMSExtensions, "public", "", "https://9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d.api.us-south.hs-crypto.appdomain.cloud/api/v2/keys"},
+		{"hpcs private without extensions", testHPCSInstanceCRN, nil, "private", "", "https://9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d.api.private.us-south.hs-crypto.appdomain.cloud/api/v2/keys"},
+		{"unknown service", "", testHPCSMixedExtensions, "public", "", "https://us-south.kms.cloud.ibm.com/api/v2/keys"},
+		// The key protect instances keep the endpoint that the resource controller returns for the visibility
+		{"kms with an hpcs endpoint", testKMSInstanceCRN, testHPCSExtensions, "public", "", "https://api.us-south.hs-crypto.cloud.ibm.com:13411/api/v2/keys"},
+		{"trailing slash", testKMSInstanceCRN, map[string]interface{}{"endpoints": map[string]interface{}{"public": "https://us-south.kms.cloud.ibm.com/"}}, "public", "", "https://us-south.kms.cloud.ibm.com/api/v2/keys"},
+	}
+	for _, tc := range testCases {
+		kpAPI := &kp.Client{Config: kp.ClientConfig{BaseURL: tc.baseURL}}
+		u, err := kmsInstanceEndpointURL(kpAPI, tc.endpointType, tc.instanceCRN, tc.extensions)
+		if assert.NoError(t, err, tc.name) {
+			assert.Equal(t, tc.url, u.String(), tc.name)
+		}
+	}
+}
+
+func TestKMSInstanceEndpointURLErrors(t *testing.T) {
+	t.Setenv("IBMCLOUD_KP_API_ENDPOINT", "")
+	kpAPI := &kp.Client{}
+	// Without the CRN, the endpoint cannot be derived, and malformed extensions do not panic
+	for _, extensions := range []map[string]interface{}{
+		nil,
+		{"endpoints": "https://us-south.kms.cloud.ibm.com"},
+		{"endpoints": map[string]interface{}{"public": 443}},
+		{"endpoints": map[string]interface{}{"private": "https://private.us-south.kms.cloud.ibm.com"}},
+	} {
+		_, err := KmsEndpointURL(kpAPI, "public", extensions)
+		assert.ErrorContains(t, err, "no public endpoint")
+	}
+}
+
+func TestKMSInstanceEndpointURLEnvOverride(t *testing.T) {
+	t.Setenv("IBMCLOUD_KP_API_ENDPOINT", "https://qa.us-south.kms.test.cloud.ibm.com")
+	u, err := kmsInstanceEndpointURL(&kp.Client{}, "public", testHPCSInstanceCRN, testHPCSExtensions)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://qa.us-south.kms.test.cloud.ibm.com/api/v2/keys", u.String())
+}
+
+func TestKMSResourceControllerURL(t *testing.T) {
+	assert.Equal(t, "https://cloud.ibm.com/services/hs-crypto/crn%3Av1%3Abluemix%3Apublic%3Ahs-crypto%3Aus-south%3Aa%2F4448261269a14562b839e0a3019ed980%3A9a2b4c6d-1e3f-4a5b-8c7d-0e1f2a3b4c5d%3A%3A",
+		kmsResourceControllerURL("https://cloud.ibm.com", testHPCSKeyCRN, "2a8e3f9c-7b1d-4e6a-9f2c-5d8b1a3e7c4f"))
+	assert.Equal(t, "https://cloud.ibm.com/services/kms/crn%3Av1%3Abluemix%3Apublic%3Akms%3Aus-south%3Aa%2F4448261269a14562b839e0a3019ed980%3A30372f20-d9f1-40b3-b486-a709e1932c9c%3A%3A",
+		kmsResourceControllerURL("https://cloud.ibm.com", "crn:v1:bluemix:public:kms:us-south:a/4448261269a14562b839e0a3019ed980:30372f20-d9f1-40b3-b486-a709e1932c9c:key:key-id", "key-id"))
+}
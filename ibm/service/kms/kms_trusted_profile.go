@@ -0,0 +1,237 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The grant types of the IAM token endpoint that exchange an API key for an access token, and an access token for
+// an access token of a trusted profile
+const (
+	kmsIAMAPIKeyGrantType = "urn:ibm:params:oauth:grant-type:apikey"
+	kmsIAMAssumeGrantType = "urn:ibm:params:oauth:grant-type:assume"
+)
+
+// The assumed tokens are renewed when they expire within kmsTrustedProfileTokenMargin
+const kmsTrustedProfileTokenMargin = 5 * time.Minute
+
+// An IAM access token and its expiration
+type kmsIAMToken struct {
+	AccessToken string
+	Expiration  time.Time
+}
+
+// kmsTrustedProfileAuthenticator obtains IAM access tokens for the KMS data sources: the token of the credentials
+// of an API key, and the token of a trusted profile assumed with an access token. kmsIAMTokenClient implements it
+// with the IAM token endpoint, and the unit tests implement it with stubs.
+type kmsTrustedProfileAuthenticator interface {
+	APIKeyToken(ctx context.Context, apiKey string) (*kmsIAMToken, error)
+	AssumeTrustedProfile(ctx context.Context, profileID string, accessToken string) (*kmsIAMToken, error)
+}
+
+// kmsIAMTokenClient requests the tokens from the IAM token endpoint of the provider, such as
+// https://iam.cloud.ibm.com/identity/token
+type kmsIAMTokenClient struct {
+	TokenURL   string
+	HTTPClient *http.Client
+}
+
+var _ kmsTrustedProfileAuthenticator = kmsIAMTokenClient{}
+
+func (c kmsIAMTokenClient) APIKeyToken(ctx context.Context, apiKey string) (*kmsIAMToken, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type": {kmsIAMAPIKeyGrantType},
+		"apikey":     {apiKey},
+	})
+}
+
+func (c kmsIAMTokenClient) AssumeTrustedProfile(ctx context.Context, profileID string, accessToken string) (*kmsIAMToken, error) {
+	return c.requestToken(ctx, url.Values{
+		"grant_type":   {kmsIAMAssumeGrantType},
+		"access_token": {accessToken},
+		"profile_id":   {profileID},
+	})
+}
+
+func (c kmsIAMTokenClient) requestToken(ctx context.Context, form url.Values) (*kmsIAMToken, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		var iamError struct {
+			ErrorCode    string `json:"errorCode"`
+			ErrorMessage string `json:"errorMessage"`
+		}
+		if json.Unmarshal(body, &iamError) == nil && iamError.ErrorMessage != "" {
+			return nil, fmt.Errorf("IAM returned %d %s: %s", response.StatusCode, iamError.ErrorCode, iamError.ErrorMessage)
+		}
+		return nil, fmt.Errorf("IAM returned %d", response.StatusCode)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Expiration  int64  `json:"expiration"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, errors.New("IAM returned no access token")
+	}
+	return &kmsIAMToken{AccessToken: token.AccessToken, Expiration: time.Unix(token.Expiration, 0)}, nil
+}
+
+// The IAM token endpoint of the Key Protect clients that do not set one
+const kmsDefaultIAMTokenURL = "https://iam.cloud.ibm.com/identity/token"
+
+// Return the access token of the credentials of the Key Protect client, for the requests that the client does not
+// implement. The API key of the client is exchanged for an access token when the client has no access token.
+func kmsClientAccessToken(ctx context.Context, api *kp.Client) (string, error) {
+	if token := kmsBareToken(api.Config.Authorization); token != "" {
+		return token, nil
+	}
+	if api.Config.APIKey == "" {
+		return "", errors.New("[ERROR] The Key Protect client has no credentials")
+	}
+	token, err := kmsNewTrustedProfileAuthenticator(api.Config.TokenURL, &api.HttpClient).APIKeyToken(ctx, api.Config.APIKey)
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] The access token of the provider credentials could not be obtained: %s", err)
+	}
+	return token.AccessToken, nil
+}
+
+// Add iam_trusted_profile_id and iam_token to the schema of a KMS data source
+func kmsWithCredentialOverrideSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["iam_trusted_profile_id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "The ID of an IAM trusted profile to assume for the requests of the data source, instead of the credentials of the provider, for example to read the keys of an instance of another account",
+	}
+	s["iam_token"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "An IAM access token for the requests of the data source, instead of the credentials of the provider. With iam_trusted_profile_id, the token that assumes the trusted profile",
+	}
+	return s
+}
+
+// Get the trusted profile and the IAM token of the schema of a data source, see kmsWithCredentialOverrideSchema
+func kmsCredentialOverrides(d *schema.ResourceData) (profileID string, iamToken string) {
+	if v, ok := d.GetOk("iam_trusted_profile_id"); ok {
+		profileID = strings.TrimSpace(v.(string))
+	}
+	if v, ok := d.GetOk("iam_token"); ok {
+		iamToken = strings.TrimSpace(v.(string))
+	}
+	return profileID, iamToken
+}
+
+// Remove the Bearer prefix of an access token
+func kmsBareToken(token string) string {
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+}
+
+// Hash a credential, so that the cache keys distinguish the credentials without holding them
+func kmsCredentialFingerprint(credential string) string {
+	if credential == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(hash[:])
+}
+
+// Return the access token that replaces the credentials of the provider for the requests of a data source, empty
+// when neither iam_trusted_profile_id nor iam_token is set. The trusted profile is assumed with iam_token when it is
+// set, and with the credentials of the Key Protect client of the provider otherwise.
+func kmsOverrideAccessToken(ctx context.Context, d *schema.ResourceData, kpAPI *kp.Client, authenticator kmsTrustedProfileAuthenticator, cache *conns.CallCache) (string, error) {
+	profileID, iamToken := kmsCredentialOverrides(d)
+	if profileID == "" {
+		return kmsBareToken(iamToken), nil
+	}
+	// The token is cached by the identity that assumes the profile: iam_token, the access token of the provider, or
+	// its API key, which is exchanged for an access token only when the profile is assumed
+	accessToken := kmsBareToken(iamToken)
+	if accessToken == "" {
+		accessToken = kmsBareToken(kpAPI.Config.Authorization)
+	}
+	identity := accessToken
+	if identity == "" && kpAPI.Config.APIKey != "" {
+		identity = "apikey:" + kpAPI.Config.APIKey
+	}
+	if identity == "" {
+		return "", fmt.Errorf("[ERROR] Cannot assume the IAM trusted profile %s: the provider has no credentials to assume it with, set iam_token", profileID)
+	}
+	// The token is shared by the data sources of the session until it expires within kmsTrustedProfileTokenMargin
+	key := fmt.Sprintf("kms_trusted_profile_token/%s/%s/%s", kpAPI.Config.TokenURL, profileID, kmsCredentialFingerprint(identity))
+	token, err := cache.Do(key, func() (interface{}, time.Time, error) {
+		if accessToken == "" {
+			apiKeyToken, err := authenticator.APIKeyToken(ctx, kpAPI.Config.APIKey)
+			if err != nil {
+				return nil, time.Time{}, fmt.Errorf("the access token of the provider credentials could not be obtained: %s", err)
+			}
+			accessToken = apiKeyToken.AccessToken
+		}
+		token, err := authenticator.AssumeTrustedProfile(ctx, profileID, accessToken)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return token, token.Expiration.Add(-kmsTrustedProfileTokenMargin), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("[ERROR] Cannot assume the IAM trusted profile %s: %s. Check that the profile exists and that its trust policy allows the identity of the provider or of iam_token to assume it", profileID, err)
+	}
+	return token.(*kmsIAMToken).AccessToken, nil
+}
+
+// The authenticator for the token endpoint and the HTTP client of the Key Protect client of the provider, replaced by
+// the unit tests
+var kmsNewTrustedProfileAuthenticator = func(tokenURL string, httpClient *http.Client) kmsTrustedProfileAuthenticator {
+	if tokenURL == "" {
+		tokenURL = kmsDefaultIAMTokenURL
+	}
+	return kmsIAMTokenClient{TokenURL: tokenURL, HTTPClient: httpClient}
+}
+
+// Replace the credentials of the Key Protect client with the access token of iam_trusted_profile_id or iam_token,
+// when one of them is set, and return the token. The token is empty when the credentials of the provider are kept.
+func kmsApplyCredentialOverrides(ctx context.Context, d *schema.ResourceData, meta interface{}, kpAPI *kp.Client) (string, error) {
+	token, err := kmsOverrideAccessToken(ctx, d, kpAPI, kmsNewTrustedProfileAuthenticator(kpAPI.Config.TokenURL, &kpAPI.HttpClient), meta.(conns.ClientSession).CallCache())
+	if err != nil || token == "" {
+		return "", err
+	}
+	kpAPI.Config.Authorization = "Bearer " + token
+	kpAPI.Config.APIKey = ""
+	return token, nil
+}
@@ -0,0 +1,210 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKMSTrustedProfileAuthenticator stubs IAM and counts the tokens it issues
+type testKMSTrustedProfileAuthenticator struct {
+	apiKeyCalls  int
+	assumeCalls  int
+	assumedWith  []string
+	expiration   time.Time
+	assumeErr    error
+	apiKeyTokens map[string]string
+}
+
+func (a *testKMSTrustedProfileAuthenticator) APIKeyToken(ctx context.Context, apiKey string) (*kmsIAMToken, error) {
+	a.apiKeyCalls++
+	return &kmsIAMToken{AccessToken: a.apiKeyTokens[apiKey], Expiration: a.expiration}, nil
+}
+
+func (a *testKMSTrustedProfileAuthenticator) AssumeTrustedProfile(ctx context.Context, profileID string, accessToken string) (*kmsIAMToken, error) {
+	a.assumeCalls++
+	a.assumedWith = append(a.assumedWith, accessToken)
+	if a.assumeErr != nil {
+		return nil, a.assumeErr
+	}
+	return &kmsIAMToken{AccessToken: "assumed-" + profileID, Expiration: a.expiration}, nil
+}
+
+func TestKMSOverrideAccessToken(t *testing.T) {
+	cache := conns.NewCallCache()
+	kpAPI := &kp.Client{Config: kp.ClientConfig{TokenURL: "https://iam.cloud.ibm.com/identity/token", Authorization: "Bearer provider-token"}}
+	authenticator := &testKMSTrustedProfileAuthenticator{expiration: time.Now().Add(time.Hour)}
+
+	// Without overrides the credentials of the provider are kept
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance"})
+	token, err := kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	assert.NoError(t, err)
+	assert.Empty(t, token)
+
+	// iam_token alone is used as is
+	d = schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_token": "Bearer other-token"})
+	token, err = kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	assert.NoError(t, err)
+	assert.Equal(t, "other-token", token)
+	assert.Equal(t, 0, authenticator.assumeCalls)
+
+	// The profile is assumed once with the token of the provider, and once with iam_token
+	d = schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_trusted_profile_id": "Profile-1"})
+	for i := 0; i < 2; i++ {
+		token, err = kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+		assert.NoError(t, err)
+		assert.Equal(t, "assumed-Profile-1", token)
+	}
+	d = schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_trusted_profile_id": "Profile-1", "iam_token": "other-token"})
+	_, err = kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, authenticator.assumeCalls)
+	assert.Equal(t, []string{"provider-token", "other-token"}, authenticator.assumedWith)
+}
+
+func TestKMSOverrideAccessTokenAPIKey(t *testing.T) {
+	cache := conns.NewCallCache()
+	kpAPI := &kp.Client{Config: kp.ClientConfig{TokenURL: "https://iam.cloud.ibm.com/identity/token", APIKey: "api-key"}}
+	authenticator := &testKMSTrustedProfileAuthenticator{expiration: time.Now().Add(time.Hour), apiKeyTokens: map[string]string{"api-key": "api-key-token"}}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_trusted_profile_id": "Profile-1"})
+
+	for i := 0; i < 2; i++ {
+		token, err := kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+		assert.NoError(t, err)
+		assert.Equal(t, "assumed-Profile-1", token)
+	}
+	// The API key is exchanged only when the profile is assumed
+	assert.Equal(t, 1, authenticator.apiKeyCalls)
+	assert.Equal(t, []string{"api-key-token"}, authenticator.assumedWith)
+}
+
+func TestKMSOverrideAccessTokenAssumeError(t *testing.T) {
+	cache := conns.NewCallCache()
+	kpAPI := &kp.Client{Config: kp.ClientConfig{Authorization: "Bearer provider-token"}}
+	authenticator := &testKMSTrustedProfileAuthenticator{assumeErr: errors.New("IAM returned 400 BXNIM0513E: The profile does not exist")}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_trusted_profile_id": "Profile-1"})
+
+	_, err := kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Cannot assume the IAM trusted profile Profile-1")
+		assert.Contains(t, err.Error(), "BXNIM0513E")
+	}
+
+	// A provider without credentials cannot assume the profile
+	_, err = kmsOverrideAccessToken(context.Background(), d, &kp.Client{}, authenticator, cache)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "set iam_token")
+	}
+}
+
+func TestKMSOverrideAccessTokenRenewal(t *testing.T) {
+	cache := conns.NewCallCache()
+	kpAPI := &kp.Client{Config: kp.ClientConfig{Authorization: "Bearer provider-token"}}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{"instance_id": "instance", "iam_trusted_profile_id": "Profile-1"})
+
+	// A token that expires within the margin is assumed again
+	authenticator := &testKMSTrustedProfileAuthenticator{expiration: time.Now().Add(kmsTrustedProfileTokenMargin - time.Minute)}
+	for i := 0; i < 2; i++ {
+		_, err := kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, authenticator.assumeCalls)
+
+	// The failures are not cached
+	authenticator = &testKMSTrustedProfileAuthenticator{expiration: time.Now().Add(time.Hour), assumeErr: errors.New("failed")}
+	cache = conns.NewCallCache()
+	_, err := kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	assert.Error(t, err)
+	authenticator.assumeErr = nil
+	_, err = kmsOverrideAccessToken(context.Background(), d, kpAPI, authenticator, cache)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, authenticator.assumeCalls)
+}
+
+func TestKMSIAMTokenClient(t *testing.T) {
+	var forms []map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		form := map[string]string{}
+		for name := range r.PostForm {
+			form[name] = r.PostForm.Get(name)
+		}
+		forms = append(forms, form)
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("profile_id") == "Profile-missing" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errorCode":"BXNIM0513E","errorMessage":"Provided profile does not exist"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"issued-token","expiration":1717243200}`))
+	}))
+	defer server.Close()
+	client := kmsIAMTokenClient{TokenURL: server.URL}
+
+	token, err := client.APIKeyToken(context.Background(), "api-key")
+	assert.NoError(t, err)
+	assert.Equal(t, "issued-token", token.AccessToken)
+	assert.Equal(t, time.Unix(1717243200, 0), token.Expiration)
+
+	_, err = client.AssumeTrustedProfile(context.Background(), "Profile-1", "access-token")
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"grant_type": kmsIAMAPIKeyGrantType, "apikey": "api-key"},
+		{"grant_type": kmsIAMAssumeGrantType, "access_token": "access-token", "profile_id": "Profile-1"},
+	}, forms)
+
+	_, err = client.AssumeTrustedProfile(context.Background(), "Profile-missing", "access-token")
+	if assert.Error(t, err) {
+		assert.Equal(t, "IAM returned 400 BXNIM0513E: Provided profile does not exist", err.Error())
+	}
+}
+
+func TestPopulateKPClientTrustedProfile(t *testing.T) {
+	authenticator := &testKMSTrustedProfileAuthenticator{expiration: time.Now().Add(time.Hour)}
+	saved := kmsNewTrustedProfileAuthenticator
+	kmsNewTrustedProfileAuthenticator = func(tokenURL string, httpClient *http.Client) kmsTrustedProfileAuthenticator {
+		assert.Equal(t, "https://iam.cloud.ibm.com/identity/token", tokenURL)
+		assert.NotNil(t, httpClient)
+		return authenticator
+	}
+	t.Cleanup(func() { kmsNewTrustedProfileAuthenticator = saved })
+
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+		"instance_id":            "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		"endpoint_url":           server.URL,
+		"iam_trusted_profile_id": "Profile-1",
+	})
+
+	sess := &testKMSClientSession{}
+	for i := 0; i < 2; i++ {
+		kpAPI, _, err := populateKPDataSourceClient(context.Background(), d, sess, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer assumed-Profile-1", kpAPI.Config.Authorization)
+	}
+	assert.Equal(t, []string{"token"}, authenticator.assumedWith)
+	// The token of the profile is reused, and the access of the profile is left to the requests of the data source
+	assert.Equal(t, 1, authenticator.assumeCalls)
+	assert.Empty(t, authorizations)
+
+	// The resources have no credential overrides
+	kpAPI, _, err := populateKPClient(d, sess, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "Bearer assumed-Profile-1", kpAPI.Config.Authorization)
+	assert.Equal(t, 1, authenticator.assumeCalls)
+}
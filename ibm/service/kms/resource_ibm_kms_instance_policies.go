@@ -34,6 +34,7 @@ func ResourceIBMKmsInstancePolicy() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"endpoint_type": {
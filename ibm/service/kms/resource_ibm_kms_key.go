@@ -21,14 +21,14 @@ import (
 
 func suppressKMSInstanceIDDiff(k, old, new string, d *schema.ResourceData) bool {
 	// TF currently uses GUID. So just check when instance crn is passed as input it has same GUID in it.
-	return old == getInstanceIDFromCRN(new)
+	// Either side may hold the CRN, since data sources keep the user supplied value in state.
+	return getInstanceIDFromCRN(old) == getInstanceIDFromCRN(new)
 }
 
 // Get Instance ID from CRN
 func getInstanceIDFromCRN(crn string) string {
-	crnSegments := strings.Split(crn, ":")
-	if len(crnSegments) > 3 {
-		return crnSegments[len(crnSegments)-3]
+	if instanceID, _, err := parseKMSInstanceID(crn); err == nil {
+		return instanceID
 	}
 	return crn
 }
@@ -52,6 +52,7 @@ func ResourceIBMKmskey() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				Description:      "Key protect or hpcs instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"key_ring_id": {
@@ -289,36 +290,149 @@ func resourceIBMKmsKeyExists(d *schema.ResourceData, meta interface{}) (bool, er
 
 // Populate KP Client using info from schema
 func populateKPClient(d *schema.ResourceData, meta interface{}, instanceID string) (kpAPI *kp.Client, instanceCRN *string, err error) {
-	kpAPI, err = meta.(conns.ClientSession).KeyManagementAPI()
+	kpAPI, instanceData, err := populateKPClientWithInstance(d, meta, instanceID)
 	if err != nil {
 		return nil, nil, err
 	}
-	var endpointType string
+	return kpAPI, kmsInstanceCRN(instanceData), nil
+}
 
-	if v, ok := d.GetOk("endpoint_type"); ok {
-		endpointType = v.(string)
+// Get the CRN of an instance that the resource controller reported, nil without instance
+func kmsInstanceCRN(instanceData *rc.ResourceInstance) *string {
+	if instanceData == nil {
+		return nil
 	}
+	return instanceData.CRN
+}
+
+// Populate KP Client using info from schema, returning the instance that the resource controller reported. The
+// instance is nil when the endpoint URL override skips the resource controller lookup.
+func populateKPClientWithInstance(d *schema.ResourceData, meta interface{}, instanceID string) (kpAPI *kp.Client, instanceData *rc.ResourceInstance, err error) {
+	return populateKPClientWithCredentials(context.Background(), d, meta, instanceID, false)
+}
+
+// Populate KP Client for a data source, whose schema has iam_trusted_profile_id and iam_token, see
+// kmsWithCredentialOverrideSchema
+func populateKPDataSourceClient(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceID string) (kpAPI *kp.Client, instanceData *rc.ResourceInstance, err error) {
+	return populateKPClientWithCredentials(ctx, d, meta, instanceID, true)
+}
+
+// Populate KP Client, replacing the credentials of the provider with iam_trusted_profile_id or iam_token when
+// credentialOverrides is set
+func populateKPClientWithCredentials(ctx context.Context, d *schema.ResourceData, meta interface{}, instanceID string, credentialOverrides bool) (kpAPI *kp.Client, instanceData *rc.ResourceInstance, err error) {
+	kpAPI, err = meta.(conns.ClientSession).KeyManagementAPI()
+	if err != nil {
+		return nil, nil, err
+	}
+	apiTimingLogs := meta.(conns.ClientSession).APITimingLogsEnabled()
+	if apiTimingLogs {
+		kpAPI.HttpClient = *conns.WithAPITimingLogs(&kpAPI.HttpClient, "kms")
+	}
+	// The data sources can replace the credentials of the provider with a trusted profile or an IAM token, which are
+	// used for the resource controller lookup too
+	var overrideToken, profileID string
+	if credentialOverrides {
+		overrideToken, err = kmsApplyCredentialOverrides(ctx, d, meta, kpAPI)
+		if err != nil {
+			return nil, nil, err
+		}
+		profileID, _ = kmsCredentialOverrides(d)
+	}
+	// The endpoint URL override is used as is, without looking up the instance in the resource controller
+	if endpointURL := kmsEndpointURLOverride(d); endpointURL != "" {
+		kpAPI.URL, err = kmsOverrideEndpointURL(endpointURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		kpAPI.Config.InstanceID = instanceID
+		return kpAPI, nil, nil
+	}
+	endpointType := kmsEndpointType(d, meta)
 
 	rsConClient, err := meta.(conns.ClientSession).ResourceControllerV2API()
 	if err != nil {
 		return nil, nil, err
 	}
+	if apiTimingLogs {
+		rsConClient = kmsResourceControllerWithAPITimingLogs(rsConClient)
+	}
+	if overrideToken != "" {
+		rsConClient = kmsResourceControllerWithAccessToken(rsConClient, overrideToken)
+	}
+	instanceData, err = getKMSResourceInstance(rsConClient, instanceID)
+	if err != nil {
+		if profileID != "" {
+			return nil, nil, fmt.Errorf("[ERROR] The IAM trusted profile %s was assumed but lacks access to instance %s: %s", profileID, instanceID, err)
+		}
+		return nil, nil, err
+	}
+	kpAPI.URL, err = kmsInstanceEndpointURL(kpAPI, endpointType, flex.StringValue(instanceData.CRN), instanceData.Extensions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	kpAPI.Config.InstanceID = instanceID
+	return kpAPI, instanceData, nil
+}
+
+// Get the instance from the resource controller, to derive the endpoints of the instance from its extensions
+func getKMSResourceInstance(rsConClient kmsResourceInstanceAPI, instanceID string) (*rc.ResourceInstance, error) {
 	resourceInstanceGet := rc.GetResourceInstanceOptions{
 		ID: &instanceID,
 	}
 
 	instanceData, resp, err := rsConClient.GetResourceInstance(&resourceInstanceGet)
+	if resp != nil && resp.StatusCode == 403 {
+		return nil, fmt.Errorf("[ERROR] Error retrieving resource instance %s: the credentials are not authorized to read the instance from the resource controller: %s. "+
+			"Set endpoint_url on the KMS data sources to the endpoint of the instance to skip the resource controller lookup", instanceID, err)
+	}
 	if err != nil || instanceData == nil {
-		return nil, nil, fmt.Errorf("[ERROR] Error retrieving resource instance: %s with resp code: %s", err, resp)
+		return nil, fmt.Errorf("[ERROR] Error retrieving resource instance: %s with resp code: %s", err, resp)
 	}
-	extensions := instanceData.Extensions
-	kpAPI.URL, err = KmsEndpointURL(kpAPI, endpointType, extensions)
-	if err != nil {
-		return nil, nil, err
+	return instanceData, nil
+}
+
+// Get the endpoint URL override from the schema, only the data sources define endpoint_url
+func kmsEndpointURLOverride(d *schema.ResourceData) string {
+	if v, ok := d.GetOk("endpoint_url"); ok {
+		if endpointURL, ok := v.(string); ok {
+			return strings.TrimSpace(endpointURL)
+		}
 	}
+	return ""
+}
 
-	kpAPI.Config.InstanceID = instanceID
-	return kpAPI, instanceData.CRN, nil
+// Construct the KMS URL from an endpoint URL override, the keys path is appended when it is missing
+func kmsOverrideEndpointURL(endpointURL string) (*url.URL, error) {
+	url1 := strings.TrimSuffix(endpointURL, "/")
+	if !strings.HasSuffix(url1, "/api/v2/keys") {
+		url1 = url1 + "/api/v2/keys"
+	}
+	u, err := url.Parse(url1)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("[ERROR] Error Parsing KMS endpoint_url %q, it must be an absolute URL such as https://us-south.kms.cloud.ibm.com", endpointURL)
+	}
+	return u, nil
+}
+
+// Get the endpoint type from the schema, defaulting to the visibility of the provider when it is not set
+func kmsEndpointType(d *schema.ResourceData, meta interface{}) string {
+	if v, ok := d.GetOk("endpoint_type"); ok {
+		return v.(string)
+	}
+	var visibility string
+	if bluemixSession, err := meta.(conns.ClientSession).BluemixSession(); err == nil {
+		visibility = bluemixSession.Config.Visibility
+	}
+	return kmsDefaultEndpointType(visibility)
+}
+
+// Get the default endpoint type for a provider visibility, private only when the provider uses private endpoints only
+func kmsDefaultEndpointType(visibility string) string {
+	if visibility == "private" {
+		return "private"
+	}
+	return "public"
 }
 
 // Set Key Details in the schema
@@ -357,10 +471,7 @@ func setKeyDetails(d *schema.ResourceData, meta interface{}, instanceID string,
 	if err != nil {
 		return err
 	}
-	id := key.ID
-	crn1 := strings.TrimSuffix(key.CRN, ":key:"+id)
-
-	d.Set(flex.ResourceControllerURL, rcontroller+"/services/kms/"+url.QueryEscape(crn1)+"%3A%3A")
+	d.Set(flex.ResourceControllerURL, kmsResourceControllerURL(rcontroller, key.CRN, key.ID))
 
 	// Get the Registration of the key
 	registrations, err := kpAPI.ListRegistrations(context.Background(), key.ID, "")
@@ -384,31 +495,22 @@ func setKeyDetails(d *schema.ResourceData, meta interface{}, instanceID string,
 
 // Extract Instance and Key related info from crn
 func getInstanceAndKeyDataFromCRN(crn string) (instanceCRN string, instanceID string, keyID string) {
-	crnData := strings.Split(crn, ":")
 	instanceCRN = fmt.Sprintf("%s::", strings.Split(crn, ":key:")[0])
-	keyID = crnData[len(crnData)-1]
-	instanceID = crnData[len(crnData)-3]
+	instanceID, keyID, err := parseKMSInstanceID(crn)
+	if err != nil {
+		// Keep the positional lookup for ids that are not key CRNs
+		crnData := strings.Split(crn, ":")
+		if len(crnData) < 3 {
+			return instanceCRN, "", crn
+		}
+		return instanceCRN, crnData[len(crnData)-3], crnData[len(crnData)-1]
+	}
 	return instanceCRN, instanceID, keyID
 }
 
-// Construct KMS URL
+// Construct KMS URL from the extensions of an instance whose CRN is unknown, see kmsInstanceEndpointURL
 func KmsEndpointURL(kpAPI *kp.Client, endpointType string, extensions map[string]interface{}) (*url.URL, error) {
-
-	exturl := extensions["endpoints"].(map[string]interface{})["public"]
-	if endpointType == "private" || strings.Contains(kpAPI.Config.BaseURL, "private") {
-		exturl = extensions["endpoints"].(map[string]interface{})["private"]
-	}
-	endpointURL := fmt.Sprintf("%s/api/v2/keys", exturl.(string))
-
-	url1 := conns.EnvFallBack([]string{"IBMCLOUD_KP_API_ENDPOINT"}, endpointURL)
-	if !strings.HasSuffix(url1, "/api/v2/keys") {
-		url1 = url1 + "/api/v2/keys"
-	}
-	u, err := url.Parse(url1)
-	if err != nil {
-		return nil, fmt.Errorf("[ERROR] Error Parsing KMS EndpointURL")
-	}
-	return u, nil
+	return kmsInstanceEndpointURL(kpAPI, endpointType, "", extensions)
 }
 
 // Extract and Validate data from schema related to a key
@@ -23,6 +23,7 @@ func ResourceIBMKmskeyAlias() *schema.Resource {
 				Required:         true,
 				Description:      "Key ID",
 				ForceNew:         true,
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"alias": {
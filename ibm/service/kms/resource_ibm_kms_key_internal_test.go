@@ -0,0 +1,171 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package kms
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/go-sdk-core/v5/core"
+	kp "github.com/IBM/keyprotect-go-client"
+	rc "github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testKMSClientSession stubs the client session with a Key Protect client and an optional resource controller URL
+type testKMSClientSession struct {
+	conns.ClientSession
+	resourceControllerURL   string
+	resourceControllerCalls int
+	keyLookupCache          bool
+	apiTimingLogs           bool
+	callCache               *conns.CallCache
+}
+
+func (sess *testKMSClientSession) CallCache() *conns.CallCache {
+	if sess.callCache == nil {
+		sess.callCache = conns.NewCallCache()
+	}
+	return sess.callCache
+}
+
+func (sess *testKMSClientSession) APITimingLogsEnabled() bool {
+	return sess.apiTimingLogs
+}
+
+func (sess *testKMSClientSession) KMSKeyLookupCacheEnabled() bool {
+	return sess.keyLookupCache
+}
+
+func (sess *testKMSClientSession) KeyManagementAPI() (*kp.Client, error) {
+	return kp.New(kp.ClientConfig{BaseURL: "https://us-south.kms.cloud.ibm.com", TokenURL: "https://iam.cloud.ibm.com/identity/token", Authorization: "Bearer token"}, kp.DefaultTransport())
+}
+
+func (sess *testKMSClientSession) ResourceControllerV2API() (*rc.ResourceControllerV2, error) {
+	sess.resourceControllerCalls++
+	return rc.NewResourceControllerV2(&rc.ResourceControllerV2Options{
+		URL:           sess.resourceControllerURL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+}
+
+func testKMSResourceControllerForbidden(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"You are not authorized to access the resource instance"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPopulateKPClientEndpointURLOverride(t *testing.T) {
+	sess := &testKMSClientSession{resourceControllerURL: testKMSResourceControllerForbidden(t).URL}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+		"instance_id":   "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		"endpoint_type": "public",
+		"endpoint_url":  "https://private.us-south.kms.cloud.ibm.com/",
+	})
+
+	kpAPI, instanceCRN, err := populateKPClient(d, sess, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.NoError(t, err)
+	assert.Nil(t, instanceCRN)
+	assert.Equal(t, "https://private.us-south.kms.cloud.ibm.com/api/v2/keys", kpAPI.URL.String())
+	assert.Equal(t, "30372f20-d9f1-40b3-b486-a709e1932c9c", kpAPI.Config.InstanceID)
+	assert.Equal(t, 0, sess.resourceControllerCalls)
+}
+
+func TestPopulateKPClientAPITimingLogs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+		"instance_id":  "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		"endpoint_url": "https://private.us-south.kms.cloud.ibm.com/",
+	})
+
+	kpAPI, _, err := populateKPClient(d, &testKMSClientSession{}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.NoError(t, err)
+	transport := kpAPI.HttpClient.Transport
+	_, timed := transport.(*conns.APITimingTransport)
+	assert.False(t, timed)
+
+	kpAPI, _, err = populateKPClient(d, &testKMSClientSession{apiTimingLogs: true}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.NoError(t, err)
+	if assert.IsType(t, &conns.APITimingTransport{}, kpAPI.HttpClient.Transport) {
+		timedTransport := kpAPI.HttpClient.Transport.(*conns.APITimingTransport)
+		assert.Equal(t, "kms", timedTransport.Service)
+		assert.IsType(t, transport, timedTransport.Next)
+	}
+}
+
+func TestKMSResourceControllerWithAPITimingLogs(t *testing.T) {
+	rsConClient, err := (&testKMSClientSession{resourceControllerURL: "https://resource-controller.cloud.ibm.com"}).ResourceControllerV2API()
+	assert.NoError(t, err)
+	transport := rsConClient.Service.GetHTTPClient().Transport
+
+	timedClient := kmsResourceControllerWithAPITimingLogs(rsConClient)
+	assert.Equal(t, "https://resource-controller.cloud.ibm.com", timedClient.Service.GetServiceURL())
+	if assert.IsType(t, &conns.APITimingTransport{}, timedClient.Service.GetHTTPClient().Transport) {
+		assert.Equal(t, "resource_controller", timedClient.Service.GetHTTPClient().Transport.(*conns.APITimingTransport).Service)
+	}
+
+	// The client of the session is left as is
+	assert.Equal(t, transport, rsConClient.Service.GetHTTPClient().Transport)
+}
+
+func TestPopulateKPClientResourceControllerForbidden(t *testing.T) {
+	sess := &testKMSClientSession{resourceControllerURL: testKMSResourceControllerForbidden(t).URL}
+	d := schema.TestResourceDataRaw(t, DataSourceIBMKMSkey().Schema, map[string]interface{}{
+		"instance_id":   "30372f20-d9f1-40b3-b486-a709e1932c9c",
+		"endpoint_type": "public",
+	})
+
+	_, _, err := populateKPClient(d, sess, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "endpoint_url")
+	assert.Equal(t, 1, sess.resourceControllerCalls)
+}
+
+// testKMSResourceInstanceAPI fakes the resource controller with a fixed response
+type testKMSResourceInstanceAPI struct {
+	instance   *rc.ResourceInstance
+	statusCode int
+	err        error
+}
+
+func (api testKMSResourceInstanceAPI) GetResourceInstance(getResourceInstanceOptions *rc.GetResourceInstanceOptions) (*rc.ResourceInstance, *core.DetailedResponse, error) {
+	var resp *core.DetailedResponse
+	if api.statusCode != 0 {
+		resp = &core.DetailedResponse{StatusCode: api.statusCode}
+	}
+	return api.instance, resp, api.err
+}
+
+func TestGetKMSResourceInstance(t *testing.T) {
+	instance := &rc.ResourceInstance{CRN: core.StringPtr("crn:v1:bluemix:public:kms:us-south:a/account:30372f20-d9f1-40b3-b486-a709e1932c9c::")}
+	instanceData, err := getKMSResourceInstance(testKMSResourceInstanceAPI{instance: instance, statusCode: 200}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.NoError(t, err)
+	assert.Equal(t, instance, instanceData)
+
+	_, err = getKMSResourceInstance(testKMSResourceInstanceAPI{statusCode: 403, err: fmt.Errorf("Forbidden")}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.ErrorContains(t, err, "Set endpoint_url")
+
+	_, err = getKMSResourceInstance(testKMSResourceInstanceAPI{statusCode: 404, err: fmt.Errorf("Not Found")}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.ErrorContains(t, err, "Not Found")
+	assert.NotContains(t, err.Error(), "endpoint_url")
+
+	_, err = getKMSResourceInstance(testKMSResourceInstanceAPI{statusCode: 200}, "30372f20-d9f1-40b3-b486-a709e1932c9c")
+	assert.ErrorContains(t, err, "Error retrieving resource instance")
+}
+
+func TestKMSOverrideEndpointURL(t *testing.T) {
+	u, err := kmsOverrideEndpointURL("https://us-south.kms.cloud.ibm.com/api/v2/keys")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://us-south.kms.cloud.ibm.com/api/v2/keys", u.String())
+
+	_, err = kmsOverrideEndpointURL("us-south.kms.cloud.ibm.com")
+	assert.Error(t, err)
+}
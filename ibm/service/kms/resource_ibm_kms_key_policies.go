@@ -7,7 +7,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -37,6 +36,7 @@ func ResourceIBMKmskeyPolicies() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				Description:      "Key protect or hpcs instance GUID",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"key_id": {
@@ -101,6 +101,7 @@ func ResourceIBMKmskeyPolicies() *schema.Resource {
 							Description: "If set to true, Key Protect enables a rotation policy on a single key.",
 							Default:     true,
 						},
+						"policy_api_version": kmsPolicyAPIVersionSchema(),
 						"interval_month": {
 							Type:         schema.TypeInt,
 							Optional:     true,
@@ -153,6 +154,7 @@ func ResourceIBMKmskeyPolicies() *schema.Resource {
 							Required:    true,
 							Description: "If set to true, Key Protect enables a dual authorization policy on a single key.",
 						},
+						"policy_api_version": kmsPolicyAPIVersionSchema(),
 					},
 				},
 			},
@@ -239,10 +241,7 @@ func resourceIBMKmsKeyPolicyRead(context context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	id := key.ID
-	crn1 := strings.TrimSuffix(key.CRN, ":key:"+id)
-
-	d.Set(flex.ResourceControllerURL, rcontroller+"/services/kms/"+url.QueryEscape(crn1)+"%3A%3A")
+	d.Set(flex.ResourceControllerURL, kmsResourceControllerURL(rcontroller, key.CRN, key.ID))
 
 	policies, err := kpAPI.GetPolicies(context, keyid)
 
@@ -377,3 +376,12 @@ func getPolicyFromSchema(d *schema.ResourceData) kp.Policy {
 	}
 	return policy
 }
+
+// The computed policy_api_version of the rotation and dual_auth_delete policies of the key resources and data sources
+func kmsPolicyAPIVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The version of the payload of the policies API that the policy was read from: v1 for the payloads without an enabled field, where a rotation policy is enabled when interval_month is set, v2 for the payloads with an enabled field.",
+	}
+}
@@ -27,6 +27,7 @@ func ResourceIBMKmskeyRings() *schema.Resource {
 				Required:         true,
 				Description:      "Key protect Instance GUID",
 				ForceNew:         true,
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"key_ring_id": {
@@ -33,6 +33,7 @@ func ResourceIBMKmsKeyWithPolicyOverrides() *schema.Resource {
 				Required:         true,
 				ForceNew:         true,
 				Description:      "Key protect or HPCS instance GUID or CRN",
+				ValidateFunc:     validateKMSInstanceID,
 				DiffSuppressFunc: suppressKMSInstanceIDDiff,
 			},
 			"description": {
@@ -6,9 +6,7 @@ package kms
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
@@ -181,10 +179,7 @@ func resourceIBMKeyRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 	crn := d.Id()
-	crnData := strings.Split(crn, ":")
-
-	instanceID := crnData[len(crnData)-3]
-	keyid := crnData[len(crnData)-1]
+	_, instanceID, keyid := getInstanceAndKeyDataFromCRN(crn)
 	api.Config.InstanceID = instanceID
 	// keyid := d.Id()
 	key, err := api.GetKey(context.Background(), keyid)
@@ -210,10 +205,7 @@ func resourceIBMKeyRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	id := key.ID
-	crn1 := strings.TrimSuffix(key.CRN, ":key:"+id)
-
-	d.Set(flex.ResourceControllerURL, rcontroller+"/services/kms/"+url.QueryEscape(crn1)+"%3A%3A")
+	d.Set(flex.ResourceControllerURL, kmsResourceControllerURL(rcontroller, key.CRN, key.ID))
 
 	return nil
 
@@ -234,10 +226,7 @@ func resourceIBMKeyDelete(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 	crn := d.Id()
-	crnData := strings.Split(crn, ":")
-
-	instanceID := crnData[len(crnData)-3]
-	keyid := crnData[len(crnData)-1]
+	_, instanceID, keyid := getInstanceAndKeyDataFromCRN(crn)
 	api.Config.InstanceID = instanceID
 	force := d.Get("force_delete").(bool)
 	f := kp.ForceOpt{
@@ -259,10 +248,7 @@ func resourceIBMKeyExists(d *schema.ResourceData, meta interface{}) (bool, error
 		return false, err
 	}
 	crn := d.Id()
-	crnData := strings.Split(crn, ":")
-
-	instanceID := crnData[len(crnData)-3]
-	keyid := crnData[len(crnData)-1]
+	_, instanceID, keyid := getInstanceAndKeyDataFromCRN(crn)
 	api.Config.InstanceID = instanceID
 	// keyid := d.Id()
 	_, err = api.GetKey(context.Background(), keyid)
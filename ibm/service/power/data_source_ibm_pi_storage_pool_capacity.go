@@ -9,6 +9,7 @@ import (
 	"log"
 
 	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -68,7 +69,12 @@ func dataSourceIBMPIStoragePoolCapacityRead(ctx context.Context, d *schema.Resou
 	storagePool := d.Get(Arg_StoragePool).(string)
 
 	client := instance.NewIBMPIStorageCapacityClient(ctx, sess, cloudInstanceID)
-	sp, err := client.GetStoragePoolCapacity(storagePool)
+	var sp *models.StoragePoolCapacity
+	err = retryPITransientError(ctx, "get storage pool capacity", func() error {
+		var err error
+		sp, err = client.GetStoragePoolCapacity(storagePool)
+		return err
+	})
 	if err != nil {
 		log.Printf("[ERROR] get storage pool capacity failed %v", err)
 		return diag.FromErr(err)
@@ -5,9 +5,11 @@ package power
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/hashicorp/go-uuid"
@@ -21,24 +23,67 @@ func DataSourceIBMPIStoragePoolsCapacity() *schema.Resource {
 		ReadContext: dataSourceIBMPIStoragePoolsCapacityRead,
 		Schema: map[string]*schema.Schema{
 			// Arguments
+			Arg_AffinityVolumeID: {
+				Description:  "The ID of the volume whose storage pool the new volumes must be placed in. Sets affinity_compatible on the pools.",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			Arg_AntiAffinityVolumeIDs: {
+				Description: "The IDs of the volumes whose storage pools the new volumes must not be placed in. Sets affinity_compatible on the pools.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Type:        schema.TypeList,
+			},
 			Arg_CloudInstanceID: {
 				Description:  "The GUID of the service instance associated with an account.",
 				Required:     true,
 				Type:         schema.TypeString,
 				ValidateFunc: validation.NoZeroValues,
 			},
+			Arg_IncludeWorkspaceUsage: {
+				Default:     false,
+				Description: "Whether to list the volumes of the workspace and report how much of each storage pool they use. The volumes in pools that are not reported are summed in workspace_other_used_gb.",
+				Optional:    true,
+				Type:        schema.TypeBool,
+			},
 
 			//  Attributes
+			Attr_Region: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the workspace, read from the endpoint of the workspace. Not set when it is unknown.",
+			},
+			Attr_Zone: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone of the workspace, read from the location of the workspace. Not set when it is unknown.",
+			},
 			Attr_MaximumStorageAllocation: {
 				Computed:    true,
 				Description: "Maximum storage allocation.",
 				Type:        schema.TypeMap,
 			},
+			Attr_WorkspaceOtherUsedGB: {
+				Computed:    true,
+				Description: "The size (GB) of the volumes of the workspace in pools that are not reported. Only set when pi_include_workspace_usage is true.",
+				Type:        schema.TypeFloat,
+			},
+			Attr_WorkspaceOtherVolumeCount: {
+				Computed:    true,
+				Description: "The number of volumes of the workspace in pools that are not reported. Only set when pi_include_workspace_usage is true.",
+				Type:        schema.TypeInt,
+			},
 			Attr_StoragePoolsCapacity: {
 				Computed:    true,
 				Description: "List of storage pools capacity.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						Attr_AffinityCompatible: {
+							Computed:    true,
+							Description: "Whether a volume in the pool satisfies the affinity policy of pi_affinity_volume_id and pi_anti_affinity_volume_ids.",
+							Type:        schema.TypeBool,
+						},
 						Attr_MaxAllocationSize: {
 							Computed:    true,
 							Description: "Maximum allocation storage size (GB).",
@@ -64,6 +109,16 @@ func DataSourceIBMPIStoragePoolsCapacity() *schema.Resource {
 							Description: "Total pool capacity (GB).",
 							Type:        schema.TypeInt,
 						},
+						Attr_WorkspaceUsedGB: {
+							Computed:    true,
+							Description: "The size (GB) of the volumes of the workspace in the pool. Only set when pi_include_workspace_usage is true.",
+							Type:        schema.TypeFloat,
+						},
+						Attr_WorkspaceVolumeCount: {
+							Computed:    true,
+							Description: "The number of volumes of the workspace in the pool. Only set when pi_include_workspace_usage is true.",
+							Type:        schema.TypeInt,
+						},
 					},
 				},
 				Type: schema.TypeList,
@@ -81,28 +136,48 @@ func dataSourceIBMPIStoragePoolsCapacityRead(ctx context.Context, d *schema.Reso
 	cloudInstanceID := d.Get(Arg_CloudInstanceID).(string)
 
 	client := instance.NewIBMPIStorageCapacityClient(ctx, sess, cloudInstanceID)
-	spc, err := client.GetAllStoragePoolsCapacity()
+	var spc *models.StoragePoolsCapacity
+	err = retryPITransientError(ctx, "get all storage pools capacity", func() error {
+		var err error
+		spc, err = client.GetAllStoragePoolsCapacity()
+		return err
+	})
 	if err != nil {
 		log.Printf("[ERROR] get all storage pools capacity failed %v", err)
 		return diag.FromErr(err)
 	}
 
+	volumeClient := instance.NewIBMPIVolumeClient(ctx, sess, cloudInstanceID)
+	var affinityVolume *piVolumePlacement
+	if volumeID, ok := d.GetOk(Arg_AffinityVolumeID); ok {
+		affinityVolume, err = getPIVolumePlacement(ctx, volumeClient, volumeID.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] failed to get the affinity volume %s of %s: %w", volumeID, Arg_AffinityVolumeID, err))
+		}
+	}
+	antiAffinityVolumes := []piVolumePlacement{}
+	for _, volumeID := range flex.ExpandStringList(d.Get(Arg_AntiAffinityVolumeIDs).([]interface{})) {
+		volume, err := getPIVolumePlacement(ctx, volumeClient, volumeID)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] failed to get the anti-affinity volume %s of %s: %w", volumeID, Arg_AntiAffinityVolumeIDs, err))
+		}
+		antiAffinityVolumes = append(antiAffinityVolumes, *volume)
+	}
+
 	var genID, _ = uuid.GenerateUUID()
 	d.SetId(genID)
+	setPIWorkspaceLocation(ctx, d, sess, cloudInstanceID)
 
 	if spc.MaximumStorageAllocation != nil {
-		msa := spc.MaximumStorageAllocation
-		data := map[string]interface{}{
-			Attr_MaxAllocationSize: *msa.MaxAllocationSize,
-			Attr_StoragePool:       *msa.StoragePool,
-			Attr_StorageType:       *msa.StorageType,
-		}
-		d.Set(Attr_MaximumStorageAllocation, flex.Flatten(data))
+		d.Set(Attr_MaximumStorageAllocation, flex.Flatten(piMaximumStorageAllocationMap(spc.MaximumStorageAllocation)))
 	}
 
 	result := make([]map[string]interface{}, 0, len(spc.StoragePoolsCapacity))
+	poolNames := make([]string, 0, len(spc.StoragePoolsCapacity))
 	for _, sp := range spc.StoragePoolsCapacity {
+		pool := piStoragePoolPlacement{PoolName: sp.PoolName}
 		data := map[string]interface{}{
+			Attr_AffinityCompatible: piVolumeAffinityCompatible(pool, affinityVolume, antiAffinityVolumes),
 			Attr_MaxAllocationSize:  *sp.MaxAllocationSize,
 			Attr_PoolName:           sp.PoolName,
 			Attr_ReplicationEnabled: *sp.ReplicationEnabled,
@@ -110,8 +185,30 @@ func dataSourceIBMPIStoragePoolsCapacityRead(ctx context.Context, d *schema.Reso
 			Attr_TotalCapacity:      sp.TotalCapacity,
 		}
 		result = append(result, data)
+		poolNames = append(poolNames, sp.PoolName)
+	}
+	if d.Get(Arg_IncludeWorkspaceUsage).(bool) {
+		usage, err := getPIWorkspaceUsageByPool(ctx, volumeClient, poolNames)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("[ERROR] failed to get the volumes of the workspace for %s: %w", Arg_IncludeWorkspaceUsage, err))
+		}
+		setPIWorkspaceUsage(d, result, usage)
 	}
 	d.Set(Attr_StoragePoolsCapacity, result)
 
 	return nil
 }
+
+// Get the storage pool of a volume
+func getPIVolumePlacement(ctx context.Context, volumeClient *instance.IBMPIVolumeClient, volumeID string) (*piVolumePlacement, error) {
+	var volume *models.Volume
+	err := retryPITransientError(ctx, "get volume", func() error {
+		var err error
+		volume, err = volumeClient.Get(volumeID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &piVolumePlacement{VolumeID: volumeID, PoolName: volume.VolumePool}, nil
+}
@@ -6,10 +6,16 @@ package power
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"log"
 
 	st "github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/ibmpisession"
+	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 
@@ -39,11 +45,47 @@ func DataSourceIBMPIStorageTypeCapacity() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 				Description:  "Storage type name",
 			},
+			Arg_IncludeWorkspaceUsage: {
+				Default:     false,
+				Description: "Whether to list the volumes of the workspace and report how much of each storage pool they use. The volumes in pools that are not reported are summed in workspace_other_used_gb.",
+				Optional:    true,
+				Type:        schema.TypeBool,
+			},
 			// Computed Attributes
+			Attr_AsOf: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time (RFC 3339) at which the capacity was read. Capacity values are a point-in-time snapshot.",
+			},
+			Attr_Region: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the workspace, read from the endpoint of the workspace. Not set when it is unknown.",
+			},
+			Attr_Zone: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone of the workspace, read from the location of the workspace. Not set when it is unknown.",
+			},
+			Attr_CapacityJSON: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The capacity of the storage type as a JSON document with the maximum storage allocation and all storage pools. Fields without a value are omitted.",
+			},
 			Attr_MaximumStorageAllocation: {
 				Type:        schema.TypeMap,
 				Computed:    true,
-				Description: "Maximum storage allocation",
+				Description: "Maximum storage allocation. The max_allocation_size value is an integer number of GB.",
+			},
+			Attr_WorkspaceOtherUsedGB: {
+				Computed:    true,
+				Description: "The size (GB) of the volumes of the workspace in pools that are not reported. Only set when pi_include_workspace_usage is true.",
+				Type:        schema.TypeFloat,
+			},
+			Attr_WorkspaceOtherVolumeCount: {
+				Computed:    true,
+				Description: "The number of volumes of the workspace in pools that are not reported. Only set when pi_include_workspace_usage is true.",
+				Type:        schema.TypeInt,
 			},
 			Attr_StoragePoolsCapacity: {
 				Type:        schema.TypeList,
@@ -71,6 +113,16 @@ func DataSourceIBMPIStorageTypeCapacity() *schema.Resource {
 							Computed:    true,
 							Description: "Total pool capacity (GB)",
 						},
+						Attr_WorkspaceUsedGB: {
+							Computed:    true,
+							Description: "The size (GB) of the volumes of the workspace in the pool. Only set when pi_include_workspace_usage is true.",
+							Type:        schema.TypeFloat,
+						},
+						Attr_WorkspaceVolumeCount: {
+							Computed:    true,
+							Description: "The number of volumes of the workspace in the pool. Only set when pi_include_workspace_usage is true.",
+							Type:        schema.TypeInt,
+						},
 					},
 				},
 			},
@@ -88,25 +140,27 @@ func dataSourceIBMPIStorageTypeCapacityRead(ctx context.Context, d *schema.Resou
 	storageType := d.Get(PITypeName).(string)
 
 	client := st.NewIBMPIStorageCapacityClient(ctx, sess, cloudInstanceID)
-	stc, err := client.GetStorageTypeCapacity(storageType)
+	var stc *models.StorageTypeCapacity
+	err = retryPITransientError(ctx, "get storage type capacity", func() error {
+		var err error
+		stc, err = client.GetStorageTypeCapacity(storageType)
+		return err
+	})
 	if err != nil {
 		log.Printf("[ERROR] get storage type capacity failed %v", err)
 		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", cloudInstanceID, storageType))
+	setPIWorkspaceLocation(ctx, d, sess, cloudInstanceID)
+	d.Set(Attr_AsOf, time.Now().UTC().Format(time.RFC3339))
 
 	if stc.MaximumStorageAllocation != nil {
-		msa := stc.MaximumStorageAllocation
-		data := map[string]interface{}{
-			Attr_MaxAllocationSize: *msa.MaxAllocationSize,
-			Attr_StoragePool:       *msa.StoragePool,
-			Attr_StorageType:       *msa.StorageType,
-		}
-		d.Set(Attr_MaximumStorageAllocation, flex.Flatten(data))
+		d.Set(Attr_MaximumStorageAllocation, flex.Flatten(piMaximumStorageAllocationMap(stc.MaximumStorageAllocation)))
 	}
 
 	result := make([]map[string]interface{}, 0, len(stc.StoragePoolsCapacity))
+	poolNames := make([]string, 0, len(stc.StoragePoolsCapacity))
 	for _, sp := range stc.StoragePoolsCapacity {
 		data := map[string]interface{}{
 			Attr_MaxAllocationSize: *sp.MaxAllocationSize,
@@ -115,8 +169,71 @@ func dataSourceIBMPIStorageTypeCapacityRead(ctx context.Context, d *schema.Resou
 			Attr_TotalCapacity:     sp.TotalCapacity,
 		}
 		result = append(result, data)
+		poolNames = append(poolNames, sp.PoolName)
+	}
+	if d.Get(Arg_IncludeWorkspaceUsage).(bool) {
+		volumeClient := st.NewIBMPIVolumeClient(ctx, sess, cloudInstanceID)
+		usage, err := getPIWorkspaceUsageByPool(ctx, volumeClient, poolNames)
+		if err != nil {
+			return diag.Errorf("[ERROR] failed to get the volumes of the workspace for %s: %v", Arg_IncludeWorkspaceUsage, err)
+		}
+		setPIWorkspaceUsage(d, result, usage)
 	}
 	d.Set(Attr_StoragePoolsCapacity, result)
 
+	capacityJSON, err := piStorageTypeCapacityJSONString(stc)
+	if err != nil {
+		return diag.Errorf("error encoding capacity_json: %v", err)
+	}
+	d.Set(Attr_CapacityJSON, capacityJSON)
+
 	return nil
 }
+
+// piWorkspaceLocation returns the region and the zone of the workspace, which are empty when they are unknown. The
+// zone is the data center of the workspace location, and the region is the region of the endpoint of the workspace,
+// us-south for https://us-south.power-iaas.cloud.ibm.com.
+func piWorkspaceLocation(ws *models.Workspace) (string, string) {
+	if ws == nil || ws.Location == nil {
+		return "", ""
+	}
+	region := ""
+	if endpoint, err := url.Parse(ws.Location.URL); err == nil {
+		host := strings.TrimPrefix(endpoint.Hostname(), "private.")
+		if i := strings.Index(host, ".power-iaas."); i > 0 {
+			region = host[:i]
+		}
+	}
+	zone := ""
+	if ws.Location.Region != nil {
+		zone = *ws.Location.Region
+	}
+	return region, zone
+}
+
+// setPIWorkspaceLocation sets the region and zone attributes from the workspace, leaving the unknown ones unset. The
+// capacity is read without them when the workspace cannot be read.
+func setPIWorkspaceLocation(ctx context.Context, d *schema.ResourceData, sess *ibmpisession.IBMPISession, cloudInstanceID string) {
+	ws, err := st.NewIBMPIWorkspacesClient(ctx, sess, cloudInstanceID).Get(cloudInstanceID)
+	if err != nil {
+		log.Printf("[WARN] get workspace %s failed, region and zone are not set: %v", cloudInstanceID, err)
+		return
+	}
+	region, zone := piWorkspaceLocation(ws)
+	if region != "" {
+		d.Set(Attr_Region, region)
+	}
+	if zone != "" {
+		d.Set(Attr_Zone, zone)
+	}
+}
+
+// piMaximumStorageAllocationMap returns the maximum storage allocation for the map attribute, with max_allocation_size
+// formatted as an integer number of GB.
+func piMaximumStorageAllocationMap(msa *models.MaximumStorageAllocation) map[string]interface{} {
+	return map[string]interface{}{
+		Attr_MaxAllocationSize: strconv.FormatInt(*msa.MaxAllocationSize, 10),
+		Attr_StoragePool:       *msa.StoragePool,
+		Attr_StorageType:       *msa.StorageType,
+	}
+}
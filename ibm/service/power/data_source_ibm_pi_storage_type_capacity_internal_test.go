@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPIWorkspaceLocation(t *testing.T) {
+	testcases := []struct {
+		name           string
+		ws             *models.Workspace
+		expectedRegion string
+		expectedZone   string
+	}{
+		{
+			name:           "region and zone",
+			ws:             &models.Workspace{Location: &models.Location{Region: flex.PtrToString("dal12"), Type: "data-center", URL: "https://us-south.power-iaas.cloud.ibm.com"}},
+			expectedRegion: "us-south",
+			expectedZone:   "dal12",
+		},
+		{
+			name:           "private endpoint",
+			ws:             &models.Workspace{Location: &models.Location{Region: flex.PtrToString("fra04"), URL: "https://private.eu-de.power-iaas.cloud.ibm.com"}},
+			expectedRegion: "eu-de",
+			expectedZone:   "fra04",
+		},
+		{
+			name:         "unknown endpoint",
+			ws:           &models.Workspace{Location: &models.Location{Region: flex.PtrToString("dal12"), URL: "https://power.example.com"}},
+			expectedZone: "dal12",
+		},
+		{
+			name: "no location",
+			ws:   &models.Workspace{},
+		},
+		{
+			name: "no workspace",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			region, zone := piWorkspaceLocation(tc.ws)
+			assert.Equal(t, tc.expectedRegion, region)
+			assert.Equal(t, tc.expectedZone, zone)
+		})
+	}
+}
+
+func TestPIMaximumStorageAllocationMap(t *testing.T) {
+	msa := testPIStorageTypeCapacity().MaximumStorageAllocation
+	*msa.MaxAllocationSize = 1048576
+
+	assert.Equal(t, map[string]string{
+		Attr_MaxAllocationSize: "1048576",
+		Attr_StoragePool:       "Tier1-Flash-2",
+		Attr_StorageType:       "tier1",
+	}, map[string]string(flex.Flatten(piMaximumStorageAllocationMap(msa))))
+}
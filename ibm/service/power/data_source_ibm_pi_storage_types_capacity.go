@@ -9,6 +9,7 @@ import (
 	"log"
 
 	st "github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/power/models"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 
@@ -34,6 +35,21 @@ func DataSourceIBMPIStorageTypesCapacity() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 			},
 			// Computed Attributes
+			Attr_Region: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the workspace, read from the endpoint of the workspace. Not set when it is unknown.",
+			},
+			Attr_Zone: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone of the workspace, read from the location of the workspace. Not set when it is unknown.",
+			},
+			Attr_CapacityJSON: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The capacity of all storage types as a JSON document with the maximum storage allocation and all storage types with their storage pools. Fields without a value are omitted.",
+			},
 			Attr_MaximumStorageAllocation: {
 				Type:        schema.TypeMap,
 				Computed:    true,
@@ -79,6 +95,16 @@ func DataSourceIBMPIStorageTypesCapacity() *schema.Resource {
 								},
 							},
 						},
+						Attr_ProvisioningEnabled: {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether volumes can be provisioned with the storage type, inferred from the capacity of its storage pools",
+						},
+						Attr_StatusReason: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The reason why volumes cannot be provisioned with the storage type, empty when provisioning is enabled",
+						},
 						Attr_StorageType: {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -100,7 +126,12 @@ func dataSourceIBMPIStorageTypesCapacityRead(ctx context.Context, d *schema.Reso
 	cloudInstanceID := d.Get(helpers.PICloudInstanceId).(string)
 
 	client := st.NewIBMPIStorageCapacityClient(ctx, sess, cloudInstanceID)
-	stc, err := client.GetAllStorageTypesCapacity()
+	var stc *models.StorageTypesCapacity
+	err = retryPITransientError(ctx, "get all storage types capacity", func() error {
+		var err error
+		stc, err = client.GetAllStorageTypesCapacity()
+		return err
+	})
 	if err != nil {
 		log.Printf("[ERROR] get all storage types capacity failed %v", err)
 		return diag.FromErr(err)
@@ -108,6 +139,7 @@ func dataSourceIBMPIStorageTypesCapacityRead(ctx context.Context, d *schema.Reso
 
 	var genID, _ = uuid.GenerateUUID()
 	d.SetId(genID)
+	setPIWorkspaceLocation(ctx, d, sess, cloudInstanceID)
 
 	if stc.MaximumStorageAllocation != nil {
 		msa := stc.MaximumStorageAllocation
@@ -142,10 +174,39 @@ func dataSourceIBMPIStorageTypesCapacityRead(ctx context.Context, d *schema.Reso
 		}
 		stResult[Attr_StoragePoolsCapacity] = spc
 		stResult[Attr_StorageType] = st.StorageType
+		provisioningEnabled, statusReason := piStorageTypeProvisioningStatus(st)
+		stResult[Attr_ProvisioningEnabled] = provisioningEnabled
+		stResult[Attr_StatusReason] = statusReason
 		stcResult = append(stcResult, stResult)
 	}
 
 	d.Set(StorageTypesCapacity, stcResult)
 
+	capacityJSON, err := piStorageTypesCapacityJSONString(stc)
+	if err != nil {
+		return diag.Errorf("error encoding capacity_json: %v", err)
+	}
+	d.Set(Attr_CapacityJSON, capacityJSON)
+
 	return nil
 }
+
+// piStorageTypeProvisioningStatus infers whether volumes can be provisioned with a storage type and, when they
+// cannot, why. The API does not report whether a storage type is closed for provisioning, so a storage type is
+// considered enabled as soon as its maximum storage allocation or one of its storage pools reports a maximum
+// allocation size greater than zero. A storage type without pools is reported as disabled and a storage type
+// whose pools all report no available capacity as full; the two cannot be told apart further.
+func piStorageTypeProvisioningStatus(st *models.StorageTypeCapacity) (bool, string) {
+	if st.MaximumStorageAllocation != nil && st.MaximumStorageAllocation.MaxAllocationSize != nil && *st.MaximumStorageAllocation.MaxAllocationSize > 0 {
+		return true, ""
+	}
+	if len(st.StoragePoolsCapacity) == 0 {
+		return false, "no storage pool is reported for the storage type, it may be disabled for provisioning"
+	}
+	for _, sp := range st.StoragePoolsCapacity {
+		if sp.MaxAllocationSize != nil && *sp.MaxAllocationSize > 0 {
+			return true, ""
+		}
+	}
+	return false, "no storage pool of the storage type reports available capacity, it may be full"
+}
@@ -0,0 +1,68 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPIStorageTypeProvisioningStatus(t *testing.T) {
+	size := func(gb int64) *int64 { return &gb }
+
+	testcases := []struct {
+		name            string
+		storageType     *models.StorageTypeCapacity
+		expectedEnabled bool
+		expectedReason  string
+	}{
+		{
+			name: "maximum storage allocation",
+			storageType: &models.StorageTypeCapacity{
+				MaximumStorageAllocation: &models.MaximumStorageAllocation{MaxAllocationSize: size(2048)},
+				StorageType:              "tier1",
+			},
+			expectedEnabled: true,
+		},
+		{
+			name: "one pool with capacity",
+			storageType: &models.StorageTypeCapacity{
+				StoragePoolsCapacity: []*models.StoragePoolCapacity{
+					{MaxAllocationSize: size(0), PoolName: "Tier1-Flash-1"},
+					{MaxAllocationSize: size(512), PoolName: "Tier1-Flash-2"},
+				},
+				StorageType: "tier1",
+			},
+			expectedEnabled: true,
+		},
+		{
+			name: "all pools full",
+			storageType: &models.StorageTypeCapacity{
+				MaximumStorageAllocation: &models.MaximumStorageAllocation{MaxAllocationSize: size(0)},
+				StoragePoolsCapacity: []*models.StoragePoolCapacity{
+					{MaxAllocationSize: size(0), PoolName: "Tier3-Flash-1"},
+					{PoolName: "Tier3-Flash-2"},
+				},
+				StorageType: "tier3",
+			},
+			expectedEnabled: false,
+			expectedReason:  "no storage pool of the storage type reports available capacity, it may be full",
+		},
+		{
+			name:            "no pools",
+			storageType:     &models.StorageTypeCapacity{StorageType: "tier5k"},
+			expectedEnabled: false,
+			expectedReason:  "no storage pool is reported for the storage type, it may be disabled for provisioning",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			enabled, reason := piStorageTypeProvisioningStatus(tc.storageType)
+			assert.Equal(t, tc.expectedEnabled, enabled)
+			assert.Equal(t, tc.expectedReason, reason)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"encoding/json"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+)
+
+// The documents of the capacity_json attributes. They are marshaled from these types rather than from the models of
+// the Power client so that their field names do not change when the client is upgraded. Keys are written in the order
+// of the fields and nil values are omitted.
+type piMaximumStorageAllocationJSON struct {
+	MaxAllocationSize *int64  `json:"max_allocation_size,omitempty"`
+	StoragePool       *string `json:"storage_pool,omitempty"`
+	StorageType       *string `json:"storage_type,omitempty"`
+}
+
+type piStoragePoolCapacityJSON struct {
+	MaxAllocationSize *int64 `json:"max_allocation_size,omitempty"`
+	PoolName          string `json:"pool_name"`
+	StorageType       string `json:"storage_type"`
+	TotalCapacity     int64  `json:"total_capacity"`
+}
+
+type piStorageTypeCapacityJSON struct {
+	StorageType              string                          `json:"storage_type"`
+	MaximumStorageAllocation *piMaximumStorageAllocationJSON `json:"maximum_storage_allocation,omitempty"`
+	StoragePoolsCapacity     []piStoragePoolCapacityJSON     `json:"storage_pools_capacity"`
+}
+
+type piStorageTypesCapacityJSON struct {
+	MaximumStorageAllocation *piMaximumStorageAllocationJSON `json:"maximum_storage_allocation,omitempty"`
+	StorageTypesCapacity     []piStorageTypeCapacityJSON     `json:"storage_types_capacity"`
+}
+
+// piStorageTypeCapacityJSONString returns the capacity_json document of the capacity of a storage type.
+func piStorageTypeCapacityJSONString(stc *models.StorageTypeCapacity) (string, error) {
+	return piMarshalCapacityJSON(newPIStorageTypeCapacityJSON(stc))
+}
+
+// piStorageTypesCapacityJSONString returns the capacity_json document of the capacity of all storage types.
+func piStorageTypesCapacityJSONString(stc *models.StorageTypesCapacity) (string, error) {
+	doc := piStorageTypesCapacityJSON{
+		MaximumStorageAllocation: newPIMaximumStorageAllocationJSON(stc.MaximumStorageAllocation),
+		StorageTypesCapacity:     make([]piStorageTypeCapacityJSON, 0, len(stc.StorageTypesCapacity)),
+	}
+	for _, st := range stc.StorageTypesCapacity {
+		if st != nil {
+			doc.StorageTypesCapacity = append(doc.StorageTypesCapacity, newPIStorageTypeCapacityJSON(st))
+		}
+	}
+	return piMarshalCapacityJSON(doc)
+}
+
+func piMarshalCapacityJSON(doc interface{}) (string, error) {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func newPIStorageTypeCapacityJSON(stc *models.StorageTypeCapacity) piStorageTypeCapacityJSON {
+	doc := piStorageTypeCapacityJSON{
+		StorageType:              stc.StorageType,
+		MaximumStorageAllocation: newPIMaximumStorageAllocationJSON(stc.MaximumStorageAllocation),
+		StoragePoolsCapacity:     make([]piStoragePoolCapacityJSON, 0, len(stc.StoragePoolsCapacity)),
+	}
+	for _, sp := range stc.StoragePoolsCapacity {
+		if sp == nil {
+			continue
+		}
+		doc.StoragePoolsCapacity = append(doc.StoragePoolsCapacity, piStoragePoolCapacityJSON{
+			MaxAllocationSize: sp.MaxAllocationSize,
+			PoolName:          sp.PoolName,
+			StorageType:       sp.StorageType,
+			TotalCapacity:     sp.TotalCapacity,
+		})
+	}
+	return doc
+}
+
+func newPIMaximumStorageAllocationJSON(msa *models.MaximumStorageAllocation) *piMaximumStorageAllocationJSON {
+	if msa == nil {
+		return nil
+	}
+	return &piMaximumStorageAllocationJSON{
+		MaxAllocationSize: msa.MaxAllocationSize,
+		StoragePool:       msa.StoragePool,
+		StorageType:       msa.StorageType,
+	}
+}
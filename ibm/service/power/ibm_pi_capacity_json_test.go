@@ -0,0 +1,64 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPICapacityGolden(t *testing.T, name string) string {
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading golden file %s: %v", name, err)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func testPIStorageTypeCapacity() *models.StorageTypeCapacity {
+	size := func(gb int64) *int64 { return &gb }
+	str := func(s string) *string { return &s }
+
+	return &models.StorageTypeCapacity{
+		MaximumStorageAllocation: &models.MaximumStorageAllocation{
+			MaxAllocationSize: size(2048),
+			StoragePool:       str("Tier1-Flash-2"),
+			StorageType:       str("tier1"),
+		},
+		StoragePoolsCapacity: []*models.StoragePoolCapacity{
+			{MaxAllocationSize: size(1024), PoolName: "Tier1-Flash-1", StorageType: "tier1", TotalCapacity: 40960},
+			{MaxAllocationSize: size(2048), PoolName: "Tier1-Flash-2", StorageType: "tier1", TotalCapacity: 81920},
+			{PoolName: "Tier1-Flash-3", StorageType: "tier1", TotalCapacity: 20480},
+		},
+		StorageType: "tier1",
+	}
+}
+
+func TestPIStorageTypeCapacityJSONString(t *testing.T) {
+	capacityJSON, err := piStorageTypeCapacityJSONString(testPIStorageTypeCapacity())
+	assert.NoError(t, err)
+	assert.Equal(t, testPICapacityGolden(t, "storage_type_capacity.golden.json"), capacityJSON)
+}
+
+func TestPIStorageTypesCapacityJSONString(t *testing.T) {
+	size := func(gb int64) *int64 { return &gb }
+
+	stc := &models.StorageTypesCapacity{
+		StorageTypesCapacity: []*models.StorageTypeCapacity{
+			testPIStorageTypeCapacity(),
+			{
+				MaximumStorageAllocation: &models.MaximumStorageAllocation{MaxAllocationSize: size(0)},
+				StorageType:              "tier5k",
+			},
+		},
+	}
+	capacityJSON, err := piStorageTypesCapacityJSONString(stc)
+	assert.NoError(t, err)
+	assert.Equal(t, testPICapacityGolden(t, "storage_types_capacity.golden.json"), capacityJSON)
+}
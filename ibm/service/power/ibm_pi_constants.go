@@ -4,6 +4,8 @@ import "time"
 
 const (
 	// Arguments
+	Arg_AffinityVolumeID                    = "pi_affinity_volume_id"
+	Arg_AntiAffinityVolumeIDs               = "pi_anti_affinity_volume_ids"
 	Arg_CloudConnectionName                 = "pi_cloud_connection_name"
 	Arg_CloudInstanceID                     = "pi_cloud_instance_id"
 	Arg_DatacenterZone                      = "pi_datacenter_zone"
@@ -17,6 +19,7 @@ const (
 	Arg_IBMiPHA                             = "pi_ibmi_pha"
 	Arg_IBMiRDSUsers                        = "pi_ibmi_rds_users"
 	Arg_ImageName                           = "pi_image_name"
+	Arg_IncludeWorkspaceUsage               = "pi_include_workspace_usage"
 	Arg_InstanceName                        = "pi_instance_name"
 	Arg_KeyName                             = "pi_key_name"
 	Arg_NetworkName                         = "pi_network_name"
@@ -49,8 +52,10 @@ const (
 	Attr_AccessConfig                                = "access_config"
 	Attr_Action                                      = "action"
 	Attr_Addresses                                   = "addresses"
+	Attr_AffinityCompatible                          = "affinity_compatible"
 	Attr_AllocatedCores                              = "allocated_cores"
 	Attr_Architecture                                = "architecture"
+	Attr_AsOf                                        = "as_of"
 	Attr_Auxiliary                                   = "auxiliary"
 	Attr_AuxiliaryChangedVolumeName                  = "auxiliary_changed_volume_name"
 	Attr_AuxiliaryVolumeName                         = "auxiliary_volume_name"
@@ -61,6 +66,7 @@ const (
 	Attr_BootVolumeID                                = "boot_volume_id"
 	Attr_Capabilities                                = "capabilities"
 	Attr_Capacity                                    = "capacity"
+	Attr_CapacityJSON                                = "capacity_json"
 	Attr_Certified                                   = "certified"
 	Attr_CIDR                                        = "cidr"
 	Attr_ClassicEnabled                              = "classic_enabled"
@@ -202,6 +208,7 @@ const (
 	Attr_ProfileID                                   = "profile_id"
 	Attr_Profiles                                    = "profiles"
 	Attr_Progress                                    = "progress"
+	Attr_ProvisioningEnabled                         = "provisioning_enabled"
 	Attr_PublicIP                                    = "public_ip"
 	Attr_PVMInstanceID                               = "pvm_instance_id"
 	Attr_PVMInstances                                = "pvm_instances"
@@ -254,6 +261,7 @@ const (
 	Attr_Status                                      = "status"
 	Attr_StatusDescriptionErrors                     = "status_description_errors"
 	Attr_StatusDetail                                = "status_detail"
+	Attr_StatusReason                                = "status_reason"
 	Attr_StoragePool                                 = "storage_pool"
 	Attr_StoragePoolAffinity                         = "storage_pool_affinity"
 	Attr_StoragePoolsCapacity                        = "storage_pools_capacity"
@@ -295,10 +303,15 @@ const (
 	Attr_WorkspaceID                                 = "pi_workspace_id"
 	Attr_WorkspaceLocation                           = "pi_workspace_location"
 	Attr_WorkspaceName                               = "pi_workspace_name"
+	Attr_WorkspaceOtherUsedGB                        = "workspace_other_used_gb"
+	Attr_WorkspaceOtherVolumeCount                   = "workspace_other_volume_count"
 	Attr_Workspaces                                  = "workspaces"
 	Attr_WorkspaceStatus                             = "pi_workspace_status"
 	Attr_WorkspaceType                               = "pi_workspace_type"
+	Attr_WorkspaceUsedGB                             = "workspace_used_gb"
+	Attr_WorkspaceVolumeCount                        = "workspace_volume_count"
 	Attr_WWN                                         = "wwn"
+	Attr_Zone                                        = "zone"
 	OS_IBMI                                          = "ibmi"
 
 	// TODO: Second Half Cleanup, remove extra variables
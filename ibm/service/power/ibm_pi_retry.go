@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"syscall"
+	"time"
+)
+
+// Retry settings for the transient failures of the Power API.
+const piRetryMaxAttempts = 4
+
+// piRetryInitialDelay is the delay before the first retry, doubled before each following retry.
+var piRetryInitialDelay = 2 * time.Second
+
+// retryPITransientError calls fn until it succeeds, fails with an error that is not transient or
+// piRetryMaxAttempts calls are made. Server errors, connection resets and temporary DNS failures are
+// retried with an exponential backoff, client errors are returned immediately.
+func retryPITransientError(ctx context.Context, operation string, fn func() error) error {
+	delay := piRetryInitialDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == piRetryMaxAttempts || !isPITransientError(err) {
+			return err
+		}
+		log.Printf("[WARN] %s failed on attempt %d of %d, retrying in %s: %v", operation, attempt, piRetryMaxAttempts, delay, err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = delay * 2
+	}
+}
+
+// isPITransientError reports whether a Power API call that failed with err can succeed when it is retried.
+func isPITransientError(err error) bool {
+	var clientError interface{ IsClientError() bool }
+	if errors.As(err, &clientError) && clientError.IsClientError() {
+		return false
+	}
+	var serverError interface{ IsServerError() bool }
+	if errors.As(err, &serverError) && serverError.IsServerError() {
+		return true
+	}
+	var codeError interface{ Code() int }
+	if errors.As(err, &codeError) {
+		return codeError.Code() >= 500
+	}
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return dnsError.IsTemporary || dnsError.IsTimeout
+	}
+	return false
+}
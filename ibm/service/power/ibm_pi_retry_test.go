@@ -0,0 +1,110 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testPIAPIError mimics the errors of the generated Power API client.
+type testPIAPIError struct {
+	code int
+}
+
+func (e *testPIAPIError) Error() string       { return fmt.Sprintf("[%d] api error", e.code) }
+func (e *testPIAPIError) Code() int           { return e.code }
+func (e *testPIAPIError) IsClientError() bool { return e.code >= 400 && e.code < 500 }
+func (e *testPIAPIError) IsServerError() bool { return e.code >= 500 && e.code < 600 }
+
+// testPIStorageCapacityClient fails with the given errors before it succeeds.
+type testPIStorageCapacityClient struct {
+	failures []error
+	calls    int
+}
+
+func (c *testPIStorageCapacityClient) GetStorageTypeCapacity(storageType string) (string, error) {
+	c.calls++
+	if c.calls <= len(c.failures) {
+		return "", fmt.Errorf("failed to Get Storage Type Capacity %s: %w", storageType, c.failures[c.calls-1])
+	}
+	return storageType, nil
+}
+
+func testPIRetry(t *testing.T, ctx context.Context, client *testPIStorageCapacityClient) (string, error) {
+	initialDelay := piRetryInitialDelay
+	piRetryInitialDelay = time.Millisecond
+	t.Cleanup(func() { piRetryInitialDelay = initialDelay })
+
+	var capacity string
+	err := retryPITransientError(ctx, "get storage type capacity", func() error {
+		var err error
+		capacity, err = client.GetStorageTypeCapacity("tier3")
+		return err
+	})
+	return capacity, err
+}
+
+func TestRetryPITransientErrorSucceedsAfterTransientFailures(t *testing.T) {
+	client := &testPIStorageCapacityClient{failures: []error{
+		&testPIAPIError{code: 503},
+		syscall.ECONNRESET,
+		&net.DNSError{Err: "server misbehaving", Name: "eu-de.power-iaas.cloud.ibm.com", IsTemporary: true},
+	}}
+
+	capacity, err := testPIRetry(t, context.Background(), client)
+	assert.Nil(t, err)
+	assert.Equal(t, "tier3", capacity)
+	assert.Equal(t, 4, client.calls)
+}
+
+func TestRetryPITransientErrorStopsAfterMaxAttempts(t *testing.T) {
+	client := &testPIStorageCapacityClient{failures: []error{
+		&testPIAPIError{code: 503},
+		&testPIAPIError{code: 502},
+		&testPIAPIError{code: 500},
+		&testPIAPIError{code: 504},
+		&testPIAPIError{code: 503},
+	}}
+
+	_, err := testPIRetry(t, context.Background(), client)
+	var apiError *testPIAPIError
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, 504, apiError.code)
+	assert.Equal(t, piRetryMaxAttempts, client.calls)
+}
+
+func TestRetryPITransientErrorDoesNotRetryClientErrors(t *testing.T) {
+	client := &testPIStorageCapacityClient{failures: []error{&testPIAPIError{code: 404}}}
+
+	_, err := testPIRetry(t, context.Background(), client)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestRetryPITransientErrorRespectsContextCancellation(t *testing.T) {
+	client := &testPIStorageCapacityClient{failures: []error{&testPIAPIError{code: 503}, &testPIAPIError{code: 503}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := testPIRetry(t, ctx, client)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestIsPITransientError(t *testing.T) {
+	assert.True(t, isPITransientError(&testPIAPIError{code: 500}))
+	assert.False(t, isPITransientError(&testPIAPIError{code: 400}))
+	assert.True(t, isPITransientError(fmt.Errorf("wrapped: %w", syscall.ECONNRESET)))
+	assert.True(t, isPITransientError(&net.DNSError{IsTimeout: true}))
+	assert.False(t, isPITransientError(&net.DNSError{IsNotFound: true}))
+	assert.False(t, isPITransientError(errors.New("invalid storage type")))
+}
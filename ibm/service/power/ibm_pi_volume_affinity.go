@@ -0,0 +1,30 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+// piStoragePoolPlacement is a storage pool where a volume can be placed
+type piStoragePoolPlacement struct {
+	PoolName string
+}
+
+// piVolumePlacement is the storage pool of an existing volume
+type piVolumePlacement struct {
+	VolumeID string
+	PoolName string
+}
+
+// piVolumeAffinityCompatible returns whether a volume that is placed in the pool satisfies the affinity policy of the
+// volumes: it must be in the pool of the affinity volume, when there is one, and in another pool than each of the
+// anti-affinity volumes. Every pool is compatible when there is neither an affinity nor an anti-affinity volume.
+func piVolumeAffinityCompatible(pool piStoragePoolPlacement, affinityVolume *piVolumePlacement, antiAffinityVolumes []piVolumePlacement) bool {
+	if affinityVolume != nil && affinityVolume.PoolName != pool.PoolName {
+		return false
+	}
+	for _, volume := range antiAffinityVolumes {
+		if volume.PoolName == pool.PoolName {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPIVolumeAffinityCompatible(t *testing.T) {
+	tier1 := piStoragePoolPlacement{PoolName: "Tier1-Flash-1"}
+	tier3 := piStoragePoolPlacement{PoolName: "Tier3-Flash-2"}
+	anchor := &piVolumePlacement{VolumeID: "a1b2c3", PoolName: "Tier1-Flash-1"}
+
+	testcases := []struct {
+		name                string
+		pool                piStoragePoolPlacement
+		affinityVolume      *piVolumePlacement
+		antiAffinityVolumes []piVolumePlacement
+		compatible          bool
+	}{
+		{
+			name:       "no policy",
+			pool:       tier3,
+			compatible: true,
+		},
+		{
+			name:           "pool of the affinity volume",
+			pool:           tier1,
+			affinityVolume: anchor,
+			compatible:     true,
+		},
+		{
+			name:           "other pool than the affinity volume",
+			pool:           tier3,
+			affinityVolume: anchor,
+		},
+		{
+			name:                "pool of an anti-affinity volume",
+			pool:                tier1,
+			antiAffinityVolumes: []piVolumePlacement{{VolumeID: "d4e5f6", PoolName: "Tier3-Flash-2"}, *anchor},
+		},
+		{
+			name:                "other pool than the anti-affinity volumes",
+			pool:                tier3,
+			antiAffinityVolumes: []piVolumePlacement{*anchor},
+			compatible:          true,
+		},
+		{
+			name:                "affinity and anti-affinity volumes in the same pool",
+			pool:                tier1,
+			affinityVolume:      anchor,
+			antiAffinityVolumes: []piVolumePlacement{{VolumeID: "g7h8i9", PoolName: "Tier1-Flash-1"}},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.compatible, piVolumeAffinityCompatible(tc.pool, tc.affinityVolume, tc.antiAffinityVolumes))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"context"
+
+	"github.com/IBM-Cloud/power-go-client/clients/instance"
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// piPoolWorkspaceUsage is the storage that the volumes of the workspace use in a pool
+type piPoolWorkspaceUsage struct {
+	UsedGB      float64
+	VolumeCount int
+}
+
+// piWorkspaceUsage is the storage that the volumes of the workspace use, per pool of the capacity response. The
+// volumes whose pool is not in the capacity response, such as the pools of another storage type or the pools that no
+// longer report capacity, are summed in Other, apart from the pools so that no pool name can collide with them.
+type piWorkspaceUsage struct {
+	Pools map[string]piPoolWorkspaceUsage
+	Other piPoolWorkspaceUsage
+}
+
+// piWorkspaceUsageByPool sums the sizes of the volumes per pool. Each of the pool names has an entry, zero when the
+// workspace has no volume in the pool, and the volumes in other pools are summed in Other.
+func piWorkspaceUsageByPool(volumes []*models.VolumeReference, poolNames []string) piWorkspaceUsage {
+	usage := piWorkspaceUsage{Pools: make(map[string]piPoolWorkspaceUsage, len(poolNames))}
+	for _, poolName := range poolNames {
+		usage.Pools[poolName] = piPoolWorkspaceUsage{}
+	}
+	for _, volume := range volumes {
+		if volume == nil {
+			continue
+		}
+		poolUsage, ok := usage.Pools[volume.VolumePool]
+		if !ok {
+			poolUsage = usage.Other
+		}
+		if volume.Size != nil {
+			poolUsage.UsedGB += *volume.Size
+		}
+		poolUsage.VolumeCount++
+		if ok {
+			usage.Pools[volume.VolumePool] = poolUsage
+		} else {
+			usage.Other = poolUsage
+		}
+	}
+	return usage
+}
+
+// getPIWorkspaceUsageByPool lists the volumes of the workspace and sums their sizes per pool. The volumes are returned
+// in a single page, the volumes API does not paginate them.
+func getPIWorkspaceUsageByPool(ctx context.Context, volumeClient *instance.IBMPIVolumeClient, poolNames []string) (piWorkspaceUsage, error) {
+	var volumes *models.Volumes
+	err := retryPITransientError(ctx, "get all volumes", func() error {
+		var err error
+		volumes, err = volumeClient.GetAll()
+		return err
+	})
+	if err != nil {
+		return piWorkspaceUsage{}, err
+	}
+	return piWorkspaceUsageByPool(volumes.Volumes, poolNames), nil
+}
+
+// setPIWorkspaceUsage adds the usage of its pool to each flattened pool capacity, and sets the usage of the other pools
+func setPIWorkspaceUsage(d *schema.ResourceData, pools []map[string]interface{}, usage piWorkspaceUsage) {
+	for _, pool := range pools {
+		poolUsage := usage.Pools[pool[Attr_PoolName].(string)]
+		pool[Attr_WorkspaceUsedGB] = poolUsage.UsedGB
+		pool[Attr_WorkspaceVolumeCount] = poolUsage.VolumeCount
+	}
+	d.Set(Attr_WorkspaceOtherUsedGB, usage.Other.UsedGB)
+	d.Set(Attr_WorkspaceOtherVolumeCount, usage.Other.VolumeCount)
+}
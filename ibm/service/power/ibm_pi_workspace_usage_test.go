@@ -0,0 +1,105 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package power
+
+import (
+	"testing"
+
+	"github.com/IBM-Cloud/power-go-client/power/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPIWorkspaceUsageByPool(t *testing.T) {
+	volume := func(pool string, size float64) *models.VolumeReference {
+		return &models.VolumeReference{VolumePool: pool, Size: &size}
+	}
+
+	testcases := []struct {
+		name      string
+		volumes   []*models.VolumeReference
+		poolNames []string
+		expected  piWorkspaceUsage
+	}{
+		{
+			name:      "no volumes",
+			poolNames: []string{"Tier1-Flash-1"},
+			expected: piWorkspaceUsage{
+				Pools: map[string]piPoolWorkspaceUsage{"Tier1-Flash-1": {}},
+			},
+		},
+		{
+			name: "volumes in the pools",
+			volumes: []*models.VolumeReference{
+				volume("Tier1-Flash-1", 100),
+				volume("Tier1-Flash-1", 20.5),
+				volume("Tier1-Flash-2", 10),
+			},
+			poolNames: []string{"Tier1-Flash-1", "Tier1-Flash-2", "Tier1-Flash-3"},
+			expected: piWorkspaceUsage{
+				Pools: map[string]piPoolWorkspaceUsage{
+					"Tier1-Flash-1": {UsedGB: 120.5, VolumeCount: 2},
+					"Tier1-Flash-2": {UsedGB: 10, VolumeCount: 1},
+					"Tier1-Flash-3": {},
+				},
+			},
+		},
+		{
+			name: "volumes in other pools",
+			volumes: []*models.VolumeReference{
+				volume("Tier1-Flash-1", 100),
+				volume("Tier3-Flash-1", 50),
+				volume("", 5),
+				{VolumePool: "Tier3-Flash-2"},
+				nil,
+			},
+			poolNames: []string{"Tier1-Flash-1"},
+			expected: piWorkspaceUsage{
+				Pools: map[string]piPoolWorkspaceUsage{"Tier1-Flash-1": {UsedGB: 100, VolumeCount: 1}},
+				Other: piPoolWorkspaceUsage{UsedGB: 55, VolumeCount: 3},
+			},
+		},
+		{
+			name: "pool named other",
+			volumes: []*models.VolumeReference{
+				volume("other", 30),
+				volume("Tier3-Flash-1", 50),
+			},
+			poolNames: []string{"other"},
+			expected: piWorkspaceUsage{
+				Pools: map[string]piPoolWorkspaceUsage{"other": {UsedGB: 30, VolumeCount: 1}},
+				Other: piPoolWorkspaceUsage{UsedGB: 50, VolumeCount: 1},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, piWorkspaceUsageByPool(tc.volumes, tc.poolNames))
+		})
+	}
+}
+
+func TestSetPIWorkspaceUsage(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, DataSourceIBMPIStoragePoolsCapacity().Schema, map[string]interface{}{
+		Arg_CloudInstanceID:       "a1b2c3",
+		Arg_IncludeWorkspaceUsage: true,
+	})
+	pools := []map[string]interface{}{
+		{Attr_PoolName: "Tier1-Flash-1"},
+		{Attr_PoolName: "Tier1-Flash-2"},
+	}
+	setPIWorkspaceUsage(d, pools, piWorkspaceUsage{
+		Pools: map[string]piPoolWorkspaceUsage{
+			"Tier1-Flash-1": {UsedGB: 120.5, VolumeCount: 2},
+			"Tier1-Flash-2": {},
+		},
+		Other: piPoolWorkspaceUsage{UsedGB: 55, VolumeCount: 3},
+	})
+	assert.Equal(t, []map[string]interface{}{
+		{Attr_PoolName: "Tier1-Flash-1", Attr_WorkspaceUsedGB: 120.5, Attr_WorkspaceVolumeCount: 2},
+		{Attr_PoolName: "Tier1-Flash-2", Attr_WorkspaceUsedGB: float64(0), Attr_WorkspaceVolumeCount: 0},
+	}, pools)
+	assert.Equal(t, 55.0, d.Get(Attr_WorkspaceOtherUsedGB))
+	assert.Equal(t, 3, d.Get(Attr_WorkspaceOtherVolumeCount))
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+const (
+	projectConfigAdoptionPending  = "pending"
+	projectConfigAdoptionDeployed = "deployed"
+	projectConfigAdoptionFailed   = "failed"
+)
+
+// projectConfigAdoptionComment is the comment of the force approval that adopts an existing deployment.
+const projectConfigAdoptionComment = "Adopted the existing deployment of the Schematics workspace with Terraform, validation skipped."
+
+// projectConfigAdoptionGuidance is appended to the errors of the adoption of an existing deployment.
+const projectConfigAdoptionGuidance = "Check that the Schematics workspace `schematics.0.workspace_crn` was applied successfully and that it " +
+	"uses the deployable architecture that is identified by `locator_id`, or set `adopt_existing_deployment` to false and deploy the configuration from the project."
+
+// projectConfigAdoptExistingDeployment marks a configuration that was created from an existing Schematics workspace as
+// deployed. The validation is skipped by force approving the configuration, as the console does, and the approved
+// version is deployed, which records the deployed version of the configuration. The workspace is already applied, so
+// the deployment makes no changes to its resources. The configuration is polled until the deployed version is reported.
+func projectConfigAdoptExistingDeployment(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string, timeout time.Duration, pollInterval time.Duration) error {
+	forceApproveOptions := &projectv1.ForceApproveOptions{}
+	forceApproveOptions.SetProjectID(projectID)
+	forceApproveOptions.SetID(configID)
+	forceApproveOptions.SetComment(projectConfigAdoptionComment)
+
+	_, _, err := projectClient.ForceApproveWithContext(context, forceApproveOptions)
+	if err != nil {
+		return fmt.Errorf("The service rejected the adoption of the existing deployment of configuration %s: %s. %s", configID, err, projectConfigAdoptionGuidance)
+	}
+
+	deployConfigOptions := &projectv1.DeployConfigOptions{}
+	deployConfigOptions.SetProjectID(projectID)
+	deployConfigOptions.SetID(configID)
+
+	_, _, err = projectClient.DeployConfigWithContext(context, deployConfigOptions)
+	if err != nil {
+		return fmt.Errorf("The configuration %s was approved but the service rejected the deployment that records the existing deployment: %s. %s", configID, err, projectConfigAdoptionGuidance)
+	}
+
+	waiter := newProjectActionWaiter(projectConfigAdoptionRefreshFunc(context, projectClient, projectID, configID), []string{projectConfigAdoptionPending}, []string{projectConfigAdoptionDeployed}, []string{projectConfigAdoptionFailed}, timeout, pollInterval)
+	adoptedConfig, err := waiter.WaitForStateContext(context)
+	if err != nil {
+		var failedErr *projectWaitFailedError
+		if errors.As(err, &failedErr) {
+			err = &projectConfigActionFailedError{State: projectConfigStringValue(adoptedConfig.(*projectv1.ProjectConfig).State)}
+		}
+		return fmt.Errorf("The configuration %s was approved but the existing deployment was not adopted: %s. %s", configID, err, projectConfigAdoptionGuidance)
+	}
+	return nil
+}
+
+func projectConfigAdoptionRefreshFunc(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(configID)
+
+		projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		return projectConfig, projectConfigAdoptionStatus(projectConfig), nil
+	}
+}
+
+// projectConfigAdoptionStatus returns whether the adoption of an existing deployment is complete, which is when the
+// configuration has a deployed version. The adoption failed when the configuration reached a failed state.
+func projectConfigAdoptionStatus(projectConfig *projectv1.ProjectConfig) string {
+	if projectConfig.DeployedVersion != nil {
+		return projectConfigAdoptionDeployed
+	}
+	if projectConfig.State != nil && strings.HasSuffix(*projectConfig.State, "_failed") {
+		return projectConfigAdoptionFailed
+	}
+	return projectConfigAdoptionPending
+}
@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigAdoptionStatus(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *projectv1.ProjectConfig
+		status string
+	}{
+		{
+			name:   "approved",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("approved")},
+			status: projectConfigAdoptionPending,
+		},
+		{
+			name:   "no state",
+			config: &projectv1.ProjectConfig{},
+			status: projectConfigAdoptionPending,
+		},
+		{
+			name: "deployed version",
+			config: &projectv1.ProjectConfig{
+				State:           core.StringPtr("deployed"),
+				DeployedVersion: &projectv1.ProjectConfigVersionSummary{Version: core.Int64Ptr(1)},
+			},
+			status: projectConfigAdoptionDeployed,
+		},
+		{
+			name:   "deploying failed",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("deploying_failed")},
+			status: projectConfigAdoptionFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.status, projectConfigAdoptionStatus(tc.config))
+		})
+	}
+}
+
+func TestProjectConfigAdoptExistingDeployment(t *testing.T) {
+	var posts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			posts = append(posts, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "cfg-1", "version": 1}`))
+			return
+		}
+		// The deployed version is reported once the approved version is deployed
+		if len(posts) < 2 {
+			_, _ = w.Write([]byte(`{"id": "cfg-1", "state": "approved"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "cfg-1", "state": "deployed", "deployed_version": {"version": 1, "state": "deployed", "href": "https://projects.example.com/v1/projects/project-1/configs/cfg-1/versions/1"}}`))
+	}))
+	defer server.Close()
+
+	projectClient, err := projectv1.NewProjectV1(&projectv1.ProjectV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	assert.NoError(t, err)
+
+	err = projectConfigAdoptExistingDeployment(context.Background(), projectClient, "project-1", "cfg-1", time.Minute, time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"force_approve", "deploy"}, posts)
+}
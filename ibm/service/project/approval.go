@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigAwaitingApprovalStates are the states of a configuration whose validation succeeded and whose approval
+// is pending. The Projects API reports no finer state code to this provider, so the state alone decides: a validated
+// configuration becomes approved when it is approved, and a draft again when it is edited.
+var projectConfigAwaitingApprovalStates = map[string]bool{
+	"validated": true,
+}
+
+// projectConfigAwaitingApproval returns whether a configuration in the state waits for an approval of its validated
+// version.
+func projectConfigAwaitingApproval(state *string) bool {
+	return state != nil && projectConfigAwaitingApprovalStates[*state]
+}
+
+// projectConfigNeedsAttentionSummaryToMap summarizes the needs attention events of a configuration: their number, the
+// number of the events with severity ERROR and the names of the events, in order.
+func projectConfigNeedsAttentionSummaryToMap(events []projectv1.ProjectConfigNeedsAttentionState) map[string]interface{} {
+	errorCount := 0
+	names := []string{}
+	for _, event := range events {
+		if projectConfigIsErrorEvent(event) {
+			errorCount++
+		}
+		names = append(names, projectConfigStringValue(event.Event))
+	}
+	return map[string]interface{}{
+		"count":       len(events),
+		"error_count": errorCount,
+		"events":      names,
+	}
+}
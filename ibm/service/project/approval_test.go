@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigAwaitingApproval(t *testing.T) {
+	testCases := []struct {
+		state    string
+		awaiting bool
+	}{
+		{"validated", true},
+		{"draft", false},
+		{"validating", false},
+		{"validating_failed", false},
+		{"approved", false},
+		{"deployed", false},
+		{"superseded", false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.state, func(t *testing.T) {
+			assert.Equal(t, tc.awaiting, projectConfigAwaitingApproval(core.StringPtr(tc.state)))
+		})
+	}
+	assert.False(t, projectConfigAwaitingApproval(nil))
+}
+
+func TestProjectConfigNeedsAttentionSummaryToMap(t *testing.T) {
+	events := []projectv1.ProjectConfigNeedsAttentionState{
+		{Event: core.StringPtr("project.config.validate.cost_estimate_failed"), Severity: core.StringPtr("WARNING")},
+		{Event: core.StringPtr("project.config.compliance.failed"), Severity: core.StringPtr("ERROR")},
+		{Severity: core.StringPtr("error")},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"count":       3,
+		"error_count": 2,
+		"events":      []string{"project.config.validate.cost_estimate_failed", "project.config.compliance.failed", "unknown"},
+	}, projectConfigNeedsAttentionSummaryToMap(events))
+	assert.Equal(t, map[string]interface{}{"count": 0, "error_count": 0, "events": []string{}}, projectConfigNeedsAttentionSummaryToMap(nil))
+}
+
+func TestDataSourceIbmProjectConfigsValidatedVersion(t *testing.T) {
+	modelMap, err := dataSourceIbmProjectConfigsProjectConfigSummaryToMap(&projectv1.ProjectConfigSummary{
+		ID:      core.StringPtr("cfg-1"),
+		Version: core.Int64Ptr(4),
+		State:   core.StringPtr("validated"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, modelMap["validated_version"])
+
+	modelMap, err = dataSourceIbmProjectConfigsProjectConfigSummaryToMap(&projectv1.ProjectConfigSummary{
+		ID:      core.StringPtr("cfg-2"),
+		Version: core.Int64Ptr(2),
+		State:   core.StringPtr("approved"),
+	})
+	assert.NoError(t, err)
+	assert.NotContains(t, modelMap, "validated_version")
+}
@@ -0,0 +1,77 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+)
+
+// The authorization methods of a configuration
+const (
+	projectConfigAuthMethodAPIKey         = "api_key"
+	projectConfigAuthMethodTrustedProfile = "trusted_profile"
+)
+
+// projectConfigAuthorizationsPayload returns the authorizations of the definition block as the complete object that
+// the service applies when an update changes them. The service ignores an authorizations object with only the changed
+// sub-field, so the method is always sent, inferred from the credential when it is not set, and the credential of the
+// other method is sent as null so that it is removed. An API key that is not set is not sent, so that the API key of
+// the configuration is kept. It returns nil when the definition has no authorizations.
+func projectConfigAuthorizationsPayload(definitionMap map[string]interface{}) map[string]interface{} {
+	authorizationsList, ok := definitionMap["authorizations"].([]interface{})
+	if !ok || len(authorizationsList) == 0 || authorizationsList[0] == nil {
+		return nil
+	}
+	authorizations := authorizationsList[0].(map[string]interface{})
+	method, _ := authorizations["method"].(string)
+	trustedProfileID, _ := authorizations["trusted_profile_id"].(string)
+	apiKey, _ := authorizations["api_key"].(string)
+	if method == "" {
+		if trustedProfileID != "" {
+			method = projectConfigAuthMethodTrustedProfile
+		} else {
+			method = projectConfigAuthMethodAPIKey
+		}
+	}
+
+	payload := map[string]interface{}{"method": method}
+	switch method {
+	case projectConfigAuthMethodTrustedProfile:
+		payload["trusted_profile_id"] = trustedProfileID
+		payload["api_key"] = nil
+	case projectConfigAuthMethodAPIKey:
+		payload["trusted_profile_id"] = nil
+		if apiKey != "" {
+			payload["api_key"] = apiKey
+		}
+	default:
+		if trustedProfileID != "" {
+			payload["trusted_profile_id"] = trustedProfileID
+		}
+		if apiKey != "" {
+			payload["api_key"] = apiKey
+		}
+	}
+	return payload
+}
+
+// projectConfigCheckAuthorizations reads the definition of the configuration again and checks that the service
+// applied the method and the trusted profile of the authorizations that were sent. The API key is not returned by
+// the service, so it cannot be checked.
+func projectConfigCheckAuthorizations(ctx context.Context, api projectConfigDefinitionAPI, projectID string, configID string, sent map[string]interface{}) error {
+	current, _, _, err := api.GetConfigDefinition(ctx, projectID, configID)
+	if err != nil {
+		return fmt.Errorf("Failed to read the authorizations of configuration %s after the update: %s", configID, err)
+	}
+	applied, _ := current["authorizations"].(map[string]interface{})
+	for _, key := range []string{"method", "trusted_profile_id"} {
+		sentValue, _ := sent[key].(string)
+		appliedValue, _ := applied[key].(string)
+		if sentValue != appliedValue {
+			return fmt.Errorf("The service did not apply the authorizations of configuration %s: %s is %q instead of %q. Check the authorizations and apply again", configID, key, appliedValue, sentValue)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testProjectConfigAuthorizationsDefinition(authorizations map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"authorizations": []interface{}{authorizations}}
+}
+
+func TestProjectConfigAuthorizationsPayload(t *testing.T) {
+	// Switching to a trusted profile removes the API key
+	payload := projectConfigAuthorizationsPayload(testProjectConfigAuthorizationsDefinition(map[string]interface{}{"method": "trusted_profile", "trusted_profile_id": "Profile-1", "api_key": ""}))
+	assert.Equal(t, map[string]interface{}{"method": "trusted_profile", "trusted_profile_id": "Profile-1", "api_key": nil}, payload)
+
+	// Switching back to an API key removes the trusted profile
+	payload = projectConfigAuthorizationsPayload(testProjectConfigAuthorizationsDefinition(map[string]interface{}{"method": "api_key", "trusted_profile_id": "", "api_key": "key"}))
+	assert.Equal(t, map[string]interface{}{"method": "api_key", "trusted_profile_id": nil, "api_key": "key"}, payload)
+
+	// An API key that is not set keeps the API key of the configuration
+	payload = projectConfigAuthorizationsPayload(testProjectConfigAuthorizationsDefinition(map[string]interface{}{"method": "api_key", "trusted_profile_id": "", "api_key": ""}))
+	assert.Equal(t, map[string]interface{}{"method": "api_key", "trusted_profile_id": nil}, payload)
+
+	// The method is inferred from the credential when it is not set
+	payload = projectConfigAuthorizationsPayload(testProjectConfigAuthorizationsDefinition(map[string]interface{}{"method": "", "trusted_profile_id": "Profile-1", "api_key": ""}))
+	assert.Equal(t, "trusted_profile", payload["method"])
+	payload = projectConfigAuthorizationsPayload(testProjectConfigAuthorizationsDefinition(map[string]interface{}{"method": "", "trusted_profile_id": "", "api_key": "key"}))
+	assert.Equal(t, "api_key", payload["method"])
+
+	assert.Nil(t, projectConfigAuthorizationsPayload(map[string]interface{}{}))
+	assert.Nil(t, projectConfigAuthorizationsPayload(map[string]interface{}{"authorizations": []interface{}{}}))
+}
+
+func TestProjectConfigCheckAuthorizations(t *testing.T) {
+	sent := map[string]interface{}{"method": "trusted_profile", "trusted_profile_id": "Profile-1", "api_key": nil}
+
+	api := &testProjectConfigDefinitionAPI{definition: map[string]interface{}{"authorizations": map[string]interface{}{"method": "trusted_profile", "trusted_profile_id": "Profile-1"}}}
+	assert.NoError(t, projectConfigCheckAuthorizations(context.Background(), api, "project", "config", sent))
+	assert.Equal(t, 1, api.gets)
+
+	// The service kept the previous method
+	api = &testProjectConfigDefinitionAPI{definition: map[string]interface{}{"authorizations": map[string]interface{}{"method": "api_key"}}}
+	err := projectConfigCheckAuthorizations(context.Background(), api, "project", "config", sent)
+	assert.ErrorContains(t, err, `method is "api_key" instead of "trusted_profile"`)
+
+	// Switching back to an API key must remove the trusted profile
+	sent = map[string]interface{}{"method": "api_key", "trusted_profile_id": nil}
+	api = &testProjectConfigDefinitionAPI{definition: map[string]interface{}{"authorizations": map[string]interface{}{"method": "api_key", "trusted_profile_id": "Profile-1"}}}
+	err = projectConfigCheckAuthorizations(context.Background(), api, "project", "config", sent)
+	assert.ErrorContains(t, err, `trusted_profile_id is "Profile-1" instead of ""`)
+
+	api = &testProjectConfigDefinitionAPI{getErr: errors.New("Internal Server Error")}
+	err = projectConfigCheckAuthorizations(context.Background(), api, "project", "config", sent)
+	assert.ErrorContains(t, err, "Internal Server Error")
+}
@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+	goversion "github.com/hashicorp/go-version"
+)
+
+// projectConfigAvailableUpdate returns the available_update of a configuration whose deployable architecture is the
+// catalog version of locatorID. The Projects API only reports that an update is available, so the update is derived
+// from the catalog as the latest version of the same offering, kind and flavor that is newer, consumable and not
+// deprecated. It returns nil when the catalog has no such version, which the service may still offer as an update.
+func projectConfigAvailableUpdate(context context.Context, catalogClient projectCatalogAPI, locatorID string) (map[string]interface{}, error) {
+	getVersionOptions := &catalogmanagementv1.GetVersionOptions{}
+	getVersionOptions.SetVersionLocID(locatorID)
+
+	current, response, err := catalogClient.GetVersionWithContext(context, getVersionOptions)
+	if err != nil {
+		if response != nil && response.StatusCode == 404 {
+			return nil, &projectConfigLocatorNotFoundError{locatorID: locatorID}
+		}
+		return nil, err
+	}
+	if current == nil || len(current.Kinds) == 0 || len(current.Kinds[0].Versions) == 0 {
+		return nil, &projectConfigLocatorNotFoundError{locatorID: locatorID}
+	}
+	currentVersion := current.Kinds[0].Versions[0]
+	catalogID := projectConfigFirstString(currentVersion.CatalogID, current.CatalogID)
+	offeringID := projectConfigFirstString(currentVersion.OfferingID, current.ID)
+	if catalogID == "" || offeringID == "" {
+		return nil, fmt.Errorf("The catalog version of locator_id %s does not reference its catalog and offering", locatorID)
+	}
+
+	getOfferingOptions := &catalogmanagementv1.GetOfferingOptions{}
+	getOfferingOptions.SetCatalogIdentifier(catalogID)
+	getOfferingOptions.SetOfferingID(offeringID)
+
+	offering, _, err := catalogClient.GetOfferingWithContext(context, getOfferingOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := projectConfigLatestCompatibleVersion(current.Kinds[0], currentVersion, offering)
+	if latest == nil {
+		return nil, nil
+	}
+	availableUpdate := map[string]interface{}{
+		"locator_id": projectConfigFirstString(latest.VersionLocator),
+		"version":    projectConfigFirstString(latest.Version),
+	}
+	if offering.OfferingDocsURL != nil {
+		availableUpdate["release_notes_url"] = *offering.OfferingDocsURL
+	}
+	return availableUpdate, nil
+}
+
+// projectConfigLatestCompatibleVersion returns the latest version of offering with the kind and the flavor of
+// currentVersion that is newer than it, consumable and not deprecated, or nil when there is none. The versions
+// are compared as semantic versions, and the versions that are not are skipped.
+func projectConfigLatestCompatibleVersion(currentKind catalogmanagementv1.Kind, currentVersion catalogmanagementv1.Version, offering *catalogmanagementv1.Offering) *catalogmanagementv1.Version {
+	if offering == nil || currentVersion.Version == nil {
+		return nil
+	}
+	latestSemver, err := goversion.NewVersion(*currentVersion.Version)
+	if err != nil {
+		return nil
+	}
+	if offering.Deprecated != nil && *offering.Deprecated {
+		return nil
+	}
+
+	var latest *catalogmanagementv1.Version
+	for _, kind := range offering.Kinds {
+		if !projectConfigSameKind(currentKind, kind) {
+			continue
+		}
+		for i, version := range kind.Versions {
+			if version.VersionLocator == nil || version.Version == nil ||
+				projectConfigFlavorName(version.Flavor) != projectConfigFlavorName(currentVersion.Flavor) ||
+				(version.Deprecated != nil && *version.Deprecated) || (version.IsConsumable != nil && !*version.IsConsumable) {
+				continue
+			}
+			semver, err := goversion.NewVersion(*version.Version)
+			if err != nil || !semver.GreaterThan(latestSemver) {
+				continue
+			}
+			latestSemver = semver
+			latest = &kind.Versions[i]
+		}
+	}
+	return latest
+}
+
+// projectConfigSameKind returns whether two kinds of an offering are the same, by ID, or by format and target kind
+// when the catalog does not return the ID.
+func projectConfigSameKind(kind catalogmanagementv1.Kind, other catalogmanagementv1.Kind) bool {
+	if kind.ID != nil && other.ID != nil {
+		return *kind.ID == *other.ID
+	}
+	return projectConfigFirstString(kind.FormatKind) == projectConfigFirstString(other.FormatKind) &&
+		projectConfigFirstString(kind.TargetKind) == projectConfigFirstString(other.TargetKind)
+}
+
+func projectConfigFlavorName(flavor *catalogmanagementv1.Flavor) string {
+	if flavor == nil {
+		return ""
+	}
+	return projectConfigFirstString(flavor.Name)
+}
+
+// projectConfigFirstString returns the first of values that is set and not empty, or an empty string.
+func projectConfigFirstString(values ...*string) string {
+	for _, value := range values {
+		if value != nil && *value != "" {
+			return *value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const testProjectConfigLocatorID = "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+
+// testProjectCatalogAPI fakes the catalogmanagementv1 client with the versions of a single offering
+type testProjectCatalogAPI struct {
+	versions     map[string]*catalogmanagementv1.Offering
+	offering     *catalogmanagementv1.Offering
+	offeringErr  error
+	offeringIDs  []string
+	versionCalls int
+}
+
+func (api *testProjectCatalogAPI) GetVersionWithContext(ctx context.Context, getVersionOptions *catalogmanagementv1.GetVersionOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error) {
+	api.versionCalls++
+	version, ok := api.versions[*getVersionOptions.VersionLocID]
+	if !ok {
+		return nil, &core.DetailedResponse{StatusCode: 404}, errors.New("Not Found")
+	}
+	return version, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func (api *testProjectCatalogAPI) GetOfferingWithContext(ctx context.Context, getOfferingOptions *catalogmanagementv1.GetOfferingOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error) {
+	api.offeringIDs = append(api.offeringIDs, *getOfferingOptions.CatalogIdentifier+"/"+*getOfferingOptions.OfferingID)
+	if api.offeringErr != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.offeringErr
+	}
+	return api.offering, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func testProjectCatalogVersion(version string, flavor string) catalogmanagementv1.Version {
+	return catalogmanagementv1.Version{
+		Version:        core.StringPtr(version),
+		VersionLocator: core.StringPtr("1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.version-" + version + "-" + flavor),
+		CatalogID:      core.StringPtr("1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc"),
+		OfferingID:     core.StringPtr("offering-1"),
+		Flavor:         &catalogmanagementv1.Flavor{Name: core.StringPtr(flavor)},
+	}
+}
+
+func testProjectCatalogAPIWithVersions(versions ...catalogmanagementv1.Version) *testProjectCatalogAPI {
+	return &testProjectCatalogAPI{
+		versions: map[string]*catalogmanagementv1.Offering{
+			testProjectConfigLocatorID: {
+				ID:    core.StringPtr("offering-1"),
+				Kinds: []catalogmanagementv1.Kind{{ID: core.StringPtr("kind-terraform"), Versions: []catalogmanagementv1.Version{testProjectCatalogVersion("1.2.0", "standard")}}},
+			},
+		},
+		offering: &catalogmanagementv1.Offering{
+			ID:              core.StringPtr("offering-1"),
+			OfferingDocsURL: core.StringPtr("https://github.com/terraform-ibm-modules/terraform-ibm-landing-zone/releases"),
+			Kinds: []catalogmanagementv1.Kind{
+				{ID: core.StringPtr("kind-terraform"), Versions: versions},
+				{ID: core.StringPtr("kind-helm"), Versions: []catalogmanagementv1.Version{testProjectCatalogVersion("9.0.0", "standard")}},
+			},
+		},
+	}
+}
+
+func TestProjectConfigAvailableUpdate(t *testing.T) {
+	deprecated := testProjectCatalogVersion("1.5.0", "standard")
+	deprecated.Deprecated = core.BoolPtr(true)
+	notConsumable := testProjectCatalogVersion("1.4.0", "standard")
+	notConsumable.IsConsumable = core.BoolPtr(false)
+
+	api := testProjectCatalogAPIWithVersions(
+		testProjectCatalogVersion("1.1.0", "standard"),
+		testProjectCatalogVersion("1.2.0", "standard"),
+		testProjectCatalogVersion("1.3.1", "standard"),
+		testProjectCatalogVersion("1.10.0", "standard"),
+		testProjectCatalogVersion("2.0.0", "quickstart"),
+		testProjectCatalogVersion("latest", "standard"),
+		deprecated,
+		notConsumable,
+	)
+	availableUpdate, err := projectConfigAvailableUpdate(context.Background(), api, testProjectConfigLocatorID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"locator_id":        "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.version-1.10.0-standard",
+		"version":           "1.10.0",
+		"release_notes_url": "https://github.com/terraform-ibm-modules/terraform-ibm-landing-zone/releases",
+	}, availableUpdate)
+	assert.Equal(t, []string{"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc/offering-1"}, api.offeringIDs)
+
+	// No newer compatible version
+	api = testProjectCatalogAPIWithVersions(testProjectCatalogVersion("1.2.0", "standard"), testProjectCatalogVersion("1.3.0", "quickstart"), deprecated)
+	availableUpdate, err = projectConfigAvailableUpdate(context.Background(), api, testProjectConfigLocatorID)
+	assert.NoError(t, err)
+	assert.Nil(t, availableUpdate)
+
+	// An offering without documentation URL
+	api = testProjectCatalogAPIWithVersions(testProjectCatalogVersion("1.3.0", "standard"))
+	api.offering.OfferingDocsURL = nil
+	availableUpdate, err = projectConfigAvailableUpdate(context.Background(), api, testProjectConfigLocatorID)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"locator_id": "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.version-1.3.0-standard",
+		"version":    "1.3.0",
+	}, availableUpdate)
+
+	// A deprecated offering has no update
+	api = testProjectCatalogAPIWithVersions(testProjectCatalogVersion("1.3.0", "standard"))
+	api.offering.Deprecated = core.BoolPtr(true)
+	availableUpdate, err = projectConfigAvailableUpdate(context.Background(), api, testProjectConfigLocatorID)
+	assert.NoError(t, err)
+	assert.Nil(t, availableUpdate)
+
+	_, err = projectConfigAvailableUpdate(context.Background(), api, "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.missing")
+	assert.IsType(t, &projectConfigLocatorNotFoundError{}, err)
+
+	api = testProjectCatalogAPIWithVersions()
+	api.offeringErr = errors.New("Internal Server Error")
+	_, err = projectConfigAvailableUpdate(context.Background(), api, testProjectConfigLocatorID)
+	assert.EqualError(t, err, "Internal Server Error")
+}
+
+func TestProjectConfigSameKind(t *testing.T) {
+	assert.True(t, projectConfigSameKind(catalogmanagementv1.Kind{ID: core.StringPtr("a")}, catalogmanagementv1.Kind{ID: core.StringPtr("a")}))
+	assert.False(t, projectConfigSameKind(catalogmanagementv1.Kind{ID: core.StringPtr("a")}, catalogmanagementv1.Kind{ID: core.StringPtr("b")}))
+	assert.True(t, projectConfigSameKind(
+		catalogmanagementv1.Kind{FormatKind: core.StringPtr("terraform"), TargetKind: core.StringPtr("terraform")},
+		catalogmanagementv1.Kind{ID: core.StringPtr("b"), FormatKind: core.StringPtr("terraform"), TargetKind: core.StringPtr("terraform")}))
+	assert.False(t, projectConfigSameKind(
+		catalogmanagementv1.Kind{FormatKind: core.StringPtr("terraform")},
+		catalogmanagementv1.Kind{FormatKind: core.StringPtr("helm")}))
+}
+
+func TestDataSourceIbmProjectConfigReadAvailableUpdate(t *testing.T) {
+	read := func(updateAvailable bool, resolveLocator bool, catalogClient *testProjectCatalogAPI) (*schema.ResourceData, diag.Diagnostics) {
+		api := &testProjectConfigAPI{
+			config: &projectv1.ProjectConfig{
+				ID:              core.StringPtr("a1b2c3"),
+				UpdateAvailable: core.BoolPtr(updateAvailable),
+				Href:            core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d-926c-4653-a15b-ed17d7b34b22/configs/a1b2c3"),
+				Definition: &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+					Name:      core.StringPtr("network"),
+					LocatorID: core.StringPtr(testProjectConfigLocatorID),
+				},
+			},
+		}
+		d := schema.TestResourceDataRaw(t, DataSourceIbmProjectConfig().Schema, map[string]interface{}{
+			"project_id":        "b0a2c11d-926c-4653-a15b-ed17d7b34b22",
+			"project_config_id": "a1b2c3",
+			"resolve_locator":   resolveLocator,
+		})
+		return d, dataSourceIbmProjectConfigReadWithClient(context.Background(), d, api, &api.environments, api, catalogClient, "")
+	}
+
+	catalogClient := testProjectCatalogAPIWithVersions(testProjectCatalogVersion("1.3.0", "standard"))
+	d, diags := read(true, true, catalogClient)
+	assert.Empty(t, diags)
+	assert.Equal(t, "1.3.0", d.Get("available_update.0.version"))
+	assert.Equal(t, "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.version-1.3.0-standard", d.Get("available_update.0.locator_id"))
+
+	// The catalog is not queried without an update or without resolve_locator
+	for _, args := range [][2]bool{{false, true}, {true, false}} {
+		catalogClient = testProjectCatalogAPIWithVersions(testProjectCatalogVersion("1.3.0", "standard"))
+		d, diags = read(args[0], args[1], catalogClient)
+		assert.Empty(t, diags)
+		assert.Empty(t, d.Get("available_update"))
+		assert.Zero(t, catalogClient.versionCalls)
+	}
+
+	// A catalog that cannot be read is reported as a warning
+	catalogClient = testProjectCatalogAPIWithVersions()
+	catalogClient.offeringErr = errors.New("Internal Server Error")
+	d, diags = read(true, true, catalogClient)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Internal Server Error", diags[0].Detail)
+	assert.Empty(t, d.Get("available_update"))
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/IBM/schematics-go-sdk/schematicsv1"
+)
+
+// projectConfigAPI is the subset of the projectv1 client that the configuration reads use. The client returned by
+// ProjectV1 implements it, and the unit tests implement it with fakes so that the reads run without credentials.
+type projectConfigAPI interface {
+	GetConfigWithContext(ctx context.Context, getConfigOptions *projectv1.GetConfigOptions) (*projectv1.ProjectConfig, *core.DetailedResponse, error)
+	ListConfigsWithContext(ctx context.Context, listConfigsOptions *projectv1.ListConfigsOptions) (*projectv1.ProjectConfigCollection, *core.DetailedResponse, error)
+	ListConfigResourcesWithContext(ctx context.Context, listConfigResourcesOptions *projectv1.ListConfigResourcesOptions) (*projectv1.ProjectConfigResourceCollection, *core.DetailedResponse, error)
+}
+
+// projectListAPI is the subset of the projectv1 client that lists the projects of the account.
+type projectListAPI interface {
+	ListProjectsWithContext(ctx context.Context, listProjectsOptions *projectv1.ListProjectsOptions) (*projectv1.ProjectCollection, *core.DetailedResponse, error)
+}
+
+// projectEnvironmentListAPI is the subset of the projectv1 client that lists the environments of a project.
+type projectEnvironmentListAPI interface {
+	ListProjectEnvironmentsWithContext(ctx context.Context, listProjectEnvironmentsOptions *projectv1.ListProjectEnvironmentsOptions) (*projectv1.EnvironmentCollection, *core.DetailedResponse, error)
+}
+
+// projectEnvironmentGetAPI is the subset of the projectv1 client that reads an environment of a project.
+type projectEnvironmentGetAPI interface {
+	GetProjectEnvironmentWithContext(ctx context.Context, getProjectEnvironmentOptions *projectv1.GetProjectEnvironmentOptions) (*projectv1.Environment, *core.DetailedResponse, error)
+}
+
+// projectJobLogAPI is the subset of the schematicsv1 client that reads the logs of the jobs of the project actions.
+type projectJobLogAPI interface {
+	GetServiceURL() string
+	ListJobLogsWithContext(ctx context.Context, listJobLogsOptions *schematicsv1.ListJobLogsOptions) (*schematicsv1.JobLog, *core.DetailedResponse, error)
+}
+
+// projectWorkspaceAPI is the subset of the schematicsv1 client that reads the Schematics workspaces of the
+// configurations.
+type projectWorkspaceAPI interface {
+	GetWorkspaceWithContext(ctx context.Context, getWorkspaceOptions *schematicsv1.GetWorkspaceOptions) (*schematicsv1.WorkspaceResponse, *core.DetailedResponse, error)
+}
+
+// projectCatalogAPI is the subset of the catalogmanagementv1 client that reads the versions of the deployable
+// architectures of the configurations.
+type projectCatalogAPI interface {
+	GetVersionWithContext(ctx context.Context, getVersionOptions *catalogmanagementv1.GetVersionOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error)
+	GetOfferingWithContext(ctx context.Context, getOfferingOptions *catalogmanagementv1.GetOfferingOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error)
+}
+
+var (
+	_ projectConfigAPI          = (*projectv1.ProjectV1)(nil)
+	_ projectListAPI            = (*projectv1.ProjectV1)(nil)
+	_ projectEnvironmentListAPI = (*projectv1.ProjectV1)(nil)
+	_ projectEnvironmentGetAPI  = (*projectv1.ProjectV1)(nil)
+	_ projectJobLogAPI          = (*schematicsv1.SchematicsV1)(nil)
+	_ projectWorkspaceAPI       = (*schematicsv1.SchematicsV1)(nil)
+	_ projectCatalogAPI         = (*catalogmanagementv1.CatalogManagementV1)(nil)
+)
@@ -0,0 +1,43 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+)
+
+// projectConfigCheckInheritComplianceProfile checks that a configuration that inherits the compliance profile of its
+// environment has an environment and no compliance profile of its own. environmentKnown is false while the
+// environment_id is not known at plan time.
+func projectConfigCheckInheritComplianceProfile(inherit bool, hasComplianceProfile bool, environmentKnown bool, environmentID string, environmentName string) error {
+	if !inherit {
+		return nil
+	}
+	if hasComplianceProfile {
+		return fmt.Errorf("definition.0.compliance_profile cannot be set with inherit_compliance_profile, the configuration inherits the compliance profile of its environment")
+	}
+	if !environmentKnown || environmentID != "" || environmentName != "" {
+		return nil
+	}
+	return fmt.Errorf("inherit_compliance_profile requires the environment of the configuration in definition.0.environment_id or definition.0.environment_name, whose compliance profile the configuration inherits")
+}
+
+// projectConfigEnvironmentComplianceProfile returns the compliance profile of the environment of the project, as the
+// list of the compliance_profile blocks. The list is empty when the environment has no compliance profile.
+func projectConfigEnvironmentComplianceProfile(context context.Context, projectClient projectEnvironmentGetAPI, projectID string, environmentID string) ([]map[string]interface{}, error) {
+	environment, err := projectGetEnvironment(context, projectClient, projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	complianceProfile := []map[string]interface{}{}
+	if environment.Definition != nil && environment.Definition.ComplianceProfile != nil {
+		complianceProfileMap, err := resourceIbmProjectConfigProjectComplianceProfileToMap(environment.Definition.ComplianceProfile)
+		if err != nil {
+			return nil, err
+		}
+		complianceProfile = append(complianceProfile, complianceProfileMap)
+	}
+	return complianceProfile, nil
+}
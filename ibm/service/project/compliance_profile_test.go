@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectEnvironmentGetAPI fakes the read of the environments of a project by ID.
+type testProjectEnvironmentGetAPI struct {
+	environments map[string]*projectv1.Environment
+	getErr       error
+}
+
+func (api *testProjectEnvironmentGetAPI) GetProjectEnvironmentWithContext(ctx context.Context, getProjectEnvironmentOptions *projectv1.GetProjectEnvironmentOptions) (*projectv1.Environment, *core.DetailedResponse, error) {
+	if api.getErr != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.getErr
+	}
+	environment, ok := api.environments[*getProjectEnvironmentOptions.ID]
+	if !ok {
+		return nil, &core.DetailedResponse{StatusCode: 404}, errors.New("Not Found")
+	}
+	return environment, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func TestProjectConfigCheckInheritComplianceProfile(t *testing.T) {
+	assert.NoError(t, projectConfigCheckInheritComplianceProfile(false, true, true, "", ""))
+	assert.NoError(t, projectConfigCheckInheritComplianceProfile(true, false, true, "a1b2c3", ""))
+	assert.NoError(t, projectConfigCheckInheritComplianceProfile(true, false, true, "", "production"))
+	// The environment_id of an environment created in the same apply is not known yet
+	assert.NoError(t, projectConfigCheckInheritComplianceProfile(true, false, false, "", ""))
+
+	assert.EqualError(t, projectConfigCheckInheritComplianceProfile(true, false, true, "", ""),
+		"inherit_compliance_profile requires the environment of the configuration in definition.0.environment_id or definition.0.environment_name, whose compliance profile the configuration inherits")
+	assert.EqualError(t, projectConfigCheckInheritComplianceProfile(true, true, true, "a1b2c3", ""),
+		"definition.0.compliance_profile cannot be set with inherit_compliance_profile, the configuration inherits the compliance profile of its environment")
+}
+
+func TestProjectConfigEnvironmentComplianceProfile(t *testing.T) {
+	api := &testProjectEnvironmentGetAPI{environments: map[string]*projectv1.Environment{
+		"a1b2c3": {
+			ID: core.StringPtr("a1b2c3"),
+			Definition: &projectv1.EnvironmentDefinitionRequiredPropertiesResponse{
+				Name: core.StringPtr("production"),
+				ComplianceProfile: &projectv1.ProjectComplianceProfile{
+					ID:               core.StringPtr("profile-id"),
+					InstanceID:       core.StringPtr("instance-id"),
+					InstanceLocation: core.StringPtr("us-south"),
+					AttachmentID:     core.StringPtr("attachment-id"),
+					ProfileName:      core.StringPtr("CIS IBM Foundations Benchmark"),
+				},
+			},
+		},
+		"d4e5f6": {
+			ID:         core.StringPtr("d4e5f6"),
+			Definition: &projectv1.EnvironmentDefinitionRequiredPropertiesResponse{Name: core.StringPtr("development")},
+		},
+	}}
+
+	complianceProfile, err := projectConfigEnvironmentComplianceProfile(context.Background(), api, "project", "a1b2c3")
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{
+		"id":                core.StringPtr("profile-id"),
+		"instance_id":       core.StringPtr("instance-id"),
+		"instance_location": core.StringPtr("us-south"),
+		"attachment_id":     core.StringPtr("attachment-id"),
+		"profile_name":      core.StringPtr("CIS IBM Foundations Benchmark"),
+	}}, complianceProfile)
+
+	complianceProfile, err = projectConfigEnvironmentComplianceProfile(context.Background(), api, "project", "d4e5f6")
+	assert.NoError(t, err)
+	assert.Empty(t, complianceProfile)
+
+	_, err = projectConfigEnvironmentComplianceProfile(context.Background(), api, "project", "g7h8i9")
+	assert.EqualError(t, err, "GetProjectEnvironmentWithContext failed for the environment g7h8i9: Not Found")
+}
@@ -0,0 +1,219 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigUpdateAttempts bounds the updates of a configuration that another update modified concurrently
+const projectConfigUpdateAttempts = 3
+
+// The definition properties whose entries are merged one by one onto a definition that another update modified
+var projectConfigMergedDefinitionMaps = map[string]bool{
+	"inputs":   true,
+	"settings": true,
+}
+
+// projectConfigDefinitionAPI reads and updates the definition of a configuration as JSON, together with the ETag of
+// the configuration. The ETag is empty when the service does not return one.
+type projectConfigDefinitionAPI interface {
+	GetConfigDefinition(ctx context.Context, projectID string, configID string) (map[string]interface{}, string, *core.DetailedResponse, error)
+	UpdateConfigDefinition(ctx context.Context, projectID string, configID string, definition map[string]interface{}, ifMatch string) (*core.DetailedResponse, error)
+}
+
+// projectConfigDefinitionClient implements projectConfigDefinitionAPI with GetConfig and UpdateConfig, or with the
+// requests of definition_json.go for the configurations whose definition is set by definition_json.
+type projectConfigDefinitionClient struct {
+	projectClient  *projectv1.ProjectV1
+	definitionJSON bool
+}
+
+var _ projectConfigDefinitionAPI = (*projectConfigDefinitionClient)(nil)
+
+func (c *projectConfigDefinitionClient) GetConfigDefinition(ctx context.Context, projectID string, configID string) (map[string]interface{}, string, *core.DetailedResponse, error) {
+	if !c.definitionJSON {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(configID)
+		projectConfig, response, err := c.projectClient.GetConfigWithContext(ctx, getConfigOptions)
+		if err != nil {
+			return nil, "", response, err
+		}
+		definition := map[string]interface{}{}
+		if !core.IsNil(projectConfig.Definition) {
+			if definition, err = projectConfigModelPayload(projectConfig.Definition); err != nil {
+				return nil, "", response, err
+			}
+		}
+		return definition, projectConfigETag(response), response, nil
+	}
+
+	_, rawDefinition, response, err := projectConfigGetWithRawDefinition(ctx, c.projectClient, projectID, configID)
+	if err != nil {
+		return nil, "", response, err
+	}
+	definition := map[string]interface{}{}
+	if len(rawDefinition) > 0 {
+		if err = json.Unmarshal(rawDefinition, &definition); err != nil {
+			return nil, "", response, err
+		}
+	}
+	return definition, projectConfigETag(response), response, nil
+}
+
+// UpdateConfigDefinition sends definition with UpdateConfig, whose definition model drops the properties that it does
+// not declare and the properties that are null. A definition that is set by definition_json, or that clears a
+// property with null such as a removed environment_id, is sent as JSON instead.
+func (c *projectConfigDefinitionClient) UpdateConfigDefinition(ctx context.Context, projectID string, configID string, definition map[string]interface{}, ifMatch string) (*core.DetailedResponse, error) {
+	if c.definitionJSON || projectConfigDefinitionHasNull(definition) {
+		return projectConfigUpdateWithDefinitionJSON(ctx, c.projectClient, projectID, configID, definition, ifMatch)
+	}
+
+	bytes, err := json.Marshal(definition)
+	if err != nil {
+		return nil, err
+	}
+	definitionModel := &projectv1.ProjectConfigDefinitionPatch{}
+	if err = json.Unmarshal(bytes, definitionModel); err != nil {
+		return nil, err
+	}
+	updateConfigOptions := &projectv1.UpdateConfigOptions{}
+	updateConfigOptions.SetProjectID(projectID)
+	updateConfigOptions.SetID(configID)
+	updateConfigOptions.SetDefinition(definitionModel)
+	if ifMatch != "" {
+		updateConfigOptions.SetHeaders(map[string]string{"If-Match": ifMatch})
+	}
+	_, response, err := c.projectClient.UpdateConfigWithContext(ctx, updateConfigOptions)
+	return response, err
+}
+
+// projectConfigDefinitionHasNull reports whether a property of the definition is null, which clears the property.
+func projectConfigDefinitionHasNull(definition map[string]interface{}) bool {
+	for _, value := range definition {
+		if value == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// projectConfigETag returns the ETag header of a response of the Projects API, empty when there is none.
+func projectConfigETag(response *core.DetailedResponse) string {
+	if response == nil || response.Headers == nil {
+		return ""
+	}
+	return response.Headers.Get("ETag")
+}
+
+// projectConfigUpdateDefinition updates the definition of a configuration from the definition that was planned,
+// without overwriting the changes of another update. The update is sent with If-Match and the ETag that the last read
+// stored. When another update modified the configuration in between, the service rejects the update with 412: the
+// configuration is then read again and only the changes of the plan, from oldDefinition to newDefinition, are
+// applied onto the definition that was read, up to projectConfigUpdateAttempts times. Without an ETag, the
+// configuration is read again right before the update and the changes of the plan are applied onto it, which narrows
+// the window of a concurrent update but cannot close it.
+func projectConfigUpdateDefinition(ctx context.Context, api projectConfigDefinitionAPI, projectID string, configID string, etag string, oldDefinition map[string]interface{}, newDefinition map[string]interface{}) (*core.DetailedResponse, error) {
+	definition := newDefinition
+	if etag == "" {
+		current, currentETag, response, err := api.GetConfigDefinition(ctx, projectID, configID)
+		if err != nil {
+			return response, err
+		}
+		definition = projectConfigRebaseDefinition(current, oldDefinition, newDefinition)
+		etag = currentETag
+	}
+	for attempt := 1; ; attempt++ {
+		response, err := api.UpdateConfigDefinition(ctx, projectID, configID, definition, etag)
+		if err == nil || response == nil || response.StatusCode != http.StatusPreconditionFailed || etag == "" {
+			return response, err
+		}
+		if attempt == projectConfigUpdateAttempts {
+			return response, fmt.Errorf("the configuration %s was modified by another update during each of the %d update attempts: %w", configID, projectConfigUpdateAttempts, err)
+		}
+		log.Printf("[DEBUG] ibm_project_config %s was modified by another update, merging the changes onto the current definition (attempt %d of %d)", configID, attempt, projectConfigUpdateAttempts)
+
+		current, currentETag, response, err := api.GetConfigDefinition(ctx, projectID, configID)
+		if err != nil {
+			return response, err
+		}
+		definition = projectConfigRebaseDefinition(current, oldDefinition, newDefinition)
+		etag = currentETag
+	}
+}
+
+// projectConfigRebaseDefinition applies the changes of the plan, from oldDefinition to newDefinition, onto the
+// current definition of the configuration. The properties that the plan does not change keep their current value, and
+// the entries of the inputs and settings are merged one by one, so that another update of other inputs is kept. A
+// property or an entry that the plan removes is removed, the properties as null as projectConfigDefinitionJSONPayload
+// does.
+func projectConfigRebaseDefinition(current map[string]interface{}, oldDefinition map[string]interface{}, newDefinition map[string]interface{}) map[string]interface{} {
+	definition := make(map[string]interface{}, len(current))
+	for key, value := range current {
+		definition[key] = value
+	}
+	for _, key := range projectConfigUnionKeys(oldDefinition, newDefinition) {
+		oldValue, newValue := oldDefinition[key], newDefinition[key]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		oldEntries, oldIsMap := oldValue.(map[string]interface{})
+		newEntries, newIsMap := newValue.(map[string]interface{})
+		if projectConfigMergedDefinitionMaps[key] && (oldIsMap || oldValue == nil) && newIsMap {
+			definition[key] = projectConfigRebaseEntries(definition[key], oldEntries, newEntries)
+			continue
+		}
+		if _, ok := newDefinition[key]; ok {
+			definition[key] = newValue
+		} else {
+			definition[key] = nil
+		}
+	}
+	return definition
+}
+
+func projectConfigRebaseEntries(current interface{}, oldEntries map[string]interface{}, newEntries map[string]interface{}) map[string]interface{} {
+	entries := map[string]interface{}{}
+	if currentEntries, ok := current.(map[string]interface{}); ok {
+		for key, value := range currentEntries {
+			entries[key] = value
+		}
+	}
+	for _, key := range projectConfigUnionKeys(oldEntries, newEntries) {
+		oldValue, oldOk := oldEntries[key]
+		newValue, newOk := newEntries[key]
+		if oldOk == newOk && reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		if newOk {
+			entries[key] = newValue
+		} else {
+			delete(entries, key)
+		}
+	}
+	return entries
+}
+
+func projectConfigUnionKeys(maps ...map[string]interface{}) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, m := range maps {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
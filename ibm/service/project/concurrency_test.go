@@ -0,0 +1,238 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectConfigDefinitionAPI fakes the definition of a configuration with an ETag that changes on each update.
+// concurrentUpdates are applied, one per update attempt, before the update is checked, as another pipeline would.
+type testProjectConfigDefinitionAPI struct {
+	definition        map[string]interface{}
+	version           int
+	noETag            bool
+	concurrentUpdates []map[string]interface{}
+	getErr            error
+
+	gets    int
+	ifMatch []string
+}
+
+func (api *testProjectConfigDefinitionAPI) etag() string {
+	if api.noETag {
+		return ""
+	}
+	return fmt.Sprintf(`"v%d"`, api.version)
+}
+
+func (api *testProjectConfigDefinitionAPI) GetConfigDefinition(ctx context.Context, projectID string, configID string) (map[string]interface{}, string, *core.DetailedResponse, error) {
+	api.gets++
+	if api.getErr != nil {
+		return nil, "", &core.DetailedResponse{StatusCode: http.StatusInternalServerError}, api.getErr
+	}
+	definition := map[string]interface{}{}
+	for key, value := range api.definition {
+		definition[key] = value
+	}
+	return definition, api.etag(), &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+}
+
+func (api *testProjectConfigDefinitionAPI) UpdateConfigDefinition(ctx context.Context, projectID string, configID string, definition map[string]interface{}, ifMatch string) (*core.DetailedResponse, error) {
+	api.ifMatch = append(api.ifMatch, ifMatch)
+	if len(api.concurrentUpdates) > 0 {
+		api.definition = projectConfigRebaseDefinition(api.definition, map[string]interface{}{}, api.concurrentUpdates[0])
+		api.concurrentUpdates = api.concurrentUpdates[1:]
+		api.version++
+	}
+	if ifMatch != "" && ifMatch != api.etag() {
+		return &core.DetailedResponse{StatusCode: http.StatusPreconditionFailed}, errors.New("Precondition Failed")
+	}
+	api.definition = definition
+	api.version++
+	return &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+}
+
+func testProjectConfigDefinitionMap(inputs map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"name": "config", "locator_id": "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.v1", "inputs": inputs}
+}
+
+func TestProjectConfigRebaseDefinition(t *testing.T) {
+	current := map[string]interface{}{
+		"name":        "config",
+		"description": "changed by another update",
+		"inputs":      map[string]interface{}{"region": "eu-de", "prefix": "other", "tags": "a"},
+		"settings":    map[string]interface{}{"TF_LOG": "DEBUG"},
+	}
+	oldDefinition := map[string]interface{}{
+		"name":     "config",
+		"inputs":   map[string]interface{}{"region": "us-south", "prefix": "app", "tags": "a"},
+		"settings": map[string]interface{}{"TF_LOG": "INFO"},
+	}
+	newDefinition := map[string]interface{}{
+		"name":     "renamed",
+		"inputs":   map[string]interface{}{"region": "us-south", "prefix": "app", "zone": "1"},
+		"settings": map[string]interface{}{"TF_LOG": "INFO"},
+	}
+	assert.Equal(t, map[string]interface{}{
+		"name":        "renamed",
+		"description": "changed by another update",
+		"inputs":      map[string]interface{}{"region": "eu-de", "prefix": "other", "zone": "1"},
+		"settings":    map[string]interface{}{"TF_LOG": "DEBUG"},
+	}, projectConfigRebaseDefinition(current, oldDefinition, newDefinition))
+
+	// A property that the plan removes is sent as null, an input that it changes wins over the current one
+	oldDefinition = map[string]interface{}{"inputs": map[string]interface{}{"region": "us-south"}, "stack_options": map[string]interface{}{"parallel": true}}
+	newDefinition = map[string]interface{}{"inputs": map[string]interface{}{"region": "us-east"}, "stack_options": nil}
+	assert.Equal(t, map[string]interface{}{
+		"name":          "config",
+		"description":   "changed by another update",
+		"inputs":        map[string]interface{}{"region": "us-east", "prefix": "other", "tags": "a"},
+		"settings":      map[string]interface{}{"TF_LOG": "DEBUG"},
+		"stack_options": nil,
+	}, projectConfigRebaseDefinition(current, oldDefinition, newDefinition))
+}
+
+func TestProjectConfigUpdateDefinition(t *testing.T) {
+	oldDefinition := testProjectConfigDefinitionMap(map[string]interface{}{"region": "us-south", "prefix": "app"})
+	newDefinition := testProjectConfigDefinitionMap(map[string]interface{}{"region": "us-south", "prefix": "app2"})
+
+	t.Run("no concurrent update", func(t *testing.T) {
+		api := &testProjectConfigDefinitionAPI{definition: oldDefinition, version: 1}
+		_, err := projectConfigUpdateDefinition(context.Background(), api, "project-1", "cfg-1", `"v1"`, oldDefinition, newDefinition)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`"v1"`}, api.ifMatch)
+		assert.Equal(t, 0, api.gets)
+		assert.Equal(t, newDefinition, api.definition)
+	})
+
+	t.Run("concurrent update of another input", func(t *testing.T) {
+		api := &testProjectConfigDefinitionAPI{
+			definition:        oldDefinition,
+			version:           1,
+			concurrentUpdates: []map[string]interface{}{{"inputs": map[string]interface{}{"region": "eu-de", "prefix": "app"}}},
+		}
+		_, err := projectConfigUpdateDefinition(context.Background(), api, "project-1", "cfg-1", `"v1"`, oldDefinition, newDefinition)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`"v1"`, `"v2"`}, api.ifMatch)
+		assert.Equal(t, 1, api.gets)
+		// Both the input of the other update and the input of the plan are kept
+		assert.Equal(t, testProjectConfigDefinitionMap(map[string]interface{}{"region": "eu-de", "prefix": "app2"}), api.definition)
+	})
+
+	t.Run("concurrent update on each attempt", func(t *testing.T) {
+		concurrentUpdates := []map[string]interface{}{}
+		for i := 0; i < projectConfigUpdateAttempts; i++ {
+			concurrentUpdates = append(concurrentUpdates, map[string]interface{}{"description": fmt.Sprintf("update %d", i)})
+		}
+		api := &testProjectConfigDefinitionAPI{definition: oldDefinition, version: 1, concurrentUpdates: concurrentUpdates}
+		response, err := projectConfigUpdateDefinition(context.Background(), api, "project-1", "cfg-1", `"v1"`, oldDefinition, newDefinition)
+		assert.EqualError(t, err, "the configuration cfg-1 was modified by another update during each of the 3 update attempts: Precondition Failed")
+		assert.Equal(t, http.StatusPreconditionFailed, response.StatusCode)
+		assert.Len(t, api.ifMatch, projectConfigUpdateAttempts)
+		assert.Equal(t, projectConfigUpdateAttempts-1, api.gets)
+	})
+
+	t.Run("read error after a concurrent update", func(t *testing.T) {
+		api := &testProjectConfigDefinitionAPI{
+			definition:        oldDefinition,
+			version:           1,
+			concurrentUpdates: []map[string]interface{}{{"description": "other"}},
+			getErr:            errors.New("Internal Server Error"),
+		}
+		_, err := projectConfigUpdateDefinition(context.Background(), api, "project-1", "cfg-1", `"v1"`, oldDefinition, newDefinition)
+		assert.EqualError(t, err, "Internal Server Error")
+		assert.Len(t, api.ifMatch, 1)
+	})
+
+	t.Run("no ETag", func(t *testing.T) {
+		api := &testProjectConfigDefinitionAPI{
+			definition: testProjectConfigDefinitionMap(map[string]interface{}{"region": "eu-de", "prefix": "app"}),
+			noETag:     true,
+		}
+		_, err := projectConfigUpdateDefinition(context.Background(), api, "project-1", "cfg-1", "", oldDefinition, newDefinition)
+		assert.NoError(t, err)
+		// The configuration is read right before the update, which is sent without If-Match
+		assert.Equal(t, 1, api.gets)
+		assert.Equal(t, []string{""}, api.ifMatch)
+		assert.Equal(t, testProjectConfigDefinitionMap(map[string]interface{}{"region": "eu-de", "prefix": "app2"}), api.definition)
+	})
+}
+
+func TestProjectConfigDefinitionClientETag(t *testing.T) {
+	var ifMatch string
+	var updateBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Header().Set("ETag", `"3-a1b2c3"`)
+			_, _ = w.Write([]byte(`{"id": "cfg-1", "definition": {"name": "config", "inputs": {"region": "us-south"}, "stack_options": {"parallel": true}}}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		updateBody = nil
+		_ = json.Unmarshal(body, &updateBody)
+		ifMatch = r.Header.Get("If-Match")
+		_, _ = w.Write([]byte(`{"id": "cfg-1"}`))
+	}))
+	defer server.Close()
+
+	projectClient, err := projectv1.NewProjectV1(&projectv1.ProjectV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	assert.NoError(t, err)
+
+	for _, definitionJSON := range []bool{false, true} {
+		api := &projectConfigDefinitionClient{projectClient: projectClient, definitionJSON: definitionJSON}
+
+		definition, etag, _, err := api.GetConfigDefinition(context.Background(), "project-1", "cfg-1")
+		assert.NoError(t, err)
+		assert.Equal(t, `"3-a1b2c3"`, etag)
+		assert.Equal(t, "config", definition["name"])
+		assert.Equal(t, map[string]interface{}{"region": "us-south"}, definition["inputs"])
+
+		_, err = api.UpdateConfigDefinition(context.Background(), "project-1", "cfg-1", definition, etag)
+		assert.NoError(t, err)
+		assert.Equal(t, `"3-a1b2c3"`, ifMatch)
+
+		_, err = api.UpdateConfigDefinition(context.Background(), "project-1", "cfg-1", definition, "")
+		assert.NoError(t, err)
+		assert.Empty(t, ifMatch)
+
+		// The definition models of GetConfig and UpdateConfig drop the properties that they do not declare
+		sentDefinition, _ := updateBody["definition"].(map[string]interface{})
+		assert.Equal(t, "config", sentDefinition["name"])
+		if definitionJSON {
+			assert.Equal(t, map[string]interface{}{"parallel": true}, sentDefinition["stack_options"])
+		} else {
+			assert.NotContains(t, sentDefinition, "stack_options")
+		}
+
+		// A property that is cleared with null is sent as null
+		_, err = api.UpdateConfigDefinition(context.Background(), "project-1", "cfg-1", map[string]interface{}{"name": "config", "environment_id": nil}, etag)
+		assert.NoError(t, err)
+		sentDefinition, _ = updateBody["definition"].(map[string]interface{})
+		assert.Contains(t, sentDefinition, "environment_id")
+		assert.Nil(t, sentDefinition["environment_id"])
+	}
+}
+
+func TestProjectConfigDefinitionHasNull(t *testing.T) {
+	assert.False(t, projectConfigDefinitionHasNull(map[string]interface{}{"name": "config", "environment_id": "env-1"}))
+	// The null entries of the inputs are kept by the definition model
+	assert.False(t, projectConfigDefinitionHasNull(map[string]interface{}{"inputs": map[string]interface{}{"labels": nil}}))
+	assert.True(t, projectConfigDefinitionHasNull(map[string]interface{}{"name": "config", "environment_id": nil}))
+}
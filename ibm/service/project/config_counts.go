@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigStates are the states of a configuration that the Projects API reports. Every state has an entry in
+// config_state_counts, so that a state without configurations counts 0 instead of being missing.
+var projectConfigStates = []string{
+	"applied",
+	"apply_failed",
+	"approved",
+	"deleted",
+	"deleting",
+	"deleting_failed",
+	"deployed",
+	"deploying",
+	"deploying_failed",
+	"discarded",
+	"draft",
+	"superseded",
+	"undeploying",
+	"undeploying_failed",
+	"validated",
+	"validating",
+	"validating_failed",
+}
+
+// projectConfigUnknownState is the entry of config_state_counts of the configurations without a state.
+const projectConfigUnknownState = "unknown"
+
+// projectConfigStateCounts counts the configurations by state. The known states count 0 when no configuration is in
+// them, and the states that are not known yet are counted under their name.
+func projectConfigStateCounts(configs []projectv1.ProjectConfigSummary) map[string]interface{} {
+	counts := make(map[string]interface{}, len(projectConfigStates))
+	for _, state := range projectConfigStates {
+		counts[state] = 0
+	}
+	for _, config := range configs {
+		state := projectConfigUnknownState
+		if config.State != nil && *config.State != "" {
+			state = *config.State
+		}
+		count, _ := counts[state].(int)
+		counts[state] = count + 1
+	}
+	return counts
+}
+
+// projectConfigStateCountsOf counts the configurations of a project by state. The configuration summaries that are
+// embedded in the project are counted when there are any, otherwise the configurations are listed.
+func projectConfigStateCountsOf(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, embedded []projectv1.ProjectConfigSummary) (map[string]interface{}, error) {
+	configs := embedded
+	if len(configs) == 0 {
+		var err error
+		configs, err = projectListConfigs(context, projectClient, limiter, projectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return projectConfigStateCounts(configs), nil
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectConfigSummaries returns the summaries of configurations in the states, one per state
+func testProjectConfigSummaries(states ...string) []projectv1.ProjectConfigSummary {
+	configs := []projectv1.ProjectConfigSummary{}
+	for _, state := range states {
+		configs = append(configs, projectv1.ProjectConfigSummary{ID: core.StringPtr("config-" + state), State: core.StringPtr(state)})
+	}
+	return configs
+}
+
+func TestProjectConfigStateCounts(t *testing.T) {
+	// Every known state is counted, some of them more than once
+	configs := testProjectConfigSummaries(projectConfigStates...)
+	configs = append(configs, testProjectConfigSummaries("draft", "draft", "deployed", "validating_failed")...)
+	counts := projectConfigStateCounts(configs)
+	assert.Len(t, counts, len(projectConfigStates))
+	for _, state := range projectConfigStates {
+		expected := 1
+		switch state {
+		case "draft":
+			expected = 3
+		case "deployed", "validating_failed":
+			expected = 2
+		}
+		assert.Equal(t, expected, counts[state], state)
+	}
+
+	// The known states count 0 without configurations
+	counts = projectConfigStateCounts(nil)
+	assert.Len(t, counts, len(projectConfigStates))
+	for _, state := range projectConfigStates {
+		assert.Equal(t, 0, counts[state], state)
+	}
+
+	// The states that are not known yet, and the configurations without a state, are counted too
+	configs = append(testProjectConfigSummaries("migrating", "migrating"), projectv1.ProjectConfigSummary{ID: core.StringPtr("config")}, projectv1.ProjectConfigSummary{State: core.StringPtr("")})
+	counts = projectConfigStateCounts(configs)
+	assert.Len(t, counts, len(projectConfigStates)+2)
+	assert.Equal(t, 2, counts["migrating"])
+	assert.Equal(t, 2, counts[projectConfigUnknownState])
+}
+
+func TestProjectConfigStateCountsOf(t *testing.T) {
+	api := &testProjectConfigAPI{configs: testProjectConfigSummaries("draft", "deployed", "deployed")}
+
+	// The embedded summaries are counted without listing the configurations
+	counts, err := projectConfigStateCountsOf(context.Background(), api, nil, "project", testProjectConfigSummaries("approved"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, counts["approved"])
+	assert.Equal(t, 0, counts["deployed"])
+	assert.Equal(t, 0, api.listConfigsCalls)
+
+	counts, err = projectConfigStateCountsOf(context.Background(), api, nil, "project", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, counts["draft"])
+	assert.Equal(t, 2, counts["deployed"])
+	assert.Equal(t, 0, counts["approved"])
+	assert.Equal(t, 1, api.listConfigsCalls)
+
+	api.err = errors.New("Internal Server Error")
+	_, err = projectConfigStateCountsOf(context.Background(), api, nil, "project", nil)
+	assert.EqualError(t, err, "Internal Server Error")
+}
+
+func TestDataSourceIbmProjectsReadConfigCounts(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	api := &testProjectListAPI{projects: 3, clock: clock.Now}
+	configClient := &testProjectConfigAPI{configs: testProjectConfigSummaries("draft", "deployed")}
+
+	d := schema.TestResourceDataRaw(t, DataSourceIbmProjects().Schema, map[string]interface{}{})
+	diags := dataSourceIbmProjectsReadWithClient(context.Background(), d, api, configClient, nil)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, d.Get("projects.0.config_state_counts"))
+	assert.Equal(t, 0, configClient.listConfigsCalls)
+
+	d = schema.TestResourceDataRaw(t, DataSourceIbmProjects().Schema, map[string]interface{}{"include_config_counts": true})
+	diags = dataSourceIbmProjectsReadWithClient(context.Background(), d, api, configClient, nil)
+	assert.False(t, diags.HasError())
+	for i := 0; i < 3; i++ {
+		counts := d.Get("projects").([]interface{})[i].(map[string]interface{})["config_state_counts"].(map[string]interface{})
+		assert.Len(t, counts, len(projectConfigStates))
+		assert.Equal(t, 1, counts["draft"])
+		assert.Equal(t, 1, counts["deployed"])
+		assert.Equal(t, 0, counts["approved"])
+	}
+	assert.Equal(t, 3, configClient.listConfigsCalls)
+
+	configClient.err = errors.New("Forbidden")
+	diags = dataSourceIbmProjectsReadWithClient(context.Background(), d, api, configClient, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "ListConfigsWithContext failed for project project-000")
+}
@@ -26,6 +26,24 @@ func DataSourceIbmProject() *schema.Resource {
 				Required:    true,
 				Description: "The unique project ID.",
 			},
+			"include_configs": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the configurations of the project to populate `configs`, instead of using the configuration summaries that are embedded in the project.",
+			},
+			"include_config_counts": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to count the configurations of the project by state in `config_state_counts`. The configurations are listed when the project embeds no configuration summaries.",
+			},
+			"config_state_counts": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The number of configurations of the project by state. Every known state has an entry, 0 when no configuration is in it. Only set when `include_config_counts` is true.",
+			},
 			"crn": &schema.Schema{
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -90,6 +108,11 @@ func DataSourceIbmProject() *schema.Resource {
 				Computed:    true,
 				Description: "A URL.",
 			},
+			"region": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the project, parsed from its CRN or its href. Empty when they do not name a region.",
+			},
 			"resource_group": &schema.Schema{
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -478,6 +501,12 @@ func dataSourceIbmProjectRead(context context.Context, d *schema.ResourceData, m
 		return tfErr.GetDiag()
 	}
 
+	region := projectRegion(project.Crn, project.Href)
+	if err = d.Set("region", region); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting region: %s", err), "(Data) ibm_project", "read")
+		return tfErr.GetDiag()
+	}
+
 	if err = d.Set("resource_group", project.ResourceGroup); err != nil {
 		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting resource_group: %s", err), "(Data) ibm_project", "read")
 		return tfErr.GetDiag()
@@ -488,16 +517,35 @@ func dataSourceIbmProjectRead(context context.Context, d *schema.ResourceData, m
 		return tfErr.GetDiag()
 	}
 
+	configSummaries := project.Configs
+	if d.Get("include_configs").(bool) {
+		configSummaries, err = projectListConfigs(context, projectClient, projectRateLimiterFor(meta), *getProjectOptions.ID)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed: %s", err.Error()), "(Data) ibm_project", "read")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+	}
+	if d.Get("include_config_counts").(bool) {
+		configStateCounts, err := projectConfigStateCountsOf(context, projectClient, projectRateLimiterFor(meta), *getProjectOptions.ID, configSummaries)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed: %s", err.Error()), "(Data) ibm_project", "read")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+		if err = d.Set("config_state_counts", configStateCounts); err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting config_state_counts: %s", err), "(Data) ibm_project", "read")
+			return tfErr.GetDiag()
+		}
+	}
 	configs := []map[string]interface{}{}
-	if project.Configs != nil {
-		for _, modelItem := range project.Configs {
-			modelMap, err := dataSourceIbmProjectProjectConfigSummaryToMap(&modelItem)
-			if err != nil {
-				tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project", "read")
-				return tfErr.GetDiag()
-			}
-			configs = append(configs, modelMap)
+	for _, modelItem := range configSummaries {
+		modelMap, err := dataSourceIbmProjectProjectConfigSummaryToMap(&modelItem)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project", "read")
+			return tfErr.GetDiag()
 		}
+		configs = append(configs, modelMap)
 	}
 	if err = d.Set("configs", configs); err != nil {
 		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting configs: %s", err), "(Data) ibm_project", "read")
@@ -534,7 +582,7 @@ func dataSourceIbmProjectRead(context context.Context, d *schema.ResourceData, m
 		return tfErr.GetDiag()
 	}
 
-	return nil
+	return projectRegionMismatchWarnings(fmt.Sprintf("Project %s", *getProjectOptions.ID), region, projectProviderRegion(meta))
 }
 
 func dataSourceIbmProjectCumulativeNeedsAttentionToMap(model *projectv1.CumulativeNeedsAttention) (map[string]interface{}, error) {
@@ -673,10 +721,8 @@ func dataSourceIbmProjectProjectEnvironmentSummaryDefinitionToMap(model *project
 func dataSourceIbmProjectProjectDefinitionPropertiesToMap(model *projectv1.ProjectDefinitionProperties) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
 	modelMap["name"] = model.Name
-	modelMap["destroy_on_delete"] = model.DestroyOnDelete
+	modelMap["destroy_on_delete"] = projectDefinitionFlag(model.DestroyOnDelete, projectDefinitionDestroyOnDeleteDefault)
 	modelMap["description"] = model.Description
-	if model.MonitoringEnabled != nil {
-		modelMap["monitoring_enabled"] = model.MonitoringEnabled
-	}
+	modelMap["monitoring_enabled"] = projectDefinitionFlag(model.MonitoringEnabled, projectDefinitionMonitoringEnabledDefault)
 	return modelMap, nil
 }
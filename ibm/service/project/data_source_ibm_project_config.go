@@ -5,15 +5,16 @@ package project
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/project-go-sdk/projectv1"
 )
 
@@ -32,16 +33,103 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 				Required:    true,
 				Description: "The unique configuration ID.",
 			},
+			"attention_warnings": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to emit a warning for each needs attention event of the configuration with severity ERROR.",
+			},
+			"acknowledged_event_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(projectConfigEventIDPattern, "must be the event_id of a needs attention event, not the name of the event"),
+				},
+				Description: "The IDs of needs attention events that are resolved. They are excluded from needs_attention_state, has_errors and the attention warnings.",
+			},
+			"include_deployed_resources": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the resources of the configuration to set deployed_resource_crns.",
+			},
+			"resolve_environment_inputs": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to read the inputs of the environment of the configuration to classify them in input_sources.",
+			},
+			"resolve_locator": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to query the catalog for the version that an available update would update the configuration to, to set available_update.",
+			},
+			"exclude_outputs": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"outputs_filter"},
+				Description:   "Whether to leave the outputs of the configuration out of the state, to keep it small when the outputs are large. When set, outputs reads as empty even if the configuration has outputs.",
+			},
+			"outputs_filter": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the outputs to keep in outputs. The other outputs are left out of the state and read as empty, as are the names that match no output.",
+			},
+			"include_prerequisite_status": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to list the configurations of the project to keep only the prerequisites that are not deployed yet in prerequisite_config_ids, to report the references to configurations that do not exist in missing_prerequisites, and to read the state code of the configuration into awaiting_prerequisites.",
+			},
+			"include_last_monitoring": projectConfigIncludeLastMonitoringSchema("Whether to read the last monitoring job of the configuration into last_monitoring, with an additional request."),
+			"awaiting_prerequisites": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the configuration waits for the configurations that it references to be deployed, when its state_code is awaiting_prerequisite. It is only read when include_prerequisite_status is set.",
+			},
+			"prerequisite_config_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The configurations that the inputs of the configuration reference with ref:/configs/<ID or name>. When include_prerequisite_status is set, only the IDs of those that are not deployed yet.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"missing_prerequisites": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The references of the inputs to configurations that do not exist in the project, such as deleted configurations. It is only read when include_prerequisite_status is set.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"input_sources": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The source of each effective input of the configuration: config, environment or reference.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"version": &schema.Schema{
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Description: "The version of the configuration.",
 			},
+			"labels": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The labels of the configuration, read from the reserved `labels` input of the definition.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"is_draft": &schema.Schema{
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Description: "The flag that indicates whether the version of the configuration is draft, or active.",
 			},
+			"has_errors": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether one of the events of needs_attention_state has severity ERROR.",
+			},
 			"needs_attention_state": &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -101,10 +189,20 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 				Computed:    true,
 				Description: "A date and time value in the format YYYY-MM-DDTHH:mm:ssZ or YYYY-MM-DDTHH:mm:ss.sssZ to match the date and time format as specified by RFC 3339.",
 			},
+			"last_state_change_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "An estimate of when the configuration reached its current state, the most recent of modified_at and of the timestamps of the needs attention events. Not set when neither is known.",
+			},
+			"previous_state": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the configuration before its current state, such as validating for validated. Only set for the states that can be reached from a single state.",
+			},
 			"outputs": &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
-				Description: "The outputs of a Schematics template property.",
+				Description: "The outputs of a Schematics template property. It is empty when exclude_outputs is set, and only holds the outputs of outputs_filter when it is set.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": &schema.Schema{
@@ -119,9 +217,9 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 						},
 						"value": &schema.Schema{
 							Type:        schema.TypeMap,
-							Deprecated:  "This property will be deprecated, the new property will be of type String.",
+							Deprecated:  "Use value_json, which holds the output value of every type as JSON. value is only set for the outputs whose value is an object.",
 							Computed:    true,
-							Description: "This property can be any value - a string, number, boolean, array, or object.",
+							Description: "The entries of the output value when it is an object. Strings are kept as is and the other entries are JSON encoded. It is empty for the values that are not objects.",
 						},
 						"value_json": &schema.Schema{
 							Type:        schema.TypeString,
@@ -337,11 +435,40 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 				Computed:    true,
 				Description: "The flag that indicates whether a configuration update is available.",
 			},
+			"available_update": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The version that the configuration would be updated to, when update_available is true and resolve_locator is set. It is best-effort: the Projects API does not return it, so it is derived from the catalog as the latest newer version of the same offering, kind and flavor, and it is empty when the catalog has none.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"locator_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The locator ID of the version in the catalog.",
+						},
+						"version": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version number.",
+						},
+						"release_notes_url": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The documentation URL of the offering in the catalog, where the release notes of its versions are published, when the offering has one.",
+						},
+					},
+				},
+			},
 			"href": &schema.Schema{
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "A URL.",
 			},
+			"region": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the project of the configuration, parsed from the CRN of the project or the href of the configuration. Empty when they do not name a region.",
+			},
 			"definition": &schema.Schema{
 				Type:     schema.TypeList,
 				Computed: true,
@@ -450,6 +577,25 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"members": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The member configurations of a stack configuration.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the member configuration.",
+									},
+									"config_id": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the member configuration.",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -539,6 +685,72 @@ func DataSourceIbmProjectConfig() *schema.Resource {
 					},
 				},
 			},
+			"deployed_resource_crns": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The CRNs of the resources that are deployed by the configuration. It is set when include_deployed_resources is true and the configuration has a deployed version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_monitoring": projectConfigLastMonitoringSchema(),
+			"cost_estimate": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The cost estimate of the configuration that was produced by its last validation. The costs are strings to preserve their decimal precision.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the cost estimate of the configuration.",
+						},
+						"currency": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The currency of the cost estimate of the configuration.",
+						},
+						"total_hourly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The total hourly cost estimate of the configuration.",
+						},
+						"total_monthly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The total monthly cost estimate of the configuration.",
+						},
+						"past_total_hourly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The past total hourly cost estimate of the configuration.",
+						},
+						"past_total_monthly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The past total monthly cost estimate of the configuration.",
+						},
+						"diff_total_hourly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The difference between the current and past total hourly cost estimates of the configuration.",
+						},
+						"diff_total_monthly_cost": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The difference between the current and past total monthly cost estimates of the configuration.",
+						},
+						"time_generated": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A date and time value in the format YYYY-MM-DDTHH:mm:ssZ or YYYY-MM-DDTHH:mm:ss.sssZ to match the date and time format as specified by RFC 3339.",
+						},
+						"user_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique ID of the user that triggered the validation.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -550,7 +762,22 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
 		return tfErr.GetDiag()
 	}
+	var catalogClient projectCatalogAPI
+	if d.Get("resolve_locator").(bool) {
+		catalogClient, err = meta.(conns.ClientSession).CatalogManagementV1()
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config", "read")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+	}
+	return dataSourceIbmProjectConfigReadWithClient(context, d, projectClient, projectClient, &projectConfigRawClient{projectClient: projectClient}, catalogClient, projectProviderRegion(meta))
+}
 
+// dataSourceIbmProjectConfigReadWithClient reads the configuration with the given clients into the data source. A
+// warning is returned when the project is not in providerRegion, which is empty when the region is unknown. The
+// catalogClient is only used when resolve_locator is set.
+func dataSourceIbmProjectConfigReadWithClient(context context.Context, d *schema.ResourceData, projectClient projectConfigAPI, environmentClient projectEnvironmentGetAPI, rawClient projectConfigRawAPI, catalogClient projectCatalogAPI, providerRegion string) diag.Diagnostics {
 	getConfigOptions := &projectv1.GetConfigOptions{}
 
 	getConfigOptions.SetProjectID(d.Get("project_id").(string))
@@ -575,9 +802,10 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
+	needsAttentionEvents := projectConfigUnacknowledgedEvents(projectConfig.NeedsAttentionState, d.Get("acknowledged_event_ids").(*schema.Set).List())
 	needsAttentionState := []map[string]interface{}{}
-	if projectConfig.NeedsAttentionState != nil {
-		for _, modelItem := range projectConfig.NeedsAttentionState {
+	if needsAttentionEvents != nil {
+		for _, modelItem := range needsAttentionEvents {
 			modelMap, err := dataSourceIbmProjectConfigProjectConfigNeedsAttentionStateToMap(&modelItem)
 			if err != nil {
 				tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config", "read")
@@ -590,6 +818,10 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting needs_attention_state: %s", err), "(Data) ibm_project_config", "read")
 		return tfErr.GetDiag()
 	}
+	if err = d.Set("has_errors", projectConfigHasErrors(needsAttentionEvents)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting has_errors: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
 
 	if err = d.Set("created_at", flex.DateTimeToString(projectConfig.CreatedAt)); err != nil {
 		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting created_at: %s", err), "(Data) ibm_project_config", "read")
@@ -606,9 +838,10 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
-	outputs := []map[string]interface{}{}
-	if projectConfig.Outputs != nil {
-		for _, modelItem := range projectConfig.Outputs {
+	// The outputs can be large, they are filtered after the read to keep them out of the state
+	if !d.Get("exclude_outputs").(bool) {
+		outputs := []map[string]interface{}{}
+		for _, modelItem := range projectConfigFilterOutputs(projectConfig.Outputs, flex.ExpandStringList(d.Get("outputs_filter").([]interface{}))) {
 			modelMap, err := dataSourceIbmProjectConfigOutputValueToMap(&modelItem)
 			if err != nil {
 				tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config", "read")
@@ -616,10 +849,10 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 			}
 			outputs = append(outputs, modelMap)
 		}
-	}
-	if err = d.Set("outputs", outputs); err != nil {
-		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting outputs: %s", err), "(Data) ibm_project_config", "read")
-		return tfErr.GetDiag()
+		if err = d.Set("outputs", outputs); err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting outputs: %s", err), "(Data) ibm_project_config", "read")
+			return tfErr.GetDiag()
+		}
 	}
 
 	project := []map[string]interface{}{}
@@ -655,6 +888,20 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
+	if lastStateChangeAt := projectConfigLastStateChangeAt(projectConfig); lastStateChangeAt != "" {
+		if err = d.Set("last_state_change_at", lastStateChangeAt); err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting last_state_change_at: %s", err), "(Data) ibm_project_config", "read")
+			return tfErr.GetDiag()
+		}
+	}
+
+	if previousState := projectConfigPreviousState(projectConfig); previousState != "" {
+		if err = d.Set("previous_state", previousState); err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting previous_state: %s", err), "(Data) ibm_project_config", "read")
+			return tfErr.GetDiag()
+		}
+	}
+
 	if err = d.Set("update_available", projectConfig.UpdateAvailable); err != nil {
 		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting update_available: %s", err), "(Data) ibm_project_config", "read")
 		return tfErr.GetDiag()
@@ -665,13 +912,30 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
+	region := projectRegion(projectReferenceCrn(projectConfig.Project), projectConfig.Href)
+	if err = d.Set("region", region); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting region: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
 	definition := []map[string]interface{}{}
-	if projectConfig.Definition != nil {
+	var labels map[string]interface{}
+	var configInputs map[string]interface{}
+	environmentID := ""
+	// A half-created configuration can be returned with a definition of a known type but no value.
+	if !core.IsNil(projectConfig.Definition) {
 		modelMap, err := dataSourceIbmProjectConfigProjectConfigDefinitionResponseToMap(projectConfig.Definition)
 		if err != nil {
 			tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config", "read")
 			return tfErr.GetDiag()
 		}
+		if inputs, ok := modelMap["inputs"].(map[string]interface{}); ok {
+			labels, _ = projectConfigLabelsFromInputs(inputs)
+			configInputs = inputs
+		}
+		if id, ok := modelMap["environment_id"].(*string); ok && id != nil {
+			environmentID = *id
+		}
 		definition = append(definition, modelMap)
 	}
 	if err = d.Set("definition", definition); err != nil {
@@ -679,6 +943,11 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
+	if err = d.Set("labels", labels); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting labels: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
 	approvedVersion := []map[string]interface{}{}
 	if projectConfig.ApprovedVersion != nil {
 		modelMap, err := dataSourceIbmProjectConfigProjectConfigVersionSummaryToMap(projectConfig.ApprovedVersion)
@@ -707,13 +976,190 @@ func dataSourceIbmProjectConfigRead(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
-	return nil
+	costEstimate := []map[string]interface{}{}
+	if projectConfig.LastValidated != nil && projectConfig.LastValidated.CostEstimate != nil {
+		costEstimate = append(costEstimate, dataSourceIbmProjectConfigProjectConfigMetadataCostEstimateToMap(projectConfig.LastValidated.CostEstimate))
+	}
+	if err = d.Set("cost_estimate", costEstimate); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting cost_estimate: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
+	deployedResourceCRNs := []string{}
+	if d.Get("include_deployed_resources").(bool) && projectConfig.DeployedVersion != nil {
+		deployedResourceCRNs, err = dataSourceIbmProjectConfigListDeployedResourceCRNs(context, projectClient, *getConfigOptions.ProjectID, *getConfigOptions.ID)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigResourcesWithContext failed: %s", err.Error()), "(Data) ibm_project_config", "read")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+	}
+	if err = d.Set("deployed_resource_crns", deployedResourceCRNs); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting deployed_resource_crns: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
+	diags := projectRegionMismatchWarnings(fmt.Sprintf("The project of configuration %s", *getConfigOptions.ID), region, providerRegion)
+
+	// The last monitoring job and the state code are not in the projectv1 models, they are read from the raw
+	// configuration, with an additional request that is only made when they are asked for
+	includeLastMonitoring := d.Get("include_last_monitoring").(bool)
+	includePrerequisiteStatus := d.Get("include_prerequisite_status").(bool)
+	lastMonitoring := []map[string]interface{}{}
+	stateCode := ""
+	if includeLastMonitoring || includePrerequisiteStatus {
+		rawProperties, _, err := rawClient.GetConfigRawProperties(context, *getConfigOptions.ProjectID, *getConfigOptions.ID)
+		if err == nil && includeLastMonitoring {
+			lastMonitoring, err = projectConfigLastMonitoringToMap(rawProperties["last_monitoring"])
+		}
+		if err == nil && includePrerequisiteStatus {
+			stateCode, err = projectConfigStateCode(rawProperties)
+		}
+		if err != nil {
+			lastMonitoring = []map[string]interface{}{}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The last monitoring job and the state code of configuration %s could not be read, last_monitoring is empty and awaiting_prerequisites is false", *getConfigOptions.ID),
+				Detail:   err.Error(),
+			})
+		}
+	}
+	if err = d.Set("last_monitoring", lastMonitoring); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting last_monitoring: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("awaiting_prerequisites", stateCode == projectConfigStateCodeAwaitingPrerequisite); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting awaiting_prerequisites: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
+	configName, _ := d.Get("definition.0.name").(string)
+	prerequisiteConfigIDs := projectConfigPrerequisiteRefs(configInputs, *getConfigOptions.ID, configName)
+	missingPrerequisites := []string{}
+	if includePrerequisiteStatus && len(prerequisiteConfigIDs) > 0 {
+		prerequisiteConfigIDs, missingPrerequisites, err = projectConfigPendingPrerequisites(context, projectClient, *getConfigOptions.ProjectID, prerequisiteConfigIDs)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed: %s", err.Error()), "(Data) ibm_project_config", "read")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+	}
+	if err = d.Set("prerequisite_config_ids", prerequisiteConfigIDs); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting prerequisite_config_ids: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("missing_prerequisites", missingPrerequisites); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting missing_prerequisites: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+
+	// When the inputs of the environment cannot be read, only the inputs of the configuration are classified
+	var environmentInputs map[string]interface{}
+	if d.Get("resolve_environment_inputs").(bool) && environmentID != "" {
+		environmentInputs, err = projectConfigEnvironmentInputs(context, environmentClient, *getConfigOptions.ProjectID, environmentID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The inputs of the environment of configuration %s could not be read, input_sources only classifies the inputs of the configuration", *getConfigOptions.ID),
+				Detail:   err.Error(),
+			})
+		}
+	}
+	if err = d.Set("input_sources", projectConfigInputSources(configInputs, environmentInputs)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting input_sources: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+	// The available update is best-effort, a catalog that cannot be read only leaves it empty
+	availableUpdate := []map[string]interface{}{}
+	locatorID, _ := d.Get("definition.0.locator_id").(string)
+	if d.Get("resolve_locator").(bool) && projectConfig.UpdateAvailable != nil && *projectConfig.UpdateAvailable && locatorID != "" {
+		availableUpdateMap, err := projectConfigAvailableUpdate(context, catalogClient, locatorID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The available update of configuration %s could not be read from the catalog, available_update is empty", *getConfigOptions.ID),
+				Detail:   err.Error(),
+			})
+		} else if availableUpdateMap != nil {
+			availableUpdate = append(availableUpdate, availableUpdateMap)
+		}
+	}
+	if err = d.Set("available_update", availableUpdate); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting available_update: %s", err), "(Data) ibm_project_config", "read")
+		return tfErr.GetDiag()
+	}
+	if d.Get("attention_warnings").(bool) {
+		diags = append(diags, projectConfigNeedsAttentionWarnings(*getConfigOptions.ID, needsAttentionEvents)...)
+	}
+	return diags
+}
+
+// dataSourceIbmProjectConfigListDeployedResourceCRNs lists the resources of the configuration and returns their CRNs,
+// in the order of the listing and without duplicates.
+func dataSourceIbmProjectConfigListDeployedResourceCRNs(context context.Context, projectClient projectConfigAPI, projectID string, configID string) ([]string, error) {
+	resources, _, err := projectListConfigResources(context, projectClient, nil, projectID, configID)
+	if err != nil {
+		return nil, err
+	}
+	return projectConfigAppendResourceCRNs([]string{}, map[string]bool{}, resources), nil
+}
+
+// projectConfigAppendResourceCRNs appends the CRNs of a page of resources to crns. The resources without a CRN and
+// the CRNs that are already in seen are skipped.
+func projectConfigAppendResourceCRNs(crns []string, seen map[string]bool, resources []projectv1.ProjectConfigResource) []string {
+	for _, resource := range resources {
+		if resource.ResourceCrn == nil || *resource.ResourceCrn == "" || seen[*resource.ResourceCrn] {
+			continue
+		}
+		seen[*resource.ResourceCrn] = true
+		crns = append(crns, *resource.ResourceCrn)
+	}
+	return crns
+}
+
+func dataSourceIbmProjectConfigProjectConfigMetadataCostEstimateToMap(model *projectv1.ProjectConfigMetadataCostEstimate) map[string]interface{} {
+	modelMap := make(map[string]interface{})
+	if model.Version != nil {
+		modelMap["version"] = model.Version
+	}
+	if model.Currency != nil {
+		modelMap["currency"] = model.Currency
+	}
+	if model.TotalHourlyCost != nil {
+		modelMap["total_hourly_cost"] = model.TotalHourlyCost
+	}
+	if model.TotalMonthlyCost != nil {
+		modelMap["total_monthly_cost"] = model.TotalMonthlyCost
+	}
+	if model.PastTotalHourlyCost != nil {
+		modelMap["past_total_hourly_cost"] = model.PastTotalHourlyCost
+	}
+	if model.PastTotalMonthlyCost != nil {
+		modelMap["past_total_monthly_cost"] = model.PastTotalMonthlyCost
+	}
+	if model.DiffTotalHourlyCost != nil {
+		modelMap["diff_total_hourly_cost"] = model.DiffTotalHourlyCost
+	}
+	if model.DiffTotalMonthlyCost != nil {
+		modelMap["diff_total_monthly_cost"] = model.DiffTotalMonthlyCost
+	}
+	if model.TimeGenerated != nil {
+		modelMap["time_generated"] = flex.DateTimeToString(model.TimeGenerated)
+	}
+	if model.UserID != nil {
+		modelMap["user_id"] = model.UserID
+	}
+	return modelMap
 }
 
 func dataSourceIbmProjectConfigProjectConfigNeedsAttentionStateToMap(model *projectv1.ProjectConfigNeedsAttentionState) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
-	modelMap["event_id"] = model.EventID
-	modelMap["event"] = model.Event
+	if model.EventID != nil {
+		modelMap["event_id"] = model.EventID
+	}
+	if model.Event != nil {
+		modelMap["event"] = model.Event
+	}
 	if model.Severity != nil {
 		modelMap["severity"] = model.Severity
 	}
@@ -726,24 +1172,23 @@ func dataSourceIbmProjectConfigProjectConfigNeedsAttentionStateToMap(model *proj
 	if model.TriggeredBy != nil {
 		modelMap["triggered_by"] = model.TriggeredBy
 	}
-	modelMap["timestamp"] = model.Timestamp
+	if model.Timestamp != nil {
+		modelMap["timestamp"] = model.Timestamp
+	}
 	return modelMap, nil
 }
 
 func dataSourceIbmProjectConfigOutputValueToMap(model *projectv1.OutputValue) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
-	modelMap["name"] = model.Name
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
 	if model.Description != nil {
 		modelMap["description"] = model.Description
 	}
 	if model.Value != nil {
 		modelMap["value_json"] = stringify(model.Value)
-		jsonStr, err := json.Marshal(model.Value)
-		if err != nil {
-			b := []byte(jsonStr)
-			var f interface{}
-			json.Unmarshal(b, &f)
-			valueMap := f.(map[string]interface{})
+		if valueMap, ok := projectOutputValueMap(model.Value); ok {
 			modelMap["value"] = valueMap
 		}
 	}
@@ -752,20 +1197,30 @@ func dataSourceIbmProjectConfigOutputValueToMap(model *projectv1.OutputValue) (m
 
 func dataSourceIbmProjectConfigProjectReferenceToMap(model *projectv1.ProjectReference) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
-	modelMap["id"] = model.ID
-	modelMap["href"] = model.Href
-	definitionMap, err := dataSourceIbmProjectConfigProjectDefinitionReferenceToMap(model.Definition)
-	if err != nil {
-		return modelMap, err
+	if model.ID != nil {
+		modelMap["id"] = model.ID
+	}
+	if model.Href != nil {
+		modelMap["href"] = model.Href
+	}
+	if model.Definition != nil {
+		definitionMap, err := dataSourceIbmProjectConfigProjectDefinitionReferenceToMap(model.Definition)
+		if err != nil {
+			return modelMap, err
+		}
+		modelMap["definition"] = []map[string]interface{}{definitionMap}
+	}
+	if model.Crn != nil {
+		modelMap["crn"] = model.Crn
 	}
-	modelMap["definition"] = []map[string]interface{}{definitionMap}
-	modelMap["crn"] = model.Crn
 	return modelMap, nil
 }
 
 func dataSourceIbmProjectConfigProjectDefinitionReferenceToMap(model *projectv1.ProjectDefinitionReference) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
-	modelMap["name"] = model.Name
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
 	return modelMap, nil
 }
 
@@ -838,6 +1293,8 @@ func dataSourceIbmProjectConfigProjectConfigDefinitionResponseToMap(model projec
 		return dataSourceIbmProjectConfigProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponseToMap(model.(*projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse))
 	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse); ok {
 		return dataSourceIbmProjectConfigProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponseToMap(model.(*projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse))
+	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties); ok {
+		return dataSourceIbmProjectConfigProjectConfigDefinitionResponseStackConfigDefinitionPropertiesToMap(model.(*projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties))
 	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponse); ok {
 		modelMap := make(map[string]interface{})
 		model := model.(*projectv1.ProjectConfigDefinitionResponse)
@@ -936,8 +1393,12 @@ func dataSourceIbmProjectConfigProjectConfigDefinitionResponseDAConfigDefinition
 	if model.LocatorID != nil {
 		modelMap["locator_id"] = model.LocatorID
 	}
-	modelMap["description"] = model.Description
-	modelMap["name"] = model.Name
+	if model.Description != nil {
+		modelMap["description"] = model.Description
+	}
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
 	if model.EnvironmentID != nil {
 		modelMap["environment_id"] = model.EnvironmentID
 	}
@@ -970,8 +1431,50 @@ func dataSourceIbmProjectConfigProjectConfigDefinitionResponseResourceConfigDefi
 	if model.ResourceCrns != nil {
 		modelMap["resource_crns"] = model.ResourceCrns
 	}
-	modelMap["description"] = model.Description
-	modelMap["name"] = model.Name
+	if model.Description != nil {
+		modelMap["description"] = model.Description
+	}
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
+	if model.EnvironmentID != nil {
+		modelMap["environment_id"] = model.EnvironmentID
+	}
+	if model.Authorizations != nil {
+		authorizationsMap, err := dataSourceIbmProjectConfigProjectConfigAuthToMap(model.Authorizations)
+		if err != nil {
+			return modelMap, err
+		}
+		modelMap["authorizations"] = []map[string]interface{}{authorizationsMap}
+	}
+	if model.Inputs != nil {
+		inputs := make(map[string]interface{})
+		for k, v := range model.Inputs {
+			inputs[k] = fmt.Sprintf("%v", v)
+		}
+		modelMap["inputs"] = inputs
+	}
+	if model.Settings != nil {
+		settings := make(map[string]interface{})
+		for k, v := range model.Settings {
+			settings[k] = fmt.Sprintf("%v", v)
+		}
+		modelMap["settings"] = settings
+	}
+	return modelMap, nil
+}
+
+func dataSourceIbmProjectConfigProjectConfigDefinitionResponseStackConfigDefinitionPropertiesToMap(model *projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	if model.LocatorID != nil {
+		modelMap["locator_id"] = model.LocatorID
+	}
+	if model.Description != nil {
+		modelMap["description"] = model.Description
+	}
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
 	if model.EnvironmentID != nil {
 		modelMap["environment_id"] = model.EnvironmentID
 	}
@@ -996,19 +1499,47 @@ func dataSourceIbmProjectConfigProjectConfigDefinitionResponseResourceConfigDefi
 		}
 		modelMap["settings"] = settings
 	}
+	if model.Members != nil {
+		members := []map[string]interface{}{}
+		for _, membersItem := range model.Members {
+			membersItemMap, err := dataSourceIbmProjectConfigStackConfigMemberToMap(&membersItem)
+			if err != nil {
+				return modelMap, err
+			}
+			members = append(members, membersItemMap)
+		}
+		modelMap["members"] = members
+	}
+	return modelMap, nil
+}
+
+func dataSourceIbmProjectConfigStackConfigMemberToMap(model *projectv1.StackConfigMember) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	if model.Name != nil {
+		modelMap["name"] = model.Name
+	}
+	if model.ConfigID != nil {
+		modelMap["config_id"] = model.ConfigID
+	}
 	return modelMap, nil
 }
 
 func dataSourceIbmProjectConfigProjectConfigVersionSummaryToMap(model *projectv1.ProjectConfigVersionSummary) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
-	definitionMap, err := dataSourceIbmProjectConfigProjectConfigVersionDefinitionSummaryToMap(model.Definition)
-	if err != nil {
-		return modelMap, err
+	if model.Definition != nil {
+		definitionMap, err := dataSourceIbmProjectConfigProjectConfigVersionDefinitionSummaryToMap(model.Definition)
+		if err != nil {
+			return modelMap, err
+		}
+		modelMap["definition"] = []map[string]interface{}{definitionMap}
+	}
+	if model.State != nil {
+		modelMap["state"] = model.State
 	}
-	modelMap["definition"] = []map[string]interface{}{definitionMap}
-	modelMap["state"] = model.State
 	modelMap["version"] = flex.IntValue(model.Version)
-	modelMap["href"] = model.Href
+	if model.Href != nil {
+		modelMap["href"] = model.Href
+	}
 	return modelMap, nil
 }
 
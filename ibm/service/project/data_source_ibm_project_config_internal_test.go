@@ -0,0 +1,598 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceIbmProjectConfigCostEstimateToMap(t *testing.T) {
+	timeGenerated := strfmt.DateTime(time.Date(2024, 4, 2, 9, 30, 0, 0, time.UTC))
+	model := &projectv1.ProjectConfigMetadataCostEstimate{
+		Version:              core.StringPtr("0.1"),
+		Currency:             core.StringPtr("USD"),
+		TotalHourlyCost:      core.StringPtr("0.0123456789"),
+		TotalMonthlyCost:     core.StringPtr("9.0123456789"),
+		PastTotalHourlyCost:  core.StringPtr("0.01"),
+		PastTotalMonthlyCost: core.StringPtr("7.3"),
+		DiffTotalHourlyCost:  core.StringPtr("0.0023456789"),
+		DiffTotalMonthlyCost: core.StringPtr("1.7123456789"),
+		TimeGenerated:        &timeGenerated,
+		UserID:               core.StringPtr("IBMid-1234"),
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"version":                 model.Version,
+		"currency":                model.Currency,
+		"total_hourly_cost":       model.TotalHourlyCost,
+		"total_monthly_cost":      model.TotalMonthlyCost,
+		"past_total_hourly_cost":  model.PastTotalHourlyCost,
+		"past_total_monthly_cost": model.PastTotalMonthlyCost,
+		"diff_total_hourly_cost":  model.DiffTotalHourlyCost,
+		"diff_total_monthly_cost": model.DiffTotalMonthlyCost,
+		"time_generated":          "2024-04-02T09:30:00.000Z",
+		"user_id":                 model.UserID,
+	}, dataSourceIbmProjectConfigProjectConfigMetadataCostEstimateToMap(model))
+
+	assert.Equal(t, map[string]interface{}{}, dataSourceIbmProjectConfigProjectConfigMetadataCostEstimateToMap(&projectv1.ProjectConfigMetadataCostEstimate{}))
+}
+
+func TestProjectConfigAppendResourceCRNs(t *testing.T) {
+	seen := map[string]bool{}
+	crns := projectConfigAppendResourceCRNs([]string{}, seen, nil)
+	assert.Equal(t, []string{}, crns)
+
+	crns = projectConfigAppendResourceCRNs(crns, seen, []projectv1.ProjectConfigResource{
+		{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-1")},
+		{ResourceCrn: nil, ResourceName: core.StringPtr("unnamed")},
+		{ResourceCrn: core.StringPtr("")},
+		{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::subnet:subnet-1")},
+		{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-1")},
+	})
+	assert.Equal(t, []string{
+		"crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-1",
+		"crn:v1:bluemix:public:is:us-south:a/account::subnet:subnet-1",
+	}, crns)
+
+	// The CRNs of a later page that were seen on an earlier page are not repeated.
+	crns = projectConfigAppendResourceCRNs(crns, seen, []projectv1.ProjectConfigResource{
+		{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::subnet:subnet-1")},
+		{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::instance:instance-1")},
+	})
+	assert.Equal(t, []string{
+		"crn:v1:bluemix:public:is:us-south:a/account::vpc:vpc-1",
+		"crn:v1:bluemix:public:is:us-south:a/account::subnet:subnet-1",
+		"crn:v1:bluemix:public:is:us-south:a/account::instance:instance-1",
+	}, crns)
+}
+
+// testProjectConfigAPI fakes the projectv1 client with a single configuration and its resources
+type testProjectConfigAPI struct {
+	config                   *projectv1.ProjectConfig
+	resources                []projectv1.ProjectConfigResource
+	err                      error
+	listConfigResourcesCalls int
+	environments             testProjectEnvironmentGetAPI
+	lastMonitoring           json.RawMessage
+	stateCode                string
+	rawErr                   error
+	rawCalls                 int
+	configs                  []projectv1.ProjectConfigSummary
+	listConfigsCalls         int
+}
+
+func (api *testProjectConfigAPI) GetConfigWithContext(ctx context.Context, getConfigOptions *projectv1.GetConfigOptions) (*projectv1.ProjectConfig, *core.DetailedResponse, error) {
+	if api.err != nil {
+		return nil, &core.DetailedResponse{StatusCode: 404}, api.err
+	}
+	return api.config, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func (api *testProjectConfigAPI) ListConfigsWithContext(ctx context.Context, listConfigsOptions *projectv1.ListConfigsOptions) (*projectv1.ProjectConfigCollection, *core.DetailedResponse, error) {
+	api.listConfigsCalls++
+	if api.err != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.err
+	}
+	return &projectv1.ProjectConfigCollection{Configs: api.configs}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func (api *testProjectConfigAPI) ListConfigResourcesWithContext(ctx context.Context, listConfigResourcesOptions *projectv1.ListConfigResourcesOptions) (*projectv1.ProjectConfigResourceCollection, *core.DetailedResponse, error) {
+	api.listConfigResourcesCalls++
+	return &projectv1.ProjectConfigResourceCollection{
+		Resources:      api.resources,
+		ResourcesCount: core.Int64Ptr(int64(len(api.resources))),
+	}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func (api *testProjectConfigAPI) GetConfigRawProperties(ctx context.Context, projectID string, configID string) (map[string]json.RawMessage, *core.DetailedResponse, error) {
+	api.rawCalls++
+	if api.rawErr != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.rawErr
+	}
+	rawProperties := map[string]json.RawMessage{"id": json.RawMessage(`"` + configID + `"`)}
+	if api.lastMonitoring != nil {
+		rawProperties["last_monitoring"] = api.lastMonitoring
+	}
+	if api.stateCode != "" {
+		rawProperties["state_code"] = json.RawMessage(`"` + api.stateCode + `"`)
+	}
+	return rawProperties, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func testProjectConfigRead(t *testing.T, api *testProjectConfigAPI, raw map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+	config := map[string]interface{}{"project_id": "b0a2c11d-926c-4653-a15b-ed17d7b34b22", "project_config_id": "a1b2c3"}
+	for k, v := range raw {
+		config[k] = v
+	}
+	d := schema.TestResourceDataRaw(t, DataSourceIbmProjectConfig().Schema, config)
+	return d, dataSourceIbmProjectConfigReadWithClient(context.Background(), d, api, &api.environments, api, nil, "")
+}
+
+func TestDataSourceIbmProjectConfigReadDAConfig(t *testing.T) {
+	createdAt := strfmt.DateTime(time.Date(2024, 4, 2, 9, 30, 0, 0, time.UTC))
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID:              core.StringPtr("a1b2c3"),
+			Version:         core.Int64Ptr(3),
+			IsDraft:         core.BoolPtr(false),
+			State:           core.StringPtr("deployed"),
+			UpdateAvailable: core.BoolPtr(true),
+			Href:            core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d-926c-4653-a15b-ed17d7b34b22/configs/a1b2c3"),
+			CreatedAt:       &createdAt,
+			Definition: &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+				Name:        core.StringPtr("network"),
+				Description: core.StringPtr("The network of the application"),
+				LocatorID:   core.StringPtr("1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"),
+				Inputs:      map[string]interface{}{"region": "us-south", "zones": 3, "labels": `{"team":"payments"}`},
+				Settings:    map[string]interface{}{"IBMCLOUD_TOOLCHAIN_ENDPOINT": "https://api.us-south.devops.cloud.ibm.com"},
+				Authorizations: &projectv1.ProjectConfigAuth{
+					Method:           core.StringPtr("trusted_profile"),
+					TrustedProfileID: core.StringPtr("Profile-9ac10c5c-195c-41ef-b465-68a6b6dg5f12"),
+				},
+			},
+			Outputs: []projectv1.OutputValue{
+				{Name: core.StringPtr("vpc_id"), Value: "r006-4ac2"},
+				{Name: core.StringPtr("zones"), Value: 3},
+				{Name: core.StringPtr("public"), Value: false},
+				{Name: core.StringPtr("subnets"), Value: []interface{}{"a", "b"}},
+				{Name: core.StringPtr("network"), Value: map[string]interface{}{"zone": "us-south-1", "port": 8080, "acl": map[string]interface{}{"allow": true}}},
+				{Name: core.StringPtr("pending"), Description: core.StringPtr("Not computed yet")},
+			},
+			DeployedVersion: &projectv1.ProjectConfigVersionSummary{
+				Definition: &projectv1.ProjectConfigVersionDefinitionSummary{EnvironmentID: core.StringPtr("env-1")},
+				State:      core.StringPtr("deployed"),
+				Version:    core.Int64Ptr(2),
+				Href:       core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d-926c-4653-a15b-ed17d7b34b22/configs/a1b2c3/versions/2"),
+			},
+		},
+		resources: []projectv1.ProjectConfigResource{
+			{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2")},
+			{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2")},
+		},
+	}
+
+	d, diags := testProjectConfigRead(t, api, map[string]interface{}{"include_deployed_resources": true})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "b0a2c11d-926c-4653-a15b-ed17d7b34b22/a1b2c3", d.Id())
+	assert.Equal(t, 3, d.Get("version"))
+	assert.Equal(t, "deployed", d.Get("state"))
+	assert.Equal(t, true, d.Get("update_available"))
+	assert.Equal(t, "2024-04-02T09:30:00.000Z", d.Get("created_at"))
+
+	assert.Equal(t, "network", d.Get("definition.0.name"))
+	assert.Equal(t, "The network of the application", d.Get("definition.0.description"))
+	assert.Equal(t, "us-south", d.Get("definition.0.inputs.region"))
+	assert.Equal(t, "3", d.Get("definition.0.inputs.zones"))
+	assert.Equal(t, "trusted_profile", d.Get("definition.0.authorizations.0.method"))
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, d.Get("labels"))
+
+	assert.Equal(t, 6, d.Get("outputs.#"))
+	assert.Equal(t, "r006-4ac2", d.Get("outputs.0.value_json"))
+	assert.Equal(t, "3", d.Get("outputs.1.value_json"))
+	assert.Equal(t, "false", d.Get("outputs.2.value_json"))
+	assert.Equal(t, `["a","b"]`, d.Get("outputs.3.value_json"))
+	assert.Equal(t, `{"acl":{"allow":true},"port":8080,"zone":"us-south-1"}`, d.Get("outputs.4.value_json"))
+	assert.Equal(t, map[string]interface{}{}, d.Get("outputs.0.value"))
+	assert.Equal(t, map[string]interface{}{"zone": "us-south-1", "port": "8080", "acl": `{"allow":true}`}, d.Get("outputs.4.value"))
+	assert.Equal(t, "", d.Get("outputs.5.value_json"))
+	assert.Equal(t, "Not computed yet", d.Get("outputs.5.description"))
+
+	assert.Equal(t, 2, d.Get("deployed_version.0.version"))
+	assert.Equal(t, "env-1", d.Get("deployed_version.0.definition.0.environment_id"))
+	assert.Equal(t, 0, d.Get("approved_version.#"))
+	assert.Equal(t, []interface{}{"crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2"}, d.Get("deployed_resource_crns"))
+	assert.Equal(t, 1, api.listConfigResourcesCalls)
+}
+
+func TestDataSourceIbmProjectConfigReadResourceConfig(t *testing.T) {
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID:      core.StringPtr("a1b2c3"),
+			Version: core.Int64Ptr(1),
+			Definition: &projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse{
+				Name:         core.StringPtr("imported"),
+				Description:  core.StringPtr(""),
+				ResourceCrns: []string{"crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2"},
+			},
+		},
+	}
+
+	// The optional fields that are not set by the service are read as empty values.
+	d, diags := testProjectConfigRead(t, api, map[string]interface{}{"include_deployed_resources": true})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "imported", d.Get("definition.0.name"))
+	assert.Equal(t, []interface{}{"crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2"}, d.Get("definition.0.resource_crns"))
+	assert.Equal(t, 0, d.Get("definition.0.inputs.%"))
+	assert.Equal(t, 0, d.Get("outputs.#"))
+	assert.Equal(t, 0, d.Get("project.#"))
+	assert.Equal(t, 0, d.Get("schematics.#"))
+	assert.Equal(t, 0, d.Get("deployed_version.#"))
+	assert.Equal(t, 0, d.Get("cost_estimate.#"))
+	assert.Equal(t, "", d.Get("created_at"))
+	assert.Equal(t, map[string]interface{}{}, d.Get("labels"))
+	// Nothing is deployed, so the resources are not listed.
+	assert.Equal(t, []interface{}{}, d.Get("deployed_resource_crns"))
+	assert.Equal(t, 0, api.listConfigResourcesCalls)
+}
+
+func TestDataSourceIbmProjectConfigReadStackConfig(t *testing.T) {
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID:      core.StringPtr("a1b2c3"),
+			Version: core.Int64Ptr(1),
+			Definition: &projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties{
+				Name:        core.StringPtr("stack"),
+				Description: core.StringPtr("A stack of two members"),
+				Members: []projectv1.StackConfigMember{
+					{Name: core.StringPtr("network"), ConfigID: core.StringPtr("d4e5f6")},
+					{Name: core.StringPtr("cluster"), ConfigID: core.StringPtr("g7h8i9")},
+				},
+			},
+			DeployedVersion: &projectv1.ProjectConfigVersionSummary{
+				Definition: &projectv1.ProjectConfigVersionDefinitionSummary{},
+				State:      core.StringPtr("deployed"),
+				Version:    core.Int64Ptr(1),
+				Href:       core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d-926c-4653-a15b-ed17d7b34b22/configs/a1b2c3/versions/1"),
+			},
+		},
+	}
+
+	// The resources are not listed unless include_deployed_resources is set.
+	d, diags := testProjectConfigRead(t, api, nil)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "stack", d.Get("definition.0.name"))
+	assert.Equal(t, 2, d.Get("definition.0.members.#"))
+	assert.Equal(t, "cluster", d.Get("definition.0.members.1.name"))
+	assert.Equal(t, "g7h8i9", d.Get("definition.0.members.1.config_id"))
+	assert.Equal(t, []interface{}{}, d.Get("deployed_resource_crns"))
+	assert.Equal(t, 0, api.listConfigResourcesCalls)
+}
+
+func TestDataSourceIbmProjectConfigReadError(t *testing.T) {
+	api := &testProjectConfigAPI{err: fmt.Errorf("Not Found")}
+
+	d, diags := testProjectConfigRead(t, api, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "GetConfigWithContext failed")
+	assert.Equal(t, "", d.Id())
+}
+
+func TestDataSourceIbmProjectConfigReadAcknowledgedEvents(t *testing.T) {
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID:         core.StringPtr("a1b2c3"),
+			State:      core.StringPtr("deploying_failed"),
+			Definition: &projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse{Name: core.StringPtr("resources")},
+			NeedsAttentionState: []projectv1.ProjectConfigNeedsAttentionState{
+				{EventID: core.StringPtr("0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11"), Event: core.StringPtr("project.config.deploy.failed"), Severity: core.StringPtr("ERROR")},
+				{EventID: core.StringPtr("5c7e6a2b-8f4d-4d7a-b0c1-6e2f9a8d7c22"), Event: core.StringPtr("project.config.update.available"), Severity: core.StringPtr("INFO")},
+			},
+		},
+	}
+
+	d, diags := testProjectConfigRead(t, api, map[string]interface{}{"attention_warnings": true})
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 1)
+	assert.True(t, d.Get("has_errors").(bool))
+	assert.Len(t, d.Get("needs_attention_state").([]interface{}), 2)
+
+	d, diags = testProjectConfigRead(t, api, map[string]interface{}{
+		"attention_warnings":     true,
+		"acknowledged_event_ids": []interface{}{"0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11"},
+	})
+	assert.Empty(t, diags)
+	assert.False(t, d.Get("has_errors").(bool))
+	assert.Equal(t, "5c7e6a2b-8f4d-4d7a-b0c1-6e2f9a8d7c22", d.Get("needs_attention_state.0.event_id"))
+	assert.Len(t, d.Get("needs_attention_state").([]interface{}), 1)
+}
+
+// testProjectConfigFill sets every field of v that the read can dereference: pointers are allocated, slices and maps
+// get one element and the untyped interfaces a string. The typed interfaces, such as the definition, are left to the
+// caller.
+func testProjectConfigFill(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if depth > 8 {
+			return
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		testProjectConfigFill(v.Elem(), depth+1)
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(strfmt.DateTime{}) {
+			v.Set(reflect.ValueOf(strfmt.DateTime(time.Date(2024, 4, 2, 9, 30, 0, 0, time.UTC))))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				testProjectConfigFill(v.Field(i), depth+1)
+			}
+		}
+	case reflect.Slice:
+		slice := reflect.MakeSlice(v.Type(), 1, 1)
+		testProjectConfigFill(slice.Index(0), depth+1)
+		v.Set(slice)
+	case reflect.Map:
+		key := reflect.New(v.Type().Key()).Elem()
+		testProjectConfigFill(key, depth+1)
+		value := reflect.New(v.Type().Elem()).Elem()
+		testProjectConfigFill(value, depth+1)
+		m := reflect.MakeMap(v.Type())
+		m.SetMapIndex(key, value)
+		v.Set(m)
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			v.Set(reflect.ValueOf("value"))
+		}
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1)
+	}
+}
+
+// testProjectConfigNilablePaths calls visit with the path of every pointer, slice, map and interface field that is
+// reachable from v, entering the first element of slices.
+func testProjectConfigNilablePaths(v reflect.Value, path []string, visit func([]string)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			testProjectConfigNilablePaths(v.Elem(), path, visit)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := append(append([]string{}, path...), field.Name)
+			switch v.Field(i).Kind() {
+			case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+				visit(fieldPath)
+			}
+			testProjectConfigNilablePaths(v.Field(i), fieldPath, visit)
+		}
+	case reflect.Slice:
+		if v.Len() > 0 {
+			testProjectConfigNilablePaths(v.Index(0), append(append([]string{}, path...), "0"), visit)
+		}
+	}
+}
+
+// testProjectConfigSetNil sets the field at path to its zero value
+func testProjectConfigSetNil(v reflect.Value, path []string) {
+	for _, segment := range path {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		if segment == "0" {
+			v = v.Index(0)
+		} else {
+			v = v.FieldByName(segment)
+		}
+	}
+	v.Set(reflect.Zero(v.Type()))
+}
+
+func TestDataSourceIbmProjectConfigReadPartialResponses(t *testing.T) {
+	definitions := map[string]func() projectv1.ProjectConfigDefinitionResponseIntf{
+		"definition": func() projectv1.ProjectConfigDefinitionResponseIntf {
+			return &projectv1.ProjectConfigDefinitionResponse{}
+		},
+		"da definition": func() projectv1.ProjectConfigDefinitionResponseIntf {
+			return &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{}
+		},
+		"resource definition": func() projectv1.ProjectConfigDefinitionResponseIntf {
+			return &projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse{}
+		},
+		"stack definition": func() projectv1.ProjectConfigDefinitionResponseIntf {
+			return &projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties{}
+		},
+	}
+	newConfig := func(newDefinition func() projectv1.ProjectConfigDefinitionResponseIntf) *projectv1.ProjectConfig {
+		config := &projectv1.ProjectConfig{}
+		testProjectConfigFill(reflect.ValueOf(config).Elem(), 0)
+		definition := newDefinition()
+		testProjectConfigFill(reflect.ValueOf(definition).Elem(), 0)
+		config.Definition = definition
+		return config
+	}
+	read := func(t *testing.T, config *projectv1.ProjectConfig) {
+		api := &testProjectConfigAPI{config: config, resources: []projectv1.ProjectConfigResource{{ResourceCrn: core.StringPtr("crn:v1:bluemix:public:is:us-south:a/account::vpc:r006-4ac2")}}}
+		assert.NotPanics(t, func() {
+			d, diags := testProjectConfigRead(t, api, map[string]interface{}{"include_deployed_resources": true, "attention_warnings": true})
+			assert.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, "b0a2c11d-926c-4653-a15b-ed17d7b34b22/a1b2c3", d.Id())
+		})
+	}
+
+	for name, newDefinition := range definitions {
+		t.Run(name, func(t *testing.T) {
+			read(t, newConfig(newDefinition))
+
+			paths := [][]string{}
+			testProjectConfigNilablePaths(reflect.ValueOf(newConfig(newDefinition)), nil, func(path []string) {
+				paths = append(paths, path)
+			})
+			assert.NotEmpty(t, paths)
+			for _, path := range paths {
+				t.Run(strings.Join(path, "."), func(t *testing.T) {
+					config := newConfig(newDefinition)
+					testProjectConfigSetNil(reflect.ValueOf(config), path)
+					read(t, config)
+				})
+			}
+
+			// A definition of a known type but without a value
+			config := newConfig(newDefinition)
+			config.Definition = reflect.Zero(reflect.TypeOf(newDefinition())).Interface().(projectv1.ProjectConfigDefinitionResponseIntf)
+			read(t, config)
+		})
+	}
+
+	// A configuration without any optional field
+	read(t, &projectv1.ProjectConfig{})
+}
+
+func TestDataSourceIbmProjectConfigReferencesWithoutDefinition(t *testing.T) {
+	project, err := dataSourceIbmProjectConfigProjectReferenceToMap(&projectv1.ProjectReference{ID: core.StringPtr("b0a2c11d-926c-4653-a15b-ed17d7b34b22")})
+	assert.NoError(t, err)
+	assert.NotContains(t, project, "definition")
+	assert.Contains(t, project, "id")
+
+	version, err := dataSourceIbmProjectConfigProjectConfigVersionSummaryToMap(&projectv1.ProjectConfigVersionSummary{Version: core.Int64Ptr(2)})
+	assert.NoError(t, err)
+	assert.NotContains(t, version, "definition")
+	assert.Equal(t, 2, version["version"])
+}
+
+func TestDataSourceIbmProjectConfigReadInputSources(t *testing.T) {
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID: core.StringPtr("a1b2c3"),
+			Definition: &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+				Name:          core.StringPtr("network"),
+				EnvironmentID: core.StringPtr("env-1"),
+				Inputs:        map[string]interface{}{"region": "us-south", "vpc_id": "ref:/configs/base/outputs/vpc_id"},
+			},
+		},
+		environments: testProjectEnvironmentGetAPI{environments: map[string]*projectv1.Environment{
+			"env-1": {
+				ID: core.StringPtr("env-1"),
+				Definition: &projectv1.EnvironmentDefinitionRequiredPropertiesResponse{
+					Name:   core.StringPtr("production"),
+					Inputs: map[string]interface{}{"region": "eu-de", "resource_group": "default"},
+				},
+			},
+		}},
+	}
+
+	d, diags := testProjectConfigRead(t, api, map[string]interface{}{"resolve_environment_inputs": true})
+	assert.Empty(t, diags)
+	assert.Equal(t, map[string]interface{}{"region": "config", "vpc_id": "reference", "resource_group": "environment"}, d.Get("input_sources"))
+
+	// The environment is read only with resolve_environment_inputs
+	d, diags = testProjectConfigRead(t, api, map[string]interface{}{})
+	assert.Empty(t, diags)
+	assert.Equal(t, map[string]interface{}{"region": "config", "vpc_id": "reference"}, d.Get("input_sources"))
+
+	// A failure to read the environment is a warning
+	api.environments.getErr = fmt.Errorf("Forbidden")
+	d, diags = testProjectConfigRead(t, api, map[string]interface{}{"resolve_environment_inputs": true})
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "GetProjectEnvironmentWithContext failed for the environment env-1: Forbidden", diags[0].Detail)
+	assert.Equal(t, map[string]interface{}{"region": "config", "vpc_id": "reference"}, d.Get("input_sources"))
+}
+
+func TestDataSourceIbmProjectConfigReadLastMonitoring(t *testing.T) {
+	api := &testProjectConfigAPI{config: &projectv1.ProjectConfig{ID: core.StringPtr("a1b2c3")}}
+	includeLastMonitoring := map[string]interface{}{"include_last_monitoring": true}
+
+	// Monitoring never ran
+	d, diags := testProjectConfigRead(t, api, includeLastMonitoring)
+	assert.Empty(t, diags)
+	assert.Empty(t, d.Get("last_monitoring"))
+
+	api.lastMonitoring = json.RawMessage(`{"at": "2024-05-02T03:00:00Z", "result": "passed", "href": "https://projects.api.cloud.ibm.com/v1/projects/p/configs/a1b2c3/monitor", "job": {"id": "job-1", "summary": {"plan_summary": {"add": 0, "update": 2, "destroy": 0}}}}`)
+	d, diags = testProjectConfigRead(t, api, includeLastMonitoring)
+	assert.Empty(t, diags)
+	assert.Equal(t, "2024-05-02T03:00:00Z", d.Get("last_monitoring.0.at"))
+	assert.Equal(t, "job-1", d.Get("last_monitoring.0.job_id"))
+	assert.Equal(t, 2, d.Get("last_monitoring.0.change_count"))
+	assert.Equal(t, true, d.Get("last_monitoring.0.drift_detected"))
+	assert.Equal(t, 2, api.rawCalls)
+
+	// Without include_last_monitoring, the raw configuration is not read
+	d, diags = testProjectConfigRead(t, api, nil)
+	assert.Empty(t, diags)
+	assert.Empty(t, d.Get("last_monitoring"))
+	assert.Equal(t, 2, api.rawCalls)
+
+	// A failure to read the last monitoring job is a warning
+	api.rawErr = fmt.Errorf("Internal Server Error")
+	d, diags = testProjectConfigRead(t, api, includeLastMonitoring)
+	assert.False(t, diags.HasError())
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "The last monitoring job and the state code of configuration a1b2c3 could not be read, last_monitoring is empty and awaiting_prerequisites is false", diags[0].Summary)
+	assert.Empty(t, d.Get("last_monitoring"))
+}
+
+func TestDataSourceIbmProjectConfigReadOutputsFilter(t *testing.T) {
+	api := &testProjectConfigAPI{
+		config: &projectv1.ProjectConfig{
+			ID:    core.StringPtr("a1b2c3"),
+			State: core.StringPtr("deployed"),
+			Definition: &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+				Name:   core.StringPtr("cluster"),
+				Inputs: map[string]interface{}{"region": "us-south"},
+			},
+			Outputs: []projectv1.OutputValue{
+				{Name: core.StringPtr("cluster_id"), Value: "c0a1b2"},
+				{Name: core.StringPtr("kubeconfig"), Value: "apiVersion: v1\nkind: Config"},
+				{Name: core.StringPtr("workers"), Value: 3},
+			},
+		},
+	}
+
+	d, diags := testProjectConfigRead(t, api, nil)
+	assert.Empty(t, diags)
+	assert.Equal(t, 3, d.Get("outputs.#"))
+
+	d, diags = testProjectConfigRead(t, api, map[string]interface{}{"outputs_filter": []interface{}{"workers", "cluster_id", "endpoint"}})
+	assert.Empty(t, diags)
+	assert.Equal(t, 2, d.Get("outputs.#"))
+	assert.Equal(t, "cluster_id", d.Get("outputs.0.name"))
+	assert.Equal(t, "c0a1b2", d.Get("outputs.0.value_json"))
+	assert.Equal(t, "workers", d.Get("outputs.1.name"))
+	assert.Equal(t, "deployed", d.Get("state"))
+	assert.Equal(t, "cluster", d.Get("definition.0.name"))
+
+	d, diags = testProjectConfigRead(t, api, map[string]interface{}{"exclude_outputs": true})
+	assert.Empty(t, diags)
+	assert.Empty(t, d.Get("outputs"))
+	assert.Equal(t, "deployed", d.Get("state"))
+	assert.Equal(t, "cluster", d.Get("definition.0.name"))
+	assert.Equal(t, "us-south", d.Get("definition.0.inputs.region"))
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+func DataSourceIbmProjectConfigReference() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIbmProjectConfigReferenceRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique project ID.",
+			},
+			"config_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the configuration that produces the output. It must identify a single configuration of the project.",
+			},
+			"output_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the output of the configuration.",
+			},
+			"project_config_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the configuration that is named `config_name`.",
+			},
+			"value_json": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The value of the output, JSON encoded unless it is a string.",
+			},
+			"reference": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the output that can be used as the value of an input of another configuration of the project.",
+			},
+		},
+	}
+}
+
+func dataSourceIbmProjectConfigReferenceRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectClient, err := meta.(conns.ClientSession).ProjectV1()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config_reference", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	projectID := d.Get("project_id").(string)
+	configName := d.Get("config_name").(string)
+	outputName := d.Get("output_name").(string)
+
+	summaries, err := projectListConfigs(context, projectClient, projectRateLimiterFor(meta), projectID)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed: %s", err.Error()), "(Data) ibm_project_config_reference", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+	configIDs := []string{}
+	for _, config := range summaries {
+		if config.Definition != nil && config.Definition.Name != nil && *config.Definition.Name == configName {
+			configIDs = append(configIDs, *config.ID)
+		}
+	}
+
+	if len(configIDs) == 0 {
+		err = fmt.Errorf("No configuration named %s was found in project %s", configName, projectID)
+		return flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config_reference", "read").GetDiag()
+	}
+	if len(configIDs) > 1 {
+		err = fmt.Errorf("%d configurations named %s were found in project %s: %s", len(configIDs), configName, projectID, strings.Join(configIDs, ", "))
+		return flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config_reference", "read").GetDiag()
+	}
+	configID := configIDs[0]
+
+	getConfigOptions := &projectv1.GetConfigOptions{}
+	getConfigOptions.SetProjectID(projectID)
+	getConfigOptions.SetID(configID)
+
+	projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("GetConfigWithContext failed: %s", err.Error()), "(Data) ibm_project_config_reference", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	var output *projectv1.OutputValue
+	outputNames := []string{}
+	for i, outputsItem := range projectConfig.Outputs {
+		if outputsItem.Name == nil {
+			continue
+		}
+		if *outputsItem.Name == outputName {
+			output = &projectConfig.Outputs[i]
+		}
+		outputNames = append(outputNames, *outputsItem.Name)
+	}
+	if output == nil {
+		sort.Strings(outputNames)
+		err = fmt.Errorf("The configuration %s (%s) has no output named %s, the available outputs are: [%s]", configName, configID, outputName, strings.Join(outputNames, ", "))
+		return flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config_reference", "read").GetDiag()
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", projectID, configID, outputName))
+
+	if err = d.Set("project_config_id", configID); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting project_config_id: %s", err), "(Data) ibm_project_config_reference", "read")
+		return tfErr.GetDiag()
+	}
+
+	valueJSON := ""
+	if output.Value != nil {
+		valueJSON = *stringify(output.Value)
+	}
+	if err = d.Set("value_json", valueJSON); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting value_json: %s", err), "(Data) ibm_project_config_reference", "read")
+		return tfErr.GetDiag()
+	}
+
+	if err = d.Set("reference", projectConfigOutputReference(configID, outputName)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting reference: %s", err), "(Data) ibm_project_config_reference", "read")
+		return tfErr.GetDiag()
+	}
+
+	return nil
+}
+
+// projectConfigOutputReference returns the reference to an output of a configuration in the same project.
+func projectConfigOutputReference(configID string, outputName string) string {
+	return fmt.Sprintf("ref:/configs/%s/outputs/%s", configID, outputName)
+}
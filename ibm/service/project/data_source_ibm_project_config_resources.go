@@ -0,0 +1,148 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+func DataSourceIbmProjectConfigResources() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIbmProjectConfigResourcesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique project ID.",
+			},
+			"project_config_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique configuration ID.",
+			},
+			"resources_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of resources deployed by the configuration.",
+			},
+			"resources": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The resources deployed by the configuration.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"crn": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An IBM Cloud resource name that uniquely identifies a resource.",
+						},
+						"name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the resource.",
+						},
+						"status": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the resource, `tainted` when the resource is marked to be replaced on the next deployment and `deployed` otherwise.",
+						},
+						"account_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the account of the resource, parsed from the CRN.",
+						},
+						"location": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The location of the resource, parsed from the CRN.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIbmProjectConfigResourcesRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectClient, err := meta.(conns.ClientSession).ProjectV1()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_config_resources", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	projectID := d.Get("project_id").(string)
+	configID := d.Get("project_config_id").(string)
+
+	listedResources, totalCount, err := projectListConfigResources(context, projectClient, projectRateLimiterFor(meta), projectID, configID)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigResourcesWithContext failed: %s", err.Error()), "(Data) ibm_project_config_resources", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+	accumulated := len(listedResources)
+	resources := []map[string]interface{}{}
+	for _, modelItem := range listedResources {
+		resources = append(resources, dataSourceIbmProjectConfigResourcesProjectConfigResourceToMap(&modelItem))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", projectID, configID))
+
+	if err = d.Set("resources", resources); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting resources: %s", err), "(Data) ibm_project_config_resources", "read")
+		return tfErr.GetDiag()
+	}
+
+	if err = d.Set("resources_count", projectListTotalCount(accumulated, totalCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting resources_count: %s", err), "(Data) ibm_project_config_resources", "read")
+		return tfErr.GetDiag()
+	}
+
+	return projectListShortfallDiag("(Data) ibm_project_config_resources", accumulated, totalCount)
+}
+
+func dataSourceIbmProjectConfigResourcesProjectConfigResourceToMap(model *projectv1.ProjectConfigResource) map[string]interface{} {
+	modelMap := make(map[string]interface{})
+	crn := ""
+	if model.ResourceCrn != nil {
+		crn = *model.ResourceCrn
+	}
+	modelMap["crn"] = crn
+	if model.ResourceName != nil {
+		modelMap["name"] = *model.ResourceName
+	}
+	modelMap["status"] = "deployed"
+	if model.ResourceTainted != nil && *model.ResourceTainted {
+		modelMap["status"] = "tainted"
+	}
+	modelMap["account_id"], modelMap["location"] = projectResourceCRNAccountAndLocation(crn)
+	return modelMap
+}
+
+// projectResourceCRNAccountAndLocation returns the account ID and the location of a resource CRN, in the
+// format crn:version:cname:ctype:service-name:location:scope:service-instance:resource-type:resource.
+// Both values are empty when the CRN cannot be parsed, and the account ID is empty when the scope of
+// the CRN is not an account.
+func projectResourceCRNAccountAndLocation(crn string) (string, string) {
+	segments := strings.Split(crn, ":")
+	if len(segments) < 7 || segments[0] != "crn" {
+		return "", ""
+	}
+	accountID := ""
+	if strings.HasPrefix(segments[6], "a/") {
+		accountID = strings.TrimPrefix(segments[6], "a/")
+	}
+	return accountID, segments[5]
+}
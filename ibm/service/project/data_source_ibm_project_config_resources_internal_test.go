@@ -0,0 +1,49 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectResourceCRNAccountAndLocation(t *testing.T) {
+	testCases := []struct {
+		crn       string
+		accountID string
+		location  string
+	}{
+		{"crn:v1:bluemix:public:cloud-object-storage:global:a/4329073d16d2f3663f74bfa955259139:8d7af921-b136-4078-9666-081bd8470d94::", "4329073d16d2f3663f74bfa955259139", "global"},
+		{"crn:v1:bluemix:public:is:us-south-1:a/4329073d16d2f3663f74bfa955259139::instance:0717_e21b7391-2ca2-4ab5-84a8-b92157a633b0", "4329073d16d2f3663f74bfa955259139", "us-south-1"},
+		{"crn:v1:bluemix:public:iam-identity::o/organization::serviceid:ServiceId-1234", "", ""},
+		{"not-a-crn", "", ""},
+		{"", "", ""},
+	}
+	for _, tc := range testCases {
+		accountID, location := projectResourceCRNAccountAndLocation(tc.crn)
+		assert.Equal(t, tc.accountID, accountID, tc.crn)
+		assert.Equal(t, tc.location, location, tc.crn)
+	}
+}
+
+func TestDataSourceIbmProjectConfigResourcesProjectConfigResourceToMap(t *testing.T) {
+	model := &projectv1.ProjectConfigResource{
+		ResourceCrn:     core.StringPtr("crn:v1:bluemix:public:kms:eu-de:a/4329073d16d2f3663f74bfa955259139:30372f20-d9f1-40b3-b486-a709e1932c9c::"),
+		ResourceName:    core.StringPtr("kms-instance"),
+		ResourceTainted: core.BoolPtr(true),
+	}
+	assert.Equal(t, map[string]interface{}{
+		"crn":        "crn:v1:bluemix:public:kms:eu-de:a/4329073d16d2f3663f74bfa955259139:30372f20-d9f1-40b3-b486-a709e1932c9c::",
+		"name":       "kms-instance",
+		"status":     "tainted",
+		"account_id": "4329073d16d2f3663f74bfa955259139",
+		"location":   "eu-de",
+	}, dataSourceIbmProjectConfigResourcesProjectConfigResourceToMap(model))
+
+	model.ResourceTainted = nil
+	assert.Equal(t, "deployed", dataSourceIbmProjectConfigResourcesProjectConfigResourceToMap(model)["status"])
+}
@@ -0,0 +1,360 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+func DataSourceIbmProjectConfigs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIbmProjectConfigsRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The unique project ID.",
+			},
+			"label_selector": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "List only the configurations whose labels hold all the key-value pairs of the selector.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"awaiting_approval": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List only the configurations whose validation succeeded and whose approval is pending.",
+			},
+			"include_last_monitoring": projectConfigIncludeLastMonitoringSchema("Whether to read the last monitoring job of each configuration into `last_monitoring`, with one request per configuration."),
+			"include_resource_counts": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to count the resources of each deployed configuration into `resources_count`, with one request per deployed configuration.",
+			},
+			"total_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of configurations reported by the API. When it differs from the number of items in `configs`, the list is incomplete.",
+			},
+			"config_state_counts": &schema.Schema{
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The number of configurations of the project by state, whether or not they match the filters of `configs`. Every known state has an entry, 0 when no configuration is in it.",
+			},
+			"configs": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The collection list operation response schema that defines the array property with the name `configs`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the configuration. If this parameter is empty, an ID is automatically created for the configuration.",
+						},
+						"version": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version of the configuration.",
+						},
+						"state": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The state of the configuration.",
+						},
+						"created_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A date and time value in the format YYYY-MM-DDTHH:mm:ssZ or YYYY-MM-DDTHH:mm:ss.sssZ to match the date and time format as specified by RFC 3339.",
+						},
+						"modified_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A date and time value in the format YYYY-MM-DDTHH:mm:ssZ or YYYY-MM-DDTHH:mm:ss.sssZ to match the date and time format as specified by RFC 3339.",
+						},
+						"href": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A URL.",
+						},
+						"deployment_model": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The configuration type.",
+						},
+						"labels": &schema.Schema{
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "The labels of the configuration. They are only read when `label_selector` is set.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"validated_version": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version that an approval of the configuration would approve, when the configuration is awaiting approval.",
+						},
+						"resources_count": &schema.Schema{
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of resources that the deployed configuration manages, 0 when the configuration is not deployed. It is only read when `include_resource_counts` is set, and it is not set when the resources of the configuration could not be listed.",
+						},
+						"last_monitoring": projectConfigLastMonitoringSchema(),
+						"needs_attention": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The summary of the needs attention events of the configuration. It is only read when `awaiting_approval` is set.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"count": &schema.Schema{
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of needs attention events.",
+									},
+									"error_count": &schema.Schema{
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "The number of needs attention events with severity ERROR.",
+									},
+									"events": &schema.Schema{
+										Type:        schema.TypeList,
+										Computed:    true,
+										Description: "The names of the needs attention events, in order.",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"definition": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The description of a project configuration.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"description": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "A project configuration description.",
+									},
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The configuration name. It's unique within the account across projects and regions.",
+									},
+									"locator_id": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "A unique concatenation of the catalog ID and the version ID that identify the deployable architecture in the catalog.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIbmProjectConfigsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectClient, err := meta.(conns.ClientSession).ProjectV1()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_configs", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	projectID := d.Get("project_id").(string)
+	labelSelector := d.Get("label_selector").(map[string]interface{})
+	awaitingApproval := d.Get("awaiting_approval").(bool)
+	includeLastMonitoring := d.Get("include_last_monitoring").(bool)
+	includeResourceCounts := d.Get("include_resource_counts").(bool)
+
+	// The labels, the needs attention events, the last monitoring job and the resources of the configurations are
+	// read with requests per configuration, which wait on the same limiter.
+	limiter := projectRateLimiterFor(meta)
+	configs := []map[string]interface{}{}
+	// The configurations whose resources are counted once they are all listed, by index in configs
+	deployedConfigIDs := []string{}
+	deployedConfigIndexes := []int{}
+	if err := limiter.Wait(context); err != nil {
+		return diag.FromErr(err)
+	}
+	// The labels are filtered on the inputs of the listed definitions, which the projectv1 summaries do not have
+	projectConfigCollection, rawConfigs, totalCount, err := projectListConfigsWithRawResponse(context, projectClient, projectID)
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed: %s", err.Error()), "(Data) ibm_project_configs", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+	// Every listed configuration is counted by state, before the filters
+	listedConfigs := projectConfigCollection.Configs
+
+	for i, modelItem := range listedConfigs {
+		if awaitingApproval && !projectConfigAwaitingApproval(modelItem.State) {
+			continue
+		}
+		modelMap, err := dataSourceIbmProjectConfigsProjectConfigSummaryToMap(&modelItem)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_project_configs", "read")
+			return tfErr.GetDiag()
+		}
+		var labels map[string]interface{}
+		labelsListed := false
+		if len(labelSelector) > 0 && i < len(rawConfigs) {
+			labels, labelsListed = projectConfigListedLabels(rawConfigs[i])
+			if labelsListed && !projectConfigLabelsMatch(labels, labelSelector) {
+				continue
+			}
+		}
+		if (len(labelSelector) > 0 && !labelsListed) || awaitingApproval || includeLastMonitoring {
+			// The needs attention events and the last monitoring job are only returned with each configuration,
+			// and so are the labels when the listing does not have the inputs.
+			if err := limiter.Wait(context); err != nil {
+				return diag.FromErr(err)
+			}
+			projectConfig, rawLastMonitoring, err := dataSourceIbmProjectConfigsGetConfig(context, projectClient, projectID, *modelItem.ID)
+			if err != nil {
+				tfErr := flex.TerraformErrorf(err, fmt.Sprintf("GetConfigWithContext failed: %s", err.Error()), "(Data) ibm_project_configs", "read")
+				log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+				return tfErr.GetDiag()
+			}
+			if len(labelSelector) > 0 && !labelsListed {
+				labels, err = dataSourceIbmProjectConfigsLabels(projectConfig)
+				if err != nil {
+					tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error reading the labels of configuration %s: %s", *modelItem.ID, err), "(Data) ibm_project_configs", "read")
+					return tfErr.GetDiag()
+				}
+				if !projectConfigLabelsMatch(labels, labelSelector) {
+					continue
+				}
+			}
+			if awaitingApproval {
+				modelMap["needs_attention"] = []map[string]interface{}{projectConfigNeedsAttentionSummaryToMap(projectConfig.NeedsAttentionState)}
+			}
+			if includeLastMonitoring {
+				lastMonitoring, err := projectConfigLastMonitoringToMap(rawLastMonitoring)
+				if err != nil {
+					tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error reading the last_monitoring of configuration %s: %s", *modelItem.ID, err), "(Data) ibm_project_configs", "read")
+					return tfErr.GetDiag()
+				}
+				modelMap["last_monitoring"] = lastMonitoring
+			}
+		}
+		if len(labelSelector) > 0 {
+			modelMap["labels"] = labels
+		}
+		if includeResourceCounts {
+			// The configurations that are not deployed have no resources
+			if modelItem.DeployedVersion != nil {
+				deployedConfigIDs = append(deployedConfigIDs, *modelItem.ID)
+				deployedConfigIndexes = append(deployedConfigIndexes, len(configs))
+			} else {
+				modelMap["resources_count"] = 0
+			}
+		}
+		configs = append(configs, modelMap)
+	}
+
+	var diags diag.Diagnostics
+	if len(deployedConfigIDs) > 0 {
+		counts, errs := projectConfigResourceCounts(context, projectClient, limiter, projectID, deployedConfigIDs)
+		for i, configID := range deployedConfigIDs {
+			if errs[i] != nil {
+				log.Printf("[WARN] ListConfigResourcesWithContext failed for configuration %s: %s", configID, errs[i])
+				diags = append(diags, projectConfigResourceCountWarning(configID, errs[i]))
+				continue
+			}
+			configs[deployedConfigIndexes[i]]["resources_count"] = counts[i]
+		}
+	}
+
+	d.SetId(projectID)
+
+	if err = d.Set("configs", configs); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting configs: %s", err), "(Data) ibm_project_configs", "read")
+		return tfErr.GetDiag()
+	}
+
+	if err = d.Set("config_state_counts", projectConfigStateCounts(listedConfigs)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting config_state_counts: %s", err), "(Data) ibm_project_configs", "read")
+		return tfErr.GetDiag()
+	}
+
+	if err = d.Set("total_count", projectListTotalCount(len(listedConfigs), totalCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting total_count: %s", err), "(Data) ibm_project_configs", "read")
+		return tfErr.GetDiag()
+	}
+
+	return append(diags, projectListShortfallDiag("(Data) ibm_project_configs", len(listedConfigs), totalCount)...)
+}
+
+// dataSourceIbmProjectConfigsGetConfig returns a configuration with its definition and needs attention events, and
+// its last monitoring job as returned by the service.
+func dataSourceIbmProjectConfigsGetConfig(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string) (*projectv1.ProjectConfig, json.RawMessage, error) {
+	projectConfig, rawResponse, _, err := projectConfigGetWithRawResponse(context, projectClient, projectID, configID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return projectConfig, rawResponse["last_monitoring"], nil
+}
+
+// dataSourceIbmProjectConfigsLabels returns the labels of a configuration.
+func dataSourceIbmProjectConfigsLabels(projectConfig *projectv1.ProjectConfig) (map[string]interface{}, error) {
+	if core.IsNil(projectConfig.Definition) {
+		return map[string]interface{}{}, nil
+	}
+	definitionMap, err := dataSourceIbmProjectConfigProjectConfigDefinitionResponseToMap(projectConfig.Definition)
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]interface{}{}
+	if inputs, ok := definitionMap["inputs"].(map[string]interface{}); ok {
+		if l, ok := projectConfigLabelsFromInputs(inputs); ok {
+			labels = l
+		}
+	}
+	return labels, nil
+}
+
+func dataSourceIbmProjectConfigsProjectConfigSummaryToMap(model *projectv1.ProjectConfigSummary) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	modelMap["id"] = model.ID
+	modelMap["version"] = flex.IntValue(model.Version)
+	modelMap["state"] = model.State
+	modelMap["created_at"] = flex.DateTimeToString(model.CreatedAt)
+	modelMap["modified_at"] = flex.DateTimeToString(model.ModifiedAt)
+	modelMap["href"] = model.Href
+	if projectConfigAwaitingApproval(model.State) {
+		modelMap["validated_version"] = flex.IntValue(model.Version)
+	}
+	if model.DeploymentModel != nil {
+		modelMap["deployment_model"] = model.DeploymentModel
+	}
+	if !core.IsNil(model.Definition) {
+		definitionMap := make(map[string]interface{})
+		definitionMap["description"] = model.Definition.Description
+		definitionMap["name"] = model.Definition.Name
+		if model.Definition.LocatorID != nil {
+			definitionMap["locator_id"] = model.Definition.LocatorID
+		}
+		modelMap["definition"] = []map[string]interface{}{definitionMap}
+	}
+	return modelMap, nil
+}
@@ -89,6 +89,11 @@ func DataSourceIbmProjectEnvironment() *schema.Resource {
 				Computed:    true,
 				Description: "A URL.",
 			},
+			"region": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The region of the project of the environment, parsed from the CRN of the project or the href of the environment. Empty when they do not name a region.",
+			},
 			"definition": &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -133,7 +138,7 @@ func DataSourceIbmProjectEnvironment() *schema.Resource {
 						"inputs": &schema.Schema{
 							Type:        schema.TypeMap,
 							Computed:    true,
-							Description: "The input variables that are used for configuration definition and environment.",
+							Description: "The input variables that are used for configuration definition and environment. Values that are not strings are JSON encoded.",
 							Elem: &schema.Schema{
 								Type: schema.TypeString,
 							},
@@ -235,6 +240,12 @@ func dataSourceIbmProjectEnvironmentRead(context context.Context, d *schema.Reso
 		return tfErr.GetDiag()
 	}
 
+	region := projectRegion(projectReferenceCrn(environment.Project), environment.Href)
+	if err = d.Set("region", region); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting region: %s", err), "(Data) ibm_project_environment", "read")
+		return tfErr.GetDiag()
+	}
+
 	definition := []map[string]interface{}{}
 	if environment.Definition != nil {
 		modelMap, err := dataSourceIbmProjectEnvironmentEnvironmentDefinitionRequiredPropertiesResponseToMap(environment.Definition)
@@ -249,7 +260,7 @@ func dataSourceIbmProjectEnvironmentRead(context context.Context, d *schema.Reso
 		return tfErr.GetDiag()
 	}
 
-	return nil
+	return projectRegionMismatchWarnings(fmt.Sprintf("The project of environment %s", *getProjectEnvironmentOptions.ID), region, projectProviderRegion(meta))
 }
 
 func dataSourceIbmProjectEnvironmentProjectReferenceToMap(model *projectv1.ProjectReference) (map[string]interface{}, error) {
@@ -283,11 +294,7 @@ func dataSourceIbmProjectEnvironmentEnvironmentDefinitionRequiredPropertiesRespo
 		modelMap["authorizations"] = []map[string]interface{}{authorizationsMap}
 	}
 	if model.Inputs != nil {
-		inputs := make(map[string]interface{})
-		for k, v := range model.Inputs {
-			inputs[k] = fmt.Sprintf("%v", v)
-		}
-		modelMap["inputs"] = inputs
+		modelMap["inputs"] = projectInputsToMap(model.Inputs)
 	}
 	if model.ComplianceProfile != nil {
 		complianceProfileMap, err := dataSourceIbmProjectEnvironmentProjectComplianceProfileToMap(model.ComplianceProfile)
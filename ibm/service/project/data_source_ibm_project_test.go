@@ -60,3 +60,82 @@ func testAccCheckIbmProjectDataSourceConfigBasic(projectLocation string, project
 		}
 	`, projectLocation, projectResourceGroup)
 }
+
+func TestAccIbmProjectDataSourceIncludeConfigs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmProjectDataSourceConfigIncludeConfigs(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_project.project_instance", "configs.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ibm_project.project_instance", "configs.*", map[string]string{
+						"definition.0.name": "stage-environment",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ibm_project.project_instance", "configs.*", map[string]string{
+						"definition.0.name": "prod-environment",
+					}),
+					resource.TestCheckResourceAttrSet("data.ibm_project.project_instance", "configs.0.id"),
+					resource.TestCheckResourceAttrSet("data.ibm_project.project_instance", "configs.0.state"),
+					resource.TestCheckResourceAttrSet("data.ibm_project.project_instance", "configs.0.href"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmProjectDataSourceConfigIncludeConfigs() string {
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "us-south"
+			resource_group = "Default"
+			definition {
+                name = "acme-microservice"
+                description = "acme-microservice description"
+                destroy_on_delete = true
+                monitoring_enabled = true
+            }
+		}
+
+		resource "ibm_project_config" "stage_config" {
+			project_id = ibm_project.project_instance.id
+            definition {
+                name = "stage-environment"
+                authorizations {
+                    method = "api_key"
+                    api_key = "%[1]s"
+                }
+                locator_id = "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+            }
+            lifecycle {
+                ignore_changes = [
+                    definition[0].authorizations[0].api_key,
+                ]
+            }
+		}
+
+		resource "ibm_project_config" "prod_config" {
+			project_id = ibm_project.project_instance.id
+            definition {
+                name = "prod-environment"
+                authorizations {
+                    method = "api_key"
+                    api_key = "%[1]s"
+                }
+                locator_id = "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+            }
+            lifecycle {
+                ignore_changes = [
+                    definition[0].authorizations[0].api_key,
+                ]
+            }
+		}
+
+		data "ibm_project" "project_instance" {
+			project_id = ibm_project.project_instance.id
+			include_configs = true
+			depends_on = [ibm_project_config.stage_config, ibm_project_config.prod_config]
+		}
+	`, acc.ProjectsConfigApiKey)
+}
@@ -0,0 +1,223 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+func DataSourceIbmProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIbmProjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"page_size": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      projectsMaxPageSize,
+				ValidateFunc: validation.IntBetween(1, projectsMaxPageSize),
+				Description:  "The number of projects to request per page. Smaller pages send more requests.",
+			},
+			"include_config_counts": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to count the configurations of each project by state in `config_state_counts`, which lists the configurations of each project.",
+			},
+			"total_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of projects reported by the API. When it differs from the number of items in `projects`, the list is incomplete.",
+			},
+			"projects": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The collection list operation response schema that should define the array property with the name \"projects\".",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique project ID.",
+						},
+						"crn": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An IBM Cloud resource name that uniquely identifies a resource.",
+						},
+						"created_at": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A date and time value in the format YYYY-MM-DDTHH:mm:ssZ or YYYY-MM-DDTHH:mm:ss.sssZ to match the date and time format as specified by RFC 3339.",
+						},
+						"location": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IBM Cloud location where a resource is deployed.",
+						},
+						"resource_group_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resource group ID where the project's data and tools are created.",
+						},
+						"state": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The project status value.",
+						},
+						"href": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A URL.",
+						},
+						"config_state_counts": &schema.Schema{
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+							Description: "The number of configurations of the project by state. Every known state has an entry, 0 when no configuration is in it. Only set when `include_config_counts` is true.",
+						},
+						"definition": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The definition of the project.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the project.  It's unique within the account across regions.",
+									},
+									"description": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "A brief explanation of the project's use in the configuration of a deployable architecture. You can create a project without providing a description.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// projectsMaxPageSize is the largest page of projects that the API returns.
+const projectsMaxPageSize = 100
+
+func dataSourceIbmProjectsRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectClient, err := meta.(conns.ClientSession).ProjectV1()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "(Data) ibm_projects", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+	return dataSourceIbmProjectsReadWithClient(context, d, projectClient, projectClient, projectRateLimiterFor(meta))
+}
+
+// dataSourceIbmProjectsReadWithClient reads the projects with the given client, waiting on the limiter before each page.
+// The configurations of the projects are counted with configClient when include_config_counts is set.
+func dataSourceIbmProjectsReadWithClient(context context.Context, d *schema.ResourceData, projectClient projectListAPI, configClient projectConfigAPI, limiter *projectRateLimiter) diag.Diagnostics {
+	pageSize := int64(d.Get("page_size").(int))
+
+	projects := []map[string]interface{}{}
+	accumulated, totalCount, err := projectListAll(context, limiter, func(context context.Context, start *string) (*projectListPage, error) {
+		listProjectsOptions := &projectv1.ListProjectsOptions{}
+		listProjectsOptions.SetLimit(pageSize)
+		if start != nil {
+			listProjectsOptions.SetStart(*start)
+		}
+
+		projectCollection, _, err := projectClient.ListProjectsWithContext(context, listProjectsOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, modelItem := range projectCollection.Projects {
+			modelMap, err := dataSourceIbmProjectsProjectSummaryToMap(&modelItem)
+			if err != nil {
+				return nil, err
+			}
+			projects = append(projects, modelMap)
+		}
+
+		next, err := projectCollection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		return &projectListPage{
+			Count:      len(projectCollection.Projects),
+			Next:       next,
+			TotalCount: projectCollection.TotalCount,
+		}, nil
+	})
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListProjectsWithContext failed: %s", err.Error()), "(Data) ibm_projects", "read")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	// The project summaries embed no configurations, so the configurations of each project are listed
+	if d.Get("include_config_counts").(bool) {
+		for _, project := range projects {
+			projectID := projectConfigStringValue(project["id"].(*string))
+			configStateCounts, err := projectConfigStateCountsOf(context, configClient, limiter, projectID, nil)
+			if err != nil {
+				tfErr := flex.TerraformErrorf(err, fmt.Sprintf("ListConfigsWithContext failed for project %s: %s", projectID, err.Error()), "(Data) ibm_projects", "read")
+				log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+				return tfErr.GetDiag()
+			}
+			project["config_state_counts"] = configStateCounts
+		}
+	}
+
+	d.SetId(dataSourceIbmProjectsID(d))
+
+	if err = d.Set("projects", projects); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting projects: %s", err), "(Data) ibm_projects", "read")
+		return tfErr.GetDiag()
+	}
+
+	if err = d.Set("total_count", projectListTotalCount(accumulated, totalCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting total_count: %s", err), "(Data) ibm_projects", "read")
+		return tfErr.GetDiag()
+	}
+
+	return projectListShortfallDiag("(Data) ibm_projects", accumulated, totalCount)
+}
+
+// dataSourceIbmProjectsID returns a reasonable ID for the list.
+func dataSourceIbmProjectsID(d *schema.ResourceData) string {
+	return time.Now().UTC().String()
+}
+
+func dataSourceIbmProjectsProjectSummaryToMap(model *projectv1.ProjectSummary) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	modelMap["id"] = model.ID
+	modelMap["crn"] = model.Crn
+	modelMap["created_at"] = flex.DateTimeToString(model.CreatedAt)
+	modelMap["location"] = model.Location
+	modelMap["resource_group_id"] = model.ResourceGroupID
+	modelMap["state"] = model.State
+	modelMap["href"] = model.Href
+	if !core.IsNil(model.Definition) {
+		definitionMap := make(map[string]interface{})
+		definitionMap["name"] = model.Definition.Name
+		definitionMap["description"] = model.Definition.Description
+		modelMap["definition"] = []map[string]interface{}{definitionMap}
+	}
+	return modelMap, nil
+}
@@ -0,0 +1,106 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectListAPI fakes the projectv1 client with the projects of an account, recording the options and the time
+// of each page that is listed
+type testProjectListAPI struct {
+	projects    int
+	clock       func() time.Time
+	options     []projectv1.ListProjectsOptions
+	requestedAt []time.Time
+}
+
+func (api *testProjectListAPI) ListProjectsWithContext(ctx context.Context, listProjectsOptions *projectv1.ListProjectsOptions) (*projectv1.ProjectCollection, *core.DetailedResponse, error) {
+	api.options = append(api.options, *listProjectsOptions)
+	api.requestedAt = append(api.requestedAt, api.clock())
+
+	offset := 0
+	if listProjectsOptions.Start != nil {
+		offset, _ = strconv.Atoi(*listProjectsOptions.Start)
+	}
+	limit := int(*listProjectsOptions.Limit)
+	collection := &projectv1.ProjectCollection{TotalCount: core.Int64Ptr(int64(api.projects))}
+	for i := offset; i < api.projects && i < offset+limit; i++ {
+		collection.Projects = append(collection.Projects, projectv1.ProjectSummary{ID: core.StringPtr(fmt.Sprintf("project-%03d", i))})
+	}
+	if offset+limit < api.projects {
+		collection.Next = &projectv1.PaginationLink{Href: core.StringPtr(fmt.Sprintf("https://projects.api.cloud.ibm.com/v1/projects?start=%d&limit=%d", offset+limit, limit))}
+	}
+	return collection, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func TestDataSourceIbmProjectsReadPageSize(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	testCases := []struct {
+		name      string
+		raw       map[string]interface{}
+		limiter   *projectRateLimiter
+		pageSizes []int64
+		spacing   time.Duration
+	}{
+		{
+			name:      "default page size",
+			raw:       map[string]interface{}{},
+			pageSizes: []int64{100, 100, 100},
+		},
+		{
+			name:      "page size",
+			raw:       map[string]interface{}{"page_size": 120},
+			pageSizes: []int64{120, 120},
+		},
+		{
+			name:      "page size with a rate limit",
+			raw:       map[string]interface{}{"page_size": 50},
+			limiter:   testProjectRateLimiter(2, clock),
+			pageSizes: []int64{50, 50, 50, 50, 50},
+			spacing:   500 * time.Millisecond,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			api := &testProjectListAPI{projects: 230, clock: clock.Now}
+			d := schema.TestResourceDataRaw(t, DataSourceIbmProjects().Schema, tc.raw)
+
+			diags := dataSourceIbmProjectsReadWithClient(context.Background(), d, api, nil, tc.limiter)
+			assert.False(t, diags.HasError())
+			assert.Len(t, d.Get("projects").([]interface{}), 230)
+			assert.Equal(t, 230, d.Get("total_count"))
+
+			pageSizes := []int64{}
+			for _, options := range api.options {
+				pageSizes = append(pageSizes, *options.Limit)
+			}
+			assert.Equal(t, tc.pageSizes, pageSizes)
+			for i := 1; i < len(api.requestedAt); i++ {
+				assert.Equal(t, tc.spacing, api.requestedAt[i].Sub(api.requestedAt[i-1]))
+			}
+		})
+	}
+}
+
+func TestDataSourceIbmProjectsPageSizeValidation(t *testing.T) {
+	pageSize := DataSourceIbmProjects().Schema["page_size"]
+	for _, valid := range []int{1, 100} {
+		_, errs := pageSize.ValidateFunc(valid, "page_size")
+		assert.Empty(t, errs)
+	}
+	for _, invalid := range []int{0, 101} {
+		_, errs := pageSize.ValidateFunc(invalid, "page_size")
+		assert.NotEmpty(t, errs)
+	}
+}
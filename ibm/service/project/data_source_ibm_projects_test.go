@@ -0,0 +1,52 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	acc "github.com/IBM-Cloud/terraform-provider-ibm/ibm/acctest"
+)
+
+func TestAccIbmProjectsDataSourceBasic(t *testing.T) {
+	projectLocation := fmt.Sprintf("us-south")
+	projectResourceGroup := fmt.Sprintf("Default")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmProjectsDataSourceConfigBasic(projectLocation, projectResourceGroup),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_projects.projects_instance", "id"),
+					resource.TestCheckResourceAttrSet("data.ibm_projects.projects_instance", "total_count"),
+					resource.TestCheckResourceAttrSet("data.ibm_projects.projects_instance", "projects.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmProjectsDataSourceConfigBasic(projectLocation string, projectResourceGroup string) string {
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "%s"
+			resource_group = "%s"
+			definition {
+                name = "acme-microservice"
+                description = "acme-microservice description"
+                destroy_on_delete = true
+                monitoring_enabled = true
+            }
+		}
+
+		data "ibm_projects" "projects_instance" {
+			depends_on = [ ibm_project.project_instance ]
+		}
+	`, projectLocation, projectResourceGroup)
+}
@@ -0,0 +1,319 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+var (
+	projectConfigModeledDefinitionKeysOnce  sync.Once
+	projectConfigModeledDefinitionKeysValue map[string]bool
+)
+
+// projectConfigModeledDefinitionKeys returns the definition properties that the definition block models, which are
+// not reported in unmapped_definition_json. They are read from the schema of the block once.
+func projectConfigModeledDefinitionKeys() map[string]bool {
+	projectConfigModeledDefinitionKeysOnce.Do(func() {
+		definition := ResourceIbmProjectConfig().Schema["definition"].Elem.(*schema.Resource)
+		projectConfigModeledDefinitionKeysValue = make(map[string]bool, len(definition.Schema))
+		for key := range definition.Schema {
+			projectConfigModeledDefinitionKeysValue[key] = true
+		}
+	})
+	return projectConfigModeledDefinitionKeysValue
+}
+
+// projectConfigParseDefinitionJSON parses the value of definition_json, which must be a JSON object. An empty value
+// has no properties.
+func projectConfigParseDefinitionJSON(definitionJSON string) (map[string]interface{}, error) {
+	if strings.TrimSpace(definitionJSON) == "" {
+		return nil, nil
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(definitionJSON), &value); err != nil {
+		return nil, fmt.Errorf("definition_json is not valid JSON: %s", err)
+	}
+	definition, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("definition_json must be a JSON object")
+	}
+	if inputs, ok := definition["inputs"]; ok && inputs != nil {
+		if _, ok := inputs.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("The inputs of definition_json must be a JSON object")
+		}
+	}
+	return definition, nil
+}
+
+func validateProjectConfigDefinitionJSON(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := projectConfigParseDefinitionJSON(v.(string)); err != nil {
+		errors = append(errors, err)
+	}
+	return
+}
+
+// projectConfigSuppressEquivalentDefinitionJSON suppresses the differences of definition_json in formatting and key order.
+func projectConfigSuppressEquivalentDefinitionJSON(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if json.Unmarshal([]byte(old), &oldValue) != nil || json.Unmarshal([]byte(new), &newValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// projectConfigDefinitionJSONInputs returns the inputs of a definition set by definition_json, nil when it has none.
+func projectConfigDefinitionJSONInputs(definition map[string]interface{}) map[string]interface{} {
+	inputs, _ := definition["inputs"].(map[string]interface{})
+	return inputs
+}
+
+// projectConfigDefinitionJSONPayload returns the JSON payload of the definition set by definition_json, with the
+// labels in their reserved input. The properties of removedDefinition that are no longer set are sent as null so that
+// an update removes them.
+func projectConfigDefinitionJSONPayload(definition map[string]interface{}, labels map[string]interface{}, removedDefinition map[string]interface{}) map[string]interface{} {
+	payload := make(map[string]interface{}, len(definition)+len(removedDefinition))
+	for key := range removedDefinition {
+		if _, ok := definition[key]; !ok {
+			payload[key] = nil
+		}
+	}
+	for key, value := range definition {
+		payload[key] = value
+	}
+	if len(labels) > 0 {
+		payload["inputs"] = projectConfigInputsWithLabels(projectConfigDefinitionJSONInputs(definition), labels)
+	}
+	return payload
+}
+
+// projectConfigDefinitionPayload returns the JSON payload of a definition block, with the labels in their reserved
+// input.
+func projectConfigDefinitionPayload(definitionMap map[string]interface{}, labels map[string]interface{}) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{}, len(definitionMap))
+	for key, value := range definitionMap {
+		modelMap[key] = value
+	}
+	if inputs, ok := modelMap["inputs"].(map[string]interface{}); ok || len(labels) > 0 {
+		modelMap["inputs"] = projectConfigInputsWithLabels(inputs, labels)
+	}
+	definitionModel, err := resourceIbmProjectConfigMapToProjectConfigDefinitionPatch(modelMap)
+	if err != nil {
+		return nil, err
+	}
+	return projectConfigModelPayload(definitionModel)
+}
+
+// projectConfigModelPayload returns a projectv1 model as the JSON object that the service receives.
+func projectConfigModelPayload(model interface{}) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]interface{}{}
+	if err = json.Unmarshal(bytes, &payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// projectConfigUnmappedDefinition returns the properties of a definition, as returned by the service, that the
+// definition block does not model.
+func projectConfigUnmappedDefinition(rawDefinition json.RawMessage) (map[string]interface{}, error) {
+	unmapped := map[string]interface{}{}
+	if len(rawDefinition) == 0 {
+		return unmapped, nil
+	}
+	if err := json.Unmarshal(rawDefinition, &unmapped); err != nil {
+		return nil, err
+	}
+	for key := range projectConfigModeledDefinitionKeys() {
+		delete(unmapped, key)
+	}
+	return unmapped, nil
+}
+
+// projectConfigReadDefinitionJSON returns the value of definition_json from the definition that the service
+// returned, limited to the properties that definition_json sets and without the reserved input of the labels. A
+// property that changed outside of Terraform is then a difference of definition_json.
+func projectConfigReadDefinitionJSON(definition map[string]interface{}, rawDefinition json.RawMessage) (string, error) {
+	current := map[string]interface{}{}
+	if len(rawDefinition) > 0 {
+		if err := json.Unmarshal(rawDefinition, &current); err != nil {
+			return "", err
+		}
+	}
+	read := make(map[string]interface{}, len(definition))
+	for key := range definition {
+		if value, ok := current[key]; ok {
+			read[key] = value
+		}
+	}
+	if inputs, ok := read["inputs"].(map[string]interface{}); ok {
+		if _, ok := projectConfigDefinitionJSONInputs(definition)[projectConfigLabelsInput]; !ok {
+			delete(inputs, projectConfigLabelsInput)
+		}
+	}
+	return projectConfigDefinitionJSONString(read)
+}
+
+func projectConfigDefinitionJSONString(definition map[string]interface{}) (string, error) {
+	bytes, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+// The requests below send and receive the definition as JSON, because the definition models of projectv1 drop the
+// properties that they do not declare. They are otherwise the requests of CreateConfig, UpdateConfig and GetConfig,
+// and they are only used for the configurations whose definition is set by definition_json.
+
+func projectConfigCreateWithDefinitionJSON(context context.Context, projectClient *projectv1.ProjectV1, createConfigOptions *projectv1.CreateConfigOptions, definition map[string]interface{}) (*projectv1.ProjectConfig, *core.DetailedResponse, error) {
+	body := map[string]interface{}{"definition": definition}
+	if createConfigOptions.Schematics != nil {
+		body["schematics"] = createConfigOptions.Schematics
+	}
+	pathParamsMap := map[string]string{
+		"project_id": *createConfigOptions.ProjectID,
+	}
+	rawResponse, response, err := projectConfigRequest(context, projectClient, core.POST, `/v1/projects/{project_id}/configs`, pathParamsMap, nil, body)
+	if err != nil {
+		return nil, response, err
+	}
+	var projectConfig *projectv1.ProjectConfig
+	if err = core.UnmarshalModel(rawResponse, "", &projectConfig, projectv1.UnmarshalProjectConfig); err != nil {
+		return nil, response, err
+	}
+	return projectConfig, response, nil
+}
+
+// projectConfigUpdateWithDefinitionJSON updates the definition of a configuration. The update is sent with an
+// If-Match header when ifMatch is not empty.
+func projectConfigUpdateWithDefinitionJSON(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string, definition map[string]interface{}, ifMatch string) (*core.DetailedResponse, error) {
+	pathParamsMap := map[string]string{
+		"project_id": projectID,
+		"id":         configID,
+	}
+	headers := map[string]string{}
+	if ifMatch != "" {
+		headers["If-Match"] = ifMatch
+	}
+	_, response, err := projectConfigRequest(context, projectClient, core.PATCH, `/v1/projects/{project_id}/configs/{id}`, pathParamsMap, headers, map[string]interface{}{"definition": definition})
+	return response, err
+}
+
+// projectConfigGetWithRawDefinition gets a configuration together with its definition as returned by the service.
+func projectConfigGetWithRawDefinition(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string) (*projectv1.ProjectConfig, json.RawMessage, *core.DetailedResponse, error) {
+	projectConfig, rawResponse, response, err := projectConfigGetWithRawResponse(context, projectClient, projectID, configID)
+	if err != nil {
+		return nil, nil, response, err
+	}
+	return projectConfig, rawResponse["definition"], response, nil
+}
+
+// projectConfigGetWithRawResponse gets a configuration together with its properties as returned by the service, for
+// the properties that the projectv1 models do not have.
+func projectConfigGetWithRawResponse(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string) (*projectv1.ProjectConfig, map[string]json.RawMessage, *core.DetailedResponse, error) {
+	pathParamsMap := map[string]string{
+		"project_id": projectID,
+		"id":         configID,
+	}
+	rawResponse, response, err := projectConfigRequest(context, projectClient, core.GET, `/v1/projects/{project_id}/configs/{id}`, pathParamsMap, nil, nil)
+	if err != nil {
+		return nil, nil, response, err
+	}
+	var projectConfig *projectv1.ProjectConfig
+	if err = core.UnmarshalModel(rawResponse, "", &projectConfig, projectv1.UnmarshalProjectConfig); err != nil {
+		return nil, nil, response, err
+	}
+	return projectConfig, rawResponse, response, nil
+}
+
+// projectListConfigsWithRawResponse lists the configurations of a project, with the configurations as returned by the
+// service for the properties that the projectv1 summaries do not have, in the order of the listing, and the
+// total_count of the listing when the service reports it.
+func projectListConfigsWithRawResponse(context context.Context, projectClient *projectv1.ProjectV1, projectID string) (*projectv1.ProjectConfigCollection, []map[string]json.RawMessage, *int64, error) {
+	pathParamsMap := map[string]string{
+		"project_id": projectID,
+	}
+	rawResponse, _, err := projectConfigRequest(context, projectClient, core.GET, `/v1/projects/{project_id}/configs`, pathParamsMap, nil, nil)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	var projectConfigCollection *projectv1.ProjectConfigCollection
+	if err = core.UnmarshalModel(rawResponse, "", &projectConfigCollection, projectv1.UnmarshalProjectConfigCollection); err != nil {
+		return nil, nil, nil, err
+	}
+	rawConfigs := []map[string]json.RawMessage{}
+	if len(rawResponse["configs"]) > 0 {
+		if err = json.Unmarshal(rawResponse["configs"], &rawConfigs); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	var totalCount *int64
+	if len(rawResponse["total_count"]) > 0 {
+		if err = json.Unmarshal(rawResponse["total_count"], &totalCount); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return projectConfigCollection, rawConfigs, totalCount, nil
+}
+
+// projectConfigRawAPI reads the properties of a configuration as returned by the service, for the properties that
+// the projectv1 models do not have, such as last_monitoring and state_code.
+type projectConfigRawAPI interface {
+	GetConfigRawProperties(ctx context.Context, projectID string, configID string) (map[string]json.RawMessage, *core.DetailedResponse, error)
+}
+
+// projectConfigRawClient implements projectConfigRawAPI with projectConfigRequest.
+type projectConfigRawClient struct {
+	projectClient *projectv1.ProjectV1
+}
+
+var _ projectConfigRawAPI = (*projectConfigRawClient)(nil)
+
+func (c *projectConfigRawClient) GetConfigRawProperties(ctx context.Context, projectID string, configID string) (map[string]json.RawMessage, *core.DetailedResponse, error) {
+	pathParamsMap := map[string]string{
+		"project_id": projectID,
+		"id":         configID,
+	}
+	return projectConfigRequest(ctx, c.projectClient, core.GET, `/v1/projects/{project_id}/configs/{id}`, pathParamsMap, nil, nil)
+}
+
+func projectConfigRequest(context context.Context, projectClient *projectv1.ProjectV1, method string, path string, pathParamsMap map[string]string, headers map[string]string, body interface{}) (map[string]json.RawMessage, *core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	builder = builder.WithContext(context)
+	builder.EnableGzipCompression = projectClient.GetEnableGzipCompression()
+	if _, err := builder.ResolveRequestURL(projectClient.Service.Options.URL, path, pathParamsMap); err != nil {
+		return nil, nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	for name, value := range headers {
+		builder.AddHeader(name, value)
+	}
+	if body != nil {
+		builder.AddHeader("Content-Type", "application/json")
+		if _, err := builder.SetBodyContentJSON(body); err != nil {
+			return nil, nil, err
+		}
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rawResponse map[string]json.RawMessage
+	response, err := projectClient.Service.Request(request, &rawResponse)
+	return rawResponse, response, err
+}
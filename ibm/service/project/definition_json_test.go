@@ -0,0 +1,234 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigParseDefinitionJSON(t *testing.T) {
+	testcases := []struct {
+		name               string
+		definitionJSON     string
+		expectedDefinition map[string]interface{}
+		expectedError      string
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:               "object",
+			definitionJSON:     `{"stack_options": {"parallel": true}}`,
+			expectedDefinition: map[string]interface{}{"stack_options": map[string]interface{}{"parallel": true}},
+		},
+		{
+			name:           "invalid",
+			definitionJSON: `{"stack_options":`,
+			expectedError:  "definition_json is not valid JSON: unexpected end of JSON input",
+		},
+		{
+			name:           "array",
+			definitionJSON: `[{"stack_options": {}}]`,
+			expectedError:  "definition_json must be a JSON object",
+		},
+		{
+			name:           "null",
+			definitionJSON: `null`,
+			expectedError:  "definition_json must be a JSON object",
+		},
+		{
+			name:               "modeled properties",
+			definitionJSON:     `{"stack_options": {}, "name": "config", "locator_id": "1082e7d2.cd596f95-global"}`,
+			expectedDefinition: map[string]interface{}{"stack_options": map[string]interface{}{}, "name": "config", "locator_id": "1082e7d2.cd596f95-global"},
+		},
+		{
+			name:           "inputs that are not an object",
+			definitionJSON: `{"name": "config", "inputs": ["region"]}`,
+			expectedError:  "The inputs of definition_json must be a JSON object",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			definition, err := projectConfigParseDefinitionJSON(tc.definitionJSON)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedDefinition, definition)
+		})
+	}
+}
+
+func TestProjectConfigSuppressEquivalentDefinitionJSON(t *testing.T) {
+	assert.True(t, projectConfigSuppressEquivalentDefinitionJSON("definition_json", `{"a":1,"b":{"c":true}}`, "{\n  \"b\": {\"c\": true},\n  \"a\": 1\n}", nil))
+	assert.False(t, projectConfigSuppressEquivalentDefinitionJSON("definition_json", `{"a":1}`, `{"a":2}`, nil))
+	assert.False(t, projectConfigSuppressEquivalentDefinitionJSON("definition_json", "", `{"a":1}`, nil))
+}
+
+func TestProjectConfigDefinitionJSONPayload(t *testing.T) {
+	payload := projectConfigDefinitionJSONPayload(
+		map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"region": "us-south"}, "stack_options": map[string]interface{}{"parallel": true}},
+		map[string]interface{}{"owner": "team-a"},
+		map[string]interface{}{"stack_options": map[string]interface{}{}, "retired_option": "x"},
+	)
+	assert.Equal(t, map[string]interface{}{
+		"name":           "config",
+		"inputs":         map[string]interface{}{"region": "us-south", "labels": `{"owner":"team-a"}`},
+		"stack_options":  map[string]interface{}{"parallel": true},
+		"retired_option": nil,
+	}, payload)
+
+	// Labels without inputs
+	payload = projectConfigDefinitionJSONPayload(map[string]interface{}{"name": "config"}, map[string]interface{}{"owner": "team-a"}, nil)
+	assert.Equal(t, map[string]interface{}{
+		"name":   "config",
+		"inputs": map[string]interface{}{"labels": `{"owner":"team-a"}`},
+	}, payload)
+}
+
+func TestProjectConfigDefinitionPayload(t *testing.T) {
+	payload, err := projectConfigDefinitionPayload(map[string]interface{}{
+		"name":        "config",
+		"description": "description",
+		"inputs":      map[string]interface{}{"region": "us-south"},
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":        "config",
+		"description": "description",
+		"inputs":      map[string]interface{}{"region": "us-south"},
+	}, payload)
+}
+
+func TestProjectConfigModeledDefinitionKeys(t *testing.T) {
+	keys := projectConfigModeledDefinitionKeys()
+	assert.True(t, keys["name"])
+	assert.True(t, keys["inputs"])
+	assert.False(t, keys["stack_options"])
+	assert.Equal(t, keys, projectConfigModeledDefinitionKeys())
+}
+
+func TestResourceIbmProjectConfigDefinitionExactlyOne(t *testing.T) {
+	validate := func(raw map[string]interface{}) diag.Diagnostics {
+		return ResourceIbmProjectConfig().Validate(terraform.NewResourceConfigRaw(raw))
+	}
+	definition := []interface{}{map[string]interface{}{"name": "config", "locator_id": "1082e7d2.cd596f95-global"}}
+	definitionJSON := `{"name": "config", "locator_id": "1082e7d2.cd596f95-global"}`
+
+	assert.False(t, validate(map[string]interface{}{"project_id": "project-1", "definition": definition}).HasError())
+	assert.False(t, validate(map[string]interface{}{"project_id": "project-1", "definition_json": definitionJSON}).HasError())
+	assert.True(t, validate(map[string]interface{}{"project_id": "project-1"}).HasError())
+	assert.True(t, validate(map[string]interface{}{"project_id": "project-1", "definition": definition, "definition_json": definitionJSON}).HasError())
+}
+
+func TestProjectConfigUnmappedDefinition(t *testing.T) {
+	rawDefinition := json.RawMessage(`{"name": "config", "locator_id": "1082e7d2.cd596f95-global", "inputs": {"a": 1}, "stack_options": {"parallel": true}, "preview": "x"}`)
+
+	unmapped, err := projectConfigUnmappedDefinition(rawDefinition)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"stack_options": map[string]interface{}{"parallel": true}, "preview": "x"}, unmapped)
+
+	unmappedJSON, err := projectConfigDefinitionJSONString(unmapped)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"preview":"x","stack_options":{"parallel":true}}`, unmappedJSON)
+
+	unmapped, err = projectConfigUnmappedDefinition(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, unmapped)
+}
+
+func TestProjectConfigReadDefinitionJSON(t *testing.T) {
+	rawDefinition := json.RawMessage(`{"name": "config", "description": "set by the service", "inputs": {"region": "us-south", "labels": "{\"owner\":\"team-a\"}"}, "stack_options": {"parallel": true}}`)
+
+	// Only the properties that definition_json sets are read back, a property that the service dropped is a difference.
+	readJSON, err := projectConfigReadDefinitionJSON(map[string]interface{}{"name": "config", "stack_options": map[string]interface{}{"parallel": false}, "dropped": 1}, rawDefinition)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"config","stack_options":{"parallel":true}}`, readJSON)
+
+	// The reserved input of the labels is only read back when definition_json sets it
+	readJSON, err = projectConfigReadDefinitionJSON(map[string]interface{}{"inputs": map[string]interface{}{"region": "us-south"}}, rawDefinition)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"inputs":{"region":"us-south"}}`, readJSON)
+
+	readJSON, err = projectConfigReadDefinitionJSON(map[string]interface{}{"inputs": map[string]interface{}{"labels": "{}"}}, rawDefinition)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"inputs":{"labels":"{\"owner\":\"team-a\"}","region":"us-south"}}`, readJSON)
+}
+
+func TestProjectConfigCreateWithDefinitionJSON(t *testing.T) {
+	var requestPath string
+	var requestBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.Method + " " + r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &requestBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "cfg-1", "definition": {"name": "config", "stack_options": {"parallel": true}}}`))
+	}))
+	defer server.Close()
+
+	projectClient, err := projectv1.NewProjectV1(&projectv1.ProjectV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	assert.NoError(t, err)
+
+	createConfigOptions := &projectv1.CreateConfigOptions{}
+	createConfigOptions.SetProjectID("project-1")
+	definition := map[string]interface{}{"name": "config", "stack_options": map[string]interface{}{"parallel": true}}
+
+	projectConfig, _, err := projectConfigCreateWithDefinitionJSON(context.Background(), projectClient, createConfigOptions, definition)
+	assert.NoError(t, err)
+	assert.Equal(t, "cfg-1", *projectConfig.ID)
+	assert.Equal(t, "POST /v1/projects/project-1/configs", requestPath)
+	assert.Equal(t, map[string]interface{}{"definition": definition}, requestBody)
+
+	_, rawDefinition, _, err := projectConfigGetWithRawDefinition(context.Background(), projectClient, "project-1", "cfg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "GET /v1/projects/project-1/configs/cfg-1", requestPath)
+	assert.JSONEq(t, `{"name": "config", "stack_options": {"parallel": true}}`, string(rawDefinition))
+}
+
+func TestProjectConfigRawClient(t *testing.T) {
+	lastMonitoring := `{"at": "2024-05-02T03:00:00Z", "result": "passed"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/projects/project-1/configs/cfg-1" {
+			_, _ = w.Write([]byte(`{"id": "cfg-1", "state_code": "awaiting_prerequisite", "last_monitoring": ` + lastMonitoring + `}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "cfg-2"}`))
+	}))
+	defer server.Close()
+
+	projectClient, err := projectv1.NewProjectV1(&projectv1.ProjectV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	assert.NoError(t, err)
+	api := &projectConfigRawClient{projectClient: projectClient}
+
+	rawProperties, _, err := api.GetConfigRawProperties(context.Background(), "project-1", "cfg-1")
+	assert.NoError(t, err)
+	assert.JSONEq(t, lastMonitoring, string(rawProperties["last_monitoring"]))
+	assert.JSONEq(t, `"awaiting_prerequisite"`, string(rawProperties["state_code"]))
+
+	rawProperties, _, err = api.GetConfigRawProperties(context.Background(), "project-1", "cfg-2")
+	assert.NoError(t, err)
+	assert.Nil(t, rawProperties["last_monitoring"])
+}
@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// projectConfigDependencyTimeout bounds the wait for the configurations that a new configuration depends on, which
+// may not be listed yet right after they were created.
+const projectConfigDependencyTimeout = 1 * time.Minute
+
+// projectConfigMissingDependencies returns the configurations of ids and names that are not configurations of the
+// project, each described as `ID <id>` or `name <name>`.
+func projectConfigMissingDependencies(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, ids []string, names []string) ([]string, error) {
+	summaries, err := projectListConfigs(context, projectClient, limiter, projectID)
+	if err != nil {
+		return nil, err
+	}
+	existingIDs := map[string]bool{}
+	existingNames := map[string]bool{}
+	for _, summary := range summaries {
+		if summary.ID != nil {
+			existingIDs[*summary.ID] = true
+		}
+		if summary.Definition != nil && summary.Definition.Name != nil {
+			existingNames[*summary.Definition.Name] = true
+		}
+	}
+
+	missing := []string{}
+	for _, id := range ids {
+		if !existingIDs[id] {
+			missing = append(missing, fmt.Sprintf("ID %s", id))
+		}
+	}
+	for _, name := range names {
+		if !existingNames[name] {
+			missing = append(missing, fmt.Sprintf("name %s", name))
+		}
+	}
+	return missing, nil
+}
+
+// projectConfigMissingDependenciesError is the error of a configuration whose dependencies are not configurations of
+// the project.
+type projectConfigMissingDependenciesError struct {
+	projectID string
+	missing   []string
+}
+
+func (e *projectConfigMissingDependenciesError) Error() string {
+	return fmt.Sprintf("The configuration depends on configurations that do not exist in project %s: %s. Create them before this configuration, or fix depends_on_config_ids and depends_on_config_names", e.projectID, strings.Join(e.missing, ", "))
+}
+
+// projectConfigWaitForDependencies waits until the configurations of ids and names are configurations of the project,
+// retrying until timeout to tolerate the eventual consistency of the listing. It fails with the configurations that
+// are still missing.
+func projectConfigWaitForDependencies(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, ids []string, names []string, timeout time.Duration) error {
+	if len(ids) == 0 && len(names) == 0 {
+		return nil
+	}
+	return resource.RetryContext(context, timeout, func() *resource.RetryError {
+		missing, err := projectConfigMissingDependencies(context, projectClient, limiter, projectID, ids, names)
+		if err != nil {
+			return resource.NonRetryableError(fmt.Errorf("Failed to list the configurations of project %s to check the dependencies of the configuration: %s", projectID, err))
+		}
+		if len(missing) > 0 {
+			return resource.RetryableError(&projectConfigMissingDependenciesError{projectID: projectID, missing: missing})
+		}
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectConfigListAPI fakes the configuration listing of a project. The configurations of listings[i] are
+// returned by the i-th call, and those of the last listing by the calls after it.
+type testProjectConfigListAPI struct {
+	testProjectConfigAPI
+	listings [][]projectv1.ProjectConfigSummary
+	listErr  error
+	calls    int
+}
+
+func (api *testProjectConfigListAPI) ListConfigsWithContext(ctx context.Context, listConfigsOptions *projectv1.ListConfigsOptions) (*projectv1.ProjectConfigCollection, *core.DetailedResponse, error) {
+	api.calls++
+	if api.listErr != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.listErr
+	}
+	listing := api.listings[len(api.listings)-1]
+	if api.calls <= len(api.listings) {
+		listing = api.listings[api.calls-1]
+	}
+	return &projectv1.ProjectConfigCollection{Configs: listing}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func testProjectConfigSummary(id string, name string) projectv1.ProjectConfigSummary {
+	return projectv1.ProjectConfigSummary{
+		ID:         core.StringPtr(id),
+		Definition: &projectv1.ProjectConfigSummaryDefinition{Name: core.StringPtr(name)},
+	}
+}
+
+func TestProjectConfigMissingDependencies(t *testing.T) {
+	api := &testProjectConfigListAPI{listings: [][]projectv1.ProjectConfigSummary{{
+		testProjectConfigSummary("a1b2c3", "network"),
+		testProjectConfigSummary("d4e5f6", "cluster"),
+		{ID: core.StringPtr("g7h8i9")},
+	}}}
+
+	missing, err := projectConfigMissingDependencies(context.Background(), api, nil, "project", []string{"a1b2c3", "g7h8i9", "x0y0z0"}, []string{"cluster", "logging"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ID x0y0z0", "name logging"}, missing)
+
+	missing, err = projectConfigMissingDependencies(context.Background(), api, nil, "project", nil, []string{"network"})
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestProjectConfigWaitForDependencies(t *testing.T) {
+	network := testProjectConfigSummary("a1b2c3", "network")
+	cluster := testProjectConfigSummary("d4e5f6", "cluster")
+
+	t.Run("no dependencies", func(t *testing.T) {
+		api := &testProjectConfigListAPI{}
+		assert.NoError(t, projectConfigWaitForDependencies(context.Background(), api, nil, "project", nil, nil, time.Minute))
+		assert.Equal(t, 0, api.calls)
+	})
+
+	t.Run("dependencies listed after a retry", func(t *testing.T) {
+		api := &testProjectConfigListAPI{listings: [][]projectv1.ProjectConfigSummary{{network}, {network, cluster}}}
+		err := projectConfigWaitForDependencies(context.Background(), api, nil, "project", []string{"a1b2c3"}, []string{"cluster"}, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, api.calls)
+	})
+
+	t.Run("missing dependency", func(t *testing.T) {
+		api := &testProjectConfigListAPI{listings: [][]projectv1.ProjectConfigSummary{{network}}}
+		err := projectConfigWaitForDependencies(context.Background(), api, nil, "project", []string{"a1b2c3", "d4e5f6"}, nil, 2*time.Second)
+		assert.EqualError(t, err, "The configuration depends on configurations that do not exist in project project: ID d4e5f6. Create them before this configuration, or fix depends_on_config_ids and depends_on_config_names")
+		assert.Greater(t, api.calls, 1)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		api := &testProjectConfigListAPI{listErr: errors.New("Internal Server Error")}
+		err := projectConfigWaitForDependencies(context.Background(), api, nil, "project", []string{"a1b2c3"}, nil, time.Minute)
+		assert.EqualError(t, err, "Failed to list the configurations of project project to check the dependencies of the configuration: Internal Server Error")
+		assert.Equal(t, 1, api.calls)
+	})
+}
@@ -0,0 +1,172 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+const (
+	projectConfigDriftCheckPending  = "pending"
+	projectConfigDriftCheckComplete = "complete"
+	projectConfigDriftCheckFailed   = "failed"
+)
+
+// projectConfigDriftCheckResult is the outcome of a drift check, read from the last validation of the configuration.
+type projectConfigDriftCheckResult struct {
+	JobID         string
+	JobHref       string
+	JobLogURL     string
+	Result        string
+	AddCount      int64
+	ChangeCount   int64
+	DestroyCount  int64
+	DriftDetected bool
+}
+
+// projectConfigActionFailedError is the error of a project action whose configuration reached a failed state. JobID is
+// the Schematics job of the failed action, when the configuration reports it.
+type projectConfigActionFailedError struct {
+	State string
+	JobID string
+}
+
+func (e *projectConfigActionFailedError) Error() string {
+	return fmt.Sprintf("the configuration is in state %s", e.State)
+}
+
+// projectConfigRunDriftCheck validates the deployed configuration, which plans the configuration against its deployed
+// resources, and waits for the validation to complete. The plan summary of the validation is the drift. When the
+// validation fails, the end of the log of its Schematics job is added to the error.
+func projectConfigRunDriftCheck(context context.Context, projectClient *projectv1.ProjectV1, schematicsClient projectJobLogAPI, projectID string, configID string, timeout time.Duration, pollInterval time.Duration) (*projectConfigDriftCheckResult, error) {
+	getConfigOptions := &projectv1.GetConfigOptions{}
+	getConfigOptions.SetProjectID(projectID)
+	getConfigOptions.SetID(configID)
+
+	projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+	if err != nil {
+		return nil, err
+	}
+	if projectConfig.DeployedVersion == nil {
+		return nil, fmt.Errorf("The configuration %s has no deployed version to check for drift. Deploy the configuration before checking it for drift", configID)
+	}
+	previousJobID := projectConfigLastValidatedJobID(projectConfig)
+
+	validateConfigOptions := &projectv1.ValidateConfigOptions{}
+	validateConfigOptions.SetProjectID(projectID)
+	validateConfigOptions.SetID(configID)
+
+	_, _, err = projectClient.ValidateConfigWithContext(context, validateConfigOptions)
+	if err != nil {
+		return nil, fmt.Errorf("The service rejected the drift check of configuration %s: %s", configID, err)
+	}
+
+	waiter := newProjectActionWaiter(projectConfigDriftCheckRefreshFunc(context, projectClient, projectID, configID, previousJobID), []string{projectConfigDriftCheckPending}, []string{projectConfigDriftCheckComplete}, []string{projectConfigDriftCheckFailed}, timeout, pollInterval)
+	checkedConfig, err := waiter.WaitForStateContext(context)
+	if err != nil {
+		var failedErr *projectWaitFailedError
+		if errors.As(err, &failedErr) {
+			actionErr := projectConfigDriftCheckFailure(checkedConfig.(*projectv1.ProjectConfig), previousJobID)
+			return nil, projectConfigJobFailedError(context, schematicsClient, actionErr.JobID, fmt.Errorf("The drift check of configuration %s did not complete: %w", configID, actionErr))
+		}
+		return nil, fmt.Errorf("The drift check of configuration %s did not complete: %w", configID, err)
+	}
+	result := projectConfigDriftCheckResultFromConfig(checkedConfig.(*projectv1.ProjectConfig))
+	if schematicsClient != nil {
+		result.JobLogURL = projectConfigJobLogURL(schematicsClient.GetServiceURL(), result.JobID)
+	}
+	return result, nil
+}
+
+func projectConfigDriftCheckRefreshFunc(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string, previousJobID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(configID)
+
+		projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		return projectConfig, projectConfigDriftCheckStatus(projectConfig, previousJobID), nil
+	}
+}
+
+// projectConfigDriftCheckStatus returns whether the validation that was triggered by a drift check is complete, which
+// is when the configuration is no longer validating and its last validation is another job than previousJobID. The
+// drift check failed when the configuration reached a failed state.
+func projectConfigDriftCheckStatus(projectConfig *projectv1.ProjectConfig, previousJobID string) string {
+	if projectConfig.State != nil && strings.HasSuffix(*projectConfig.State, "_failed") {
+		return projectConfigDriftCheckFailed
+	}
+	if projectConfig.State != nil && *projectConfig.State == "validating" {
+		return projectConfigDriftCheckPending
+	}
+	jobID := projectConfigLastValidatedJobID(projectConfig)
+	if jobID == "" || jobID == previousJobID {
+		return projectConfigDriftCheckPending
+	}
+	return projectConfigDriftCheckComplete
+}
+
+// projectConfigDriftCheckFailure returns the error of a drift check whose configuration reached a failed state, with
+// the job of the validation when it is not the validation that ran before the drift check.
+func projectConfigDriftCheckFailure(projectConfig *projectv1.ProjectConfig, previousJobID string) *projectConfigActionFailedError {
+	failedErr := &projectConfigActionFailedError{State: projectConfigStringValue(projectConfig.State)}
+	if jobID := projectConfigLastValidatedJobID(projectConfig); jobID != previousJobID {
+		failedErr.JobID = jobID
+	}
+	return failedErr
+}
+
+func projectConfigLastValidatedJobID(projectConfig *projectv1.ProjectConfig) string {
+	if projectConfig.LastValidated == nil || projectConfig.LastValidated.Job == nil || projectConfig.LastValidated.Job.ID == nil {
+		return ""
+	}
+	return *projectConfig.LastValidated.Job.ID
+}
+
+// projectConfigDriftCheckResultFromConfig reads the result of a drift check from the last validation of the
+// configuration. Drift is detected when the plan adds, changes or destroys resources.
+func projectConfigDriftCheckResultFromConfig(projectConfig *projectv1.ProjectConfig) *projectConfigDriftCheckResult {
+	result := &projectConfigDriftCheckResult{}
+	lastValidated := projectConfig.LastValidated
+	if lastValidated == nil {
+		return result
+	}
+	if lastValidated.Href != nil {
+		result.JobHref = *lastValidated.Href
+	}
+	if lastValidated.Result != nil {
+		result.Result = *lastValidated.Result
+	}
+	if lastValidated.Job == nil {
+		return result
+	}
+	if lastValidated.Job.ID != nil {
+		result.JobID = *lastValidated.Job.ID
+	}
+	if lastValidated.Job.Summary != nil && lastValidated.Job.Summary.PlanSummary != nil {
+		planSummary := lastValidated.Job.Summary.PlanSummary
+		if planSummary.Add != nil {
+			result.AddCount = *planSummary.Add
+		}
+		if planSummary.Update != nil {
+			result.ChangeCount = *planSummary.Update
+		}
+		if planSummary.Destroy != nil {
+			result.DestroyCount = *planSummary.Destroy
+		}
+	}
+	result.DriftDetected = result.AddCount+result.ChangeCount+result.DestroyCount > 0
+	return result
+}
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProjectConfigValidated(jobID string, add int64, update int64, destroy int64) *projectv1.LastValidatedActionWithSummary {
+	return &projectv1.LastValidatedActionWithSummary{
+		Href:   core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d/configs/a1b2c3/versions/2"),
+		Result: core.StringPtr("passed"),
+		Job: &projectv1.ActionJobWithIdAndSummary{
+			ID: core.StringPtr(jobID),
+			Summary: &projectv1.ActionJobSummary{
+				PlanSummary: &projectv1.ActionJobPlanSummary{
+					Add:     core.Int64Ptr(add),
+					Update:  core.Int64Ptr(update),
+					Destroy: core.Int64Ptr(destroy),
+				},
+			},
+		},
+	}
+}
+
+func TestProjectConfigDriftCheckStatus(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *projectv1.ProjectConfig
+		status string
+		err    string
+		jobID  string
+	}{
+		{
+			name:   "validating",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validating"), LastValidated: testProjectConfigValidated("job-1", 0, 0, 0)},
+			status: projectConfigDriftCheckPending,
+		},
+		{
+			name:   "previous validation",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("deployed"), LastValidated: testProjectConfigValidated("job-1", 0, 0, 0)},
+			status: projectConfigDriftCheckPending,
+		},
+		{
+			name:   "no validation",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("deployed")},
+			status: projectConfigDriftCheckPending,
+		},
+		{
+			name:   "new validation",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validated"), LastValidated: testProjectConfigValidated("job-2", 0, 0, 0)},
+			status: projectConfigDriftCheckComplete,
+		},
+		{
+			name:   "validating failed",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validating_failed"), LastValidated: testProjectConfigValidated("job-2", 0, 0, 0)},
+			status: projectConfigDriftCheckFailed,
+			err:    "the configuration is in state validating_failed",
+			jobID:  "job-2",
+		},
+		{
+			name:   "previous validation failed",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validating_failed"), LastValidated: testProjectConfigValidated("job-1", 0, 0, 0)},
+			status: projectConfigDriftCheckFailed,
+			err:    "the configuration is in state validating_failed",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.status, projectConfigDriftCheckStatus(tc.config, "job-1"))
+			if tc.err != "" {
+				failedErr := projectConfigDriftCheckFailure(tc.config, "job-1")
+				assert.EqualError(t, failedErr, tc.err)
+				assert.Equal(t, tc.jobID, failedErr.JobID)
+			}
+		})
+	}
+}
+
+func TestProjectConfigDriftCheckResultFromConfig(t *testing.T) {
+	result := projectConfigDriftCheckResultFromConfig(&projectv1.ProjectConfig{LastValidated: testProjectConfigValidated("job-2", 1, 2, 0)})
+	assert.Equal(t, &projectConfigDriftCheckResult{
+		JobID:         "job-2",
+		JobHref:       "https://projects.api.cloud.ibm.com/v1/projects/b0a2c11d/configs/a1b2c3/versions/2",
+		Result:        "passed",
+		AddCount:      1,
+		ChangeCount:   2,
+		DestroyCount:  0,
+		DriftDetected: true,
+	}, result)
+
+	result = projectConfigDriftCheckResultFromConfig(&projectv1.ProjectConfig{LastValidated: testProjectConfigValidated("job-3", 0, 0, 0)})
+	assert.False(t, result.DriftDetected)
+	assert.Equal(t, "job-3", result.JobID)
+
+	// A validation without a plan summary reports no drift.
+	result = projectConfigDriftCheckResultFromConfig(&projectv1.ProjectConfig{
+		LastValidated: &projectv1.LastValidatedActionWithSummary{Job: &projectv1.ActionJobWithIdAndSummary{ID: core.StringPtr("job-4")}},
+	})
+	assert.Equal(t, &projectConfigDriftCheckResult{JobID: "job-4"}, result)
+
+	assert.Equal(t, &projectConfigDriftCheckResult{}, projectConfigDriftCheckResultFromConfig(&projectv1.ProjectConfig{}))
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigEnvironmentTimeout bounds the wait for the environment named by environment_name, which may not be
+// listed yet right after it was created.
+const projectConfigEnvironmentTimeout = 1 * time.Minute
+
+// projectConfigEnvironmentNotFoundError is the error of an environment name that matches no environment of the
+// project.
+type projectConfigEnvironmentNotFoundError struct {
+	projectID string
+	name      string
+}
+
+func (e *projectConfigEnvironmentNotFoundError) Error() string {
+	return fmt.Sprintf("The project %s has no environment named %s. Create the environment before the configuration, or fix environment_name", e.projectID, e.name)
+}
+
+// projectConfigEnvironmentIDByName returns the ID of the environment of the project whose definition name is name.
+// It fails with a projectConfigEnvironmentNotFoundError when no environment has the name, and when several do.
+func projectConfigEnvironmentIDByName(context context.Context, projectClient projectEnvironmentListAPI, limiter *projectRateLimiter, projectID string, name string) (string, error) {
+	ids := []string{}
+	_, _, err := projectListAll(context, limiter, func(context context.Context, start *string) (*projectListPage, error) {
+		listProjectEnvironmentsOptions := &projectv1.ListProjectEnvironmentsOptions{}
+		listProjectEnvironmentsOptions.SetProjectID(projectID)
+		if start != nil {
+			listProjectEnvironmentsOptions.SetStart(*start)
+		}
+
+		environmentCollection, _, err := projectClient.ListProjectEnvironmentsWithContext(context, listProjectEnvironmentsOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, environment := range environmentCollection.Environments {
+			if environment.ID != nil && environment.Definition != nil && environment.Definition.Name != nil && *environment.Definition.Name == name {
+				ids = append(ids, *environment.ID)
+			}
+		}
+
+		next, err := environmentCollection.GetNextStart()
+		if err != nil {
+			return nil, err
+		}
+		return &projectListPage{
+			Count: len(environmentCollection.Environments),
+			Next:  next,
+		}, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to list the environments of project %s to resolve environment_name %s: %s", projectID, name, err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", &projectConfigEnvironmentNotFoundError{projectID: projectID, name: name}
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("The project %s has %d environments named %s: %s. Set environment_id to the ID of the environment instead of environment_name", projectID, len(ids), name, strings.Join(ids, ", "))
+	}
+}
+
+// projectConfigWaitForEnvironmentID returns the ID of the environment of the project named name, retrying until
+// timeout while no environment has the name to tolerate the eventual consistency of the listing.
+func projectConfigWaitForEnvironmentID(context context.Context, projectClient projectEnvironmentListAPI, limiter *projectRateLimiter, projectID string, name string, timeout time.Duration) (string, error) {
+	var environmentID string
+	err := resource.RetryContext(context, timeout, func() *resource.RetryError {
+		var err error
+		environmentID, err = projectConfigEnvironmentIDByName(context, projectClient, limiter, projectID, name)
+		if _, ok := err.(*projectConfigEnvironmentNotFoundError); ok {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	return environmentID, err
+}
+
+// projectConfigResolveEnvironmentName sets the environment_id of the definition to the ID of the environment named
+// by its environment_name, when it has one.
+func projectConfigResolveEnvironmentName(context context.Context, projectClient projectEnvironmentListAPI, limiter *projectRateLimiter, projectID string, definitionMap map[string]interface{}) error {
+	environmentName, _ := definitionMap["environment_name"].(string)
+	if environmentName == "" {
+		return nil
+	}
+	environmentID, err := projectConfigWaitForEnvironmentID(context, projectClient, limiter, projectID, environmentName, projectConfigEnvironmentTimeout)
+	if err != nil {
+		return err
+	}
+	definitionMap["environment_id"] = environmentID
+	return nil
+}
+
+// projectConfigEnvironmentRemoved reports whether the definition block of the configuration, as it is configured,
+// removes the configuration from its environment oldEnvironmentID: neither environment_id nor environment_name are
+// set. It is false while either is unknown.
+func projectConfigEnvironmentRemoved(rawDefinition cty.Value, oldEnvironmentID string) bool {
+	if oldEnvironmentID == "" || !rawDefinition.IsKnown() || rawDefinition.IsNull() || rawDefinition.LengthInt() != 1 {
+		return false
+	}
+	definition := rawDefinition.Index(cty.NumberIntVal(0))
+	if !definition.IsKnown() || definition.IsNull() {
+		return false
+	}
+	for _, key := range []string{"environment_id", "environment_name"} {
+		value := definition.GetAttr(key)
+		if !value.IsKnown() || (!value.IsNull() && value.AsString() != "") {
+			return false
+		}
+	}
+	return true
+}
+
+// projectGetEnvironment reads the environment of the project.
+func projectGetEnvironment(context context.Context, projectClient projectEnvironmentGetAPI, projectID string, environmentID string) (*projectv1.Environment, error) {
+	getProjectEnvironmentOptions := &projectv1.GetProjectEnvironmentOptions{}
+	getProjectEnvironmentOptions.SetProjectID(projectID)
+	getProjectEnvironmentOptions.SetID(environmentID)
+
+	environment, _, err := projectClient.GetProjectEnvironmentWithContext(context, getProjectEnvironmentOptions)
+	if err != nil {
+		return nil, fmt.Errorf("GetProjectEnvironmentWithContext failed for the environment %s: %s", environmentID, err)
+	}
+	return environment, nil
+}
@@ -0,0 +1,136 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectEnvironmentListAPI fakes the environment listing of a project. The environments of listings[i] are
+// returned by the i-th call, and those of the last listing by the calls after it.
+type testProjectEnvironmentListAPI struct {
+	listings [][]projectv1.Environment
+	listErr  error
+	calls    int
+}
+
+func (api *testProjectEnvironmentListAPI) ListProjectEnvironmentsWithContext(ctx context.Context, listProjectEnvironmentsOptions *projectv1.ListProjectEnvironmentsOptions) (*projectv1.EnvironmentCollection, *core.DetailedResponse, error) {
+	api.calls++
+	if api.listErr != nil {
+		return nil, &core.DetailedResponse{StatusCode: 500}, api.listErr
+	}
+	listing := api.listings[len(api.listings)-1]
+	if api.calls <= len(api.listings) {
+		listing = api.listings[api.calls-1]
+	}
+	return &projectv1.EnvironmentCollection{Environments: listing}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func testProjectEnvironment(id string, name string) projectv1.Environment {
+	return projectv1.Environment{
+		ID:         core.StringPtr(id),
+		Definition: &projectv1.EnvironmentDefinitionRequiredPropertiesResponse{Name: core.StringPtr(name)},
+	}
+}
+
+func TestProjectConfigEnvironmentIDByName(t *testing.T) {
+	api := &testProjectEnvironmentListAPI{listings: [][]projectv1.Environment{{
+		testProjectEnvironment("a1b2c3", "development"),
+		testProjectEnvironment("d4e5f6", "production"),
+		testProjectEnvironment("g7h8i9", "staging"),
+		testProjectEnvironment("j0k1l2", "staging"),
+		{ID: core.StringPtr("m3n4o5")},
+	}}}
+
+	environmentID, err := projectConfigEnvironmentIDByName(context.Background(), api, nil, "project", "production")
+	assert.NoError(t, err)
+	assert.Equal(t, "d4e5f6", environmentID)
+
+	// The name matches exactly
+	_, err = projectConfigEnvironmentIDByName(context.Background(), api, nil, "project", "Production")
+	assert.IsType(t, &projectConfigEnvironmentNotFoundError{}, err)
+
+	_, err = projectConfigEnvironmentIDByName(context.Background(), api, nil, "project", "staging")
+	assert.EqualError(t, err, "The project project has 2 environments named staging: g7h8i9, j0k1l2. Set environment_id to the ID of the environment instead of environment_name")
+}
+
+func TestProjectConfigWaitForEnvironmentID(t *testing.T) {
+	development := testProjectEnvironment("a1b2c3", "development")
+	production := testProjectEnvironment("d4e5f6", "production")
+
+	t.Run("environment listed after a retry", func(t *testing.T) {
+		api := &testProjectEnvironmentListAPI{listings: [][]projectv1.Environment{{development}, {development, production}}}
+		environmentID, err := projectConfigWaitForEnvironmentID(context.Background(), api, nil, "project", "production", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, "d4e5f6", environmentID)
+		assert.Equal(t, 2, api.calls)
+	})
+
+	t.Run("missing environment", func(t *testing.T) {
+		api := &testProjectEnvironmentListAPI{listings: [][]projectv1.Environment{{development}}}
+		_, err := projectConfigWaitForEnvironmentID(context.Background(), api, nil, "project", "production", 2*time.Second)
+		assert.EqualError(t, err, "The project project has no environment named production. Create the environment before the configuration, or fix environment_name")
+		assert.Greater(t, api.calls, 1)
+	})
+
+	t.Run("ambiguous environment", func(t *testing.T) {
+		api := &testProjectEnvironmentListAPI{listings: [][]projectv1.Environment{{production, testProjectEnvironment("g7h8i9", "production")}}}
+		_, err := projectConfigWaitForEnvironmentID(context.Background(), api, nil, "project", "production", time.Minute)
+		assert.ErrorContains(t, err, "has 2 environments named production")
+		assert.Equal(t, 1, api.calls)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		api := &testProjectEnvironmentListAPI{listErr: errors.New("Internal Server Error")}
+		_, err := projectConfigWaitForEnvironmentID(context.Background(), api, nil, "project", "production", time.Minute)
+		assert.EqualError(t, err, "Failed to list the environments of project project to resolve environment_name production: Internal Server Error")
+		assert.Equal(t, 1, api.calls)
+	})
+}
+
+func TestProjectConfigResolveEnvironmentName(t *testing.T) {
+	api := &testProjectEnvironmentListAPI{listings: [][]projectv1.Environment{{testProjectEnvironment("d4e5f6", "production")}}}
+
+	definitionMap := map[string]interface{}{"environment_id": "a1b2c3", "environment_name": ""}
+	assert.NoError(t, projectConfigResolveEnvironmentName(context.Background(), api, nil, "project", definitionMap))
+	assert.Equal(t, "a1b2c3", definitionMap["environment_id"])
+	assert.Equal(t, 0, api.calls)
+
+	definitionMap = map[string]interface{}{"environment_id": "", "environment_name": "production"}
+	assert.NoError(t, projectConfigResolveEnvironmentName(context.Background(), api, nil, "project", definitionMap))
+	assert.Equal(t, "d4e5f6", definitionMap["environment_id"])
+}
+
+func TestProjectConfigEnvironmentRemoved(t *testing.T) {
+	rawDefinition := func(environmentID cty.Value, environmentName cty.Value) cty.Value {
+		return cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{
+			"name":             cty.StringVal("config"),
+			"environment_id":   environmentID,
+			"environment_name": environmentName,
+		})})
+	}
+	null := cty.NullVal(cty.String)
+
+	// Neither environment_id nor environment_name are configured any more
+	assert.True(t, projectConfigEnvironmentRemoved(rawDefinition(null, null), "env-1"))
+	assert.True(t, projectConfigEnvironmentRemoved(rawDefinition(cty.StringVal(""), null), "env-1"))
+	// The configuration has no environment to remove
+	assert.False(t, projectConfigEnvironmentRemoved(rawDefinition(null, null), ""))
+	// The environment is set by its ID or resolved from its name
+	assert.False(t, projectConfigEnvironmentRemoved(rawDefinition(cty.StringVal("env-2"), null), "env-1"))
+	assert.False(t, projectConfigEnvironmentRemoved(rawDefinition(null, cty.StringVal("development")), "env-1"))
+	// The environment is not known until apply
+	assert.False(t, projectConfigEnvironmentRemoved(rawDefinition(cty.UnknownVal(cty.String), null), "env-1"))
+	assert.False(t, projectConfigEnvironmentRemoved(cty.UnknownVal(rawDefinition(null, null).Type()), "env-1"))
+	// The definition is set by definition_json
+	assert.False(t, projectConfigEnvironmentRemoved(cty.NullVal(rawDefinition(null, null).Type()), "env-1"))
+}
@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+// projectAPIError is an entry of the errors array of the error responses of the Projects API. Target is the field
+// that the error is about, such as definition.inputs.region, and is empty when the error is not about a field.
+type projectAPIError struct {
+	Code    string
+	Message string
+	Target  string
+}
+
+// The indexes of the targets, such as definition.authorizations[0].api_key
+var projectAPIErrorTargetIndexRegexp = regexp.MustCompile(`\[(\d+)\]`)
+
+// projectAPIErrors reads the errors array of an error response. The target of an error is either the name of the
+// field or a target object with the name of the field.
+func projectAPIErrors(response *core.DetailedResponse) []projectAPIError {
+	if response == nil {
+		return nil
+	}
+	result, ok := response.Result.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	errorList, _ := result["errors"].([]interface{})
+	apiErrors := make([]projectAPIError, 0, len(errorList))
+	for _, errorItem := range errorList {
+		errorMap, ok := errorItem.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		apiError := projectAPIError{}
+		apiError.Code, _ = errorMap["code"].(string)
+		apiError.Message, _ = errorMap["message"].(string)
+		switch target := errorMap["target"].(type) {
+		case string:
+			apiError.Target = target
+		case map[string]interface{}:
+			apiError.Target, _ = target["name"].(string)
+		}
+		apiErrors = append(apiErrors, apiError)
+	}
+	return apiErrors
+}
+
+// projectAPIErrorTargetSegments splits the target of an error into the names and the indexes of its fields. The
+// target is either a dotted name, such as definition.inputs.region, or a JSON pointer, such as /definition/inputs.
+func projectAPIErrorTargetSegments(target string) []string {
+	separator := "."
+	if strings.HasPrefix(target, "/") {
+		separator = "/"
+	}
+	target = projectAPIErrorTargetIndexRegexp.ReplaceAllString(target, separator+"$1")
+	segments := []string{}
+	for _, segment := range strings.Split(target, separator) {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+// projectAPIErrorTargetPath maps the target of an error to the path of the argument of the resource schema. The
+// objects of the API are blocks of a single element, such as definition.0, unless the target has an index. The keys
+// of a map, such as the inputs, are kept in the path. The second return value is false when the target is not an
+// argument of the schema.
+func projectAPIErrorTargetPath(resourceSchema map[string]*schema.Schema, target string) (cty.Path, bool) {
+	segments := projectAPIErrorTargetSegments(target)
+	if len(segments) == 0 {
+		return nil, false
+	}
+	path := cty.Path{}
+	current := resourceSchema
+	for i := 0; i < len(segments); i++ {
+		attribute, ok := current[segments[i]]
+		if !ok {
+			return nil, false
+		}
+		path = path.GetAttr(segments[i])
+		rest := segments[i+1:]
+		if len(rest) == 0 {
+			return path, true
+		}
+
+		switch attribute.Type {
+		case schema.TypeMap:
+			return path.IndexString(strings.Join(rest, ".")), true
+		case schema.TypeList:
+			index, err := strconv.Atoi(rest[0])
+			if err == nil {
+				path = path.IndexInt(int64(index))
+				i++
+				rest = rest[1:]
+			} else {
+				path = path.IndexInt(0)
+			}
+			if len(rest) == 0 {
+				return path, true
+			}
+			resource, ok := attribute.Elem.(*schema.Resource)
+			if !ok {
+				return nil, false
+			}
+			current = resource.Schema
+		default:
+			return nil, false
+		}
+	}
+	return path, true
+}
+
+// projectConfigAPIErrorDiag returns the diagnostics of a failed request of the configuration resource. A 400
+// response of the Projects API with field errors has a diagnostic for each error about an argument of the resource,
+// which points at the argument. The errors that cannot be mapped to an argument, and the other failures, are
+// reported in a single diagnostic with the summary.
+func projectConfigAPIErrorDiag(err error, response *core.DetailedResponse, summary string, operation string) diag.Diagnostics {
+	tfErr := flex.TerraformErrorf(err, summary, "ibm_project_config", operation)
+	log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+	if response == nil || response.StatusCode != http.StatusBadRequest {
+		return tfErr.GetDiag()
+	}
+
+	resourceSchema := ResourceIbmProjectConfig().Schema
+	var diags diag.Diagnostics
+	unmapped := false
+	for _, apiError := range projectAPIErrors(response) {
+		path, ok := projectAPIErrorTargetPath(resourceSchema, apiError.Target)
+		if !ok {
+			unmapped = true
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       apiError.Message,
+			Detail:        fmt.Sprintf("The Projects API rejected %s with error %s: %s", apiError.Target, apiError.Code, apiError.Message),
+			AttributePath: path,
+		})
+	}
+	if len(diags) == 0 || unmapped {
+		diags = append(tfErr.GetDiag(), diags...)
+	}
+	return diags
+}
@@ -0,0 +1,155 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// Synthetic error responses of CreateConfig, written after the error model of the Projects API. They are not captured
+// from the service: the traces, messages and targets are illustrative.
+const (
+	testProjectConfigInputError = `{
+  "status_code": 400,
+  "trace": "8b5f1d2c-7d3e-4c1a-9a3f-0e2d5c6b7a81",
+  "errors": [
+    {
+      "code": "invalid_request",
+      "message": "definition.inputs.region: not allowed",
+      "target": {"type": "field", "name": "definition.inputs.region"}
+    }
+  ]
+}`
+	testProjectConfigFieldErrors = `{
+  "status_code": 400,
+  "trace": "2c8e4a1f-5b6d-4e7f-8a9b-1c2d3e4f5a6b",
+  "errors": [
+    {"code": "missing_field", "message": "locator_id is required", "target": "definition.locator_id"},
+    {"code": "invalid_field", "message": "The API key is not valid", "target": "definition.authorizations[0].api_key"},
+    {"code": "invalid_request", "message": "The limit is out of range", "target": {"type": "parameter", "name": "configs.limit"}}
+  ]
+}`
+	testProjectConfigNoTargetError = `{
+  "status_code": 400,
+  "trace": "6d1e3f5a-7b9c-4d2e-8f0a-1b3c5d7e9f2a",
+  "errors": [
+    {"code": "invalid_request", "message": "Request body is not valid JSON"}
+  ]
+}`
+)
+
+func testProjectErrorResponse(t *testing.T, statusCode int, body string) *core.DetailedResponse {
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &result))
+	return &core.DetailedResponse{StatusCode: statusCode, Result: result}
+}
+
+func TestProjectAPIErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		body   string
+		errors []projectAPIError
+	}{
+		{
+			name:   "target object",
+			body:   testProjectConfigInputError,
+			errors: []projectAPIError{{Code: "invalid_request", Message: "definition.inputs.region: not allowed", Target: "definition.inputs.region"}},
+		},
+		{
+			name: "target names",
+			body: testProjectConfigFieldErrors,
+			errors: []projectAPIError{
+				{Code: "missing_field", Message: "locator_id is required", Target: "definition.locator_id"},
+				{Code: "invalid_field", Message: "The API key is not valid", Target: "definition.authorizations[0].api_key"},
+				{Code: "invalid_request", Message: "The limit is out of range", Target: "configs.limit"},
+			},
+		},
+		{
+			name:   "no target",
+			body:   testProjectConfigNoTargetError,
+			errors: []projectAPIError{{Code: "invalid_request", Message: "Request body is not valid JSON"}},
+		},
+		{
+			name:   "no errors",
+			body:   `{"status_code": 400, "message": "Bad Request"}`,
+			errors: []projectAPIError{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.errors, projectAPIErrors(testProjectErrorResponse(t, 400, tc.body)))
+		})
+	}
+	assert.Empty(t, projectAPIErrors(nil))
+	assert.Empty(t, projectAPIErrors(&core.DetailedResponse{StatusCode: 400, RawResult: []byte("Bad Request")}))
+}
+
+func TestProjectAPIErrorTargetPath(t *testing.T) {
+	resourceSchema := ResourceIbmProjectConfig().Schema
+	testCases := []struct {
+		target string
+		path   cty.Path
+	}{
+		{"definition.inputs.region", cty.GetAttrPath("definition").IndexInt(0).GetAttr("inputs").IndexString("region")},
+		{"definition.inputs", cty.GetAttrPath("definition").IndexInt(0).GetAttr("inputs")},
+		{"definition.locator_id", cty.GetAttrPath("definition").IndexInt(0).GetAttr("locator_id")},
+		{"definition.0.locator_id", cty.GetAttrPath("definition").IndexInt(0).GetAttr("locator_id")},
+		{"/definition/settings/TF_LOG", cty.GetAttrPath("definition").IndexInt(0).GetAttr("settings").IndexString("TF_LOG")},
+		{"definition.authorizations[0].api_key", cty.GetAttrPath("definition").IndexInt(0).GetAttr("authorizations").IndexInt(0).GetAttr("api_key")},
+		{"definition.authorizations.api_key", cty.GetAttrPath("definition").IndexInt(0).GetAttr("authorizations").IndexInt(0).GetAttr("api_key")},
+		{"definition", cty.GetAttrPath("definition")},
+		{"project_id", cty.GetAttrPath("project_id")},
+		{"definition.unknown_field", nil},
+		{"definition.locator_id.version", nil},
+		{"configs", nil},
+		{"", nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			path, ok := projectAPIErrorTargetPath(resourceSchema, tc.target)
+			assert.Equal(t, tc.path != nil, ok)
+			if tc.path != nil {
+				assert.True(t, tc.path.Equals(path), "%#v", path)
+			}
+		})
+	}
+}
+
+func TestProjectConfigAPIErrorDiag(t *testing.T) {
+	err := errors.New("definition.inputs.region: not allowed")
+
+	diags := projectConfigAPIErrorDiag(err, testProjectErrorResponse(t, 400, testProjectConfigInputError), "CreateConfigWithContext failed: "+err.Error(), "create")
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "definition.inputs.region: not allowed", diags[0].Summary)
+	assert.Equal(t, "The Projects API rejected definition.inputs.region with error invalid_request: definition.inputs.region: not allowed", diags[0].Detail)
+	assert.True(t, cty.GetAttrPath("definition").IndexInt(0).GetAttr("inputs").IndexString("region").Equals(diags[0].AttributePath))
+
+	// All the field errors are reported, along with the summary for the errors that are not about an argument
+	diags = projectConfigAPIErrorDiag(err, testProjectErrorResponse(t, 400, testProjectConfigFieldErrors), "CreateConfigWithContext failed", "create")
+	assert.Len(t, diags, 3)
+	assert.Contains(t, diags[0].Summary, "CreateConfigWithContext failed")
+	assert.Nil(t, diags[0].AttributePath)
+	assert.Equal(t, "locator_id is required", diags[1].Summary)
+	assert.True(t, cty.GetAttrPath("definition").IndexInt(0).GetAttr("locator_id").Equals(diags[1].AttributePath))
+	assert.Equal(t, "The API key is not valid", diags[2].Summary)
+
+	diags = projectConfigAPIErrorDiag(err, testProjectErrorResponse(t, 400, testProjectConfigNoTargetError), "CreateConfigWithContext failed", "create")
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+
+	// The errors of other status codes are not field errors
+	diags = projectConfigAPIErrorDiag(err, testProjectErrorResponse(t, 409, testProjectConfigInputError), "CreateConfigWithContext failed", "create")
+	assert.Len(t, diags, 1)
+	assert.Nil(t, diags[0].AttributePath)
+	diags = projectConfigAPIErrorDiag(err, nil, "CreateConfigWithContext failed", "create")
+	assert.Len(t, diags, 1)
+}
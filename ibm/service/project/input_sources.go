@@ -0,0 +1,67 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"strings"
+)
+
+// The sources of the effective inputs of a configuration in input_sources
+const (
+	projectConfigInputSourceConfig      = "config"
+	projectConfigInputSourceEnvironment = "environment"
+	projectConfigInputSourceReference   = "reference"
+)
+
+// projectConfigInputReferencePrefix starts the values of the inputs that reference the outputs of other
+// configurations or the inputs of the environment, such as ref:/configs/<config>/outputs/<output>.
+const projectConfigInputReferencePrefix = "ref:/"
+
+// projectConfigInputSources classifies the effective inputs of a configuration by their source: an input whose value
+// is a reference is "reference", an input of the configuration is "config", and an input that only the environment
+// sets is "environment". environmentInputs is nil when the inputs of the environment are not known, and the reserved
+// labels input is not an input of the configuration.
+func projectConfigInputSources(configInputs map[string]interface{}, environmentInputs map[string]interface{}) map[string]interface{} {
+	sources := map[string]interface{}{}
+	for name, value := range environmentInputs {
+		sources[name] = projectConfigInputSource(value, projectConfigInputSourceEnvironment)
+	}
+	for name, value := range configInputs {
+		if name == projectConfigLabelsInput {
+			continue
+		}
+		sources[name] = projectConfigInputSource(value, projectConfigInputSourceConfig)
+	}
+	return sources
+}
+
+func projectConfigInputSource(value interface{}, source string) string {
+	switch v := value.(type) {
+	case string:
+		if strings.HasPrefix(strings.TrimSpace(v), projectConfigInputReferencePrefix) {
+			return projectConfigInputSourceReference
+		}
+	case *string:
+		if v != nil {
+			return projectConfigInputSource(*v, source)
+		}
+	}
+	return source
+}
+
+// projectConfigEnvironmentInputs returns the inputs of the environment of the project.
+func projectConfigEnvironmentInputs(context context.Context, projectClient projectEnvironmentGetAPI, projectID string, environmentID string) (map[string]interface{}, error) {
+	environment, err := projectGetEnvironment(context, projectClient, projectID, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	inputs := map[string]interface{}{}
+	if environment.Definition != nil {
+		for name, value := range environment.Definition.Inputs {
+			inputs[name] = value
+		}
+	}
+	return inputs, nil
+}
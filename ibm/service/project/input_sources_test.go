@@ -0,0 +1,50 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigInputSources(t *testing.T) {
+	configInputs := map[string]interface{}{
+		"region":     "us-south",
+		"prefix":     "app",
+		"vpc_id":     "ref:/configs/network/outputs/vpc_id",
+		"zones":      "3",
+		"labels":     `{"team":"payments"}`,
+		"ssh_key_id": core.StringPtr(" ref:/configs/keys/outputs/ssh_key_id"),
+	}
+	environmentInputs := map[string]interface{}{
+		"region":         "eu-de",
+		"resource_group": "default",
+		"api_key":        "ref:/secrets/api-key",
+		"tags":           []interface{}{"a", "b"},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"region":         "config",
+		"prefix":         "config",
+		"vpc_id":         "reference",
+		"zones":          "config",
+		"ssh_key_id":     "reference",
+		"resource_group": "environment",
+		"api_key":        "reference",
+		"tags":           "environment",
+	}, projectConfigInputSources(configInputs, environmentInputs))
+
+	// Without the inputs of the environment, only the inputs of the configuration are classified
+	assert.Equal(t, map[string]interface{}{
+		"region":     "config",
+		"prefix":     "config",
+		"vpc_id":     "reference",
+		"zones":      "config",
+		"ssh_key_id": "reference",
+	}, projectConfigInputSources(configInputs, nil))
+
+	assert.Equal(t, map[string]interface{}{}, projectConfigInputSources(nil, nil))
+}
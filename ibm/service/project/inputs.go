@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectInputsToMap flattens the inputs of a configuration or an environment into the values of a string
+// map. Strings are kept as they are and the other values are JSON encoded, so that numbers, booleans, lists
+// and objects round trip through projectInputsFromMap.
+func projectInputsToMap(inputs map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(inputs))
+	for k, v := range inputs {
+		result[k] = *stringify(v)
+	}
+	return result
+}
+
+// projectInputsFromMap expands the values of a string map into the inputs of a configuration or an
+// environment, see projectInputValue.
+func projectInputsFromMap(inputs map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(inputs))
+	for k, v := range inputs {
+		if s, ok := v.(string); ok {
+			result[k] = projectInputValue(s)
+		} else {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// projectInputValue returns the value that is encoded by s when s is a single JSON value, and s itself
+// otherwise. Numbers are kept as json.Number so that large integers are not rounded.
+func projectInputValue(s string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return s
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return s
+	}
+	return value
+}
+
+// suppressEquivalentProjectInput suppresses the diff of an input whose old and new values encode the same
+// value, for example when the service returns a JSON document with a different layout.
+func suppressEquivalentProjectInput(k, old, new string, d *schema.ResourceData) bool {
+	return *stringify(projectInputValue(old)) == *stringify(projectInputValue(new))
+}
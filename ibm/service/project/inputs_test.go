@@ -0,0 +1,51 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectInputValue(t *testing.T) {
+	assert.Equal(t, json.Number("3"), projectInputValue("3"))
+	assert.Equal(t, json.Number("12345678901234567890"), projectInputValue("12345678901234567890"))
+	assert.Equal(t, true, projectInputValue("true"))
+	assert.Equal(t, []interface{}{"a", json.Number("1")}, projectInputValue(`["a", 1]`))
+	assert.Equal(t, map[string]interface{}{"zone": "us-south-1"}, projectInputValue(`{"zone": "us-south-1"}`))
+	assert.Equal(t, "quoted", projectInputValue(`"quoted"`))
+	assert.Equal(t, "us-south", projectInputValue("us-south"))
+	assert.Equal(t, "1 2", projectInputValue("1 2"))
+	assert.Equal(t, "", projectInputValue(""))
+}
+
+func TestProjectInputsRoundTrip(t *testing.T) {
+	configured := map[string]interface{}{
+		"region":   "us-south",
+		"replicas": "3",
+		"ha":       "true",
+		"zones":    `["us-south-1","us-south-2"]`,
+		"tags":     `{"env":"dev"}`,
+	}
+
+	// The service returns the JSON values that were sent, decoded with the default number type.
+	var returned map[string]interface{}
+	sent, err := json.Marshal(projectInputsFromMap(configured))
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(sent, &returned))
+	assert.Equal(t, float64(3), returned["replicas"])
+	assert.Equal(t, true, returned["ha"])
+
+	assert.Equal(t, configured, projectInputsToMap(returned))
+}
+
+func TestSuppressEquivalentProjectInput(t *testing.T) {
+	assert.True(t, suppressEquivalentProjectInput("definition.0.inputs.zones", `["a","b"]`, `[ "a", "b" ]`, nil))
+	assert.True(t, suppressEquivalentProjectInput("definition.0.inputs.tags", `{"b":1,"a":2}`, `{"a": 2, "b": 1}`, nil))
+	assert.True(t, suppressEquivalentProjectInput("definition.0.inputs.region", "us-south", "us-south", nil))
+	assert.False(t, suppressEquivalentProjectInput("definition.0.inputs.replicas", "3", "4", nil))
+	assert.False(t, suppressEquivalentProjectInput("definition.0.inputs.region", "us-south", "us-east", nil))
+}
@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/IBM/schematics-go-sdk/schematicsv1"
+)
+
+const (
+	// The number of lines at the end of the Schematics job log that are added to the error of a failed job
+	projectConfigJobLogTailLines = 50
+	// The lines of the job log are truncated to projectConfigJobLogMaxLineLength characters
+	projectConfigJobLogMaxLineLength = 500
+)
+
+// The values that follow these names in the job log are replaced by projectConfigJobLogRedacted, such as the API key
+// of the authorizations or the secrets in the inputs and the environment variables of the configuration.
+var (
+	projectConfigJobLogSecretPattern = regexp.MustCompile(`(?i)([\w.-]*(?:password|passwd|secret|token|api_?key|private_?key|access_?key|credentials?)[\w.-]*"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;]+)`)
+	projectConfigJobLogBearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[\w.~+/=-]+`)
+)
+
+const projectConfigJobLogRedacted = "[REDACTED]"
+
+// projectConfigJobLogURL returns the URL of the log of a Schematics job, from the URL of the Schematics service.
+func projectConfigJobLogURL(schematicsURL string, jobID string) string {
+	if schematicsURL == "" || jobID == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/v2/jobs/%s/logs", strings.TrimSuffix(schematicsURL, "/"), jobID)
+}
+
+// projectConfigJobLogTail reads the log of a Schematics job and returns its last lines, sanitized of the secrets.
+func projectConfigJobLogTail(context context.Context, schematicsClient projectJobLogAPI, jobID string) (string, error) {
+	listJobLogsOptions := &schematicsv1.ListJobLogsOptions{}
+	listJobLogsOptions.SetJobID(jobID)
+
+	jobLog, _, err := schematicsClient.ListJobLogsWithContext(context, listJobLogsOptions)
+	if err != nil {
+		return "", err
+	}
+	if jobLog == nil || jobLog.Details == nil {
+		return "", nil
+	}
+	return projectConfigSanitizeJobLog(projectConfigLastLines(string(*jobLog.Details), projectConfigJobLogTailLines)), nil
+}
+
+// projectConfigJobFailedError adds the end of the log of the failed Schematics job to the error of a project action,
+// with the URL of the log. The log is best effort: when it cannot be read, the original error is returned with the
+// reason, so that it is never masked by the failure to read the log.
+func projectConfigJobFailedError(context context.Context, schematicsClient projectJobLogAPI, jobID string, err error) error {
+	if jobID == "" || schematicsClient == nil {
+		return err
+	}
+	logURL := projectConfigJobLogURL(schematicsClient.GetServiceURL(), jobID)
+	tail, logErr := projectConfigJobLogTail(context, schematicsClient, jobID)
+	if logErr != nil {
+		log.Printf("[WARN] Failed to read the log of Schematics job %s: %s", jobID, logErr)
+		return fmt.Errorf("%s\nThe log of Schematics job %s could not be read: %s. See the log at %s", err, jobID, logErr, logURL)
+	}
+	if tail == "" {
+		return fmt.Errorf("%s\nSee the log of Schematics job %s at %s", err, jobID, logURL)
+	}
+	return fmt.Errorf("%s\nThe last lines of the log of Schematics job %s, see the full log at %s:\n%s", err, jobID, logURL, tail)
+}
+
+// projectConfigLastLines returns the last n lines of the text, each truncated to projectConfigJobLogMaxLineLength.
+func projectConfigLastLines(text string, n int) string {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	for i, line := range lines {
+		if len(line) > projectConfigJobLogMaxLineLength {
+			lines[i] = line[:projectConfigJobLogMaxLineLength] + "..."
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// projectConfigSanitizeJobLog replaces the values of the secrets in the job log by projectConfigJobLogRedacted.
+func projectConfigSanitizeJobLog(text string) string {
+	text = projectConfigJobLogSecretPattern.ReplaceAllString(text, "${1}"+projectConfigJobLogRedacted)
+	return projectConfigJobLogBearerPattern.ReplaceAllString(text, "${1}"+projectConfigJobLogRedacted)
+}
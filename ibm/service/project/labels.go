@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// projectConfigLabelsInput is the input that holds the labels of a configuration. The Projects API has no
+// labels on configurations, so the provider stores them as a JSON object in this reserved input.
+const projectConfigLabelsInput = "labels"
+
+// projectConfigInputsWithLabels returns a copy of inputs that holds the labels in the reserved input. The copy does not
+// have the reserved input when there are no labels.
+func projectConfigInputsWithLabels(inputs map[string]interface{}, labels map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(inputs)+1)
+	for k, v := range inputs {
+		result[k] = v
+	}
+	if len(labels) > 0 {
+		result[projectConfigLabelsInput] = *stringify(labels)
+	}
+	return result
+}
+
+// projectConfigPayloadWithoutLabels returns a copy of the payload of a definition update that deletes the labels that
+// the configuration held: the reserved input is null in the inputs of the copy, as the service merges the inputs of
+// an update into the inputs of the configuration.
+func projectConfigPayloadWithoutLabels(payload map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(payload)+1)
+	for k, v := range payload {
+		result[k] = v
+	}
+	inputs, _ := payload["inputs"].(map[string]interface{})
+	inputsWithoutLabels := make(map[string]interface{}, len(inputs)+1)
+	for k, v := range inputs {
+		inputsWithoutLabels[k] = v
+	}
+	inputsWithoutLabels[projectConfigLabelsInput] = nil
+	result["inputs"] = inputsWithoutLabels
+	return result
+}
+
+// projectConfigCopyDefinitionMap returns a copy of a definition block, so that the definition can be completed for
+// a request without modifying the map that d.Get returned.
+func projectConfigCopyDefinitionMap(definitionMap map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(definitionMap))
+	for k, v := range definitionMap {
+		result[k] = v
+	}
+	return result
+}
+
+// projectConfigLabelsFromInputs returns the labels that are held by the reserved input of inputs. The second
+// return value is false when the reserved input is not set or does not hold a JSON object.
+func projectConfigLabelsFromInputs(inputs map[string]interface{}) (map[string]interface{}, bool) {
+	value, ok := inputs[projectConfigLabelsInput]
+	if !ok || value == nil {
+		return nil, false
+	}
+
+	var labels map[string]interface{}
+	switch v := value.(type) {
+	case string:
+		if err := json.Unmarshal([]byte(v), &labels); err != nil {
+			return nil, false
+		}
+	case map[string]interface{}:
+		labels = v
+	default:
+		return nil, false
+	}
+
+	result := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		if s, ok := v.(string); ok {
+			result[k] = s
+		} else {
+			result[k] = fmt.Sprintf("%v", v)
+		}
+	}
+	return result, true
+}
+
+// projectConfigListedLabels returns the labels of a configuration of a listing, read from the inputs of its listed
+// definition. The second return value is false when the listing does not have the inputs of the configuration, whose
+// labels are then read with the configuration.
+func projectConfigListedLabels(rawConfig map[string]json.RawMessage) (map[string]interface{}, bool) {
+	var definition struct {
+		Inputs map[string]interface{} `json:"inputs"`
+	}
+	if len(rawConfig["definition"]) == 0 || json.Unmarshal(rawConfig["definition"], &definition) != nil || definition.Inputs == nil {
+		return nil, false
+	}
+	if labels, ok := projectConfigLabelsFromInputs(definition.Inputs); ok {
+		return labels, true
+	}
+	return map[string]interface{}{}, true
+}
+
+// projectConfigLabelsMatch reports whether labels hold every key-value pair of the selector.
+func projectConfigLabelsMatch(labels map[string]interface{}, selector map[string]interface{}) bool {
+	for k, v := range selector {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigInputsWithLabels(t *testing.T) {
+	inputs := map[string]interface{}{"app_repo_name": "repo"}
+
+	assert.Equal(t, inputs, projectConfigInputsWithLabels(inputs, nil))
+
+	result := projectConfigInputsWithLabels(inputs, map[string]interface{}{"team": "payments"})
+	assert.Equal(t, map[string]interface{}{"app_repo_name": "repo", "labels": `{"team":"payments"}`}, result)
+	// The inputs of the definition are left unchanged.
+	assert.Equal(t, map[string]interface{}{"app_repo_name": "repo"}, inputs)
+
+	// The copy without labels does not share the inputs of the definition either
+	result = projectConfigInputsWithLabels(inputs, nil)
+	result["region"] = "us-south"
+	assert.Equal(t, map[string]interface{}{"app_repo_name": "repo"}, inputs)
+	assert.Equal(t, map[string]interface{}{}, projectConfigInputsWithLabels(nil, nil))
+}
+
+func TestProjectConfigPayloadWithoutLabels(t *testing.T) {
+	payload := map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"app_repo_name": "repo"}}
+	assert.Equal(t, map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"app_repo_name": "repo", "labels": nil}}, projectConfigPayloadWithoutLabels(payload))
+	assert.Equal(t, map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"app_repo_name": "repo"}}, payload)
+
+	// A definition_json without inputs
+	assert.Equal(t, map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"labels": nil}}, projectConfigPayloadWithoutLabels(map[string]interface{}{"name": "config"}))
+}
+
+func TestProjectConfigDefinitionPayloadLabels(t *testing.T) {
+	definitionMap := map[string]interface{}{"name": "config", "inputs": map[string]interface{}{"app_repo_name": "repo"}}
+	payload, err := projectConfigDefinitionPayload(definitionMap, map[string]interface{}{"team": "payments"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"app_repo_name": "repo", "labels": `{"team":"payments"}`}, payload["inputs"])
+	// The definition block is left unchanged
+	assert.Equal(t, map[string]interface{}{"app_repo_name": "repo"}, definitionMap["inputs"])
+
+	// A definition without inputs is sent without inputs when there are no labels
+	payload, err = projectConfigDefinitionPayload(map[string]interface{}{"name": "config"}, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, payload, "inputs")
+}
+
+func TestProjectConfigListedLabels(t *testing.T) {
+	labels, ok := projectConfigListedLabels(map[string]json.RawMessage{"definition": json.RawMessage(`{"name": "config", "inputs": {"labels": "{\"team\":\"payments\"}"}}`)})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"team": "payments"}, labels)
+
+	labels, ok = projectConfigListedLabels(map[string]json.RawMessage{"definition": json.RawMessage(`{"name": "config", "inputs": {"app_repo_name": "repo"}}`)})
+	assert.True(t, ok)
+	assert.Empty(t, labels)
+
+	// The labels of a listed definition without inputs are read with the configuration
+	_, ok = projectConfigListedLabels(map[string]json.RawMessage{"definition": json.RawMessage(`{"name": "config"}`)})
+	assert.False(t, ok)
+	_, ok = projectConfigListedLabels(map[string]json.RawMessage{"id": json.RawMessage(`"cfg-1"`)})
+	assert.False(t, ok)
+}
+
+func TestProjectConfigLabelsFromInputs(t *testing.T) {
+	labels, ok := projectConfigLabelsFromInputs(map[string]interface{}{"labels": `{"team":"payments","cost_center":"42"}`})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"team": "payments", "cost_center": "42"}, labels)
+
+	labels, ok = projectConfigLabelsFromInputs(map[string]interface{}{"labels": map[string]interface{}{"cost_center": 42}})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"cost_center": "42"}, labels)
+
+	_, ok = projectConfigLabelsFromInputs(map[string]interface{}{"app_repo_name": "repo"})
+	assert.False(t, ok)
+
+	_, ok = projectConfigLabelsFromInputs(map[string]interface{}{"labels": "not json"})
+	assert.False(t, ok)
+}
+
+func TestProjectConfigLabelsMatch(t *testing.T) {
+	labels := map[string]interface{}{"team": "payments", "cost_center": "42"}
+
+	assert.True(t, projectConfigLabelsMatch(labels, map[string]interface{}{}))
+	assert.True(t, projectConfigLabelsMatch(labels, map[string]interface{}{"team": "payments"}))
+	assert.True(t, projectConfigLabelsMatch(labels, map[string]interface{}{"team": "payments", "cost_center": "42"}))
+	assert.False(t, projectConfigLabelsMatch(labels, map[string]interface{}{"team": "search"}))
+	assert.False(t, projectConfigLabelsMatch(labels, map[string]interface{}{"team": "payments", "env": "prod"}))
+	assert.False(t, projectConfigLabelsMatch(nil, map[string]interface{}{"team": "payments"}))
+}
@@ -0,0 +1,138 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+// projectConfigLastMonitoring is the last monitoring job of a configuration, which the Projects API runs
+// periodically to detect drift when monitoring is enabled on the project. The projectv1 models do not have the
+// last_monitoring property of the configuration, so it is read from the raw response.
+type projectConfigLastMonitoring struct {
+	At     *string                         `json:"at"`
+	Href   *string                         `json:"href"`
+	Result *string                         `json:"result"`
+	Job    *projectConfigLastMonitoringJob `json:"job"`
+}
+
+type projectConfigLastMonitoringJob struct {
+	ID      *string `json:"id"`
+	Summary *struct {
+		PlanSummary *struct {
+			Add     *int64 `json:"add"`
+			Update  *int64 `json:"update"`
+			Destroy *int64 `json:"destroy"`
+		} `json:"plan_summary"`
+	} `json:"summary"`
+}
+
+// projectConfigIncludeLastMonitoringSchema is the schema of the include_last_monitoring argument, which opts in to
+// the request that reads last_monitoring.
+func projectConfigIncludeLastMonitoringSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: description,
+	}
+}
+
+// projectConfigLastMonitoringSchema is the schema of the last_monitoring attribute.
+func projectConfigLastMonitoringSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The last job that checked the deployed configuration for drift, when monitoring is enabled on the project. It is only read when `include_last_monitoring` is set, and it is empty when monitoring never ran.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"at": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The time of the last monitoring job, in the format YYYY-MM-DDTHH:mm:ssZ.",
+				},
+				"result": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The result of the last monitoring job.",
+				},
+				"job_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the Schematics job of the last monitoring job.",
+				},
+				"job_href": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The URL of the last monitoring job.",
+				},
+				"add_count": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of resources that the plan of the last monitoring job adds, 0 when the job has no plan summary.",
+				},
+				"change_count": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of resources that the plan of the last monitoring job changes, 0 when the job has no plan summary.",
+				},
+				"destroy_count": &schema.Schema{
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of resources that the plan of the last monitoring job destroys, 0 when the job has no plan summary.",
+				},
+				"drift_detected": &schema.Schema{
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the plan of the last monitoring job adds, changes or destroys resources.",
+				},
+			},
+		},
+	}
+}
+
+// projectConfigLastMonitoringToMap maps the last_monitoring property of a configuration to the last_monitoring
+// block: an empty list when monitoring never ran. The counts of the plan summary of the job are the drift, and they
+// are 0 when the job has no plan summary.
+func projectConfigLastMonitoringToMap(rawLastMonitoring json.RawMessage) ([]map[string]interface{}, error) {
+	if len(rawLastMonitoring) == 0 || bytes.Equal(bytes.TrimSpace(rawLastMonitoring), []byte("null")) {
+		return []map[string]interface{}{}, nil
+	}
+	var lastMonitoring projectConfigLastMonitoring
+	if err := json.Unmarshal(rawLastMonitoring, &lastMonitoring); err != nil {
+		return nil, err
+	}
+	var addCount, changeCount, destroyCount int64
+	jobID := ""
+	if job := lastMonitoring.Job; job != nil {
+		jobID = flex.StringValue(job.ID)
+		if job.Summary != nil && job.Summary.PlanSummary != nil {
+			planSummary := job.Summary.PlanSummary
+			if planSummary.Add != nil {
+				addCount = *planSummary.Add
+			}
+			if planSummary.Update != nil {
+				changeCount = *planSummary.Update
+			}
+			if planSummary.Destroy != nil {
+				destroyCount = *planSummary.Destroy
+			}
+		}
+	}
+	return []map[string]interface{}{{
+		"at":             flex.StringValue(lastMonitoring.At),
+		"result":         flex.StringValue(lastMonitoring.Result),
+		"job_id":         jobID,
+		"job_href":       flex.StringValue(lastMonitoring.Href),
+		"add_count":      int(addCount),
+		"change_count":   int(changeCount),
+		"destroy_count":  int(destroyCount),
+		"drift_detected": addCount+changeCount+destroyCount > 0,
+	}}, nil
+}
@@ -0,0 +1,55 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigLastMonitoringToMap(t *testing.T) {
+	lastMonitoring, err := projectConfigLastMonitoringToMap(json.RawMessage(`{
+		"at": "2024-05-02T03:00:00Z",
+		"result": "passed",
+		"href": "https://projects.api.cloud.ibm.com/v1/projects/p/configs/c/monitor",
+		"job": {"id": "job-1", "summary": {"plan_summary": {"add": 1, "update": 2, "destroy": 3}}}
+	}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{
+		"at":             "2024-05-02T03:00:00Z",
+		"result":         "passed",
+		"job_id":         "job-1",
+		"job_href":       "https://projects.api.cloud.ibm.com/v1/projects/p/configs/c/monitor",
+		"add_count":      1,
+		"change_count":   2,
+		"destroy_count":  3,
+		"drift_detected": true,
+	}}, lastMonitoring)
+
+	// A job without plan summary has no drift
+	lastMonitoring, err = projectConfigLastMonitoringToMap(json.RawMessage(`{"at": "2024-05-02T03:00:00Z", "result": "failed", "job": {"id": "job-2"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{
+		"at":             "2024-05-02T03:00:00Z",
+		"result":         "failed",
+		"job_id":         "job-2",
+		"job_href":       "",
+		"add_count":      0,
+		"change_count":   0,
+		"destroy_count":  0,
+		"drift_detected": false,
+	}}, lastMonitoring)
+
+	// Monitoring never ran
+	for _, raw := range []json.RawMessage{nil, json.RawMessage(`null`), json.RawMessage(" null ")} {
+		lastMonitoring, err = projectConfigLastMonitoringToMap(raw)
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{}, lastMonitoring)
+	}
+
+	_, err = projectConfigLastMonitoringToMap(json.RawMessage(`"passed"`))
+	assert.Error(t, err)
+}
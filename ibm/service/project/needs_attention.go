@@ -0,0 +1,84 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigNeedsAttentionErrorSeverity is the severity of the needs attention events that are reported
+// as warnings on reads.
+const projectConfigNeedsAttentionErrorSeverity = "ERROR"
+
+// projectConfigNeedsAttentionWarnings returns one warning for each needs attention event of a configuration
+// with severity ERROR, so that a configuration that fails out of band is visible in the plan output.
+func projectConfigNeedsAttentionWarnings(configID string, events []projectv1.ProjectConfigNeedsAttentionState) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, event := range events {
+		if !projectConfigIsErrorEvent(event) {
+			continue
+		}
+		detail := fmt.Sprintf("The event %s was raised on configuration %s at %s.", projectConfigStringValue(event.Event), configID, projectConfigStringValue(event.Timestamp))
+		if event.ActionURL != nil && *event.ActionURL != "" {
+			detail += fmt.Sprintf(" See %s to resolve it.", *event.ActionURL)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Project configuration needs attention: %s", projectConfigStringValue(event.Event)),
+			Detail:   detail,
+		})
+	}
+	return diags
+}
+
+func projectConfigStringValue(s *string) string {
+	if s == nil {
+		return "unknown"
+	}
+	return *s
+}
+
+func projectConfigIsErrorEvent(event projectv1.ProjectConfigNeedsAttentionState) bool {
+	return event.Severity != nil && strings.EqualFold(*event.Severity, projectConfigNeedsAttentionErrorSeverity)
+}
+
+// projectConfigHasErrors returns whether one of the needs attention events has severity ERROR.
+func projectConfigHasErrors(events []projectv1.ProjectConfigNeedsAttentionState) bool {
+	for _, event := range events {
+		if projectConfigIsErrorEvent(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// projectConfigEventIDPattern matches the IDs of needs attention events. It does not match event names such as
+// project.config.deploy.failed, which are not unique to an event.
+var projectConfigEventIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// projectConfigUnacknowledgedEvents returns the needs attention events whose ID is not acknowledged, in order. The
+// Projects API cannot clear the events of a configuration, so they are acknowledged on the client.
+func projectConfigUnacknowledgedEvents(events []projectv1.ProjectConfigNeedsAttentionState, acknowledgedEventIDs []interface{}) []projectv1.ProjectConfigNeedsAttentionState {
+	if len(acknowledgedEventIDs) == 0 {
+		return events
+	}
+	acknowledged := make(map[string]bool, len(acknowledgedEventIDs))
+	for _, id := range acknowledgedEventIDs {
+		acknowledged[id.(string)] = true
+	}
+	unacknowledged := []projectv1.ProjectConfigNeedsAttentionState{}
+	for _, event := range events {
+		if event.EventID != nil && acknowledged[*event.EventID] {
+			continue
+		}
+		unacknowledged = append(unacknowledged, event)
+	}
+	return unacknowledged
+}
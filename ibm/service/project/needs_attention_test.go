@@ -0,0 +1,92 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigNeedsAttentionWarnings(t *testing.T) {
+	events := []projectv1.ProjectConfigNeedsAttentionState{
+		{
+			EventID:   core.StringPtr("1"),
+			Event:     core.StringPtr("project.config.deploy.failed"),
+			Severity:  core.StringPtr("ERROR"),
+			ActionURL: core.StringPtr("https://cloud.ibm.com/projects/p1/configurations/c1"),
+			Timestamp: core.StringPtr("2024-03-01T10:00:00Z"),
+		},
+		{
+			EventID:   core.StringPtr("2"),
+			Event:     core.StringPtr("project.config.update.available"),
+			Severity:  core.StringPtr("INFO"),
+			Timestamp: core.StringPtr("2024-03-01T11:00:00Z"),
+		},
+		{
+			EventID:     core.StringPtr("3"),
+			Event:       core.StringPtr("project.config.approved"),
+			TriggeredBy: core.StringPtr("IBMid-123"),
+			Timestamp:   core.StringPtr("2024-03-01T12:00:00Z"),
+		},
+		{
+			EventID:   core.StringPtr("4"),
+			Event:     core.StringPtr("project.config.validate.failed"),
+			Severity:  core.StringPtr("error"),
+			Timestamp: core.StringPtr("2024-03-01T13:00:00Z"),
+		},
+	}
+
+	diags := projectConfigNeedsAttentionWarnings("c1", events)
+	assert.Equal(t, diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Project configuration needs attention: project.config.deploy.failed",
+			Detail:   "The event project.config.deploy.failed was raised on configuration c1 at 2024-03-01T10:00:00Z. See https://cloud.ibm.com/projects/p1/configurations/c1 to resolve it.",
+		},
+		{
+			Severity: diag.Warning,
+			Summary:  "Project configuration needs attention: project.config.validate.failed",
+			Detail:   "The event project.config.validate.failed was raised on configuration c1 at 2024-03-01T13:00:00Z.",
+		},
+	}, diags)
+
+	assert.Nil(t, projectConfigNeedsAttentionWarnings("c1", nil))
+	assert.Nil(t, projectConfigNeedsAttentionWarnings("c1", events[1:3]))
+}
+
+func TestProjectConfigUnacknowledgedEvents(t *testing.T) {
+	events := []projectv1.ProjectConfigNeedsAttentionState{
+		{EventID: core.StringPtr("0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11"), Event: core.StringPtr("project.config.deploy.failed"), Severity: core.StringPtr("ERROR")},
+		{EventID: core.StringPtr("5c7e6a2b-8f4d-4d7a-b0c1-6e2f9a8d7c22"), Event: core.StringPtr("project.config.update.available"), Severity: core.StringPtr("INFO")},
+		{Event: core.StringPtr("project.config.validate.failed"), Severity: core.StringPtr("ERROR")},
+	}
+
+	assert.Equal(t, events, projectConfigUnacknowledgedEvents(events, nil))
+	assert.True(t, projectConfigHasErrors(events))
+
+	unacknowledged := projectConfigUnacknowledgedEvents(events, []interface{}{"0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11", "unknown"})
+	assert.Equal(t, events[1:], unacknowledged)
+	assert.True(t, projectConfigHasErrors(unacknowledged), "an event without ID cannot be acknowledged")
+
+	unacknowledged = projectConfigUnacknowledgedEvents(events[:2], []interface{}{"0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11"})
+	assert.Equal(t, events[1:2], unacknowledged)
+	assert.False(t, projectConfigHasErrors(unacknowledged))
+
+	assert.Empty(t, projectConfigUnacknowledgedEvents(events[:1], []interface{}{"0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11"}))
+	assert.False(t, projectConfigHasErrors(nil))
+}
+
+func TestProjectConfigEventIDPattern(t *testing.T) {
+	for _, id := range []string{"0b3a3e1f-1d5e-4a4f-9c55-3c1d0c6f6a11", "1", "evt_42"} {
+		assert.True(t, projectConfigEventIDPattern.MatchString(id), id)
+	}
+	for _, id := range []string{"", "project.config.deploy.failed", "0b3a3e1f 1d5e", strings.Repeat("a", 129)} {
+		assert.False(t, projectConfigEventIDPattern.MatchString(id), id)
+	}
+}
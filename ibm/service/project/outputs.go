@@ -0,0 +1,28 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigFilterOutputs returns the outputs whose name is one of names, in the order of outputs, or all the
+// outputs when names is empty. The names that match no output are ignored, an output can be missing until the
+// configuration is deployed.
+func projectConfigFilterOutputs(outputs []projectv1.OutputValue, names []string) []projectv1.OutputValue {
+	if len(names) == 0 {
+		return outputs
+	}
+	included := make(map[string]bool, len(names))
+	for _, name := range names {
+		included[name] = true
+	}
+	filtered := []projectv1.OutputValue{}
+	for _, output := range outputs {
+		if output.Name != nil && included[*output.Name] {
+			filtered = append(filtered, output)
+		}
+	}
+	return filtered
+}
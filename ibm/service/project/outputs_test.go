@@ -0,0 +1,26 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigFilterOutputs(t *testing.T) {
+	outputs := []projectv1.OutputValue{
+		{Name: core.StringPtr("vpc_id"), Value: "r006-4ac2"},
+		{Name: core.StringPtr("kubeconfig"), Value: "apiVersion: v1"},
+		{Name: core.StringPtr("zones"), Value: 3},
+		{Description: core.StringPtr("No name")},
+	}
+
+	assert.Equal(t, outputs, projectConfigFilterOutputs(outputs, nil))
+	assert.Equal(t, []projectv1.OutputValue{outputs[0], outputs[2]}, projectConfigFilterOutputs(outputs, []string{"zones", "vpc_id", "subnets"}))
+	assert.Empty(t, projectConfigFilterOutputs(outputs, []string{"subnets"}))
+	assert.Empty(t, projectConfigFilterOutputs(nil, []string{"vpc_id"}))
+}
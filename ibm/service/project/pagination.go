@@ -0,0 +1,128 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// projectListPage describes a single page returned by a project list call.
+type projectListPage struct {
+	// Count is the number of items contributed by the page.
+	Count int
+	// Next is the start token of the following page, nil when there are no more pages.
+	Next *string
+	// TotalCount is the size of the whole collection as reported by the API, when present.
+	TotalCount *int64
+}
+
+// projectListAll walks a project list call page by page. The fetch function retrieves the page
+// that begins at the given start token (nil for the first page) and accumulates its items.
+// Each page waits on the limiter first, a nil limiter does not limit the requests.
+// It returns the number of accumulated items and the last total_count reported by the API.
+func projectListAll(context context.Context, limiter *projectRateLimiter, fetch func(context.Context, *string) (*projectListPage, error)) (int, *int64, error) {
+	var start *string
+	var totalCount *int64
+	accumulated := 0
+	for {
+		if err := limiter.Wait(context); err != nil {
+			return accumulated, totalCount, err
+		}
+		page, err := fetch(context, start)
+		if err != nil {
+			return accumulated, totalCount, err
+		}
+		accumulated += page.Count
+		if page.TotalCount != nil {
+			totalCount = page.TotalCount
+		}
+		if page.Next == nil || *page.Next == "" {
+			break
+		}
+		start = page.Next
+	}
+	return accumulated, totalCount, nil
+}
+
+// projectListShortfallDiag returns a warning when fewer items were accumulated than the
+// total_count reported by the API, which happens when a page is returned without a next
+// token before the end of the collection is reached.
+func projectListShortfallDiag(resourceName string, accumulated int, totalCount *int64) diag.Diagnostics {
+	if totalCount == nil || int64(accumulated) >= *totalCount {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s returned a partial list", resourceName),
+			Detail: fmt.Sprintf("The API reported a total_count of %d but only %d items were retrieved, %d items are missing. "+
+				"The service may have ended the pagination early, retry the operation to read the complete list.",
+				*totalCount, accumulated, *totalCount-int64(accumulated)),
+		},
+	}
+}
+
+// projectListTotalCount returns the total_count to expose on a plural data source, falling back
+// to the number of accumulated items when the API does not report it.
+func projectListTotalCount(accumulated int, totalCount *int64) int {
+	if totalCount != nil {
+		return int(*totalCount)
+	}
+	return accumulated
+}
+
+// projectListConfigs returns the summaries of all the configurations of a project.
+func projectListConfigs(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string) ([]projectv1.ProjectConfigSummary, error) {
+	configs := []projectv1.ProjectConfigSummary{}
+	_, _, err := projectListAll(context, limiter, func(context context.Context, start *string) (*projectListPage, error) {
+		listConfigsOptions := &projectv1.ListConfigsOptions{}
+		listConfigsOptions.SetProjectID(projectID)
+
+		projectConfigCollection, _, err := projectClient.ListConfigsWithContext(context, listConfigsOptions)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, projectConfigCollection.Configs...)
+
+		// The configurations of a project are returned in a single page.
+		return &projectListPage{
+			Count: len(projectConfigCollection.Configs),
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return configs, nil
+}
+
+// projectListConfigResources returns all the resources of a configuration, and the resources_count reported by the
+// API, when present.
+func projectListConfigResources(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, configID string) ([]projectv1.ProjectConfigResource, *int64, error) {
+	resources := []projectv1.ProjectConfigResource{}
+	_, totalCount, err := projectListAll(context, limiter, func(context context.Context, start *string) (*projectListPage, error) {
+		listConfigResourcesOptions := &projectv1.ListConfigResourcesOptions{}
+		listConfigResourcesOptions.SetProjectID(projectID)
+		listConfigResourcesOptions.SetID(configID)
+
+		projectConfigResourceCollection, _, err := projectClient.ListConfigResourcesWithContext(context, listConfigResourcesOptions)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, projectConfigResourceCollection.Resources...)
+
+		// The resources of a configuration are returned in a single page.
+		return &projectListPage{
+			Count:      len(projectConfigResourceCollection.Resources),
+			TotalCount: projectConfigResourceCollection.ResourcesCount,
+		}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resources, totalCount, nil
+}
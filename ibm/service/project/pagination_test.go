@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectPages fakes a project list call that returns the given pages in order, recording the start token of each
+// request
+func testProjectPages(pages []*projectListPage, errAt int, starts *[]string) func(context.Context, *string) (*projectListPage, error) {
+	index := 0
+	return func(_ context.Context, start *string) (*projectListPage, error) {
+		if start == nil {
+			*starts = append(*starts, "")
+		} else {
+			*starts = append(*starts, *start)
+		}
+		if index == errAt {
+			return nil, errors.New("Internal Server Error")
+		}
+		page := pages[index]
+		index++
+		return page, nil
+	}
+}
+
+func TestProjectListAll(t *testing.T) {
+	pages := []*projectListPage{
+		{Count: 10, Next: core.StringPtr("b"), TotalCount: core.Int64Ptr(25)},
+		{Count: 10, Next: core.StringPtr("c")},
+		{Count: 5, TotalCount: core.Int64Ptr(25)},
+	}
+
+	starts := []string{}
+	accumulated, totalCount, err := projectListAll(context.Background(), nil, testProjectPages(pages, -1, &starts))
+	assert.NoError(t, err)
+	assert.Equal(t, 25, accumulated)
+	assert.Equal(t, int64(25), *totalCount)
+	// Each page starts at the token of the page before it
+	assert.Equal(t, []string{"", "b", "c"}, starts)
+
+	// An empty next token ends the listing like a missing one
+	starts = []string{}
+	accumulated, totalCount, err = projectListAll(context.Background(), nil, testProjectPages([]*projectListPage{{Count: 3, Next: core.StringPtr("")}}, -1, &starts))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, accumulated)
+	assert.Nil(t, totalCount)
+	assert.Equal(t, []string{""}, starts)
+
+	// A failed page stops the listing with the items accumulated before it
+	starts = []string{}
+	accumulated, totalCount, err = projectListAll(context.Background(), nil, testProjectPages(pages, 1, &starts))
+	assert.EqualError(t, err, "Internal Server Error")
+	assert.Equal(t, 10, accumulated)
+	assert.Equal(t, int64(25), *totalCount)
+	assert.Equal(t, []string{"", "b"}, starts)
+}
+
+func TestProjectListShortfallDiag(t *testing.T) {
+	// The whole collection was listed, or the API does not report its size
+	assert.Nil(t, projectListShortfallDiag("(Data) ibm_projects", 25, core.Int64Ptr(25)))
+	assert.Nil(t, projectListShortfallDiag("(Data) ibm_projects", 20, nil))
+
+	// The pagination ended before the total_count was reached
+	diags := projectListShortfallDiag("(Data) ibm_projects", 20, core.Int64Ptr(25))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "(Data) ibm_projects returned a partial list", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, "total_count of 25 but only 20 items were retrieved, 5 items are missing")
+}
+
+func TestProjectListTotalCount(t *testing.T) {
+	assert.Equal(t, 25, projectListTotalCount(20, core.Int64Ptr(25)))
+	assert.Equal(t, 20, projectListTotalCount(20, nil))
+}
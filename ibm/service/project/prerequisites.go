@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigStateCodeAwaitingPrerequisite is the state_code of a member configuration of a stack that waits for
+// the configurations that it references to be deployed.
+const projectConfigStateCodeAwaitingPrerequisite = "awaiting_prerequisite"
+
+// projectConfigStateCode returns the state_code of the raw properties of a configuration, empty when the
+// configuration does not have one. The projectv1 models do not have the state_code property.
+func projectConfigStateCode(rawProperties map[string]json.RawMessage) (string, error) {
+	rawStateCode := rawProperties["state_code"]
+	if len(rawStateCode) == 0 || bytes.Equal(bytes.TrimSpace(rawStateCode), []byte("null")) {
+		return "", nil
+	}
+	var stateCode string
+	if err := json.Unmarshal(rawStateCode, &stateCode); err != nil {
+		return "", err
+	}
+	return stateCode, nil
+}
+
+// projectConfigPrerequisiteRefs returns the sorted IDs or names of the configurations that the inputs reference, the
+// prerequisites of the configuration. The references of the configuration to itself are ignored.
+func projectConfigPrerequisiteRefs(inputs map[string]interface{}, configID string, configName string) []string {
+	seen := map[string]bool{}
+	refs := []string{}
+	for _, ref := range projectConfigReferencedConfigs(inputs) {
+		if seen[ref] || ref == configID || (configName != "" && ref == configName) {
+			continue
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// projectConfigPendingPrerequisites lists the configurations of the project once, and returns the sorted IDs of the
+// prerequisites of refs that are not deployed yet, and the refs that match no configuration of the project, such as
+// the references to deleted configurations. A ref matches the ID of a configuration, else its name.
+func projectConfigPendingPrerequisites(context context.Context, projectClient projectConfigAPI, projectID string, refs []string) ([]string, []string, error) {
+	summaries, err := projectListConfigs(context, projectClient, nil, projectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byID := map[string]projectv1.ProjectConfigSummary{}
+	byName := map[string]projectv1.ProjectConfigSummary{}
+	for _, summary := range summaries {
+		if summary.ID != nil {
+			byID[*summary.ID] = summary
+		}
+		if summary.Definition != nil && summary.Definition.Name != nil {
+			byName[*summary.Definition.Name] = summary
+		}
+	}
+
+	pending := []string{}
+	missing := []string{}
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		summary, ok := byID[ref]
+		if !ok {
+			summary, ok = byName[ref]
+		}
+		if !ok || summary.ID == nil {
+			missing = append(missing, ref)
+			continue
+		}
+		if summary.DeployedVersion == nil && !seen[*summary.ID] {
+			seen[*summary.ID] = true
+			pending = append(pending, *summary.ID)
+		}
+	}
+	sort.Strings(pending)
+	sort.Strings(missing)
+	return pending, missing, nil
+}
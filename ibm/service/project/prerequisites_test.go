@@ -0,0 +1,118 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigStateCode(t *testing.T) {
+	testCases := []struct {
+		rawProperties map[string]json.RawMessage
+		stateCode     string
+		err           bool
+	}{
+		{map[string]json.RawMessage{"id": json.RawMessage(`"a1b2c3"`)}, "", false},
+		{map[string]json.RawMessage{"state_code": json.RawMessage(`null`)}, "", false},
+		{map[string]json.RawMessage{"state_code": json.RawMessage(`"awaiting_prerequisite"`)}, "awaiting_prerequisite", false},
+		{map[string]json.RawMessage{"state_code": json.RawMessage(`3`)}, "", true},
+	}
+	for _, tc := range testCases {
+		stateCode, err := projectConfigStateCode(tc.rawProperties)
+		assert.Equal(t, tc.err, err != nil, string(tc.rawProperties["state_code"]))
+		assert.Equal(t, tc.stateCode, stateCode, string(tc.rawProperties["state_code"]))
+	}
+}
+
+func TestProjectConfigPrerequisiteRefs(t *testing.T) {
+	inputs := map[string]interface{}{
+		"vpc_id":     "ref:/configs/network/outputs/vpc_id",
+		"subnet_ids": []interface{}{"ref:/configs/network/outputs/subnet_id", "ref:/configs/d4e5f6/outputs/subnet_id"},
+		"tags":       `{"owner":"ref:/configs/a0b0c0/outputs/owner"}`,
+		"self":       "ref:/configs/cluster/outputs/name",
+		"self_id":    "ref:/configs/g7h8i9/outputs/name",
+		"region":     "us-south",
+	}
+	assert.Equal(t, []string{"a0b0c0", "d4e5f6", "network"}, projectConfigPrerequisiteRefs(inputs, "g7h8i9", "cluster"))
+	assert.Empty(t, projectConfigPrerequisiteRefs(nil, "g7h8i9", "cluster"))
+}
+
+func TestProjectConfigPendingPrerequisites(t *testing.T) {
+	network := testProjectConfigSummary("a1b2c3", "network")
+	cluster := testProjectConfigSummary("d4e5f6", "cluster")
+	cluster.DeployedVersion = &projectv1.ProjectConfigVersionSummary{Version: core.Int64Ptr(1)}
+	logging := testProjectConfigSummary("g7h8i9", "logging")
+	api := &testProjectConfigListAPI{listings: [][]projectv1.ProjectConfigSummary{{network, cluster, logging}}}
+
+	// The refs match the IDs first, then the names, and a prerequisite referenced twice is reported once
+	pending, missing, err := projectConfigPendingPrerequisites(context.Background(), api, "project", []string{"a1b2c3", "cluster", "deleted", "logging", "network"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1b2c3", "g7h8i9"}, pending)
+	assert.Equal(t, []string{"deleted"}, missing)
+	assert.Equal(t, 1, api.calls)
+
+	api = &testProjectConfigListAPI{listErr: errors.New("Internal Server Error")}
+	_, _, err = projectConfigPendingPrerequisites(context.Background(), api, "project", []string{"network"})
+	assert.EqualError(t, err, "Internal Server Error")
+}
+
+func TestDataSourceIbmProjectConfigReadPrerequisites(t *testing.T) {
+	network := testProjectConfigSummary("a1b2c3", "network")
+	api := &testProjectConfigListAPI{
+		testProjectConfigAPI: testProjectConfigAPI{
+			config: &projectv1.ProjectConfig{
+				ID: core.StringPtr("g7h8i9"),
+				Definition: &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+					Name: core.StringPtr("cluster"),
+					Inputs: map[string]interface{}{
+						"vpc_id":    "ref:/configs/network/outputs/vpc_id",
+						"subnet_id": "ref:/configs/a1b2c3/outputs/subnet_id",
+						"bucket":    "ref:/configs/storage/outputs/bucket",
+					},
+				},
+			},
+			stateCode: projectConfigStateCodeAwaitingPrerequisite,
+		},
+		listings: [][]projectv1.ProjectConfigSummary{{network}},
+	}
+	read := func(raw map[string]interface{}) *schema.ResourceData {
+		config := map[string]interface{}{"project_id": "b0a2c11d-926c-4653-a15b-ed17d7b34b22", "project_config_id": "g7h8i9"}
+		for k, v := range raw {
+			config[k] = v
+		}
+		d := schema.TestResourceDataRaw(t, DataSourceIbmProjectConfig().Schema, config)
+		assert.Empty(t, dataSourceIbmProjectConfigReadWithClient(context.Background(), d, api, &api.environments, api, nil, ""))
+		return d
+	}
+
+	// Without include_prerequisite_status, the references are reported as they are, and neither the project is
+	// listed nor the state code read
+	d := read(nil)
+	assert.Equal(t, false, d.Get("awaiting_prerequisites"))
+	assert.Equal(t, []interface{}{"a1b2c3", "network", "storage"}, d.Get("prerequisite_config_ids"))
+	assert.Empty(t, d.Get("missing_prerequisites"))
+	assert.Equal(t, 0, api.calls)
+
+	d = read(map[string]interface{}{"include_prerequisite_status": true})
+	assert.Equal(t, true, d.Get("awaiting_prerequisites"))
+	assert.Equal(t, []interface{}{"a1b2c3"}, d.Get("prerequisite_config_ids"))
+	assert.Equal(t, []interface{}{"storage"}, d.Get("missing_prerequisites"))
+	assert.Equal(t, 1, api.calls)
+
+	// Once deployed, the prerequisite is no longer pending
+	api.stateCode = ""
+	api.listings[0][0].DeployedVersion = &projectv1.ProjectConfigVersionSummary{Version: core.Int64Ptr(1)}
+	d = read(map[string]interface{}{"include_prerequisite_status": true})
+	assert.Equal(t, false, d.Get("awaiting_prerequisites"))
+	assert.Empty(t, d.Get("prerequisite_config_ids"))
+	assert.Equal(t, []interface{}{"storage"}, d.Get("missing_prerequisites"))
+}
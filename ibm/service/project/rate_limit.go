@@ -0,0 +1,91 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+)
+
+// projectRateLimiterService is the key of the rate limiter that the requests to the Projects API share.
+const projectRateLimiterService = "project"
+
+// projectRateLimiter is a token bucket with a burst of one token: it spaces the requests that wait on it by the
+// interval of its rate, whichever data source sends them, so that walking many pages does not burst the rate limits
+// of the API.
+type projectRateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+
+	now   func() time.Time
+	sleep func(context.Context, time.Duration) error
+}
+
+func newProjectRateLimiter(requestsPerSecond float64) *projectRateLimiter {
+	return &projectRateLimiter{
+		interval: projectRateLimiterInterval(requestsPerSecond),
+		now:      time.Now,
+		sleep:    projectSleep,
+	}
+}
+
+func projectRateLimiterInterval(requestsPerSecond float64) time.Duration {
+	return time.Duration(float64(time.Second) / requestsPerSecond)
+}
+
+// projectRateLimiterFor returns the rate limiter of the Projects API of the session, with the
+// project_requests_per_second of its provider configuration, nil when the requests are not limited. Each provider
+// configuration has its own session, so the provider aliases of a process do not share a limiter or a rate.
+func projectRateLimiterFor(meta interface{}) *projectRateLimiter {
+	sess := meta.(conns.ClientSession)
+	return projectSessionRateLimiter(sess.CallCache(), projectRateLimiterService, sess.ProjectRequestsPerSecond())
+}
+
+// projectSessionRateLimiter returns the rate limiter of the service, kept in the call cache of the session so that the
+// data sources that are read in parallel share the budget of the service. It is created on first use with
+// requestsPerSecond, which is fixed for the session. It returns nil when requestsPerSecond is not positive.
+func projectSessionRateLimiter(cache *conns.CallCache, service string, requestsPerSecond float64) *projectRateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	limiter, _ := cache.Do("project_rate_limiter/"+service, func() (interface{}, time.Time, error) {
+		return newProjectRateLimiter(requestsPerSecond), time.Time{}, nil
+	})
+	return limiter.(*projectRateLimiter)
+}
+
+// Wait blocks until the next request may be sent, or until the context is done. A nil limiter does not wait.
+func (l *projectRateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	now := l.now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	return l.sleep(ctx, delay)
+}
+
+func projectSleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
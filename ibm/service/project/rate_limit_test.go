@@ -0,0 +1,154 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectClock is a fake clock that the sleeps of a rate limiter advance
+type testProjectClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *testProjectClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testProjectClock) Sleep(ctx context.Context, delay time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, delay)
+	c.now = c.now.Add(delay)
+	return ctx.Err()
+}
+
+func testProjectRateLimiter(requestsPerSecond float64, clock *testProjectClock) *projectRateLimiter {
+	limiter := newProjectRateLimiter(requestsPerSecond)
+	limiter.now = clock.Now
+	limiter.sleep = clock.Sleep
+	return limiter
+}
+
+// testProjectPager fakes a project list call of the given number of pages, recording when each page is requested
+func testProjectPager(clock func() time.Time, pages int, requestedAt *[]time.Time) func(context.Context, *string) (*projectListPage, error) {
+	return func(_ context.Context, start *string) (*projectListPage, error) {
+		*requestedAt = append(*requestedAt, clock())
+		index := 0
+		if start != nil {
+			fmt.Sscanf(*start, "%d", &index)
+		}
+		page := &projectListPage{Count: 10}
+		if index+1 < pages {
+			next := fmt.Sprintf("%d", index+1)
+			page.Next = &next
+		}
+		return page, nil
+	}
+}
+
+func TestProjectListAllRateLimited(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := testProjectRateLimiter(4, clock)
+
+	var requestedAt []time.Time
+	accumulated, _, err := projectListAll(context.Background(), limiter, testProjectPager(clock.Now, 4, &requestedAt))
+	assert.NoError(t, err)
+	assert.Equal(t, 40, accumulated)
+	assert.Len(t, requestedAt, 4)
+	for i := 1; i < len(requestedAt); i++ {
+		assert.Equal(t, 250*time.Millisecond, requestedAt[i].Sub(requestedAt[i-1]))
+	}
+	// The first page is not delayed
+	assert.Equal(t, []time.Duration{250 * time.Millisecond, 250 * time.Millisecond, 250 * time.Millisecond}, clock.sleeps)
+
+	// A listing that starts after the interval elapsed is not delayed either
+	clock.now = clock.now.Add(time.Second)
+	clock.sleeps = nil
+	_, _, err = projectListAll(context.Background(), limiter, testProjectPager(clock.Now, 1, &requestedAt))
+	assert.NoError(t, err)
+	assert.Empty(t, clock.sleeps)
+}
+
+func TestProjectListAllWithoutLimiter(t *testing.T) {
+	var requestedAt []time.Time
+	accumulated, _, err := projectListAll(context.Background(), nil, testProjectPager(time.Now, 3, &requestedAt))
+	assert.NoError(t, err)
+	assert.Equal(t, 30, accumulated)
+	assert.Len(t, requestedAt, 3)
+}
+
+func TestProjectListAllRateLimitedCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := testProjectRateLimiter(1, clock)
+
+	var requestedAt []time.Time
+	pager := testProjectPager(clock.Now, 3, &requestedAt)
+	accumulated, _, err := projectListAll(ctx, limiter, func(ctx context.Context, start *string) (*projectListPage, error) {
+		cancel()
+		return pager(ctx, start)
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 10, accumulated)
+	assert.Len(t, requestedAt, 1)
+}
+
+func TestProjectSessionRateLimiter(t *testing.T) {
+	cache := conns.NewCallCache()
+	assert.Nil(t, projectSessionRateLimiter(cache, "test-disabled", 0))
+
+	limiter := projectSessionRateLimiter(cache, "test-shared", 50)
+	assert.Same(t, limiter, projectSessionRateLimiter(cache, "test-shared", 50))
+	assert.NotSame(t, limiter, projectSessionRateLimiter(cache, "test-other", 50))
+	assert.Equal(t, 20*time.Millisecond, limiter.interval)
+
+	// The data sources that list in parallel share the budget of the service: the requests of both pagers are
+	// spaced by the interval of the rate as a whole
+	var mu sync.Mutex
+	var requestedAt []time.Time
+	record := func(_ context.Context, start *string) (*projectListPage, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		requestedAt = append(requestedAt, time.Now())
+		if start == nil {
+			next := "last"
+			return &projectListPage{Count: 1, Next: &next}, nil
+		}
+		return &projectListPage{Count: 1}, nil
+	}
+	started := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := projectListAll(context.Background(), projectSessionRateLimiter(cache, "test-shared", 50), record)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, requestedAt, 6)
+	sort.Slice(requestedAt, func(i, j int) bool { return requestedAt[i].Before(requestedAt[j]) })
+	assert.GreaterOrEqual(t, requestedAt[5].Sub(started), 5*20*time.Millisecond)
+
+	// Another provider configuration has its own limiter, with its own rate
+	otherLimiter := projectSessionRateLimiter(conns.NewCallCache(), "test-shared", 10)
+	assert.NotSame(t, limiter, otherLimiter)
+	assert.Equal(t, 100*time.Millisecond, otherLimiter.interval)
+	assert.Equal(t, 20*time.Millisecond, limiter.interval)
+}
@@ -0,0 +1,96 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigReferenceRegexp matches the references to the configurations of the same project, such as
+// ref:/configs/<config ID or name>/outputs/<output name>. The first group is the ID or the name of the configuration.
+var projectConfigReferenceRegexp = regexp.MustCompile(`ref:/configs/([^/\s"]+)/`)
+
+// projectConfigReferencedConfigs returns the IDs or names of the configurations that are referenced by value. Maps
+// and lists are walked, and strings are scanned as a whole so that references in JSON encoded inputs are found.
+func projectConfigReferencedConfigs(value interface{}) []string {
+	referenced := []string{}
+	switch v := value.(type) {
+	case string:
+		for _, match := range projectConfigReferenceRegexp.FindAllStringSubmatch(v, -1) {
+			referenced = append(referenced, match[1])
+		}
+	case *string:
+		if v != nil {
+			referenced = append(referenced, projectConfigReferencedConfigs(*v)...)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			referenced = append(referenced, projectConfigReferencedConfigs(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			referenced = append(referenced, projectConfigReferencedConfigs(item)...)
+		}
+	}
+	return referenced
+}
+
+// projectConfigInputsReference reports whether inputs reference a configuration that is identified by one of refs.
+func projectConfigInputsReference(inputs map[string]interface{}, refs ...string) bool {
+	for _, referenced := range projectConfigReferencedConfigs(inputs) {
+		for _, ref := range refs {
+			if ref != "" && referenced == ref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// projectConfigReferencingConfigNames returns the sorted names of the other configurations of the project whose
+// inputs reference the configuration, by its ID or its name.
+func projectConfigReferencingConfigNames(context context.Context, projectClient projectConfigAPI, projectID string, configID string, configName string) ([]string, error) {
+	summaries, err := projectListConfigs(context, projectClient, nil, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, summary := range summaries {
+		if summary.ID == nil || *summary.ID == configID {
+			continue
+		}
+		// The inputs are only returned with the definition of each configuration.
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(*summary.ID)
+
+		projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+		if err != nil {
+			return nil, err
+		}
+		if projectConfig.Definition == nil {
+			continue
+		}
+		definitionMap, err := resourceIbmProjectConfigProjectConfigDefinitionResponseToMap(projectConfig.Definition)
+		if err != nil {
+			return nil, err
+		}
+		inputs, _ := definitionMap["inputs"].(map[string]interface{})
+		if !projectConfigInputsReference(inputs, configID, configName) {
+			continue
+		}
+		name := *summary.ID
+		if summary.Definition != nil && summary.Definition.Name != nil {
+			name = *summary.Definition.Name
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
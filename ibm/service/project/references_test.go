@@ -0,0 +1,38 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigReferencedConfigs(t *testing.T) {
+	assert.Equal(t, []string{"network"}, projectConfigReferencedConfigs("ref:/configs/network/outputs/vpc_id"))
+	assert.Equal(t, []string{}, projectConfigReferencedConfigs("ref:/inputs/region"))
+	assert.Equal(t, []string{}, projectConfigReferencedConfigs(42))
+
+	encoded := `["ref:/configs/network/outputs/vpc_id","ref:/configs/1b8d-config-id/outputs/subnet"]`
+	assert.Equal(t, []string{"network", "1b8d-config-id"}, projectConfigReferencedConfigs(encoded))
+
+	nested := map[string]interface{}{
+		"vpc_id":  "ref:/configs/network/outputs/vpc_id",
+		"subnets": []interface{}{"plain", map[string]interface{}{"id": "ref:/configs/subnets/outputs/id"}},
+	}
+	referenced := projectConfigReferencedConfigs(nested)
+	sort.Strings(referenced)
+	assert.Equal(t, []string{"network", "subnets"}, referenced)
+}
+
+func TestProjectConfigInputsReference(t *testing.T) {
+	inputs := map[string]interface{}{"vpc_id": "ref:/configs/network/outputs/vpc_id"}
+
+	assert.True(t, projectConfigInputsReference(inputs, "1b8d-config-id", "network"))
+	assert.True(t, projectConfigInputsReference(map[string]interface{}{"vpc_id": "ref:/configs/1b8d-config-id/outputs/vpc_id"}, "1b8d-config-id", "network"))
+	assert.False(t, projectConfigInputsReference(inputs, "1b8d-config-id", "database"))
+	assert.False(t, projectConfigInputsReference(inputs, "", ""))
+	assert.False(t, projectConfigInputsReference(nil, "network"))
+}
@@ -0,0 +1,94 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectRegionPattern matches the names of IBM Cloud regions, such as us-south, eu-de or eu-fr2.
+var projectRegionPattern = regexp.MustCompile(`^[a-z]{2}-[a-z]+[0-9]*$`)
+
+// projectRegion returns the region of a project from its CRN or, when the CRN has no region, from the host of the
+// href of the project or of one of its configurations or environments. It is empty when neither names a region, such
+// as the hrefs of the global endpoint.
+func projectRegion(crn *string, href *string) string {
+	if crn != nil {
+		if region := projectRegionFromCRN(*crn); region != "" {
+			return region
+		}
+	}
+	if href != nil {
+		return projectRegionFromHref(*href)
+	}
+	return ""
+}
+
+// projectReferenceCrn returns the CRN of a referenced project, nil when the project is not referenced.
+func projectReferenceCrn(project *projectv1.ProjectReference) *string {
+	if project == nil {
+		return nil
+	}
+	return project.Crn
+}
+
+// projectRegionFromCRN returns the location segment of a CRN, crn:v1:<cloud>:<type>:<service>:<location>:..., when
+// it is a region.
+func projectRegionFromCRN(crn string) string {
+	segments := strings.Split(crn, ":")
+	if len(segments) < 6 || segments[0] != "crn" || !projectRegionPattern.MatchString(segments[5]) {
+		return ""
+	}
+	return segments[5]
+}
+
+// projectRegionFromHref returns the label of the host of an href that is a region, such as us-south in
+// https://us-south.projects.cloud.ibm.com/v1/projects/<id> or https://private.us-south.projects.cloud.ibm.com/....
+func projectRegionFromHref(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return ""
+	}
+	for _, label := range strings.Split(u.Hostname(), ".") {
+		if projectRegionPattern.MatchString(label) {
+			return label
+		}
+	}
+	return ""
+}
+
+// projectProviderRegion returns the region that is configured for the provider, empty when it is unknown.
+func projectProviderRegion(meta interface{}) string {
+	sess, err := meta.(conns.ClientSession).BluemixSession()
+	if err != nil || sess == nil || sess.Config == nil {
+		return ""
+	}
+	return sess.Config.Region
+}
+
+// projectRegionMismatchWarnings returns a warning when the region of a project differs from the region of the
+// provider. The Projects API routes the requests globally, so the read succeeds, but resources that are created from
+// its outputs with the same provider target the region of the provider.
+func projectRegionMismatchWarnings(name string, region string, providerRegion string) diag.Diagnostics {
+	if region == "" || providerRegion == "" || region == providerRegion {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s is in region %s, not in the provider region %s", name, region, providerRegion),
+			Detail: fmt.Sprintf("The lookup succeeded through the global routing of the Projects API, but resources that are created from its outputs "+
+				"with this provider target region %s. Use a provider with region %q for them. Requests that are sent to the wrong region "+
+				"fail with 404 errors that look like missing permissions.", providerRegion, region),
+		},
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+)
+
+func TestProjectRegion(t *testing.T) {
+	testcases := []struct {
+		name     string
+		crn      *string
+		href     *string
+		expected string
+	}{
+		{
+			name:     "crn",
+			crn:      core.StringPtr("crn:v1:bluemix:public:project:us-south:a/4e1c48fcf8ac4b5d9fb8e6c8d10e8e15:b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0::"),
+			href:     core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+			expected: "us-south",
+		},
+		{
+			name:     "crn wins over href",
+			crn:      core.StringPtr("crn:v1:bluemix:public:project:eu-de:a/4e1c48fcf8ac4b5d9fb8e6c8d10e8e15:b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0::"),
+			href:     core.StringPtr("https://us-south.projects.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+			expected: "eu-de",
+		},
+		{
+			name:     "global crn",
+			crn:      core.StringPtr("crn:v1:bluemix:public:project:global:a/4e1c48fcf8ac4b5d9fb8e6c8d10e8e15:b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0::"),
+			href:     core.StringPtr("https://jp-tok.projects.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+			expected: "jp-tok",
+		},
+		{
+			name:     "regional href",
+			href:     core.StringPtr("https://us-south.projects.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0/configs/7b8a06e6"),
+			expected: "us-south",
+		},
+		{
+			name:     "private regional href",
+			href:     core.StringPtr("https://private.eu-gb.projects.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+			expected: "eu-gb",
+		},
+		{
+			name:     "region with a number",
+			href:     core.StringPtr("https://eu-fr2.projects.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+			expected: "eu-fr2",
+		},
+		{
+			name: "global href",
+			href: core.StringPtr("https://projects.api.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0/environments/4e1c48fc"),
+		},
+		{
+			name: "test href",
+			href: core.StringPtr("https://projects.api.test.cloud.ibm.com/v1/projects/b1d1c8a5-1c5f-4bb4-9c0b-7b8a06e6a5e0"),
+		},
+		{
+			name: "invalid crn and href",
+			crn:  core.StringPtr("not-a-crn"),
+			href: core.StringPtr("://"),
+		},
+		{
+			name: "nothing",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, projectRegion(tc.crn, tc.href))
+		})
+	}
+}
+
+func TestProjectRegionMismatchWarnings(t *testing.T) {
+	assert.Nil(t, projectRegionMismatchWarnings("Project p1", "us-south", "us-south"))
+	assert.Nil(t, projectRegionMismatchWarnings("Project p1", "", "us-south"))
+	assert.Nil(t, projectRegionMismatchWarnings("Project p1", "us-south", ""))
+
+	diags := projectRegionMismatchWarnings("Project p1", "eu-de", "us-south")
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Project p1 is in region eu-de, not in the provider region us-south", diags[0].Summary)
+	assert.Contains(t, diags[0].Detail, `Use a provider with region "eu-de"`)
+}
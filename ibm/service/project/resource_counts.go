@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// The resources of the configurations are listed concurrently, with at most projectConfigResourceCountWorkers
+// listings in flight. Each request waits on the rate limiter of the Projects API too.
+const projectConfigResourceCountWorkers = 5
+
+// projectConfigResourceCount returns the number of resources that a deployed configuration manages, as reported by
+// the resources_count of the listing, or the number of listed resources when the API does not report it.
+func projectConfigResourceCount(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, configID string) (int, error) {
+	resources, totalCount, err := projectListConfigResources(context, projectClient, limiter, projectID, configID)
+	if err != nil {
+		return 0, err
+	}
+	return projectListTotalCount(len(resources), totalCount), nil
+}
+
+// projectConfigResourceCounts lists the resources of the configurations concurrently, with at most
+// projectConfigResourceCountWorkers listings in flight. The counts and errors are in the order of configIDs.
+func projectConfigResourceCounts(context context.Context, projectClient projectConfigAPI, limiter *projectRateLimiter, projectID string, configIDs []string) ([]int, []error) {
+	counts := make([]int, len(configIDs))
+	errs := make([]error, len(configIDs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < projectConfigResourceCountWorkers && w < len(configIDs); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				counts[i], errs[i] = projectConfigResourceCount(context, projectClient, limiter, projectID, configIDs[i])
+			}
+		}()
+	}
+	for i := range configIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return counts, errs
+}
+
+// projectConfigResourceCountWarning is the warning of a configuration whose resources could not be listed.
+func projectConfigResourceCountWarning(configID string, err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The resources of configuration %s could not be listed, its resources_count is not set", configID),
+		Detail:   err.Error(),
+	}
+}
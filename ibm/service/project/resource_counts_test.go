@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectConfigResourcesAPI fakes the listing of the resources of the configurations, with the number of
+// resources of each configuration, and records the listings in flight
+type testProjectConfigResourcesAPI struct {
+	projectConfigAPI
+	resources map[string]int
+	errs      map[string]error
+	delay     time.Duration
+
+	mu          sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+}
+
+func (api *testProjectConfigResourcesAPI) ListConfigResourcesWithContext(ctx context.Context, listConfigResourcesOptions *projectv1.ListConfigResourcesOptions) (*projectv1.ProjectConfigResourceCollection, *core.DetailedResponse, error) {
+	api.mu.Lock()
+	api.calls++
+	api.inFlight++
+	if api.inFlight > api.maxInFlight {
+		api.maxInFlight = api.inFlight
+	}
+	api.mu.Unlock()
+	defer func() {
+		api.mu.Lock()
+		api.inFlight--
+		api.mu.Unlock()
+	}()
+	time.Sleep(api.delay)
+
+	configID := *listConfigResourcesOptions.ID
+	if err := api.errs[configID]; err != nil {
+		return nil, &core.DetailedResponse{StatusCode: http.StatusTooManyRequests}, err
+	}
+	resources := make([]projectv1.ProjectConfigResource, api.resources[configID])
+	for i := range resources {
+		resources[i].ResourceCrn = core.StringPtr(fmt.Sprintf("crn:v1:bluemix:public:cloud-object-storage:global:a/account::%s-%d", configID, i))
+	}
+	return &projectv1.ProjectConfigResourceCollection{
+		Resources:      resources,
+		ResourcesCount: core.Int64Ptr(int64(len(resources))),
+	}, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+}
+
+func TestProjectConfigResourceCountsBoundedParallelism(t *testing.T) {
+	api := &testProjectConfigResourcesAPI{resources: map[string]int{}, delay: 20 * time.Millisecond}
+	configIDs := []string{}
+	for i := 0; i < 4*projectConfigResourceCountWorkers; i++ {
+		configID := fmt.Sprintf("config-%d", i)
+		configIDs = append(configIDs, configID)
+		api.resources[configID] = i
+	}
+
+	counts, errs := projectConfigResourceCounts(context.Background(), api, nil, "project", configIDs)
+	for i := range configIDs {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, i, counts[i])
+	}
+	assert.Equal(t, len(configIDs), api.calls)
+	assert.LessOrEqual(t, api.maxInFlight, projectConfigResourceCountWorkers)
+	assert.Greater(t, api.maxInFlight, 1)
+}
+
+func TestProjectConfigResourceCountsPartialFailure(t *testing.T) {
+	api := &testProjectConfigResourcesAPI{
+		resources: map[string]int{"config-1": 3, "config-3": 1},
+		errs:      map[string]error{"config-2": errors.New("Too Many Requests")},
+	}
+
+	counts, errs := projectConfigResourceCounts(context.Background(), api, nil, "project", []string{"config-1", "config-2", "config-3"})
+	assert.Equal(t, 3, counts[0])
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "Too Many Requests")
+	assert.Equal(t, 1, counts[2])
+	assert.NoError(t, errs[2])
+
+	warning := projectConfigResourceCountWarning("config-2", errs[1])
+	assert.Equal(t, diag.Warning, warning.Severity)
+	assert.Equal(t, "The resources of configuration config-2 could not be listed, its resources_count is not set", warning.Summary)
+	assert.Equal(t, "Too Many Requests", warning.Detail)
+}
+
+func TestProjectConfigResourceCountsRateLimited(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	limiter := testProjectRateLimiter(2, clock)
+	api := &testProjectConfigResourcesAPI{resources: map[string]int{}}
+
+	_, errs := projectConfigResourceCounts(context.Background(), api, limiter, "project", []string{"config-1", "config-2", "config-3"})
+	for _, err := range errs {
+		assert.NoError(t, err)
+	}
+	// Each listing waits on the limiter, the first one is sent right away
+	assert.Len(t, clock.sleeps, 2)
+	for _, delay := range clock.sleeps {
+		assert.Greater(t, delay, time.Duration(0))
+	}
+}
+
+func TestProjectConfigResourceCountsNoConfigs(t *testing.T) {
+	api := &testProjectConfigResourcesAPI{}
+	counts, errs := projectConfigResourceCounts(context.Background(), api, nil, "project", []string{})
+	assert.Empty(t, counts)
+	assert.Empty(t, errs)
+	assert.Equal(t, 0, api.calls)
+}
@@ -55,7 +55,8 @@ func ResourceIbmProject() *schema.Resource {
 						},
 						"destroy_on_delete": &schema.Schema{
 							Type:        schema.TypeBool,
-							Required:    true,
+							Optional:    true,
+							Default:     projectDefinitionDestroyOnDeleteDefault,
 							Description: "The policy that indicates whether the resources are destroyed or not when a project is deleted.",
 						},
 						"description": &schema.Schema{
@@ -66,7 +67,7 @@ func ResourceIbmProject() *schema.Resource {
 						"monitoring_enabled": &schema.Schema{
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Default:     false,
+							Default:     projectDefinitionMonitoringEnabledDefault,
 							Description: "A boolean flag to enable automatic drift detection. Use this field to run a daily check to compare your configurations to your deployed resources to detect any difference.",
 						},
 					},
@@ -610,10 +611,8 @@ func resourceIbmProjectUpdate(context context.Context, d *schema.ResourceData, m
 	hasChange := false
 
 	if d.HasChange("definition") {
-		definition, err := resourceIbmProjectMapToProjectPatchDefinitionBlock(d.Get("definition.0").(map[string]interface{}))
-		if err != nil {
-			return diag.FromErr(err)
-		}
+		oldDefinition, newDefinition := d.GetChange("definition")
+		definition := resourceIbmProjectProjectPatchDefinitionBlockChanges(projectConfigDefinitionMap(oldDefinition), projectConfigDefinitionMap(newDefinition))
 		updateProjectOptions.SetDefinition(definition)
 		hasChange = true
 	}
@@ -875,31 +874,51 @@ func resourceIbmProjectMapToEnvironmentDefinitionRequiredProperties(modelMap map
 	return model, nil
 }
 
-func resourceIbmProjectMapToProjectPatchDefinitionBlock(modelMap map[string]interface{}) (*projectv1.ProjectPatchDefinitionBlock, error) {
+// resourceIbmProjectProjectPatchDefinitionBlockChanges returns the patch of the properties of the definition that
+// changed from oldMap to newMap, so that an update does not overwrite the other properties. A cleared description is
+// patched with an empty string.
+func resourceIbmProjectProjectPatchDefinitionBlockChanges(oldMap map[string]interface{}, newMap map[string]interface{}) *projectv1.ProjectPatchDefinitionBlock {
 	model := &projectv1.ProjectPatchDefinitionBlock{}
-	if modelMap["name"] != nil && modelMap["name"].(string) != "" {
-		model.Name = core.StringPtr(modelMap["name"].(string))
+	changed := func(key string) bool {
+		return newMap[key] != nil && newMap[key] != oldMap[key]
 	}
-	if modelMap["destroy_on_delete"] != nil {
-		model.DestroyOnDelete = core.BoolPtr(modelMap["destroy_on_delete"].(bool))
+	if changed("name") {
+		model.Name = core.StringPtr(newMap["name"].(string))
 	}
-	if modelMap["description"] != nil && modelMap["description"].(string) != "" {
-		model.Description = core.StringPtr(modelMap["description"].(string))
+	if changed("destroy_on_delete") {
+		model.DestroyOnDelete = core.BoolPtr(newMap["destroy_on_delete"].(bool))
 	}
-	if modelMap["monitoring_enabled"] != nil {
-		model.MonitoringEnabled = core.BoolPtr(modelMap["monitoring_enabled"].(bool))
+	if changed("description") {
+		model.Description = core.StringPtr(newMap["description"].(string))
 	}
-	return model, nil
+	if changed("monitoring_enabled") {
+		model.MonitoringEnabled = core.BoolPtr(newMap["monitoring_enabled"].(bool))
+	}
+	return model
+}
+
+// The values of the flags of the project definition that the service applies when they are not set, and that it may
+// omit from its responses
+const (
+	projectDefinitionDestroyOnDeleteDefault   = true
+	projectDefinitionMonitoringEnabledDefault = false
+)
+
+// projectDefinitionFlag returns the value of a flag of the project definition, the service default when the response
+// does not have it
+func projectDefinitionFlag(value *bool, serviceDefault bool) bool {
+	if value == nil {
+		return serviceDefault
+	}
+	return *value
 }
 
 func resourceIbmProjectProjectDefinitionPropertiesToMap(model *projectv1.ProjectDefinitionProperties) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
 	modelMap["name"] = model.Name
-	modelMap["destroy_on_delete"] = model.DestroyOnDelete
+	modelMap["destroy_on_delete"] = projectDefinitionFlag(model.DestroyOnDelete, projectDefinitionDestroyOnDeleteDefault)
 	modelMap["description"] = model.Description
-	if model.MonitoringEnabled != nil {
-		modelMap["monitoring_enabled"] = model.MonitoringEnabled
-	}
+	modelMap["monitoring_enabled"] = projectDefinitionFlag(model.MonitoringEnabled, projectDefinitionMonitoringEnabledDefault)
 	return modelMap, nil
 }
 
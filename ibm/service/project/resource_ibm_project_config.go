@@ -8,14 +8,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/validate"
 	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
 	"github.com/IBM/project-go-sdk/projectv1"
 )
 
@@ -27,6 +35,19 @@ func ResourceIbmProjectConfig() *schema.Resource {
 		DeleteContext: resourceIbmProjectConfigDelete,
 		Importer:      &schema.ResourceImporter{},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceIbmProjectConfigSettingsCustomizeDiff,
+			resourceIbmProjectConfigLabelsCustomizeDiff,
+			resourceIbmProjectConfigEnvironmentCustomizeDiff,
+			resourceIbmProjectConfigValidateInputsCustomizeDiff,
+			resourceIbmProjectConfigRevalidationCustomizeDiff,
+			resourceIbmProjectConfigInheritComplianceProfileCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"project_id": &schema.Schema{
 				Type:         schema.TypeString,
@@ -35,6 +56,137 @@ func ResourceIbmProjectConfig() *schema.Resource {
 				ValidateFunc: validate.InvokeValidator("ibm_project_config", "project_id"),
 				Description:  "The unique project ID.",
 			},
+			"validate_inputs": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to validate the definition inputs at plan time against the inputs declared by the deployable architecture version that is identified by `locator_id`.",
+			},
+			"suppress_attention_warnings": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to suppress the warnings that are emitted on reads for the needs attention events of the configuration with severity ERROR.",
+			},
+			"adopt_existing_deployment": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to mark the configuration as deployed when it is created from the existing Schematics workspace `schematics.0.workspace_crn`. The validation is skipped by force approving the configuration, and the deployment of the approved version, which makes no changes to the applied workspace, records the deployed version.",
+			},
+			"wait_for_workspace": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to wait, within the create timeout, until the configuration has the CRN of its Schematics workspace after it is created.",
+			},
+			"wait_for_workspace_status": &schema.Schema{
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"wait_for_workspace"},
+				Description:  "Whether `wait_for_workspace` also waits until the Schematics workspace is in status INACTIVE or ACTIVE, as read with the Schematics client of the provider.",
+			},
+			"workspace_crn": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CRN of the Schematics workspace of the configuration, empty while the workspace is not created.",
+			},
+			"workspace_ready": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether `wait_for_workspace_status` saw the Schematics workspace in status INACTIVE or ACTIVE when the configuration was created.",
+			},
+			"validate_on_create": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"adopt_existing_deployment"},
+				Description:   "Whether to validate the configuration after it is created, and to wait within the create timeout until the validation completes. The create fails when the validation fails, and the configuration is kept in the state as tainted, so that the next apply replaces it with a configuration created from the fixed inputs.",
+			},
+			"validated_version": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the configuration that `validate_on_create` validated.",
+			},
+			"validation_result": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The result of the last validation of the configuration, `passed` or `failed`, empty when the configuration was never validated.",
+			},
+			"validation_cost_estimate_available": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last validation of the configuration estimated its cost.",
+			},
+			"script_results":          projectConfigScriptResultsSchema(),
+			"include_last_monitoring": projectConfigIncludeLastMonitoringSchema("Whether to read the last monitoring job of the configuration into `last_monitoring`, with an additional request on each read."),
+			"last_monitoring":         projectConfigLastMonitoringSchema(),
+			"inherit_compliance_profile": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the configuration inherits the compliance profile of its environment instead of setting `definition.0.compliance_profile`. The compliance profile that the service returns for the configuration is then not compared with the definition.",
+			},
+			"inherited_compliance_profile": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The compliance profile of the environment of the configuration, when `inherit_compliance_profile` is set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique ID for the compliance profile.",
+						},
+						"instance_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A unique ID for the instance of a compliance profile.",
+						},
+						"instance_location": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The location of the compliance instance.",
+						},
+						"attachment_id": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A unique ID for the attachment to a compliance profile.",
+						},
+						"profile_name": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the compliance profile.",
+						},
+					},
+				},
+			},
+			"prevent_delete_if_referenced": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to fail the deletion of the configuration while the inputs of other configurations of the project reference it.",
+			},
+			"depends_on_config_ids": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The IDs of the configurations of the project that must exist before the configuration is created. They are checked when the configuration is created and are not sent to the Projects API.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"depends_on_config_names": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The names of the configurations of the project that must exist before the configuration is created. They are checked when the configuration is created and are not sent to the Projects API.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"labels": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The labels of the configuration, such as the team or the cost center. They are stored as a JSON object in the reserved `labels` input of the definition.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			"schematics": &schema.Schema{
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -195,10 +347,11 @@ func ResourceIbmProjectConfig() *schema.Resource {
 				},
 			},
 			"definition": &schema.Schema{
-				Type:     schema.TypeList,
-				MinItems: 1,
-				MaxItems: 1,
-				Required: true,
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"definition", "definition_json"},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"compliance_profile": &schema.Schema{
@@ -237,10 +390,11 @@ func ResourceIbmProjectConfig() *schema.Resource {
 							},
 						},
 						"locator_id": &schema.Schema{
-							Type:        schema.TypeString,
-							Optional:    true,
-							ForceNew:    true,
-							Description: "A unique concatenation of the catalog ID and the version ID that identify the deployable architecture in the catalog. I you're importing from an existing Schematics workspace that is not backed by cart, a `locator_id` is required. If you're using a Schematics workspace that is backed by cart, a `locator_id` is not necessary because the Schematics workspace has one.> There are 3 scenarios:> 1. If only a `locator_id` is specified, a new Schematics workspace is instantiated with that `locator_id`.> 2. If only a schematics `workspace_crn` is specified, a `400` is returned if a `locator_id` is not found in the existing schematics workspace.> 3. If both a Schematics `workspace_crn` and a `locator_id` are specified, a `400` message is returned if the specified `locator_id` does not agree with the `locator_id` in the existing Schematics workspace.> For more information of creating a Schematics workspace, see [Creating workspaces and importing your Terraform template](/docs/schematics?topic=schematics-sch-create-wks).",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validateProjectConfigLocatorID),
+							Description:      "A unique concatenation of the catalog ID and the version ID that identify the deployable architecture in the catalog. I you're importing from an existing Schematics workspace that is not backed by cart, a `locator_id` is required. If you're using a Schematics workspace that is backed by cart, a `locator_id` is not necessary because the Schematics workspace has one.> There are 3 scenarios:> 1. If only a `locator_id` is specified, a new Schematics workspace is instantiated with that `locator_id`.> 2. If only a schematics `workspace_crn` is specified, a `400` is returned if a `locator_id` is not found in the existing schematics workspace.> 3. If both a Schematics `workspace_crn` and a `locator_id` are specified, a `400` message is returned if the specified `locator_id` does not agree with the `locator_id` in the existing Schematics workspace.> For more information of creating a Schematics workspace, see [Creating workspaces and importing your Terraform template](/docs/schematics?topic=schematics-sch-create-wks).",
 						},
 						"description": &schema.Schema{
 							Type:        schema.TypeString,
@@ -256,7 +410,14 @@ func ResourceIbmProjectConfig() *schema.Resource {
 						"environment_id": &schema.Schema{
 							Type:        schema.TypeString,
 							Optional:    true,
-							Description: "The ID of the project environment.",
+							Computed:    true,
+							Description: "The ID of the project environment. When environment_name is set, it is the ID of the environment that the name was resolved to. Removing both removes the configuration from its environment.",
+						},
+						"environment_name": &schema.Schema{
+							Type:          schema.TypeString,
+							Optional:      true,
+							ConflictsWith: []string{"definition.0.environment_id"},
+							Description:   "The name of the project environment, resolved to the environment_id when the configuration is created or updated. The name must match the name of a single environment of the project.",
 						},
 						"authorizations": &schema.Schema{
 							Type:        schema.TypeList,
@@ -296,25 +457,69 @@ func ResourceIbmProjectConfig() *schema.Resource {
 							Description: "The Schematics environment variables to use to deploy the configuration. Settings are only available if they are specified when the configuration is initially created.",
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
+						"sensitive_settings": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The Schematics environment variables with sensitive values to use to deploy the configuration. They are merged with `settings` when the configuration is created and are never read back from the service.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"resource_crns": &schema.Schema{
 							Type:        schema.TypeList,
 							Optional:    true,
 							Description: "The CRNs of the resources that are associated with this configuration.",
 							Elem:        &schema.Schema{Type: schema.TypeString},
 						},
+						"members": &schema.Schema{
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The member configurations of a stack configuration.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the member configuration.",
+									},
+									"config_id": &schema.Schema{
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The ID of the member configuration.",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
+			"definition_json": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"definition", "definition_json"},
+				ValidateFunc:     validateProjectConfigDefinitionJSON,
+				DiffSuppressFunc: projectConfigSuppressEquivalentDefinitionJSON,
+				Description:      "The definition of the configuration as a JSON object, instead of the definition block, for the definition properties that the block does not model yet. The definition is then sent and read as JSON.",
+			},
+			"unmapped_definition_json": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A JSON object of the definition properties, as returned by the service, that the definition block does not model. It is only read when definition_json is set.",
+			},
 			"version": &schema.Schema{
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "The version of the configuration.",
+				Description: "The version of the configuration. It is kept by the metadata-only updates of the name or the description.",
 			},
 			"is_draft": &schema.Schema{
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Description: "The flag that indicates whether the version of the configuration is draft, or active.",
 			},
+			"requires_revalidation": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last update applied by Terraform changed the content of the configuration, so that it must be validated again. It is false for the updates of the name or the description only, which are applied as metadata-only updates, and for the updates that do not change the definition. It is not refreshed from the Projects API.",
+			},
 			"needs_attention_state": &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -392,9 +597,9 @@ func ResourceIbmProjectConfig() *schema.Resource {
 						},
 						"value": &schema.Schema{
 							Type:        schema.TypeMap,
-							Deprecated:  "This property will be deprecated, the new property will be of type String.",
+							Deprecated:  "Use value_json, which holds the output value of every type as JSON. value is only set for the outputs whose value is an object.",
 							Computed:    true,
-							Description: "This property can be any value - a string, number, boolean, array, or object.",
+							Description: "The entries of the output value when it is an object. Strings are kept as is and the other entries are JSON encoded. It is empty for the values that are not objects.",
 						},
 						"value_json": &schema.Schema{
 							Type:        schema.TypeString,
@@ -457,6 +662,11 @@ func ResourceIbmProjectConfig() *schema.Resource {
 				Computed:    true,
 				Description: "A URL.",
 			},
+			"etag": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ETag of the configuration when it was last read, sent with If-Match on the updates so that an update does not overwrite the changes of another update. Empty when the service does not return an ETag.",
+			},
 			"approved_version": &schema.Schema{
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -573,7 +783,394 @@ func ResourceIbmProjectConfigValidator() *validate.ResourceValidator {
 	return &resourceValidator
 }
 
+// projectConfigDeclaredInput describes an input that is declared by a deployable architecture version.
+type projectConfigDeclaredInput struct {
+	Required   bool
+	HasDefault bool
+}
+
+// projectConfigLocatorIDRegexp matches a locator_id, the ID of a catalog and the ID of a version separated by a dot.
+// The version ID can end with the region of the version, such as -global.
+var projectConfigLocatorIDRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}\.[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}(-[0-9a-zA-Z]+)?$`)
+
+// validateProjectConfigLocatorID checks that a locator_id has the <catalog ID>.<version ID> shape, so that a malformed
+// value fails the plan instead of the validation of the configuration.
+func validateProjectConfigLocatorID(v interface{}, k string) (ws []string, errors []error) {
+	locatorID := v.(string)
+	if !projectConfigLocatorIDRegexp.MatchString(locatorID) {
+		errors = append(errors, fmt.Errorf("%s: %q is not a valid locator_id, expected the ID of a catalog and the ID of a version separated by a dot, "+
+			"such as 1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global", k, locatorID))
+	}
+	return
+}
+
+// projectConfigCatalogVersion describes the deployable architecture version that is identified by a locator_id.
+type projectConfigCatalogVersion struct {
+	DeclaredInputs map[string]projectConfigDeclaredInput
+	Deprecated     bool
+}
+
+// projectConfigLocatorNotFoundError is the error of a locator_id that does not identify a version of the catalog.
+type projectConfigLocatorNotFoundError struct {
+	locatorID string
+}
+
+func (e *projectConfigLocatorNotFoundError) Error() string {
+	return fmt.Sprintf("The locator_id %s does not identify a version in the catalog. Check the catalog ID and the version ID of the deployable architecture", e.locatorID)
+}
+
+func resourceIbmProjectConfigValidateInputsCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("validate_inputs").(bool) {
+		return nil
+	}
+	if !diff.NewValueKnown("definition.0.locator_id") || !diff.NewValueKnown("definition.0.inputs") {
+		return nil
+	}
+	locatorID := diff.Get("definition.0.locator_id").(string)
+	if locatorID == "" {
+		return nil
+	}
+
+	inputs := diff.Get("definition.0.inputs").(map[string]interface{})
+	// Inputs that are not set on the configuration can be supplied by its environment.
+	checkRequired := diff.Get("definition.0.environment_id").(string) == "" && diff.Get("definition.0.environment_name").(string) == ""
+	// A CustomizeDiff cannot return warnings, they are logged here and returned as diagnostics by the create and update
+	warnings, err := projectConfigCheckInputs(context, meta, locatorID, inputs, checkRequired)
+	for _, warning := range warnings {
+		log.Printf("[WARN] %s: %s", warning.Summary, warning.Detail)
+	}
+	return err
+}
+
+// projectConfigInputsWarnings returns the warnings of the validation of the inputs of a configuration with
+// validate_inputs, which the plan could only log. The errors were already returned by the plan.
+func projectConfigInputsWarnings(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	locatorID := d.Get("definition.0.locator_id").(string)
+	if !d.Get("validate_inputs").(bool) || locatorID == "" {
+		return nil
+	}
+	inputs, _ := d.Get("definition.0.inputs").(map[string]interface{})
+	warnings, _ := projectConfigCheckInputs(context, meta, locatorID, inputs, false)
+	return warnings
+}
+
+// projectConfigCheckInputs checks the inputs of a configuration against the inputs declared by the deployable
+// architecture version of locatorID. A locator_id that is not in the catalog and inputs that do not match are errors.
+// A catalog that cannot be reached skips the check with a warning, so that offline plans still work, and a deprecated
+// version is a warning.
+func projectConfigCheckInputs(context context.Context, meta interface{}, locatorID string, inputs map[string]interface{}, checkRequired bool) (diag.Diagnostics, error) {
+	catalogVersion, err := projectConfigCatalogVersionByLocatorID(context, meta, locatorID)
+	return projectConfigCheckInputsOfVersion(locatorID, catalogVersion, err, inputs, checkRequired)
+}
+
+// projectConfigCheckInputsOfVersion checks the inputs of a configuration against the catalog version of locatorID,
+// or against the error of its retrieval.
+func projectConfigCheckInputsOfVersion(locatorID string, catalogVersion *projectConfigCatalogVersion, err error, inputs map[string]interface{}, checkRequired bool) (diag.Diagnostics, error) {
+	if _, ok := err.(*projectConfigLocatorNotFoundError); ok {
+		return nil, err
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The inputs of the configuration were not validated, the inputs of locator_id %s could not be retrieved", locatorID),
+			Detail:   err.Error(),
+		}}, nil
+	}
+	var warnings diag.Diagnostics
+	if catalogVersion.Deprecated {
+		warnings = append(warnings, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("The deployable architecture version of locator_id %s is deprecated", locatorID),
+			Detail:   "Update the configuration to a supported version.",
+		})
+	}
+	return warnings, validateProjectConfigInputs(locatorID, inputs, catalogVersion.DeclaredInputs, checkRequired)
+}
+
+// resourceIbmProjectConfigEnvironmentCustomizeDiff plans the removal of the environment of a configuration. The
+// environment_id is Computed to keep the ID that environment_name resolved to, so a plan keeps it when it is removed
+// from the definition block, unless it is cleared here. The SDK only plans top-level Computed keys, which is why the
+// definition is Computed too.
+func resourceIbmProjectConfigEnvironmentCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// The definition is planned as a whole, which needs all of its values
+	if diff.Id() == "" || !diff.GetRawPlan().GetAttr("definition").IsWhollyKnown() {
+		return nil
+	}
+	oldEnvironmentID, _ := diff.GetChange("definition.0.environment_id")
+	if !projectConfigEnvironmentRemoved(diff.GetRawConfig().GetAttr("definition"), oldEnvironmentID.(string)) {
+		return nil
+	}
+	definitionMap := projectConfigCopyDefinitionMap(diff.Get("definition.0").(map[string]interface{}))
+	definitionMap["environment_id"] = ""
+	return diff.SetNew("definition", []interface{}{definitionMap})
+}
+
+// Check that a configuration that inherits the compliance profile of its environment has an environment and no
+// compliance profile of its own
+func resourceIbmProjectConfigInheritComplianceProfileCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	_, hasComplianceProfile := diff.GetOk("definition.0.compliance_profile")
+	return projectConfigCheckInheritComplianceProfile(diff.Get("inherit_compliance_profile").(bool), hasComplianceProfile,
+		diff.NewValueKnown("definition.0.environment_id") && diff.NewValueKnown("definition.0.environment_name"),
+		diff.Get("definition.0.environment_id").(string), diff.Get("definition.0.environment_name").(string))
+}
+
+// projectCatalogVersionAPI is the catalog management call that returns the version of a locator_id
+type projectCatalogVersionAPI interface {
+	GetVersionWithContext(context.Context, *catalogmanagementv1.GetVersionOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error)
+}
+
+// projectConfigCatalogVersionByLocatorID returns the catalog version identified by locatorID, from the call cache of
+// the session so that the configurations that use the same version only fetch it once per Terraform operation.
+func projectConfigCatalogVersionByLocatorID(context context.Context, meta interface{}, locatorID string) (*projectConfigCatalogVersion, error) {
+	catalogManagementClient, err := meta.(conns.ClientSession).CatalogManagementV1()
+	if err != nil {
+		return nil, err
+	}
+	return projectConfigCachedCatalogVersion(context, catalogManagementClient, meta.(conns.ClientSession).CallCache(), locatorID)
+}
+
+// projectConfigCachedCatalogVersion returns the catalog version identified by locatorID. It fails with a
+// projectConfigLocatorNotFoundError when the catalog does not have the version. Failures are not cached.
+func projectConfigCachedCatalogVersion(context context.Context, catalogAPI projectCatalogVersionAPI, cache *conns.CallCache, locatorID string) (*projectConfigCatalogVersion, error) {
+	catalogVersion, err := cache.Do("project_catalog_version/"+locatorID, func() (interface{}, time.Time, error) {
+		getVersionOptions := &catalogmanagementv1.GetVersionOptions{}
+		getVersionOptions.SetVersionLocID(locatorID)
+
+		offering, response, err := catalogAPI.GetVersionWithContext(context, getVersionOptions)
+		if err != nil {
+			if response != nil && response.StatusCode == 404 {
+				return nil, time.Time{}, &projectConfigLocatorNotFoundError{locatorID: locatorID}
+			}
+			return nil, time.Time{}, err
+		}
+		catalogVersion, err := projectConfigCatalogVersionFromOffering(locatorID, offering)
+		return catalogVersion, time.Time{}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return catalogVersion.(*projectConfigCatalogVersion), nil
+}
+
+// projectConfigCatalogVersionFromOffering returns the version of the offering that the catalog returns for a
+// locator_id, with its declared inputs. A version is deprecated when it or its offering is deprecated.
+func projectConfigCatalogVersionFromOffering(locatorID string, offering *catalogmanagementv1.Offering) (*projectConfigCatalogVersion, error) {
+	if offering == nil || len(offering.Kinds) == 0 || len(offering.Kinds[0].Versions) == 0 {
+		return nil, &projectConfigLocatorNotFoundError{locatorID: locatorID}
+	}
+	version := offering.Kinds[0].Versions[0]
+
+	declaredInputs := make(map[string]projectConfigDeclaredInput)
+	for _, configuration := range version.Configuration {
+		if configuration.Key == nil {
+			continue
+		}
+		declaredInputs[*configuration.Key] = projectConfigDeclaredInput{
+			Required:   configuration.Required != nil && *configuration.Required,
+			HasDefault: configuration.DefaultValue != nil,
+		}
+	}
+	return &projectConfigCatalogVersion{
+		DeclaredInputs: declaredInputs,
+		Deprecated:     (version.Deprecated != nil && *version.Deprecated) || (offering.Deprecated != nil && *offering.Deprecated),
+	}, nil
+}
+
+// validateProjectConfigInputs checks the inputs of a configuration against the inputs declared by its deployable
+// architecture. Unknown input names are always reported, missing required inputs only when checkRequired is set.
+func validateProjectConfigInputs(locatorID string, inputs map[string]interface{}, declaredInputs map[string]projectConfigDeclaredInput, checkRequired bool) error {
+	unknown := []string{}
+	for name := range inputs {
+		if _, ok := declaredInputs[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	missing := []string{}
+	if checkRequired {
+		for name, declaredInput := range declaredInputs {
+			if _, ok := inputs[name]; !ok && declaredInput.Required && !declaredInput.HasDefault {
+				missing = append(missing, name)
+			}
+		}
+	}
+	if len(unknown) == 0 && len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	sort.Strings(missing)
+	problems := []string{}
+	if len(unknown) > 0 {
+		problems = append(problems, fmt.Sprintf("unknown inputs: %s", strings.Join(unknown, ", ")))
+	}
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing required inputs: %s", strings.Join(missing, ", ")))
+	}
+	return fmt.Errorf("The inputs of the configuration do not match the inputs declared by locator_id %s, %s", locatorID, strings.Join(problems, "; "))
+}
+
+// resourceIbmProjectConfigSettingsCustomizeDiff rejects changes to the settings of an existing configuration,
+// the service only honors the settings that are specified when the configuration is created.
+func resourceIbmProjectConfigSettingsCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	for _, key := range []string{"settings", "sensitive_settings"} {
+		if diff.HasChange("definition.0." + key) {
+			return fmt.Errorf("The definition %s of the configuration %s cannot be changed, settings are only applied when the configuration is created."+
+				" Replace the configuration to change them", key, diff.Id())
+		}
+	}
+	return nil
+}
+
+// resourceIbmProjectConfigLabelsCustomizeDiff rejects labels when the definition inputs, of the definition block or of
+// definition_json, also set the reserved input that holds them.
+func resourceIbmProjectConfigLabelsCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("labels") || !diff.NewValueKnown("definition.0.inputs") || !diff.NewValueKnown("definition_json") {
+		return nil
+	}
+	if len(diff.Get("labels").(map[string]interface{})) == 0 {
+		return nil
+	}
+	inputs, _ := diff.Get("definition.0.inputs").(map[string]interface{})
+	if definitionJSON, err := projectConfigParseDefinitionJSON(diff.Get("definition_json").(string)); err == nil && len(definitionJSON) > 0 {
+		inputs = projectConfigDefinitionJSONInputs(definitionJSON)
+	}
+	if _, ok := inputs[projectConfigLabelsInput]; ok {
+		return fmt.Errorf("The labels of the configuration conflict with the input %q of the definition, which is reserved to hold the labels."+
+			" Remove either the labels or the input", projectConfigLabelsInput)
+	}
+	return nil
+}
+
+// projectConfigMergedSettings returns the settings of a definition block merged with its sensitive settings,
+// nil when neither is set.
+func projectConfigMergedSettings(modelMap map[string]interface{}) map[string]interface{} {
+	var settings map[string]interface{}
+	for _, key := range []string{"settings", "sensitive_settings"} {
+		values, ok := modelMap[key].(map[string]interface{})
+		if !ok || values == nil {
+			continue
+		}
+		if settings == nil {
+			settings = make(map[string]interface{})
+		}
+		for k, v := range values {
+			settings[k] = v
+		}
+	}
+	return settings
+}
+
+// projectConfigReadSettings removes the sensitive settings from the settings returned by the service, so that
+// they are neither displayed nor reported as drift of the settings map.
+func projectConfigReadSettings(settings map[string]interface{}, sensitiveSettings map[string]interface{}) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	result := make(map[string]interface{})
+	for k, v := range settings {
+		if _, ok := sensitiveSettings[k]; !ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// projectConfigMetadataDefinitionKeys are the definition properties that only describe the configuration.
+// Changing them does not change what is validated or deployed.
+var projectConfigMetadataDefinitionKeys = map[string]bool{
+	"name":        true,
+	"description": true,
+}
+
+// projectConfigChangedDefinitionKeys returns the sorted names of the definition properties that differ
+// between the old and new values of the definition block.
+func projectConfigChangedDefinitionKeys(oldDefinition, newDefinition interface{}) []string {
+	oldMap := projectConfigDefinitionMap(oldDefinition)
+	newMap := projectConfigDefinitionMap(newDefinition)
+
+	changed := []string{}
+	for key, newValue := range newMap {
+		if !reflect.DeepEqual(oldMap[key], newValue) {
+			changed = append(changed, key)
+		}
+	}
+	for key, oldValue := range oldMap {
+		if _, ok := newMap[key]; !ok && oldValue != nil {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func projectConfigDefinitionMap(definition interface{}) map[string]interface{} {
+	definitionList, ok := definition.([]interface{})
+	if !ok || len(definitionList) == 0 || definitionList[0] == nil {
+		return map[string]interface{}{}
+	}
+	return definitionList[0].(map[string]interface{})
+}
+
+// projectConfigRequiresRevalidation reports whether a change to the given definition properties alters the
+// content of the configuration, such as locator_id, inputs or settings, and not only its metadata.
+func projectConfigRequiresRevalidation(changedKeys []string) bool {
+	for _, key := range changedKeys {
+		if !projectConfigMetadataDefinitionKeys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// projectConfigUpdateRequiresRevalidation reports whether an update alters the content of the configuration. The
+// labels describe the configuration, but the Projects API has no labels on configurations: they are stored in the
+// reserved labels input, and the service validates and deploys the inputs, so a change to the labels is a change to
+// the inputs that a metadata-only update cannot send. definition_json sets content properties, so changing it does too.
+func projectConfigUpdateRequiresRevalidation(changedKeys []string, labelsChanged bool, definitionJSONChanged bool) bool {
+	return labelsChanged || definitionJSONChanged || projectConfigRequiresRevalidation(changedKeys)
+}
+
+// projectConfigMetadataPayload returns the changed properties of a definition payload, so that a metadata-only update
+// sends neither the inputs nor the other content of the configuration.
+func projectConfigMetadataPayload(payload map[string]interface{}, changedKeys []string) map[string]interface{} {
+	metadata := make(map[string]interface{}, len(changedKeys))
+	for _, key := range changedKeys {
+		if value, ok := payload[key]; ok {
+			metadata[key] = value
+		}
+	}
+	return metadata
+}
+
+// resourceIbmProjectConfigRevalidationCustomizeDiff classifies each update in requires_revalidation, and marks the
+// attributes that are recomputed by a validation as unknown when the content of the configuration changes.
+// Metadata-only changes leave them as they are.
+func resourceIbmProjectConfigRevalidationCustomizeDiff(context context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// Every update sets requires_revalidation, so that it does not keep the value of an earlier update
+	if diff.Id() == "" || len(diff.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	oldDefinition, newDefinition := diff.GetChange("definition")
+	requiresRevalidation := projectConfigUpdateRequiresRevalidation(projectConfigChangedDefinitionKeys(oldDefinition, newDefinition), diff.HasChange("labels"), diff.HasChange("definition_json"))
+	if err := diff.SetNew("requires_revalidation", requiresRevalidation); err != nil {
+		return err
+	}
+	if !requiresRevalidation {
+		return nil
+	}
+	for _, key := range []string{"version", "state", "outputs", "validation_result", "validation_cost_estimate_available"} {
+		if err := diff.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func resourceIbmProjectConfigCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	start := time.Now()
 	projectClient, err := meta.(conns.ClientSession).ProjectV1()
 	if err != nil {
 		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
@@ -581,14 +1178,25 @@ func resourceIbmProjectConfigCreate(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
-	createConfigOptions := &projectv1.CreateConfigOptions{}
+	adoptExistingDeployment := d.Get("adopt_existing_deployment").(bool)
+	if adoptExistingDeployment && d.Get("schematics.0.workspace_crn").(string) == "" {
+		err = fmt.Errorf("adopt_existing_deployment requires the CRN of the existing Schematics workspace in schematics.0.workspace_crn")
+		return flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create").GetDiag()
+	}
 
-	createConfigOptions.SetProjectID(d.Get("project_id").(string))
-	definitionModel, err := resourceIbmProjectConfigMapToProjectConfigDefinitionPrototype(d.Get("definition.0").(map[string]interface{}))
+	// The references to configurations that do not exist yet are rejected by the service with a bad request
+	err = projectConfigWaitForDependencies(context, projectClient, projectRateLimiterFor(meta), d.Get("project_id").(string),
+		flex.ExpandStringList(d.Get("depends_on_config_ids").([]interface{})), flex.ExpandStringList(d.Get("depends_on_config_names").([]interface{})),
+		projectConfigDependencyTimeout)
 	if err != nil {
-		return diag.FromErr(err)
+		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
 	}
-	createConfigOptions.SetDefinition(definitionModel)
+
+	createConfigOptions := &projectv1.CreateConfigOptions{}
+
+	createConfigOptions.SetProjectID(d.Get("project_id").(string))
 	if _, ok := d.GetOk("schematics"); ok {
 		schematicsModel, err := resourceIbmProjectConfigMapToSchematicsWorkspace(d.Get("schematics.0").(map[string]interface{}))
 		if err != nil {
@@ -596,17 +1204,94 @@ func resourceIbmProjectConfigCreate(context context.Context, d *schema.ResourceD
 		}
 		createConfigOptions.SetSchematics(schematicsModel)
 	}
+	definitionJSON, err := projectConfigParseDefinitionJSON(d.Get("definition_json").(string))
+	if err != nil {
+		return flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create").GetDiag()
+	}
 
-	projectConfig, _, err := projectClient.CreateConfigWithContext(context, createConfigOptions)
+	var projectConfig *projectv1.ProjectConfig
+	var response *core.DetailedResponse
+	if len(definitionJSON) > 0 {
+		definitionPayload := projectConfigDefinitionJSONPayload(definitionJSON, d.Get("labels").(map[string]interface{}), nil)
+		projectConfig, response, err = projectConfigCreateWithDefinitionJSON(context, projectClient, createConfigOptions, definitionPayload)
+	} else {
+		definitionMap := projectConfigCopyDefinitionMap(d.Get("definition.0").(map[string]interface{}))
+		if err = projectConfigResolveEnvironmentName(context, projectClient, projectRateLimiterFor(meta), *createConfigOptions.ProjectID, definitionMap); err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+		inputs, _ := definitionMap["inputs"].(map[string]interface{})
+		definitionMap["inputs"] = projectConfigInputsWithLabels(inputs, d.Get("labels").(map[string]interface{}))
+		var definitionModel projectv1.ProjectConfigDefinitionPrototypeIntf
+		definitionModel, err = resourceIbmProjectConfigMapToProjectConfigDefinitionPrototype(definitionMap)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		createConfigOptions.SetDefinition(definitionModel)
+		projectConfig, response, err = projectClient.CreateConfigWithContext(context, createConfigOptions)
+	}
 	if err != nil {
-		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("CreateConfigWithContext failed: %s", err.Error()), "ibm_project_config", "create")
-		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
-		return tfErr.GetDiag()
+		return projectConfigAPIErrorDiag(err, response, fmt.Sprintf("CreateConfigWithContext failed: %s", err.Error()), "create")
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", *createConfigOptions.ProjectID, *projectConfig.ID))
+	if err = d.Set("requires_revalidation", true); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting requires_revalidation: %s", err))
+	}
 
-	return resourceIbmProjectConfigRead(context, d, meta)
+	// The workspace is created asynchronously, and the validations that run before it is ready fail
+	if d.Get("wait_for_workspace").(bool) {
+		var schematicsClient projectWorkspaceAPI
+		if d.Get("wait_for_workspace_status").(bool) {
+			client, err := meta.(conns.ClientSession).SchematicsV1()
+			if err != nil {
+				tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+				log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+				return tfErr.GetDiag()
+			}
+			schematicsClient = client
+		}
+		_, err = projectConfigWaitForWorkspace(context, projectClient, schematicsClient, *createConfigOptions.ProjectID, *projectConfig.ID, d.Timeout(schema.TimeoutCreate)-time.Since(start), projectPollIntervalFor(meta))
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+		// The workspace is only known to be ready once its status was checked
+		if schematicsClient != nil {
+			if err = d.Set("workspace_ready", true); err != nil {
+				return diag.FromErr(fmt.Errorf("Error setting workspace_ready: %s", err))
+			}
+		}
+	}
+
+	if adoptExistingDeployment {
+		err = projectConfigAdoptExistingDeployment(context, projectClient, *createConfigOptions.ProjectID, *projectConfig.ID, d.Timeout(schema.TimeoutCreate)-time.Since(start), projectPollIntervalFor(meta))
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+	}
+
+	if d.Get("validate_on_create").(bool) {
+		validatedConfig, err := projectConfigValidateOnCreate(context, projectClient, *createConfigOptions.ProjectID, *projectConfig.ID, d.Timeout(schema.TimeoutCreate)-time.Since(start), projectPollIntervalFor(meta))
+		if validatedConfig != nil {
+			if err := d.Set("validated_version", flex.IntValue(validatedConfig.Version)); err != nil {
+				return diag.FromErr(fmt.Errorf("Error setting validated_version: %s", err))
+			}
+		}
+		if err != nil {
+			// The configuration is kept in the state with the error, which taints it: the next apply deletes it and
+			// creates it again, unless it is untainted to update it in place
+			tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "create")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return append(resourceIbmProjectConfigRead(context, d, meta), tfErr.GetDiag()...)
+		}
+	}
+
+	return append(projectConfigInputsWarnings(context, d, meta), resourceIbmProjectConfigRead(context, d, meta)...)
 }
 
 func resourceIbmProjectConfigRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -617,18 +1302,26 @@ func resourceIbmProjectConfigRead(context context.Context, d *schema.ResourceDat
 		return tfErr.GetDiag()
 	}
 
-	getConfigOptions := &projectv1.GetConfigOptions{}
-
 	parts, err := flex.SepIdParts(d.Id(), "/")
 	if err != nil {
 		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "read")
 		return tfErr.GetDiag()
 	}
 
-	getConfigOptions.SetProjectID(parts[0])
-	getConfigOptions.SetID(parts[1])
-
-	projectConfig, response, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+	// The configurations whose definition is set by definition_json are read as JSON, for the properties that the
+	// projectv1 models drop
+	definitionJSON, _ := projectConfigParseDefinitionJSON(d.Get("definition_json").(string))
+	var projectConfig *projectv1.ProjectConfig
+	var rawResponse map[string]json.RawMessage
+	var response *core.DetailedResponse
+	if len(definitionJSON) > 0 {
+		projectConfig, rawResponse, response, err = projectConfigGetWithRawResponse(context, projectClient, parts[0], parts[1])
+	} else {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(parts[0])
+		getConfigOptions.SetID(parts[1])
+		projectConfig, response, err = projectClient.GetConfigWithContext(context, getConfigOptions)
+	}
 	if err != nil {
 		if response != nil && response.StatusCode == 404 {
 			d.SetId("")
@@ -643,15 +1336,90 @@ func resourceIbmProjectConfigRead(context context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err = d.Set("definition", []map[string]interface{}{definitionMap}); err != nil {
+	if sensitiveSettings, ok := d.GetOk("definition.0.sensitive_settings"); ok {
+		if settings, ok := definitionMap["settings"].(map[string]interface{}); ok {
+			definitionMap["settings"] = projectConfigReadSettings(settings, sensitiveSettings.(map[string]interface{}))
+		}
+		definitionMap["sensitive_settings"] = sensitiveSettings
+	}
+	// The environment name is not returned by the service, the environment_id that it resolved to is
+	environmentID, _ := definitionMap["environment_id"].(string)
+	if environmentName, ok := d.GetOk("definition.0.environment_name"); ok {
+		definitionMap["environment_name"] = environmentName
+	}
+	// The compliance profile of a configuration that inherits it is the one of its environment
+	inheritComplianceProfile := d.Get("inherit_compliance_profile").(bool)
+	if inheritComplianceProfile {
+		delete(definitionMap, "compliance_profile")
+	}
+	if _, ok := d.GetOk("labels"); ok {
+		if inputs, ok := definitionMap["inputs"].(map[string]interface{}); ok {
+			labels, _ := projectConfigLabelsFromInputs(inputs)
+			delete(inputs, projectConfigLabelsInput)
+			if err = d.Set("labels", labels); err != nil {
+				return diag.FromErr(fmt.Errorf("Error setting labels: %s", err))
+			}
+		}
+	}
+	if len(definitionJSON) > 0 {
+		readDefinitionJSON, err := projectConfigReadDefinitionJSON(definitionJSON, rawResponse["definition"])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error reading definition_json: %s", err))
+		}
+		if err = d.Set("definition_json", readDefinitionJSON); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting definition_json: %s", err))
+		}
+		// The definition block is Computed, it is cleared so that a block replaced by definition_json is not kept
+		if err = d.Set("definition", nil); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting definition: %s", err))
+		}
+	} else if err = d.Set("definition", []map[string]interface{}{definitionMap}); err != nil {
 		return diag.FromErr(fmt.Errorf("Error setting definition: %s", err))
 	}
+	var diags diag.Diagnostics
+	inheritedComplianceProfile := []map[string]interface{}{}
+	if inheritComplianceProfile && environmentID != "" {
+		inheritedComplianceProfile, err = projectConfigEnvironmentComplianceProfile(context, projectClient, parts[0], environmentID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The compliance profile of the environment of configuration %s could not be read", parts[1]),
+				Detail:   err.Error(),
+			})
+			inheritedComplianceProfile = nil
+		}
+	}
+	if inheritedComplianceProfile != nil {
+		if err = d.Set("inherited_compliance_profile", inheritedComplianceProfile); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting inherited_compliance_profile: %s", err))
+		}
+	}
+	unmappedDefinitionJSON := ""
+	if rawResponse != nil {
+		unmappedDefinition, err := projectConfigUnmappedDefinition(rawResponse["definition"])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error reading the unmapped definition properties: %s", err))
+		}
+		unmappedDefinitionJSON, err = projectConfigDefinitionJSONString(unmappedDefinition)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set("unmapped_definition_json", unmappedDefinitionJSON); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting unmapped_definition_json: %s", err))
+	}
 	if err = d.Set("version", flex.IntValue(projectConfig.Version)); err != nil {
 		return diag.FromErr(fmt.Errorf("Error setting version: %s", err))
 	}
 	if err = d.Set("is_draft", projectConfig.IsDraft); err != nil {
 		return diag.FromErr(fmt.Errorf("Error setting is_draft: %s", err))
 	}
+	if err = d.Set("validation_result", projectConfigValidationResult(projectConfig)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting validation_result: %s", err))
+	}
+	if err = d.Set("validation_cost_estimate_available", projectConfigValidationHasCostEstimate(projectConfig)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting validation_cost_estimate_available: %s", err))
+	}
 	needsAttentionState := []map[string]interface{}{}
 	for _, needsAttentionStateItem := range projectConfig.NeedsAttentionState {
 		needsAttentionStateItemMap, err := resourceIbmProjectConfigProjectConfigNeedsAttentionStateToMap(&needsAttentionStateItem)
@@ -703,6 +1471,47 @@ func resourceIbmProjectConfigRead(context context.Context, d *schema.ResourceDat
 	if err = d.Set("href", projectConfig.Href); err != nil {
 		return diag.FromErr(fmt.Errorf("Error setting href: %s", err))
 	}
+	workspaceCRN := projectConfigWorkspaceCRN(projectConfig)
+	if err = d.Set("workspace_crn", workspaceCRN); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting workspace_crn: %s", err))
+	}
+	if workspaceCRN == "" {
+		if err = d.Set("workspace_ready", false); err != nil {
+			return diag.FromErr(fmt.Errorf("Error setting workspace_ready: %s", err))
+		}
+	}
+	// The projectv1 models do not have last_monitoring, nor the jobs of the scripts, so the raw configuration is read
+	// when they are asked for and the response of the configuration was not read as JSON
+	includeLastMonitoring := d.Get("include_last_monitoring").(bool)
+	scriptStages := projectConfigScriptStages(projectConfig)
+	rawProperties := rawResponse
+	if rawProperties == nil && (includeLastMonitoring || len(scriptStages) > 0) {
+		rawProperties, _, err = (&projectConfigRawClient{projectClient: projectClient}).GetConfigRawProperties(context, parts[0], parts[1])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error reading the raw configuration: %s", err))
+		}
+	}
+	lastMonitoring := []map[string]interface{}{}
+	if includeLastMonitoring {
+		lastMonitoring, err = projectConfigLastMonitoringToMap(rawProperties["last_monitoring"])
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("Error reading last_monitoring: %s", err))
+		}
+	}
+	if err = d.Set("last_monitoring", lastMonitoring); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting last_monitoring: %s", err))
+	}
+	scriptResults, scriptDiags, err := projectConfigScriptResults(parts[1], scriptStages, rawProperties)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("script_results", scriptResults); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting script_results: %s", err))
+	}
+	diags = append(diags, scriptDiags...)
+	if err = d.Set("etag", projectConfigETag(response)); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting etag: %s", err))
+	}
 	if !core.IsNil(projectConfig.ApprovedVersion) {
 		approvedVersionMap, err := resourceIbmProjectConfigProjectConfigVersionSummaryToMap(projectConfig.ApprovedVersion)
 		if err != nil {
@@ -725,7 +1534,10 @@ func resourceIbmProjectConfigRead(context context.Context, d *schema.ResourceDat
 		return diag.FromErr(fmt.Errorf("Error setting project_config_id: %s", err))
 	}
 
-	return nil
+	if d.Get("suppress_attention_warnings").(bool) {
+		return diags
+	}
+	return append(diags, projectConfigNeedsAttentionWarnings(parts[1], projectConfig.NeedsAttentionState)...)
 }
 
 func resourceIbmProjectConfigUpdate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -736,44 +1548,108 @@ func resourceIbmProjectConfigUpdate(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
-	updateConfigOptions := &projectv1.UpdateConfigOptions{}
-
 	parts, err := flex.SepIdParts(d.Id(), "/")
 	if err != nil {
 		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "update")
 		return tfErr.GetDiag()
 	}
 
-	updateConfigOptions.SetProjectID(parts[0])
-	updateConfigOptions.SetID(parts[1])
-
-	hasChange := false
-
 	if d.HasChange("project_id") {
 		errMsg := fmt.Sprintf("Cannot update resource property \"%s\" with the ForceNew annotation."+
 			" The resource must be re-created to update this property.", "project_id")
 		tfErr := flex.TerraformErrorf(err, errMsg, "ibm_project_config", "update")
 		return tfErr.GetDiag()
 	}
-	if d.HasChange("definition") {
-		definition, err := resourceIbmProjectConfigMapToProjectConfigDefinitionPatch(d.Get("definition.0").(map[string]interface{}))
-		if err != nil {
-			return diag.FromErr(err)
+	oldDefinitionJSONValue, newDefinitionJSONValue := d.GetChange("definition_json")
+	oldDefinitionJSON, _ := projectConfigParseDefinitionJSON(oldDefinitionJSONValue.(string))
+	definitionJSON, err := projectConfigParseDefinitionJSON(newDefinitionJSONValue.(string))
+	if err != nil {
+		return flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "update").GetDiag()
+	}
+	requiresRevalidation := false
+	if d.HasChange("definition") || d.HasChange("labels") || d.HasChange("definition_json") {
+		oldDefinition, newDefinition := d.GetChange("definition")
+		oldLabels, newLabels := d.GetChange("labels")
+		var newPayload map[string]interface{}
+		var authorizationsPayload map[string]interface{}
+		if len(definitionJSON) > 0 {
+			newPayload = projectConfigDefinitionJSONPayload(definitionJSON, newLabels.(map[string]interface{}), oldDefinitionJSON)
+		} else {
+			definitionMap := projectConfigCopyDefinitionMap(d.Get("definition.0").(map[string]interface{}))
+			if err = projectConfigResolveEnvironmentName(context, projectClient, projectRateLimiterFor(meta), parts[0], definitionMap); err != nil {
+				tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "update")
+				log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+				return tfErr.GetDiag()
+			}
+			newPayload, err = projectConfigDefinitionPayload(definitionMap, newLabels.(map[string]interface{}))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// The payload omits an empty description, so a cleared description is sent explicitly
+			if _, ok := newPayload["description"]; !ok && d.HasChange("definition.0.description") {
+				newPayload["description"] = ""
+			}
+			// The payload omits an empty environment_id, so a removed environment is sent as null, which the definition
+			// client sends as JSON
+			if _, ok := newPayload["environment_id"]; !ok && d.HasChange("definition.0.environment_id") {
+				newPayload["environment_id"] = nil
+			}
+
+			// The authorizations are sent as a complete object whenever one of their properties changes
+			if d.HasChange("definition.0.authorizations") {
+				authorizationsPayload = projectConfigAuthorizationsPayload(definitionMap)
+				newPayload["authorizations"] = authorizationsPayload
+			}
+		}
+		if len(oldLabels.(map[string]interface{})) > 0 && len(newLabels.(map[string]interface{})) == 0 {
+			newPayload = projectConfigPayloadWithoutLabels(newPayload)
+		}
+		// The definition that the plan started from, to apply only the changes of the plan onto a definition that
+		// another update modified
+		oldPayload := map[string]interface{}{}
+		if len(oldDefinitionJSON) > 0 {
+			oldPayload = projectConfigDefinitionJSONPayload(oldDefinitionJSON, oldLabels.(map[string]interface{}), nil)
+		} else if oldDefinitionList := oldDefinition.([]interface{}); len(oldDefinitionList) > 0 && oldDefinitionList[0] != nil {
+			oldPayload, err = projectConfigDefinitionPayload(oldDefinitionList[0].(map[string]interface{}), oldLabels.(map[string]interface{}))
+			if err != nil {
+				return diag.FromErr(err)
+			}
 		}
-		updateConfigOptions.SetDefinition(definition)
-		hasChange = true
-	}
 
-	if hasChange {
-		_, _, err = projectClient.UpdateConfigWithContext(context, updateConfigOptions)
-		if err != nil {
-			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("UpdateConfigWithContext failed: %s", err.Error()), "ibm_project_config", "update")
-			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
-			return tfErr.GetDiag()
+		changedKeys := projectConfigChangedDefinitionKeys(oldDefinition, newDefinition)
+		requiresRevalidation = projectConfigUpdateRequiresRevalidation(changedKeys, d.HasChange("labels"), d.HasChange("definition_json"))
+		log.Printf("[DEBUG] ibm_project_config %s definition changes: %s, requires revalidation: %t", d.Id(), strings.Join(changedKeys, ", "), requiresRevalidation)
+		if !requiresRevalidation {
+			oldPayload = projectConfigMetadataPayload(oldPayload, changedKeys)
+			newPayload = projectConfigMetadataPayload(newPayload, changedKeys)
+			log.Printf("[INFO] ibm_project_config %s is updated with the metadata-only changes: %s", d.Id(), strings.Join(changedKeys, ", "))
+		}
+
+		definitionAPI := &projectConfigDefinitionClient{projectClient: projectClient, definitionJSON: len(definitionJSON) > 0}
+		if len(newPayload) > 0 {
+			response, err := projectConfigUpdateDefinition(context, definitionAPI, parts[0], parts[1], d.Get("etag").(string), oldPayload, newPayload)
+			if err != nil {
+				return projectConfigAPIErrorDiag(err, response, fmt.Sprintf("UpdateConfigWithContext failed: %s", err.Error()), "update")
+			}
+		}
+		if authorizationsPayload != nil {
+			if err = projectConfigCheckAuthorizations(context, definitionAPI, parts[0], parts[1], authorizationsPayload); err != nil {
+				tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "update")
+				log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+				return tfErr.GetDiag()
+			}
 		}
 	}
+	if err = d.Set("requires_revalidation", requiresRevalidation); err != nil {
+		return diag.FromErr(fmt.Errorf("Error setting requires_revalidation: %s", err))
+	}
 
-	return resourceIbmProjectConfigRead(context, d, meta)
+	var diags diag.Diagnostics
+	if d.HasChange("definition") {
+		diags = projectConfigInputsWarnings(context, d, meta)
+	}
+	return append(diags, resourceIbmProjectConfigRead(context, d, meta)...)
 }
 
 func resourceIbmProjectConfigDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -792,6 +1668,20 @@ func resourceIbmProjectConfigDelete(context context.Context, d *schema.ResourceD
 		return tfErr.GetDiag()
 	}
 
+	if d.Get("prevent_delete_if_referenced").(bool) {
+		configName := d.Get("definition.0.name").(string)
+		referencingNames, err := projectConfigReferencingConfigNames(context, projectClient, parts[0], parts[1], configName)
+		if err != nil {
+			tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Failed to check the references to the configuration: %s", err.Error()), "ibm_project_config", "delete")
+			log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+			return tfErr.GetDiag()
+		}
+		if len(referencingNames) > 0 {
+			err = fmt.Errorf("The configuration %s is referenced by the inputs of the configurations: %s. Remove the references or set prevent_delete_if_referenced to false to delete it", parts[1], strings.Join(referencingNames, ", "))
+			return flex.TerraformErrorf(err, err.Error(), "ibm_project_config", "delete").GetDiag()
+		}
+	}
+
 	deleteConfigOptions.SetProjectID(parts[0])
 	deleteConfigOptions.SetID(parts[1])
 
@@ -838,8 +1728,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPrototype(modelMap map[
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	if modelMap["resource_crns"] != nil {
 		resourceCrns := []string{}
@@ -914,8 +1804,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPrototypeDAConfigDefini
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	return model, nil
 }
@@ -946,8 +1836,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPrototypeResourceConfig
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	return model, nil
 }
@@ -991,8 +1881,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPatch(modelMap map[stri
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	if modelMap["resource_crns"] != nil {
 		resourceCrns := []string{}
@@ -1035,8 +1925,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPatchDAConfigDefinition
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	return model, nil
 }
@@ -1069,8 +1959,8 @@ func resourceIbmProjectConfigMapToProjectConfigDefinitionPatchResourceConfigDefi
 	if modelMap["inputs"] != nil {
 		model.Inputs = modelMap["inputs"].(map[string]interface{})
 	}
-	if modelMap["settings"] != nil {
-		model.Settings = modelMap["settings"].(map[string]interface{})
+	if settings := projectConfigMergedSettings(modelMap); settings != nil {
+		model.Settings = settings
 	}
 	return model, nil
 }
@@ -1144,6 +2034,8 @@ func resourceIbmProjectConfigProjectConfigDefinitionResponseToMap(model projectv
 		return resourceIbmProjectConfigProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponseToMap(model.(*projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse))
 	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse); ok {
 		return resourceIbmProjectConfigProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponseToMap(model.(*projectv1.ProjectConfigDefinitionResponseResourceConfigDefinitionPropertiesResponse))
+	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties); ok {
+		return resourceIbmProjectConfigProjectConfigDefinitionResponseStackConfigDefinitionPropertiesToMap(model.(*projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties))
 	} else if _, ok := model.(*projectv1.ProjectConfigDefinitionResponse); ok {
 		modelMap := make(map[string]interface{})
 		model := model.(*projectv1.ProjectConfigDefinitionResponse)
@@ -1309,6 +2201,58 @@ func resourceIbmProjectConfigProjectConfigDefinitionResponseResourceConfigDefini
 	return modelMap, nil
 }
 
+func resourceIbmProjectConfigProjectConfigDefinitionResponseStackConfigDefinitionPropertiesToMap(model *projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	if model.LocatorID != nil {
+		modelMap["locator_id"] = model.LocatorID
+	}
+	modelMap["description"] = model.Description
+	modelMap["name"] = model.Name
+	if model.EnvironmentID != nil {
+		modelMap["environment_id"] = model.EnvironmentID
+	}
+	if model.Authorizations != nil {
+		authorizationsMap, err := resourceIbmProjectConfigProjectConfigAuthToMap(model.Authorizations)
+		if err != nil {
+			return modelMap, err
+		}
+		modelMap["authorizations"] = []map[string]interface{}{authorizationsMap}
+	}
+	if model.Inputs != nil {
+		inputs := make(map[string]interface{})
+		for k, v := range model.Inputs {
+			inputs[k] = fmt.Sprintf("%v", v)
+		}
+		modelMap["inputs"] = inputs
+	}
+	if model.Settings != nil {
+		settings := make(map[string]interface{})
+		for k, v := range model.Settings {
+			settings[k] = fmt.Sprintf("%v", v)
+		}
+		modelMap["settings"] = settings
+	}
+	if model.Members != nil {
+		members := []map[string]interface{}{}
+		for _, membersItem := range model.Members {
+			membersItemMap, err := resourceIbmProjectConfigStackConfigMemberToMap(&membersItem)
+			if err != nil {
+				return modelMap, err
+			}
+			members = append(members, membersItemMap)
+		}
+		modelMap["members"] = members
+	}
+	return modelMap, nil
+}
+
+func resourceIbmProjectConfigStackConfigMemberToMap(model *projectv1.StackConfigMember) (map[string]interface{}, error) {
+	modelMap := make(map[string]interface{})
+	modelMap["name"] = model.Name
+	modelMap["config_id"] = model.ConfigID
+	return modelMap, nil
+}
+
 func resourceIbmProjectConfigProjectConfigNeedsAttentionStateToMap(model *projectv1.ProjectConfigNeedsAttentionState) (map[string]interface{}, error) {
 	modelMap := make(map[string]interface{})
 	modelMap["event_id"] = model.EventID
@@ -1337,12 +2281,7 @@ func resourceIbmProjectConfigOutputValueToMap(model *projectv1.OutputValue) (map
 	}
 	if model.Value != nil {
 		modelMap["value_json"] = stringify(model.Value)
-		jsonStr, err := json.Marshal(model.Value)
-		if err != nil {
-			b := []byte(jsonStr)
-			var f interface{}
-			json.Unmarshal(b, &f)
-			valueMap := f.(map[string]interface{})
+		if valueMap, ok := projectOutputValueMap(model.Value); ok {
 			modelMap["value"] = valueMap
 		}
 	}
@@ -0,0 +1,178 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+)
+
+// ResourceIbmProjectConfigDriftCheck runs a drift check of a deployed configuration when it is created. The result is
+// kept in the state and the check runs again only when the resource is replaced, such as when triggers change.
+func ResourceIbmProjectConfigDriftCheck() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIbmProjectConfigDriftCheckCreate,
+		ReadContext:   resourceIbmProjectConfigDriftCheckRead,
+		DeleteContext: resourceIbmProjectConfigDriftCheckDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The unique project ID.",
+			},
+			"project_config_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The unique configuration ID.",
+			},
+			"triggers": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that run the drift check again when they change, such as a run ID of the pipeline.",
+			},
+			"drift_detected": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the plan of the drift check adds, changes or destroys resources.",
+			},
+			"add_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of resources that the plan of the drift check adds.",
+			},
+			"change_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of resources that the plan of the drift check changes.",
+			},
+			"destroy_count": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of resources that the plan of the drift check destroys.",
+			},
+			"result": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The result of the validation that ran the drift check.",
+			},
+			"job_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the Schematics job that ran the drift check.",
+			},
+			"job_href": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A URL of the validation that ran the drift check.",
+			},
+			"job_log_url": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the log of the Schematics job that ran the drift check.",
+			},
+			"checked_at": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time when the drift check completed, in the format YYYY-MM-DDTHH:mm:ssZ.",
+			},
+		},
+	}
+}
+
+func resourceIbmProjectConfigDriftCheckCreate(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	projectClient, err := meta.(conns.ClientSession).ProjectV1()
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config_drift_check", "create")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	// The Schematics client only reads the job log, the drift check runs without it
+	var schematicsClient projectJobLogAPI
+	if client, err := meta.(conns.ClientSession).SchematicsV1(); err != nil {
+		log.Printf("[WARN] The log of the drift check job is not available: %s", err)
+	} else {
+		schematicsClient = client
+	}
+
+	projectID := d.Get("project_id").(string)
+	configID := d.Get("project_config_id").(string)
+
+	result, err := projectConfigRunDriftCheck(context, projectClient, schematicsClient, projectID, configID, d.Timeout(schema.TimeoutCreate), projectPollIntervalFor(meta))
+	if err != nil {
+		tfErr := flex.TerraformErrorf(err, err.Error(), "ibm_project_config_drift_check", "create")
+		log.Printf("[DEBUG]\n%s", tfErr.GetDebugMessage())
+		return tfErr.GetDiag()
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", projectID, configID, result.JobID))
+
+	if err = d.Set("drift_detected", result.DriftDetected); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting drift_detected: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("add_count", int(result.AddCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting add_count: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("change_count", int(result.ChangeCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting change_count: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("destroy_count", int(result.DestroyCount)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting destroy_count: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("result", result.Result); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting result: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("job_id", result.JobID); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting job_id: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("job_href", result.JobHref); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting job_href: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("job_log_url", result.JobLogURL); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting job_log_url: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+	if err = d.Set("checked_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		tfErr := flex.TerraformErrorf(err, fmt.Sprintf("Error setting checked_at: %s", err), "ibm_project_config_drift_check", "create")
+		return tfErr.GetDiag()
+	}
+
+	return resourceIbmProjectConfigDriftCheckRead(context, d, meta)
+}
+
+// resourceIbmProjectConfigDriftCheckRead keeps the result of the drift check that ran on create. Reading the
+// configuration again would report the result of later validations, which are not part of this drift check.
+func resourceIbmProjectConfigDriftCheckRead(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceIbmProjectConfigDriftCheckDelete removes the drift check from the state, the validation is kept by the project.
+func resourceIbmProjectConfigDriftCheckDelete(context context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,345 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/platform-services-go-sdk/catalogmanagementv1"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func testProjectConfigDefinition(name string, description string, locatorID string, inputs map[string]interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name":        name,
+			"description": description,
+			"locator_id":  locatorID,
+			"inputs":      inputs,
+			"settings":    map[string]interface{}{},
+		},
+	}
+}
+
+func TestProjectConfigRequiresRevalidation(t *testing.T) {
+	base := testProjectConfigDefinition("config", "description", "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global", map[string]interface{}{"app_repo_name": "repo"})
+
+	testcases := []struct {
+		name                 string
+		newDefinition        []interface{}
+		expectedChangedKeys  []string
+		requiresRevalidation bool
+	}{
+		{
+			name:                 "no change",
+			newDefinition:        base,
+			expectedChangedKeys:  []string{},
+			requiresRevalidation: false,
+		},
+		{
+			name:                 "rename",
+			newDefinition:        testProjectConfigDefinition("renamed", "description", "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global", map[string]interface{}{"app_repo_name": "repo"}),
+			expectedChangedKeys:  []string{"name"},
+			requiresRevalidation: false,
+		},
+		{
+			name:                 "rename and new description",
+			newDefinition:        testProjectConfigDefinition("renamed", "updated", "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global", map[string]interface{}{"app_repo_name": "repo"}),
+			expectedChangedKeys:  []string{"description", "name"},
+			requiresRevalidation: false,
+		},
+		{
+			name:                 "new locator_id",
+			newDefinition:        testProjectConfigDefinition("config", "description", "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.0a7e0fb5-a0d9-4ed8-8a54-fe0b3a1e1734-global", map[string]interface{}{"app_repo_name": "repo"}),
+			expectedChangedKeys:  []string{"locator_id"},
+			requiresRevalidation: true,
+		},
+		{
+			name:                 "rename and new inputs",
+			newDefinition:        testProjectConfigDefinition("renamed", "description", "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global", map[string]interface{}{"app_repo_name": "other"}),
+			expectedChangedKeys:  []string{"inputs", "name"},
+			requiresRevalidation: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			changedKeys := projectConfigChangedDefinitionKeys(base, tc.newDefinition)
+			assert.Equal(t, tc.expectedChangedKeys, changedKeys)
+			assert.Equal(t, tc.requiresRevalidation, projectConfigRequiresRevalidation(changedKeys))
+		})
+	}
+}
+
+func TestProjectConfigChangedDefinitionKeysSettings(t *testing.T) {
+	oldDefinition := testProjectConfigDefinition("config", "", "locator", map[string]interface{}{})
+	newDefinition := testProjectConfigDefinition("config", "", "locator", map[string]interface{}{})
+	newDefinition[0].(map[string]interface{})["settings"] = map[string]interface{}{"TF_LOG": "DEBUG"}
+
+	changedKeys := projectConfigChangedDefinitionKeys(oldDefinition, newDefinition)
+	assert.Equal(t, []string{"settings"}, changedKeys)
+	assert.True(t, projectConfigRequiresRevalidation(changedKeys))
+}
+
+func TestProjectConfigChangedDefinitionKeysEmpty(t *testing.T) {
+	assert.Equal(t, []string{}, projectConfigChangedDefinitionKeys(nil, []interface{}{}))
+	assert.False(t, projectConfigRequiresRevalidation(nil))
+}
+
+func TestProjectConfigUpdateRequiresRevalidation(t *testing.T) {
+	assert.False(t, projectConfigUpdateRequiresRevalidation([]string{"description"}, false, false))
+	assert.False(t, projectConfigUpdateRequiresRevalidation([]string{}, false, false))
+	assert.True(t, projectConfigUpdateRequiresRevalidation([]string{"description", "inputs"}, false, false))
+	// The labels are stored in an input, so changing them changes the inputs
+	assert.True(t, projectConfigUpdateRequiresRevalidation([]string{"description"}, true, false))
+	assert.True(t, projectConfigUpdateRequiresRevalidation([]string{}, true, false))
+	assert.True(t, projectConfigUpdateRequiresRevalidation([]string{"name"}, false, true))
+}
+
+func TestProjectConfigMetadataPayload(t *testing.T) {
+	payload := map[string]interface{}{
+		"name":        "config",
+		"description": "",
+		"locator_id":  "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global",
+		"inputs":      map[string]interface{}{"app_repo_name": "repo"},
+	}
+	assert.Equal(t, map[string]interface{}{"description": ""}, projectConfigMetadataPayload(payload, []string{"description"}))
+	assert.Equal(t, map[string]interface{}{"name": "config", "description": ""}, projectConfigMetadataPayload(payload, []string{"description", "name"}))
+	assert.Equal(t, map[string]interface{}{}, projectConfigMetadataPayload(payload, nil))
+}
+
+func TestProjectConfigDefinitionResponseToMapDescription(t *testing.T) {
+	for _, description := range []*string{core.StringPtr("  Stage environment\n"), core.StringPtr(""), nil} {
+		model := &projectv1.ProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponse{
+			Name:        core.StringPtr("config"),
+			Description: description,
+		}
+		modelMap, err := resourceIbmProjectConfigProjectConfigDefinitionResponseDAConfigDefinitionPropertiesResponseToMap(model)
+		assert.NoError(t, err)
+		assert.Equal(t, description, modelMap["description"])
+	}
+}
+
+func TestProjectConfigMergedSettings(t *testing.T) {
+	assert.Nil(t, projectConfigMergedSettings(map[string]interface{}{"name": "config"}))
+
+	merged := projectConfigMergedSettings(map[string]interface{}{
+		"settings":           map[string]interface{}{"TF_LOG": "DEBUG"},
+		"sensitive_settings": map[string]interface{}{"TF_VAR_token": "secret"},
+	})
+	assert.Equal(t, map[string]interface{}{"TF_LOG": "DEBUG", "TF_VAR_token": "secret"}, merged)
+}
+
+func TestProjectConfigReadSettings(t *testing.T) {
+	settings := map[string]interface{}{"TF_LOG": "DEBUG", "TF_VAR_token": "secret"}
+
+	assert.Equal(t, map[string]interface{}{"TF_LOG": "DEBUG"}, projectConfigReadSettings(settings, map[string]interface{}{"TF_VAR_token": "secret"}))
+	assert.Equal(t, settings, projectConfigReadSettings(settings, nil))
+	assert.Nil(t, projectConfigReadSettings(nil, map[string]interface{}{"TF_VAR_token": "secret"}))
+}
+
+func TestProjectConfigOutputReference(t *testing.T) {
+	assert.Equal(t, "ref:/configs/a3a1c8b4-1f2e-4c53-9f1e-6d2b3c4d5e6f/outputs/cluster_id", projectConfigOutputReference("a3a1c8b4-1f2e-4c53-9f1e-6d2b3c4d5e6f", "cluster_id"))
+}
+
+func TestProjectConfigDefinitionResponseToMapStack(t *testing.T) {
+	model := &projectv1.ProjectConfigDefinitionResponseStackConfigDefinitionProperties{
+		Name:        core.StringPtr("stack"),
+		Description: core.StringPtr("A stack of two members"),
+		LocatorID:   core.StringPtr("1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"),
+		Inputs:      map[string]interface{}{"region": "us-south", "replicas": 2},
+		Members: []projectv1.StackConfigMember{
+			{Name: core.StringPtr("network"), ConfigID: core.StringPtr("a1b2c3")},
+			{Name: core.StringPtr("cluster"), ConfigID: core.StringPtr("d4e5f6")},
+		},
+	}
+	expectedMembers := []map[string]interface{}{
+		{"name": core.StringPtr("network"), "config_id": core.StringPtr("a1b2c3")},
+		{"name": core.StringPtr("cluster"), "config_id": core.StringPtr("d4e5f6")},
+	}
+	expectedInputs := map[string]interface{}{"region": "us-south", "replicas": "2"}
+
+	for name, toMap := range map[string]func(projectv1.ProjectConfigDefinitionResponseIntf) (map[string]interface{}, error){
+		"data source": dataSourceIbmProjectConfigProjectConfigDefinitionResponseToMap,
+		"resource":    resourceIbmProjectConfigProjectConfigDefinitionResponseToMap,
+	} {
+		t.Run(name, func(t *testing.T) {
+			modelMap, err := toMap(model)
+			assert.Nil(t, err)
+			assert.Equal(t, model.Name, modelMap["name"])
+			assert.Equal(t, model.LocatorID, modelMap["locator_id"])
+			assert.Equal(t, expectedInputs, modelMap["inputs"])
+			assert.Equal(t, expectedMembers, modelMap["members"])
+		})
+	}
+}
+
+func TestValidateProjectConfigLocatorID(t *testing.T) {
+	for _, valid := range []string{
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global",
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.145be7c1-9ec4-4719-b586-584ee52fbed0",
+	} {
+		_, errs := validateProjectConfigLocatorID(valid, "definition.0.locator_id")
+		assert.Empty(t, errs, valid)
+	}
+	for _, invalid := range []string{
+		"",
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fccd596f95-95a2-4f21-9b84-477f21fd1e95-global",
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc",
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.",
+		"catalog.version",
+		"1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95.global",
+	} {
+		_, errs := validateProjectConfigLocatorID(invalid, "definition.0.locator_id")
+		assert.Len(t, errs, 1, invalid)
+	}
+}
+
+func TestProjectConfigCatalogVersionFromOffering(t *testing.T) {
+	locatorID := "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+	offering := func(versionDeprecated bool, offeringDeprecated bool) *catalogmanagementv1.Offering {
+		return &catalogmanagementv1.Offering{
+			Deprecated: core.BoolPtr(offeringDeprecated),
+			Kinds: []catalogmanagementv1.Kind{{
+				Versions: []catalogmanagementv1.Version{{
+					Deprecated: core.BoolPtr(versionDeprecated),
+					Configuration: []catalogmanagementv1.Configuration{
+						{Key: core.StringPtr("prefix"), Required: core.BoolPtr(true)},
+						{Key: core.StringPtr("region"), DefaultValue: "us-south"},
+					},
+				}},
+			}},
+		}
+	}
+
+	version, err := projectConfigCatalogVersionFromOffering(locatorID, offering(false, false))
+	assert.NoError(t, err)
+	assert.False(t, version.Deprecated)
+	assert.Equal(t, map[string]projectConfigDeclaredInput{
+		"prefix": {Required: true},
+		"region": {HasDefault: true},
+	}, version.DeclaredInputs)
+
+	version, err = projectConfigCatalogVersionFromOffering(locatorID, offering(true, false))
+	assert.NoError(t, err)
+	assert.True(t, version.Deprecated)
+	version, err = projectConfigCatalogVersionFromOffering(locatorID, offering(false, true))
+	assert.NoError(t, err)
+	assert.True(t, version.Deprecated)
+
+	for _, missing := range []*catalogmanagementv1.Offering{nil, {}, {Kinds: []catalogmanagementv1.Kind{{}}}} {
+		_, err = projectConfigCatalogVersionFromOffering(locatorID, missing)
+		assert.IsType(t, &projectConfigLocatorNotFoundError{}, err)
+		assert.EqualError(t, err, "The locator_id "+locatorID+" does not identify a version in the catalog. Check the catalog ID and the version ID of the deployable architecture")
+	}
+}
+
+func TestValidateProjectConfigInputs(t *testing.T) {
+	locatorID := "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+	declaredInputs := map[string]projectConfigDeclaredInput{
+		"prefix":  {Required: true},
+		"region":  {Required: true, HasDefault: true},
+		"tags":    {},
+		"api_key": {Required: true},
+	}
+
+	assert.NoError(t, validateProjectConfigInputs(locatorID, map[string]interface{}{"prefix": "app", "api_key": "key"}, declaredInputs, true))
+	// The required inputs can be supplied by the environment
+	assert.NoError(t, validateProjectConfigInputs(locatorID, map[string]interface{}{"tags": "a,b"}, declaredInputs, false))
+
+	err := validateProjectConfigInputs(locatorID, map[string]interface{}{"prefix": "app", "regoin": "us-south", "zone": "1"}, declaredInputs, true)
+	assert.EqualError(t, err, "The inputs of the configuration do not match the inputs declared by locator_id "+locatorID+", unknown inputs: regoin, zone; missing required inputs: api_key")
+	err = validateProjectConfigInputs(locatorID, map[string]interface{}{"regoin": "us-south"}, declaredInputs, false)
+	assert.EqualError(t, err, "The inputs of the configuration do not match the inputs declared by locator_id "+locatorID+", unknown inputs: regoin")
+}
+
+func TestProjectConfigCheckInputsOfVersion(t *testing.T) {
+	locatorID := "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+	version := &projectConfigCatalogVersion{DeclaredInputs: map[string]projectConfigDeclaredInput{"prefix": {Required: true}}}
+
+	warnings, err := projectConfigCheckInputsOfVersion(locatorID, version, nil, map[string]interface{}{"prefix": "app"}, true)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	_, err = projectConfigCheckInputsOfVersion(locatorID, version, nil, map[string]interface{}{}, true)
+	assert.EqualError(t, err, "The inputs of the configuration do not match the inputs declared by locator_id "+locatorID+", missing required inputs: prefix")
+
+	// A deprecated version is validated with a warning
+	deprecated := &projectConfigCatalogVersion{DeclaredInputs: version.DeclaredInputs, Deprecated: true}
+	warnings, err = projectConfigCheckInputsOfVersion(locatorID, deprecated, nil, map[string]interface{}{"prefix": "app"}, true)
+	assert.NoError(t, err)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, diag.Warning, warnings[0].Severity)
+		assert.Equal(t, "The deployable architecture version of locator_id "+locatorID+" is deprecated", warnings[0].Summary)
+	}
+
+	// A catalog that cannot be reached skips the validation with a warning
+	warnings, err = projectConfigCheckInputsOfVersion(locatorID, nil, errors.New("connection refused"), map[string]interface{}{"unknown": "app"}, true)
+	assert.NoError(t, err)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, diag.Warning, warnings[0].Severity)
+		assert.Equal(t, "connection refused", warnings[0].Detail)
+	}
+
+	// A version that is not in the catalog fails the plan
+	warnings, err = projectConfigCheckInputsOfVersion(locatorID, nil, &projectConfigLocatorNotFoundError{locatorID: locatorID}, map[string]interface{}{}, true)
+	assert.IsType(t, &projectConfigLocatorNotFoundError{}, err)
+	assert.Empty(t, warnings)
+}
+
+// testProjectCatalogVersionAPI fakes the catalog management call that returns the version of a locator_id
+type testProjectCatalogVersionAPI struct {
+	offering *catalogmanagementv1.Offering
+	status   int
+	err      error
+	calls    int
+}
+
+func (api *testProjectCatalogVersionAPI) GetVersionWithContext(ctx context.Context, options *catalogmanagementv1.GetVersionOptions) (*catalogmanagementv1.Offering, *core.DetailedResponse, error) {
+	api.calls++
+	if api.err != nil {
+		return nil, &core.DetailedResponse{StatusCode: api.status}, api.err
+	}
+	return api.offering, &core.DetailedResponse{StatusCode: http.StatusOK}, nil
+}
+
+func TestProjectConfigCachedCatalogVersion(t *testing.T) {
+	locatorID := "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+	api := &testProjectCatalogVersionAPI{offering: &catalogmanagementv1.Offering{
+		Kinds: []catalogmanagementv1.Kind{{
+			Versions: []catalogmanagementv1.Version{{
+				Configuration: []catalogmanagementv1.Configuration{{Key: core.StringPtr("prefix"), Required: core.BoolPtr(true)}},
+			}},
+		}},
+	}}
+
+	// The version of a locator_id is fetched once per session
+	cache := conns.NewCallCache()
+	for i := 0; i < 2; i++ {
+		version, err := projectConfigCachedCatalogVersion(context.Background(), api, cache, locatorID)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]projectConfigDeclaredInput{"prefix": {Required: true}}, version.DeclaredInputs)
+	}
+	assert.Equal(t, 1, api.calls)
+
+	// A locator_id that is not in the catalog is not found, and failures are not cached
+	api = &testProjectCatalogVersionAPI{status: http.StatusNotFound, err: errors.New("Not Found")}
+	cache = conns.NewCallCache()
+	for i := 0; i < 2; i++ {
+		_, err := projectConfigCachedCatalogVersion(context.Background(), api, cache, locatorID)
+		assert.IsType(t, &projectConfigLocatorNotFoundError{}, err)
+	}
+	assert.Equal(t, 2, api.calls)
+
+	api = &testProjectCatalogVersionAPI{status: http.StatusInternalServerError, err: errors.New("Internal Server Error")}
+	_, err := projectConfigCachedCatalogVersion(context.Background(), api, conns.NewCallCache(), locatorID)
+	assert.EqualError(t, err, "Internal Server Error")
+}
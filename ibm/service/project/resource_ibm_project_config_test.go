@@ -75,6 +75,227 @@ func testAccCheckIbmProjectConfigConfigBasic() string {
 	`, acc.ProjectsConfigApiKey)
 }
 
+func TestAccIbmProjectConfigDescriptionOnly(t *testing.T) {
+	versions := map[string]string{}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmProjectConfigDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigDescription("stage environment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.description", "stage environment"),
+					testAccCheckIbmProjectConfigApprove("ibm_project_config.project_config_instance"),
+				),
+			},
+			// The approval is read back before the versions are recorded
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigDescription("stage environment"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "approved_version.#", "1"),
+					resource.TestCheckResourceAttrSet("ibm_project_config.project_config_instance", "approved_version.0.version"),
+					resource.TestCheckResourceAttrSet("ibm_project_config.project_config_instance", "version"),
+					testAccCheckIbmProjectConfigVersions("ibm_project_config.project_config_instance", versions),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigDescription("stage environment of the acme microservice"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.description", "stage environment of the acme microservice"),
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "requires_revalidation", "false"),
+					testAccCheckIbmProjectConfigVersionsUnchanged("ibm_project_config.project_config_instance", versions),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigDescription(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.description", ""),
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "requires_revalidation", "false"),
+					testAccCheckIbmProjectConfigVersionsUnchanged("ibm_project_config.project_config_instance", versions),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmProjectConfigConfigDescription(description string) string {
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "us-south"
+			resource_group = "Default"
+			definition {
+                name = "acme-microservice"
+                description = "acme-microservice description"
+                destroy_on_delete = true
+                monitoring_enabled = true
+            }
+		}
+
+		resource "ibm_project_config" "project_config_instance" {
+			project_id = ibm_project.project_instance.id
+			definition {
+                name = "stage-environment"
+                description = "%s"
+                authorizations {
+                    method = "api_key"
+                    api_key = "%s"
+               }
+               locator_id = "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+               inputs = {
+                   app_repo_name = "grit-repo-name"
+               }
+            }
+            lifecycle {
+                ignore_changes = [
+                    definition[0].authorizations[0].api_key,
+                ]
+            }
+		}
+	`, description, acc.ProjectsConfigApiKey)
+}
+
+func TestAccIbmProjectConfigAuthorizationsMethod(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmProjectConfigDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigAuthorizations("api_key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.method", "api_key"),
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.trusted_profile_id", ""),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigAuthorizations("trusted_profile"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.method", "trusted_profile"),
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.trusted_profile_id", acc.IAMTrustedProfileID),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigConfigAuthorizations("api_key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.method", "api_key"),
+					resource.TestCheckResourceAttr("ibm_project_config.project_config_instance", "definition.0.authorizations.0.trusted_profile_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIbmProjectConfigConfigAuthorizations(method string) string {
+	authorizations := fmt.Sprintf(`
+                    method = "api_key"
+                    api_key = "%s"`, acc.ProjectsConfigApiKey)
+	if method == "trusted_profile" {
+		authorizations = fmt.Sprintf(`
+                    method = "trusted_profile"
+                    trusted_profile_id = "%s"`, acc.IAMTrustedProfileID)
+	}
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "us-south"
+			resource_group = "Default"
+			definition {
+                name = "acme-microservice"
+                description = "acme-microservice description"
+                destroy_on_delete = true
+                monitoring_enabled = true
+            }
+		}
+
+		resource "ibm_project_config" "project_config_instance" {
+			project_id = ibm_project.project_instance.id
+			definition {
+                name = "stage-environment"
+                authorizations {%s
+               }
+               locator_id = "1082e7d2-5e2f-0a11-a3bc-f88a8e1931fc.cd596f95-95a2-4f21-9b84-477f21fd1e95-global"
+               inputs = {
+                   app_repo_name = "grit-repo-name"
+               }
+            }
+            lifecycle {
+                ignore_changes = [
+                    definition[0].authorizations[0].api_key,
+                ]
+            }
+		}
+	`, authorizations)
+}
+
+// testAccProjectConfigVersionAttributes are the version of a configuration and the attributes of its approved and
+// deployed versions, which a metadata-only update must not change
+var testAccProjectConfigVersionAttributes = []string{
+	"version",
+	"approved_version.#",
+	"approved_version.0.version",
+	"approved_version.0.state",
+	"deployed_version.#",
+	"deployed_version.0.version",
+	"deployed_version.0.state",
+}
+
+func testAccCheckIbmProjectConfigVersions(n string, versions map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		for _, attribute := range testAccProjectConfigVersionAttributes {
+			versions[attribute] = rs.Primary.Attributes[attribute]
+		}
+		return nil
+	}
+}
+
+func testAccCheckIbmProjectConfigVersionsUnchanged(n string, versions map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		for _, attribute := range testAccProjectConfigVersionAttributes {
+			if rs.Primary.Attributes[attribute] != versions[attribute] {
+				return fmt.Errorf("%s changed from %q to %q after a description-only update", attribute, versions[attribute], rs.Primary.Attributes[attribute])
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckIbmProjectConfigApprove approves the configuration, so that it has an approved version
+func testAccCheckIbmProjectConfigApprove(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		projectClient, err := acc.TestAccProvider.Meta().(conns.ClientSession).ProjectV1()
+		if err != nil {
+			return err
+		}
+
+		parts, err := flex.SepIdParts(rs.Primary.ID, "/")
+		if err != nil {
+			return err
+		}
+
+		forceApproveOptions := &projectv1.ForceApproveOptions{}
+		forceApproveOptions.SetProjectID(parts[0])
+		forceApproveOptions.SetID(parts[1])
+		forceApproveOptions.SetComment("Approved by the description-only acceptance test")
+
+		_, _, err = projectClient.ForceApprove(forceApproveOptions)
+		return err
+	}
+}
+
 func testAccCheckIbmProjectConfigExists(n string, obj projectv1.ProjectConfig) resource.TestCheckFunc {
 
 	return func(s *terraform.State) error {
@@ -80,10 +80,11 @@ func ResourceIbmProjectEnvironment() *schema.Resource {
 							},
 						},
 						"inputs": &schema.Schema{
-							Type:        schema.TypeMap,
-							Optional:    true,
-							Description: "The input variables that are used for configuration definition and environment.",
-							Elem:        &schema.Schema{Type: schema.TypeString},
+							Type:             schema.TypeMap,
+							Optional:         true,
+							DiffSuppressFunc: suppressEquivalentProjectInput,
+							Description:      "The input variables that are used for configuration definition and environment. Values that are valid JSON, such as numbers, booleans, lists and objects, are sent as JSON values; the other values are sent as strings.",
+							Elem:             &schema.Schema{Type: schema.TypeString},
 						},
 						"compliance_profile": &schema.Schema{
 							Type:        schema.TypeList,
@@ -394,7 +395,7 @@ func resourceIbmProjectEnvironmentMapToEnvironmentDefinitionRequiredProperties(m
 		model.Authorizations = AuthorizationsModel
 	}
 	if modelMap["inputs"] != nil {
-		model.Inputs = modelMap["inputs"].(map[string]interface{})
+		model.Inputs = projectInputsFromMap(modelMap["inputs"].(map[string]interface{}))
 	}
 	if modelMap["compliance_profile"] != nil && len(modelMap["compliance_profile"].([]interface{})) > 0 {
 		ComplianceProfileModel, err := resourceIbmProjectEnvironmentMapToProjectComplianceProfile(modelMap["compliance_profile"].([]interface{})[0].(map[string]interface{}))
@@ -456,7 +457,7 @@ func resourceIbmProjectEnvironmentMapToEnvironmentDefinitionPropertiesPatch(mode
 		model.Authorizations = AuthorizationsModel
 	}
 	if modelMap["inputs"] != nil {
-		model.Inputs = modelMap["inputs"].(map[string]interface{})
+		model.Inputs = projectInputsFromMap(modelMap["inputs"].(map[string]interface{}))
 	}
 	if modelMap["compliance_profile"] != nil && len(modelMap["compliance_profile"].([]interface{})) > 0 {
 		ComplianceProfileModel, err := resourceIbmProjectEnvironmentMapToProjectComplianceProfile(modelMap["compliance_profile"].([]interface{})[0].(map[string]interface{}))
@@ -482,11 +483,7 @@ func resourceIbmProjectEnvironmentEnvironmentDefinitionRequiredPropertiesRespons
 		}
 	}
 	if model.Inputs != nil {
-		inputs := make(map[string]interface{})
-		for k, v := range model.Inputs {
-			inputs[k] = fmt.Sprintf("%v", v)
-		}
-		modelMap["inputs"] = inputs
+		modelMap["inputs"] = projectInputsToMap(model.Inputs)
 	}
 	if model.ComplianceProfile != nil {
 		complianceProfileMap, err := resourceIbmProjectEnvironmentProjectComplianceProfileToMap(model.ComplianceProfile)
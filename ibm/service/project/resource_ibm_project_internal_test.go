@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceIbmProjectProjectPatchDefinitionBlockChanges(t *testing.T) {
+	oldMap := map[string]interface{}{
+		"name":               "acme-microservice",
+		"description":        "acme-microservice description",
+		"destroy_on_delete":  true,
+		"monitoring_enabled": false,
+	}
+
+	newMap := map[string]interface{}{
+		"name":               "acme-microservice",
+		"description":        "acme-microservice description",
+		"destroy_on_delete":  true,
+		"monitoring_enabled": true,
+	}
+	assert.Equal(t, &projectv1.ProjectPatchDefinitionBlock{MonitoringEnabled: core.BoolPtr(true)}, resourceIbmProjectProjectPatchDefinitionBlockChanges(oldMap, newMap))
+
+	newMap = map[string]interface{}{
+		"name":               "acme",
+		"description":        "",
+		"destroy_on_delete":  false,
+		"monitoring_enabled": false,
+	}
+	assert.Equal(t, &projectv1.ProjectPatchDefinitionBlock{
+		Name:            core.StringPtr("acme"),
+		Description:     core.StringPtr(""),
+		DestroyOnDelete: core.BoolPtr(false),
+	}, resourceIbmProjectProjectPatchDefinitionBlockChanges(oldMap, newMap))
+
+	assert.Equal(t, &projectv1.ProjectPatchDefinitionBlock{}, resourceIbmProjectProjectPatchDefinitionBlockChanges(oldMap, oldMap))
+}
+
+func TestResourceIbmProjectProjectDefinitionPropertiesToMap(t *testing.T) {
+	// The flags that the service omits have their service default, as in the schema
+	definitionMap, err := resourceIbmProjectProjectDefinitionPropertiesToMap(&projectv1.ProjectDefinitionProperties{Name: core.StringPtr("acme")})
+	assert.NoError(t, err)
+	assert.Equal(t, true, definitionMap["destroy_on_delete"])
+	assert.Equal(t, false, definitionMap["monitoring_enabled"])
+	assert.Equal(t, projectDefinitionDestroyOnDeleteDefault, ResourceIbmProject().Schema["definition"].Elem.(*schema.Resource).Schema["destroy_on_delete"].Default)
+	assert.Equal(t, projectDefinitionMonitoringEnabledDefault, ResourceIbmProject().Schema["definition"].Elem.(*schema.Resource).Schema["monitoring_enabled"].Default)
+
+	definitionMap, err = dataSourceIbmProjectProjectDefinitionPropertiesToMap(&projectv1.ProjectDefinitionProperties{
+		Name:              core.StringPtr("acme"),
+		DestroyOnDelete:   core.BoolPtr(false),
+		MonitoringEnabled: core.BoolPtr(true),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, false, definitionMap["destroy_on_delete"])
+	assert.Equal(t, true, definitionMap["monitoring_enabled"])
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
@@ -42,6 +43,74 @@ func TestAccIbmProjectBasic(t *testing.T) {
 	})
 }
 
+func TestAccIbmProjectDefinitionFlags(t *testing.T) {
+	var conf projectv1.Project
+	name := fmt.Sprintf("tf-project-flags-%d", acctest.RandIntRange(10, 100))
+
+	step := func(destroyOnDelete bool, monitoringEnabled bool) resource.TestStep {
+		return resource.TestStep{
+			Config: testAccCheckIbmProjectConfigFlags(name, destroyOnDelete, monitoringEnabled),
+			Check: resource.ComposeAggregateTestCheckFunc(
+				testAccCheckIbmProjectExists("ibm_project.project_instance", conf),
+				resource.TestCheckResourceAttr("ibm_project.project_instance", "definition.0.destroy_on_delete", fmt.Sprintf("%t", destroyOnDelete)),
+				resource.TestCheckResourceAttr("ibm_project.project_instance", "definition.0.monitoring_enabled", fmt.Sprintf("%t", monitoringEnabled)),
+			),
+		}
+	}
+	// Each step toggles one flag, and the plan after it must be empty
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { acc.TestAccPreCheck(t) },
+		Providers:    acc.TestAccProviders,
+		CheckDestroy: testAccCheckIbmProjectDestroy,
+		Steps: []resource.TestStep{
+			step(true, false),
+			step(false, false),
+			step(false, true),
+			step(true, true),
+			resource.TestStep{
+				Config: testAccCheckIbmProjectConfigFlagsOmitted(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_project.project_instance", "definition.0.destroy_on_delete", "true"),
+					resource.TestCheckResourceAttr("ibm_project.project_instance", "definition.0.monitoring_enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config:             testAccCheckIbmProjectConfigFlagsOmitted(name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccCheckIbmProjectConfigFlags(name string, destroyOnDelete bool, monitoringEnabled bool) string {
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "us-south"
+			resource_group = "Default"
+			definition {
+				name = "%s"
+				description = "%s description"
+				destroy_on_delete = %t
+				monitoring_enabled = %t
+			}
+		}
+	`, name, name, destroyOnDelete, monitoringEnabled)
+}
+
+func testAccCheckIbmProjectConfigFlagsOmitted(name string) string {
+	return fmt.Sprintf(`
+		resource "ibm_project" "project_instance" {
+			location = "us-south"
+			resource_group = "Default"
+			definition {
+				name = "%s"
+				description = "%s description"
+			}
+		}
+	`, name, name)
+}
+
 func testAccCheckIbmProjectConfigBasic(location string, resourceGroup string) string {
 	return fmt.Sprintf(`
 		resource "ibm_project" "project_instance" {
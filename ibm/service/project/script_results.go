@@ -0,0 +1,176 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/flex"
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigScriptStage is a script of the Schematics metadata of a configuration: the stage, pre or post, of an
+// action, validate, deploy or undeploy, and the property of the configuration that has the last run of the action.
+type projectConfigScriptStage struct {
+	Action   string
+	Stage    string
+	Property string
+}
+
+// projectConfigLastAction is the last run of an action of a configuration, with the jobs of its pre and post scripts.
+// The projectv1 models do not have the jobs of the scripts, so they are read from the raw response.
+type projectConfigLastAction struct {
+	Result  *string                 `json:"result"`
+	PreJob  *projectConfigScriptJob `json:"pre_job"`
+	PostJob *projectConfigScriptJob `json:"post_job"`
+}
+
+type projectConfigScriptJob struct {
+	ID      *string `json:"id"`
+	Href    *string `json:"href"`
+	Summary *struct {
+		Failed       *int64 `json:"failed"`
+		ProjectError *struct {
+			Code    *string `json:"code"`
+			Message *string `json:"message"`
+		} `json:"project_error"`
+	} `json:"summary"`
+}
+
+// projectConfigScriptResultsSchema is the schema of the script_results attribute.
+func projectConfigScriptResultsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The results of the pre and post scripts of the Schematics metadata that ran in the last validate, deploy and undeploy of the configuration. It is empty when no script is configured.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"action": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The action that ran the script: validate, deploy or undeploy.",
+				},
+				"stage": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The stage of the action that ran the script: pre or post.",
+				},
+				"job_id": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the Schematics job that ran the script.",
+				},
+				"status": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The status of the script: succeeded, or failed when a task of the job failed or the job reported an error.",
+				},
+				"message": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The error that the job reported, when there is one.",
+				},
+				"log_url": &schema.Schema{
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The URL of the Schematics job that ran the script, for its logs.",
+				},
+			},
+		},
+	}
+}
+
+// projectConfigScriptStages returns the scripts that are configured in the Schematics metadata of the configuration,
+// in the order of the actions and their stages.
+func projectConfigScriptStages(projectConfig *projectv1.ProjectConfig) []projectConfigScriptStage {
+	stages := []projectConfigScriptStage{}
+	if projectConfig == nil || projectConfig.Schematics == nil {
+		return stages
+	}
+	schematics := projectConfig.Schematics
+	for _, script := range []struct {
+		configured bool
+		stage      projectConfigScriptStage
+	}{
+		{schematics.ValidatePreScript != nil, projectConfigScriptStage{"validate", "pre", "last_validated"}},
+		{schematics.ValidatePostScript != nil, projectConfigScriptStage{"validate", "post", "last_validated"}},
+		{schematics.DeployPreScript != nil, projectConfigScriptStage{"deploy", "pre", "last_deployed"}},
+		{schematics.DeployPostScript != nil, projectConfigScriptStage{"deploy", "post", "last_deployed"}},
+		{schematics.UndeployPreScript != nil, projectConfigScriptStage{"undeploy", "pre", "last_undeployed"}},
+		{schematics.UndeployPostScript != nil, projectConfigScriptStage{"undeploy", "post", "last_undeployed"}},
+	} {
+		if script.configured {
+			stages = append(stages, script.stage)
+		}
+	}
+	return stages
+}
+
+// projectConfigScriptResults maps the jobs of the configured scripts in the raw properties of a configuration to the
+// script_results block. The scripts whose action never ran are not reported. A post script that failed while its
+// action did not fail, which the service treats as non-fatal, is returned as a warning too.
+func projectConfigScriptResults(configID string, stages []projectConfigScriptStage, rawProperties map[string]json.RawMessage) ([]map[string]interface{}, diag.Diagnostics, error) {
+	results := []map[string]interface{}{}
+	var diags diag.Diagnostics
+	lastActions := map[string]*projectConfigLastAction{}
+	for _, stage := range stages {
+		lastAction, ok := lastActions[stage.Property]
+		if !ok {
+			rawLastAction := rawProperties[stage.Property]
+			if len(rawLastAction) > 0 && !bytes.Equal(bytes.TrimSpace(rawLastAction), []byte("null")) {
+				lastAction = &projectConfigLastAction{}
+				if err := json.Unmarshal(rawLastAction, lastAction); err != nil {
+					return nil, nil, fmt.Errorf("Error reading %s: %s", stage.Property, err)
+				}
+			}
+			lastActions[stage.Property] = lastAction
+		}
+		if lastAction == nil {
+			continue
+		}
+		job := lastAction.PreJob
+		if stage.Stage == "post" {
+			job = lastAction.PostJob
+		}
+		if job == nil {
+			continue
+		}
+
+		status, message := "succeeded", ""
+		if job.Summary != nil {
+			if job.Summary.Failed != nil && *job.Summary.Failed > 0 {
+				status = "failed"
+			}
+			if projectError := job.Summary.ProjectError; projectError != nil {
+				status = "failed"
+				message = flex.StringValue(projectError.Message)
+			}
+		}
+		results = append(results, map[string]interface{}{
+			"action":  stage.Action,
+			"stage":   stage.Stage,
+			"job_id":  flex.StringValue(job.ID),
+			"status":  status,
+			"message": message,
+			"log_url": flex.StringValue(job.Href),
+		})
+		if status == "failed" && stage.Stage == "post" && flex.StringValue(lastAction.Result) != "failed" {
+			detail := fmt.Sprintf("The %s of the configuration did not fail, see the logs of job %s at %s.", stage.Action, flex.StringValue(job.ID), flex.StringValue(job.Href))
+			if message != "" {
+				detail = message + ". " + detail
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The %s post script of configuration %s failed", stage.Action, configID),
+				Detail:   detail,
+			})
+		}
+	}
+	return results, diags, nil
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigScriptStages(t *testing.T) {
+	assert.Empty(t, projectConfigScriptStages(nil))
+	assert.Empty(t, projectConfigScriptStages(&projectv1.ProjectConfig{}))
+	assert.Empty(t, projectConfigScriptStages(&projectv1.ProjectConfig{Schematics: &projectv1.SchematicsMetadata{}}))
+
+	projectConfig := &projectv1.ProjectConfig{Schematics: &projectv1.SchematicsMetadata{
+		DeployPostScript:  &projectv1.Script{},
+		ValidatePreScript: &projectv1.Script{},
+	}}
+	assert.Equal(t, []projectConfigScriptStage{
+		{Action: "validate", Stage: "pre", Property: "last_validated"},
+		{Action: "deploy", Stage: "post", Property: "last_deployed"},
+	}, projectConfigScriptStages(projectConfig))
+}
+
+func TestProjectConfigScriptResults(t *testing.T) {
+	stages := []projectConfigScriptStage{
+		{Action: "validate", Stage: "pre", Property: "last_validated"},
+		{Action: "deploy", Stage: "pre", Property: "last_deployed"},
+		{Action: "deploy", Stage: "post", Property: "last_deployed"},
+		{Action: "undeploy", Stage: "post", Property: "last_undeployed"},
+	}
+	rawProperties := map[string]json.RawMessage{
+		"last_validated": json.RawMessage(`{"result": "passed", "pre_job": {"id": "job-1", "href": "https://schematics.example.com/jobs/job-1", "summary": {"tasks": 2, "ok": 2, "failed": 0}}}`),
+		"last_deployed": json.RawMessage(`{"result": "passed",
+			"pre_job": {"id": "job-2", "href": "https://schematics.example.com/jobs/job-2", "summary": {"tasks": 1, "ok": 1}},
+			"post_job": {"id": "job-3", "href": "https://schematics.example.com/jobs/job-3", "summary": {"tasks": 2, "ok": 1, "failed": 1, "project_error": {"code": "500", "message": "notify.sh exited with 1"}}}}`),
+		"last_undeployed": json.RawMessage(`null`),
+	}
+
+	results, diags, err := projectConfigScriptResults("cfg-1", stages, rawProperties)
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"action": "validate", "stage": "pre", "job_id": "job-1", "status": "succeeded", "message": "", "log_url": "https://schematics.example.com/jobs/job-1"},
+		{"action": "deploy", "stage": "pre", "job_id": "job-2", "status": "succeeded", "message": "", "log_url": "https://schematics.example.com/jobs/job-2"},
+		{"action": "deploy", "stage": "post", "job_id": "job-3", "status": "failed", "message": "notify.sh exited with 1", "log_url": "https://schematics.example.com/jobs/job-3"},
+	}, results)
+	// The post script failed while the deploy passed, so it is a warning
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Equal(t, "The deploy post script of configuration cfg-1 failed", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "notify.sh exited with 1")
+	}
+
+	// A failed action already fails on its own, without a warning for its post script
+	rawProperties["last_deployed"] = json.RawMessage(`{"result": "failed", "post_job": {"id": "job-3", "summary": {"failed": 1}}}`)
+	results, diags, err = projectConfigScriptResults("cfg-1", stages, rawProperties)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Empty(t, diags)
+
+	// Nothing is read without configured scripts
+	results, diags, err = projectConfigScriptResults("cfg-1", []projectConfigScriptStage{}, map[string]json.RawMessage{"last_validated": json.RawMessage(`[`)})
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, diags)
+
+	_, _, err = projectConfigScriptResults("cfg-1", stages, map[string]json.RawMessage{"last_validated": json.RawMessage(`[`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+// projectConfigPreviousStates maps the states of a configuration that can only be reached from a single state to
+// that state. The Projects API does not report the state transitions of a configuration, so the previous state of the
+// other states is not known.
+var projectConfigPreviousStates = map[string]string{
+	"validated":          "validating",
+	"validating_failed":  "validating",
+	"approved":           "validated",
+	"deployed":           "deploying",
+	"deploying_failed":   "deploying",
+	"undeploying_failed": "undeploying",
+	"deleting_failed":    "deleting",
+}
+
+// projectConfigPreviousState returns the state of the configuration before its current state, empty when it cannot
+// be derived from the current state.
+func projectConfigPreviousState(projectConfig *projectv1.ProjectConfig) string {
+	if projectConfig.State == nil {
+		return ""
+	}
+	return projectConfigPreviousStates[*projectConfig.State]
+}
+
+// projectConfigLastStateChangeAt estimates when the configuration reached its current state. The Projects API does not
+// report the time of the state transitions, so it is the most recent of the modification time of the configuration
+// and of the timestamps of its needs attention events, which the service raises when an action fails. It returns an
+// empty string when neither is known.
+func projectConfigLastStateChangeAt(projectConfig *projectv1.ProjectConfig) string {
+	var last time.Time
+	if projectConfig.ModifiedAt != nil {
+		last = time.Time(*projectConfig.ModifiedAt)
+	}
+	for _, event := range projectConfig.NeedsAttentionState {
+		if event.Timestamp == nil {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339Nano, *event.Timestamp)
+		if err != nil {
+			continue
+		}
+		if timestamp.After(last) {
+			last = timestamp
+		}
+	}
+	if last.IsZero() {
+		return ""
+	}
+	return strfmt.DateTime(last.UTC()).String()
+}
@@ -0,0 +1,63 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigPreviousState(t *testing.T) {
+	assert.Equal(t, "", projectConfigPreviousState(&projectv1.ProjectConfig{}))
+	assert.Equal(t, "validating", projectConfigPreviousState(&projectv1.ProjectConfig{State: core.StringPtr("validated")}))
+	assert.Equal(t, "deploying", projectConfigPreviousState(&projectv1.ProjectConfig{State: core.StringPtr("deploying_failed")}))
+
+	// A draft can be reached from most states, so its previous state is not known
+	assert.Equal(t, "", projectConfigPreviousState(&projectv1.ProjectConfig{State: core.StringPtr("draft")}))
+}
+
+func TestProjectConfigLastStateChangeAt(t *testing.T) {
+	modifiedAt := strfmt.DateTime(time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "", projectConfigLastStateChangeAt(&projectv1.ProjectConfig{}))
+	})
+
+	t.Run("modified at", func(t *testing.T) {
+		projectConfig := &projectv1.ProjectConfig{
+			ModifiedAt: &modifiedAt,
+			NeedsAttentionState: []projectv1.ProjectConfigNeedsAttentionState{
+				{Timestamp: core.StringPtr("2024-02-01T10:00:00Z")},
+			},
+		}
+		assert.Equal(t, "2024-03-01T10:00:00.000Z", projectConfigLastStateChangeAt(projectConfig))
+	})
+
+	t.Run("needs attention event after modified at", func(t *testing.T) {
+		projectConfig := &projectv1.ProjectConfig{
+			ModifiedAt: &modifiedAt,
+			NeedsAttentionState: []projectv1.ProjectConfigNeedsAttentionState{
+				{Timestamp: core.StringPtr("2024-03-02T12:30:00+02:00")},
+				{},
+				{Timestamp: core.StringPtr("not a timestamp")},
+			},
+		}
+		assert.Equal(t, "2024-03-02T10:30:00.000Z", projectConfigLastStateChangeAt(projectConfig))
+	})
+
+	t.Run("needs attention event only", func(t *testing.T) {
+		projectConfig := &projectv1.ProjectConfig{
+			NeedsAttentionState: []projectv1.ProjectConfigNeedsAttentionState{
+				{Timestamp: core.StringPtr("2024-03-02T10:30:00.123Z")},
+			},
+		}
+		assert.Equal(t, "2024-03-02T10:30:00.123Z", projectConfigLastStateChangeAt(projectConfig))
+	})
+}
@@ -22,3 +22,22 @@ func stringify(v interface{}) (result *string) {
 	}
 	return
 }
+
+// projectOutputValueMap returns the entries of an output value that is a JSON object, for the deprecated map
+// attribute of the outputs. Strings are kept as is and the other entries are JSON encoded. The values that are not
+// objects are only available as JSON.
+func projectOutputValueMap(v interface{}) (map[string]interface{}, bool) {
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(bytes, &object); err != nil || object == nil {
+		return nil, false
+	}
+	valueMap := make(map[string]interface{}, len(object))
+	for k, item := range object {
+		valueMap[k] = *stringify(item)
+	}
+	return valueMap, true
+}
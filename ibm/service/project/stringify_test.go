@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectOutputValueMap(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    interface{}
+		valueMap map[string]interface{}
+		ok       bool
+	}{
+		{
+			name:     "object",
+			value:    map[string]interface{}{"zone": "us-south-1", "port": 8080, "acl": map[string]interface{}{"allow": true}, "tags": []interface{}{"a"}, "empty": nil},
+			valueMap: map[string]interface{}{"zone": "us-south-1", "port": "8080", "acl": `{"allow":true}`, "tags": `["a"]`, "empty": "null"},
+			ok:       true,
+		},
+		{name: "empty object", value: map[string]interface{}{}, valueMap: map[string]interface{}{}, ok: true},
+		{name: "string", value: "r006-4ac2"},
+		{name: "number", value: 3},
+		{name: "boolean", value: false},
+		{name: "array", value: []interface{}{"a", "b"}},
+		{name: "null", value: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valueMap, ok := projectOutputValueMap(tc.value)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.valueMap, valueMap)
+		})
+	}
+}
+
+func TestResourceIbmProjectConfigOutputValueToMap(t *testing.T) {
+	modelMap, err := resourceIbmProjectConfigOutputValueToMap(&projectv1.OutputValue{
+		Name:  core.StringPtr("network"),
+		Value: map[string]interface{}{"vpc_id": "r006-4ac2", "subnets": 3},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"subnets":3,"vpc_id":"r006-4ac2"}`, *modelMap["value_json"].(*string))
+	assert.Equal(t, map[string]interface{}{"vpc_id": "r006-4ac2", "subnets": "3"}, modelMap["value"])
+
+	modelMap, err = resourceIbmProjectConfigOutputValueToMap(&projectv1.OutputValue{
+		Name:  core.StringPtr("vpc_id"),
+		Value: "r006-4ac2",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "r006-4ac2", *modelMap["value_json"].(*string))
+	assert.NotContains(t, modelMap, "value")
+}
@@ -0,0 +1,114 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+)
+
+const (
+	projectConfigValidationPending  = "pending"
+	projectConfigValidationComplete = "complete"
+	projectConfigValidationFailed   = "failed"
+)
+
+// projectConfigValidationGuidance is appended to the errors of the validations that fail on create.
+const projectConfigValidationGuidance = "The configuration is created and tainted, fix its inputs and apply again to replace it, or untaint it to update it in place."
+
+// projectConfigValidateOnCreate validates a configuration that was just created and waits until it is no longer
+// validating. The validated configuration is returned, with an error when the validation failed.
+func projectConfigValidateOnCreate(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string, timeout time.Duration, pollInterval time.Duration) (*projectv1.ProjectConfig, error) {
+	validateConfigOptions := &projectv1.ValidateConfigOptions{}
+	validateConfigOptions.SetProjectID(projectID)
+	validateConfigOptions.SetID(configID)
+
+	_, _, err := projectClient.ValidateConfigWithContext(context, validateConfigOptions)
+	if err != nil {
+		return nil, fmt.Errorf("The service rejected the validation of configuration %s: %s. %s", configID, err, projectConfigValidationGuidance)
+	}
+
+	waiter := newProjectActionWaiter(projectConfigValidationRefreshFunc(context, projectClient, projectID, configID), []string{projectConfigValidationPending}, []string{projectConfigValidationComplete}, []string{projectConfigValidationFailed}, timeout, pollInterval)
+	validatedConfig, err := waiter.WaitForStateContext(context)
+	// A failed validation is reported with the needs attention events of the configuration
+	var failedErr *projectWaitFailedError
+	if err != nil && !errors.As(err, &failedErr) {
+		return nil, fmt.Errorf("The validation of configuration %s did not complete: %s. %s", configID, err, projectConfigValidationGuidance)
+	}
+	projectConfig := validatedConfig.(*projectv1.ProjectConfig)
+	if projectConfigValidationResult(projectConfig) != "passed" {
+		return projectConfig, projectConfigValidationFailedError(configID, projectConfig)
+	}
+	return projectConfig, nil
+}
+
+func projectConfigValidationRefreshFunc(context context.Context, projectClient *projectv1.ProjectV1, projectID string, configID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(configID)
+
+		projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		return projectConfig, projectConfigValidationStatus(projectConfig), nil
+	}
+}
+
+// projectConfigValidationStatus returns whether the validation of a new configuration is complete, which is when the
+// configuration is no longer validating and has a validation, or failed, when the configuration is validating_failed.
+func projectConfigValidationStatus(projectConfig *projectv1.ProjectConfig) string {
+	state := projectConfigStringValue(projectConfig.State)
+	if state == "validating_failed" {
+		return projectConfigValidationFailed
+	}
+	if state == "validating" || projectConfig.LastValidated == nil {
+		return projectConfigValidationPending
+	}
+	return projectConfigValidationComplete
+}
+
+// projectConfigValidationResult returns the result of the last validation of the configuration, passed or failed, or
+// an empty string when the configuration was never validated. A configuration in state validating_failed failed its
+// validation even when the result is not reported.
+func projectConfigValidationResult(projectConfig *projectv1.ProjectConfig) string {
+	if projectConfigStringValue(projectConfig.State) == "validating_failed" {
+		return "failed"
+	}
+	if projectConfig.LastValidated == nil || projectConfig.LastValidated.Result == nil {
+		return ""
+	}
+	return *projectConfig.LastValidated.Result
+}
+
+// projectConfigValidationHasCostEstimate reports whether the last validation of the configuration estimated its cost.
+func projectConfigValidationHasCostEstimate(projectConfig *projectv1.ProjectConfig) bool {
+	return projectConfig.LastValidated != nil && projectConfig.LastValidated.CostEstimate != nil
+}
+
+// projectConfigValidationFailedError is the error of a failed validation, with the needs attention events of the
+// configuration that tell what to fix.
+func projectConfigValidationFailedError(configID string, projectConfig *projectv1.ProjectConfig) error {
+	message := fmt.Sprintf("The validation of configuration %s failed, the configuration is in state %s. %s", configID, projectConfigStringValue(projectConfig.State), projectConfigValidationGuidance)
+	events := []string{}
+	for _, event := range projectConfig.NeedsAttentionState {
+		description := fmt.Sprintf("%s at %s", projectConfigStringValue(event.Event), projectConfigStringValue(event.Timestamp))
+		if event.ActionURL != nil && *event.ActionURL != "" {
+			description += fmt.Sprintf(", see %s", *event.ActionURL)
+		}
+		events = append(events, description)
+	}
+	if len(events) > 0 {
+		message += "\nThe configuration needs attention:\n" + strings.Join(events, "\n")
+	}
+	return fmt.Errorf("%s", message)
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectConfigValidationStatus(t *testing.T) {
+	failed := testProjectConfigValidated("job-1", 0, 0, 0)
+	failed.Result = core.StringPtr("failed")
+	testCases := []struct {
+		name   string
+		config *projectv1.ProjectConfig
+		status string
+		result string
+	}{
+		{
+			name:   "not yet validating",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("draft")},
+			status: projectConfigValidationPending,
+		},
+		{
+			name:   "validating",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validating")},
+			status: projectConfigValidationPending,
+		},
+		{
+			name:   "validated",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validated"), LastValidated: testProjectConfigValidated("job-1", 1, 0, 0)},
+			status: projectConfigValidationComplete,
+			result: "passed",
+		},
+		{
+			name:   "failed with a result",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("draft"), LastValidated: failed},
+			status: projectConfigValidationComplete,
+			result: "failed",
+		},
+		{
+			name:   "failed without a result",
+			config: &projectv1.ProjectConfig{State: core.StringPtr("validating_failed")},
+			status: projectConfigValidationFailed,
+			result: "failed",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.status, projectConfigValidationStatus(tc.config), tc.name)
+		assert.Equal(t, tc.result, projectConfigValidationResult(tc.config), tc.name)
+	}
+}
+
+func TestProjectConfigValidationHasCostEstimate(t *testing.T) {
+	assert.False(t, projectConfigValidationHasCostEstimate(&projectv1.ProjectConfig{}))
+	validated := testProjectConfigValidated("job-1", 1, 0, 0)
+	assert.False(t, projectConfigValidationHasCostEstimate(&projectv1.ProjectConfig{LastValidated: validated}))
+	validated.CostEstimate = &projectv1.ProjectConfigMetadataCostEstimate{}
+	assert.True(t, projectConfigValidationHasCostEstimate(&projectv1.ProjectConfig{LastValidated: validated}))
+}
+
+func TestProjectConfigValidationFailedError(t *testing.T) {
+	projectConfig := &projectv1.ProjectConfig{State: core.StringPtr("validating_failed")}
+	assert.EqualError(t, projectConfigValidationFailedError("a1b2c3", projectConfig),
+		"The validation of configuration a1b2c3 failed, the configuration is in state validating_failed. The configuration is created and tainted, fix its inputs and apply again to replace it, or untaint it to update it in place.")
+
+	projectConfig.NeedsAttentionState = []projectv1.ProjectConfigNeedsAttentionState{
+		{Event: core.StringPtr("project.config.validate.failed"), Timestamp: core.StringPtr("2024-05-02T10:00:00Z"), ActionURL: core.StringPtr("https://cloud.ibm.com/schematics/workspaces/job-1")},
+		{Event: core.StringPtr("project.config.validate.cost_estimate_failed"), Timestamp: core.StringPtr("2024-05-02T10:01:00Z")},
+	}
+	assert.EqualError(t, projectConfigValidationFailedError("a1b2c3", projectConfig),
+		"The validation of configuration a1b2c3 failed, the configuration is in state validating_failed. The configuration is created and tainted, fix its inputs and apply again to replace it, or untaint it to update it in place.\n"+
+			"The configuration needs attention:\n"+
+			"project.config.validate.failed at 2024-05-02T10:00:00Z, see https://cloud.ibm.com/schematics/workspaces/job-1\n"+
+			"project.config.validate.cost_estimate_failed at 2024-05-02T10:01:00Z")
+}
+
+func TestProjectConfigValidateOnCreateFailed(t *testing.T) {
+	var validations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			validations++
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"id": "cfg-1", "state": "validating"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "cfg-1", "state": "validating_failed", "needs_attention_state": [{"event": "project.config.validate.failed", "timestamp": "2024-05-02T10:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	projectClient, err := projectv1.NewProjectV1(&projectv1.ProjectV1Options{
+		URL:           server.URL,
+		Authenticator: &core.NoAuthAuthenticator{},
+	})
+	assert.NoError(t, err)
+
+	// The failed configuration is returned with the error, so that the create keeps it in the state to be replaced
+	projectConfig, err := projectConfigValidateOnCreate(context.Background(), projectClient, "project-1", "cfg-1", time.Minute, time.Millisecond)
+	assert.Equal(t, 1, validations)
+	if assert.NotNil(t, projectConfig) {
+		assert.Equal(t, "cfg-1", *projectConfig.ID)
+		assert.Equal(t, "failed", projectConfigValidationResult(projectConfig))
+	}
+	assert.ErrorContains(t, err, "failed, the configuration is in state validating_failed")
+	assert.ErrorContains(t, err, "apply again to replace it")
+	assert.ErrorContains(t, err, "project.config.validate.failed at 2024-05-02T10:00:00Z")
+}
@@ -0,0 +1,150 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/conns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// The interval of the first poll of a waiter, and the interval that the polls back off to. The interval doubles after
+// each poll until it reaches projectWaitMaxInterval, or the base interval when it is longer.
+const (
+	projectWaitDefaultInterval = 5 * time.Second
+	projectWaitMaxInterval     = 60 * time.Second
+)
+
+// projectWaiter polls Refresh until it returns one of the Target states, as resource.StateChangeConf does, with an
+// interval that backs off from Interval and is jittered, so that the configurations that are applied together do not
+// poll the Projects API in lockstep. The wait fails when Refresh returns an error or one of the Failed states, or a
+// state that is not in Pending when Pending is set. The result of the failed refresh is returned with the
+// *projectWaitFailedError of a failed state.
+type projectWaiter struct {
+	Pending  []string
+	Target   []string
+	Failed   []string
+	Refresh  resource.StateRefreshFunc
+	Timeout  time.Duration
+	Delay    time.Duration
+	Interval time.Duration
+
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+	jitter func(time.Duration) time.Duration
+}
+
+// newProjectWaiter returns a waiter that polls refresh until it returns one of the target states, and fails when it
+// returns one of the failed states. A pollInterval of 0 uses projectWaitDefaultInterval.
+func newProjectWaiter(refresh resource.StateRefreshFunc, pending []string, target []string, failed []string, timeout time.Duration, pollInterval time.Duration) *projectWaiter {
+	if pollInterval <= 0 {
+		pollInterval = projectWaitDefaultInterval
+	}
+	return &projectWaiter{
+		Pending:  pending,
+		Target:   target,
+		Failed:   failed,
+		Refresh:  refresh,
+		Timeout:  timeout,
+		Interval: pollInterval,
+		now:      time.Now,
+		sleep:    projectSleep,
+		jitter:   projectWaitJitter,
+	}
+}
+
+// newProjectActionWaiter returns a waiter for an action that was just triggered on a configuration. The first poll
+// waits for the poll interval, as the configuration does not report the action right away.
+func newProjectActionWaiter(refresh resource.StateRefreshFunc, pending []string, target []string, failed []string, timeout time.Duration, pollInterval time.Duration) *projectWaiter {
+	waiter := newProjectWaiter(refresh, pending, target, failed, timeout, pollInterval)
+	waiter.Delay = waiter.Interval
+	return waiter
+}
+
+// projectWaitFailedError is returned by a waiter whose refresh returned one of its failed states, with the result of
+// that refresh.
+type projectWaitFailedError struct {
+	State  string
+	Target []string
+}
+
+func (e *projectWaitFailedError) Error() string {
+	return fmt.Sprintf("the wait for state %s reached the failed state %s", strings.Join(e.Target, ", "), e.State)
+}
+
+// projectPollIntervalFor returns the project_poll_interval of the provider, 0 for the default interval.
+func projectPollIntervalFor(meta interface{}) time.Duration {
+	return meta.(conns.ClientSession).ProjectPollInterval()
+}
+
+// projectWaitJitter returns a random delay between the interval and a quarter more, so that the polls are never
+// sooner than the interval.
+func projectWaitJitter(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Int63n(int64(interval/4)+1))
+}
+
+// WaitForStateContext polls until the target state, and returns the result of the last refresh. It returns a
+// *resource.TimeoutError when the timeout expires first, and the error of the context when it is done.
+func (w *projectWaiter) WaitForStateContext(ctx context.Context) (interface{}, error) {
+	deadline := w.now().Add(w.Timeout)
+	if w.Delay > 0 {
+		if err := w.sleep(ctx, w.Delay); err != nil {
+			return nil, err
+		}
+	}
+
+	maxInterval := projectWaitMaxInterval
+	if w.Interval > maxInterval {
+		maxInterval = w.Interval
+	}
+	interval := w.Interval
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		result, state, err := w.Refresh()
+		if err != nil {
+			return nil, err
+		}
+		if projectWaitStateIn(state, w.Target) {
+			return result, nil
+		}
+		if projectWaitStateIn(state, w.Failed) {
+			return result, &projectWaitFailedError{State: state, Target: w.Target}
+		}
+		if len(w.Pending) > 0 && !projectWaitStateIn(state, w.Pending) {
+			return result, &resource.UnexpectedStateError{State: state, ExpectedState: w.Target}
+		}
+
+		remaining := deadline.Sub(w.now())
+		if remaining <= 0 {
+			return result, &resource.TimeoutError{LastState: state, Timeout: w.Timeout, ExpectedState: w.Target}
+		}
+		delay := w.jitter(interval)
+		if delay > remaining {
+			delay = remaining
+		}
+		if err := w.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func projectWaitStateIn(state string, states []string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
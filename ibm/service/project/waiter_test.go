@@ -0,0 +1,144 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testProjectWaiter returns a waiter on the fake clock that refreshes the states of states, one per poll, and the last
+// state for the polls after them. The jitter waits the full interval, so that the sleeps are the intervals.
+func testProjectWaiter(clock *testProjectClock, states []string, timeout time.Duration, pollInterval time.Duration) (*projectWaiter, *int) {
+	polls := 0
+	refresh := func() (interface{}, string, error) {
+		state := states[len(states)-1]
+		if polls < len(states) {
+			state = states[polls]
+		}
+		polls++
+		return polls, state, nil
+	}
+	waiter := newProjectWaiter(refresh, []string{"pending"}, []string{"done"}, nil, timeout, pollInterval)
+	waiter.now = clock.Now
+	waiter.sleep = clock.Sleep
+	waiter.jitter = func(interval time.Duration) time.Duration { return interval }
+	return waiter, &polls
+}
+
+func TestProjectWaiterSuccess(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, polls := testProjectWaiter(clock, []string{"pending", "pending", "pending", "pending", "pending", "pending", "pending", "done"}, time.Hour, 0)
+
+	result, err := waiter.WaitForStateContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 8, result)
+	assert.Equal(t, 8, *polls)
+	// The interval backs off from 5 seconds and stays at 60 seconds
+	assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 60 * time.Second, 60 * time.Second, 60 * time.Second}, clock.sleeps)
+}
+
+func TestProjectWaiterDelayAndPollInterval(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, _ := testProjectWaiter(clock, []string{"pending", "pending", "done"}, time.Hour, 2*time.Second)
+	waiter.Delay = waiter.Interval
+
+	_, err := waiter.WaitForStateContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second, 4 * time.Second}, clock.sleeps)
+
+	// A base interval over 60 seconds is not capped
+	clock = &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, _ = testProjectWaiter(clock, []string{"pending", "pending", "done"}, time.Hour, 90*time.Second)
+	_, err = waiter.WaitForStateContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{90 * time.Second, 90 * time.Second}, clock.sleeps)
+}
+
+func TestProjectWaiterFailedState(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, polls := testProjectWaiter(clock, []string{"pending", "failed"}, time.Hour, 0)
+	waiter.Failed = []string{"failed"}
+
+	result, err := waiter.WaitForStateContext(context.Background())
+	assert.EqualError(t, err, "the wait for state done reached the failed state failed")
+	var failedErr *projectWaitFailedError
+	assert.True(t, errors.As(err, &failedErr))
+	assert.Equal(t, "failed", failedErr.State)
+	// The result of the failed refresh is returned with the error
+	assert.Equal(t, 2, result)
+	assert.Equal(t, 2, *polls)
+
+	// A state that is neither pending nor failed is unexpected
+	waiter, _ = testProjectWaiter(clock, []string{"pending", "unknown"}, time.Hour, 0)
+	_, err = waiter.WaitForStateContext(context.Background())
+	var unexpectedErr *resource.UnexpectedStateError
+	assert.True(t, errors.As(err, &unexpectedErr))
+	assert.Equal(t, "unknown", unexpectedErr.State)
+
+	// The error of the refresh is returned as is
+	refreshErr := errors.New("the configuration is in state deploying_failed")
+	waiter.Refresh = func() (interface{}, string, error) { return nil, "", refreshErr }
+	_, err = waiter.WaitForStateContext(context.Background())
+	assert.Equal(t, refreshErr, err)
+}
+
+func TestProjectWaiterTimeout(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, polls := testProjectWaiter(clock, []string{"pending"}, time.Minute, 0)
+
+	_, err := waiter.WaitForStateContext(context.Background())
+	var timeoutErr *resource.TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.Equal(t, "pending", timeoutErr.LastState)
+	assert.Equal(t, time.Minute, timeoutErr.Timeout)
+	// The last sleep is cut to the deadline, which is polled once more before the wait times out
+	assert.Equal(t, []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 25 * time.Second}, clock.sleeps)
+	assert.Equal(t, 5, *polls)
+}
+
+func TestProjectWaiterContextCancel(t *testing.T) {
+	clock := &testProjectClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	waiter, polls := testProjectWaiter(clock, []string{"pending"}, time.Hour, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	refresh := waiter.Refresh
+	waiter.Refresh = func() (interface{}, string, error) {
+		if *polls == 2 {
+			cancel()
+		}
+		return refresh()
+	}
+
+	_, err := waiter.WaitForStateContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 3, *polls)
+
+	// A context that is done before the wait does not poll
+	waiter, polls = testProjectWaiter(clock, []string{"done"}, time.Hour, 0)
+	_, err = waiter.WaitForStateContext(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, *polls)
+}
+
+func TestProjectWaitJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		delay := projectWaitJitter(10 * time.Second)
+		assert.GreaterOrEqual(t, delay, 10*time.Second)
+		assert.LessOrEqual(t, delay, 12500*time.Millisecond)
+	}
+}
+
+func TestNewProjectActionWaiter(t *testing.T) {
+	waiter := newProjectActionWaiter(nil, []string{"pending"}, []string{"done"}, []string{"failed"}, time.Hour, 0)
+	assert.Equal(t, projectWaitDefaultInterval, waiter.Delay)
+	assert.Equal(t, []string{"failed"}, waiter.Failed)
+
+	waiter = newProjectActionWaiter(nil, []string{"pending"}, []string{"done"}, nil, time.Hour, 2*time.Second)
+	assert.Equal(t, 2*time.Second, waiter.Delay)
+}
@@ -0,0 +1,120 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/IBM/schematics-go-sdk/schematicsv1"
+)
+
+const (
+	projectConfigWorkspacePending = "pending"
+	projectConfigWorkspaceReady   = "ready"
+)
+
+// The statuses of a Schematics workspace that can run the jobs of the project actions, and the statuses that it does
+// not leave without a change of its template
+var (
+	projectConfigWorkspaceReadyStatuses  = map[string]bool{"INACTIVE": true, "ACTIVE": true}
+	projectConfigWorkspaceFailedStatuses = map[string]bool{"FAILED": true, "TEMPLATE_ERROR": true}
+)
+
+// projectConfigWorkspaceCRN returns the CRN of the Schematics workspace of the configuration, empty while the
+// workspace is not created yet.
+func projectConfigWorkspaceCRN(projectConfig *projectv1.ProjectConfig) string {
+	if projectConfig == nil || projectConfig.Schematics == nil || projectConfig.Schematics.WorkspaceCrn == nil {
+		return ""
+	}
+	return *projectConfig.Schematics.WorkspaceCrn
+}
+
+// projectConfigWorkspaceID returns the ID of a Schematics workspace from its CRN, such as
+// crn:v1:bluemix:public:schematics:us-south:a/<account>:<instance>:workspace:<workspace ID>.
+func projectConfigWorkspaceID(workspaceCRN string) (string, error) {
+	parts := strings.Split(workspaceCRN, ":")
+	if len(parts) != 10 || parts[0] != "crn" || parts[8] != "workspace" || parts[9] == "" {
+		return "", fmt.Errorf("%s is not the CRN of a Schematics workspace", workspaceCRN)
+	}
+	return parts[9], nil
+}
+
+// projectConfigWorkspaceStatus returns whether a Schematics workspace in the status is ready to run the jobs of the
+// project actions. The workspace failed when its template could not be loaded.
+func projectConfigWorkspaceStatus(status string) (string, error) {
+	status = strings.ToUpper(status)
+	if projectConfigWorkspaceReadyStatuses[status] {
+		return projectConfigWorkspaceReady, nil
+	}
+	if projectConfigWorkspaceFailedStatuses[status] {
+		return "", fmt.Errorf("the Schematics workspace is in status %s", status)
+	}
+	return projectConfigWorkspacePending, nil
+}
+
+// projectConfigWaitForWorkspace waits until the configuration has the CRN of its Schematics workspace and returns it.
+// With a Schematics client, it then waits until the workspace is ready to run the jobs of the project actions.
+func projectConfigWaitForWorkspace(context context.Context, projectClient projectConfigAPI, schematicsClient projectWorkspaceAPI, projectID string, configID string, timeout time.Duration, pollInterval time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+	waiter := newProjectWaiter(projectConfigWorkspaceCRNRefreshFunc(context, projectClient, projectID, configID), []string{projectConfigWorkspacePending}, []string{projectConfigWorkspaceReady}, nil, timeout, pollInterval)
+	projectConfig, err := waiter.WaitForStateContext(context)
+	if err != nil {
+		return "", fmt.Errorf("The Schematics workspace of configuration %s was not created: %s", configID, err)
+	}
+	workspaceCRN := projectConfigWorkspaceCRN(projectConfig.(*projectv1.ProjectConfig))
+	if schematicsClient == nil {
+		return workspaceCRN, nil
+	}
+
+	workspaceID, err := projectConfigWorkspaceID(workspaceCRN)
+	if err != nil {
+		return workspaceCRN, err
+	}
+	waiter = newProjectWaiter(projectConfigWorkspaceStatusRefreshFunc(context, schematicsClient, workspaceID), []string{projectConfigWorkspacePending}, []string{projectConfigWorkspaceReady}, nil, time.Until(deadline), pollInterval)
+	if _, err = waiter.WaitForStateContext(context); err != nil {
+		return workspaceCRN, fmt.Errorf("The Schematics workspace %s of configuration %s is not ready: %s", workspaceID, configID, err)
+	}
+	return workspaceCRN, nil
+}
+
+func projectConfigWorkspaceCRNRefreshFunc(context context.Context, projectClient projectConfigAPI, projectID string, configID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		getConfigOptions := &projectv1.GetConfigOptions{}
+		getConfigOptions.SetProjectID(projectID)
+		getConfigOptions.SetID(configID)
+
+		projectConfig, _, err := projectClient.GetConfigWithContext(context, getConfigOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		if projectConfigWorkspaceCRN(projectConfig) == "" {
+			return projectConfig, projectConfigWorkspacePending, nil
+		}
+		return projectConfig, projectConfigWorkspaceReady, nil
+	}
+}
+
+func projectConfigWorkspaceStatusRefreshFunc(context context.Context, schematicsClient projectWorkspaceAPI, workspaceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		getWorkspaceOptions := &schematicsv1.GetWorkspaceOptions{}
+		getWorkspaceOptions.SetWID(workspaceID)
+
+		workspace, _, err := schematicsClient.GetWorkspaceWithContext(context, getWorkspaceOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		status := ""
+		if workspace.Status != nil {
+			status = *workspace.Status
+		}
+		state, err := projectConfigWorkspaceStatus(status)
+		return workspace, state, err
+	}
+}
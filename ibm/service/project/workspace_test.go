@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2024 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package project
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/project-go-sdk/projectv1"
+	"github.com/IBM/schematics-go-sdk/schematicsv1"
+	"github.com/stretchr/testify/assert"
+)
+
+const testProjectWorkspaceCRN = "crn:v1:bluemix:public:schematics:us-south:a/4448261269a14562b839e0a3019ed980:c8a5c7b9-6d54-4b33-9b7b-3c2b2e1c2f3a:workspace:us-south.workspace.projects-service.3ae3f8d5"
+
+// testProjectWorkspaceAPI fakes the Schematics workspaces with the statuses of statuses, one per read, and the last
+// status for the reads after them.
+type testProjectWorkspaceAPI struct {
+	statuses []string
+	err      error
+	calls    int
+}
+
+func (api *testProjectWorkspaceAPI) GetWorkspaceWithContext(ctx context.Context, getWorkspaceOptions *schematicsv1.GetWorkspaceOptions) (*schematicsv1.WorkspaceResponse, *core.DetailedResponse, error) {
+	api.calls++
+	if api.err != nil {
+		return nil, &core.DetailedResponse{StatusCode: 404}, api.err
+	}
+	status := api.statuses[len(api.statuses)-1]
+	if api.calls <= len(api.statuses) {
+		status = api.statuses[api.calls-1]
+	}
+	return &schematicsv1.WorkspaceResponse{Status: core.StringPtr(status)}, &core.DetailedResponse{StatusCode: 200}, nil
+}
+
+func TestProjectConfigWorkspaceID(t *testing.T) {
+	workspaceID, err := projectConfigWorkspaceID(testProjectWorkspaceCRN)
+	assert.NoError(t, err)
+	assert.Equal(t, "us-south.workspace.projects-service.3ae3f8d5", workspaceID)
+
+	_, err = projectConfigWorkspaceID("crn:v1:bluemix:public:schematics:us-south:a/4448261269a14562b839e0a3019ed980::action:us-south.action.a1")
+	assert.EqualError(t, err, "crn:v1:bluemix:public:schematics:us-south:a/4448261269a14562b839e0a3019ed980::action:us-south.action.a1 is not the CRN of a Schematics workspace")
+	_, err = projectConfigWorkspaceID("us-south.workspace.projects-service.3ae3f8d5")
+	assert.Error(t, err)
+}
+
+func TestProjectConfigWorkspaceStatus(t *testing.T) {
+	for _, status := range []string{"INACTIVE", "ACTIVE", "active"} {
+		state, err := projectConfigWorkspaceStatus(status)
+		assert.NoError(t, err)
+		assert.Equal(t, projectConfigWorkspaceReady, state, status)
+	}
+	for _, status := range []string{"", "DRAFT", "CONNECTING", "INPROGRESS"} {
+		state, err := projectConfigWorkspaceStatus(status)
+		assert.NoError(t, err)
+		assert.Equal(t, projectConfigWorkspacePending, state, status)
+	}
+	_, err := projectConfigWorkspaceStatus("TEMPLATE_ERROR")
+	assert.EqualError(t, err, "the Schematics workspace is in status TEMPLATE_ERROR")
+}
+
+func TestProjectConfigWorkspaceCRN(t *testing.T) {
+	assert.Equal(t, "", projectConfigWorkspaceCRN(nil))
+	assert.Equal(t, "", projectConfigWorkspaceCRN(&projectv1.ProjectConfig{}))
+	assert.Equal(t, testProjectWorkspaceCRN, projectConfigWorkspaceCRN(&projectv1.ProjectConfig{
+		Schematics: &projectv1.SchematicsMetadata{WorkspaceCrn: core.StringPtr(testProjectWorkspaceCRN)},
+	}))
+}
+
+func TestProjectConfigWaitForWorkspace(t *testing.T) {
+	api := &testProjectConfigAPI{config: &projectv1.ProjectConfig{
+		ID:         core.StringPtr("a1b2c3"),
+		Schematics: &projectv1.SchematicsMetadata{WorkspaceCrn: core.StringPtr(testProjectWorkspaceCRN)},
+	}}
+
+	workspaceCRN, err := projectConfigWaitForWorkspace(context.Background(), api, nil, "project", "a1b2c3", time.Minute, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, testProjectWorkspaceCRN, workspaceCRN)
+
+	schematicsClient := &testProjectWorkspaceAPI{statuses: []string{"INACTIVE"}}
+	workspaceCRN, err = projectConfigWaitForWorkspace(context.Background(), api, schematicsClient, "project", "a1b2c3", time.Minute, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, testProjectWorkspaceCRN, workspaceCRN)
+	assert.Equal(t, 1, schematicsClient.calls)
+
+	schematicsClient = &testProjectWorkspaceAPI{statuses: []string{"FAILED"}}
+	_, err = projectConfigWaitForWorkspace(context.Background(), api, schematicsClient, "project", "a1b2c3", time.Minute, 0)
+	assert.EqualError(t, err, "The Schematics workspace us-south.workspace.projects-service.3ae3f8d5 of configuration a1b2c3 is not ready: the Schematics workspace is in status FAILED")
+
+	schematicsClient = &testProjectWorkspaceAPI{err: errors.New("Not Found")}
+	_, err = projectConfigWaitForWorkspace(context.Background(), api, schematicsClient, "project", "a1b2c3", time.Minute, 0)
+	assert.ErrorContains(t, err, "Not Found")
+}
+
+func TestProjectConfigWorkspaceCRNRefreshFunc(t *testing.T) {
+	api := &testProjectConfigAPI{config: &projectv1.ProjectConfig{ID: core.StringPtr("a1b2c3")}}
+	refresh := projectConfigWorkspaceCRNRefreshFunc(context.Background(), api, "project", "a1b2c3")
+
+	_, state, err := refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, projectConfigWorkspacePending, state)
+
+	api.config.Schematics = &projectv1.SchematicsMetadata{WorkspaceCrn: core.StringPtr(testProjectWorkspaceCRN)}
+	_, state, err = refresh()
+	assert.NoError(t, err)
+	assert.Equal(t, projectConfigWorkspaceReady, state)
+
+	api.err = errors.New("Not Found")
+	_, _, err = refresh()
+	assert.EqualError(t, err, "Not Found")
+}